@@ -0,0 +1,121 @@
+// Package aggregator rolls transactions and logs indexes of blockchain into summary tables of
+// index database, so dashboards read daily activity of addresses without scanning indexes.
+package aggregator
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// DefaultInterval is time between aggregation rounds, every round aggregates again blocks of the
+// current day indexed since the previous round.
+const DefaultInterval = 10 * time.Minute
+
+const day = 24 * time.Hour
+
+var (
+	aggregatedDays = metrics.NewCounterVec("seer_aggregator_days_total",
+		"Days aggregated into address daily stats by result", "chain", "result")
+	aggregatedAddresses = metrics.NewCounterVec("seer_aggregator_addresses_total",
+		"Rows of active addresses written to address daily stats of complete days", "chain")
+)
+
+// Aggregator aggregates per-address daily stats of blockchain. Complete days, days before the day
+// of the latest indexed block, are aggregated once, stats of the current day are replaced with
+// every round until the day is complete.
+type Aggregator struct {
+	blockchain string
+	interval   time.Duration
+}
+
+// NewAggregator creates aggregator of daily stats of blockchain.
+func NewAggregator(blockchain string) *Aggregator {
+	return &Aggregator{
+		blockchain: blockchain,
+		interval:   DefaultInterval,
+	}
+}
+
+// SetInterval sets time between rounds.
+func (a *Aggregator) SetInterval(interval time.Duration) {
+	if interval > 0 {
+		a.interval = interval
+	}
+}
+
+// Run aggregates indexed days every interval until context is cancelled. Failed rounds are logged
+// and retried with the next round.
+func (a *Aggregator) Run(ctx context.Context) error {
+	for {
+		days, err := a.Round(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Printf("Aggregation round of %s failed: %v", a.blockchain, err)
+		} else {
+			log.Printf("Aggregation round of %s aggregated %d days", a.blockchain, days)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(a.interval):
+		}
+	}
+}
+
+// Round aggregates days after the last complete day up to the day of the latest indexed block,
+// days without complete aggregation start with the day of the first indexed block. It returns
+// number of aggregated days including the current day.
+func (a *Aggregator) Round(ctx context.Context) (int, error) {
+	firstTimestamp, latestTimestamp, ok, err := indexer.DBConnection.ReadIndexedTimeRange(ctx, a.blockchain)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read indexed blocks: %w", err)
+	}
+	if !ok {
+		return 0, nil
+	}
+
+	latestDay := time.Unix(int64(latestTimestamp), 0).UTC().Truncate(day)
+
+	nextDay := time.Unix(int64(firstTimestamp), 0).UTC().Truncate(day)
+	completedDay, ok, err := indexer.DBConnection.ReadCompletedDay(ctx, a.blockchain, indexer.AddressDailyStatsAggregation)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read the last complete day: %w", err)
+	}
+	if ok {
+		nextDay = completedDay.UTC().Truncate(day).Add(day)
+	}
+
+	var aggregated int
+	for current := nextDay; !current.After(latestDay); current = current.Add(day) {
+		if ctx.Err() != nil {
+			return aggregated, ctx.Err()
+		}
+
+		// Blocks are indexed in order, so day is complete once block of the next day is indexed
+		complete := current.Before(latestDay)
+
+		addresses, err := indexer.DBConnection.AggregateAddressDailyStats(ctx, a.blockchain, current, complete)
+		if err != nil {
+			aggregatedDays.Inc(a.blockchain, "error")
+			return aggregated, fmt.Errorf("failed to aggregate %s: %w", current.Format(time.DateOnly), err)
+		}
+
+		if complete {
+			aggregatedDays.Inc(a.blockchain, "complete")
+			aggregatedAddresses.Add(float64(addresses), a.blockchain)
+		} else {
+			aggregatedDays.Inc(a.blockchain, "current")
+		}
+		aggregated++
+	}
+
+	return aggregated, nil
+}
@@ -0,0 +1,646 @@
+package aptos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/version"
+)
+
+// transactionsPageLimit is maximum page size of fullnode REST API
+const transactionsPageLimit = 100
+
+func init() {
+	seer_common.RegisterHasher("aptos", seer_common.MoveHasher{})
+}
+
+func NewClient(url string, timeout int) (*Client, error) {
+	return &Client{
+		baseURL:    strings.TrimSuffix(url, "/"),
+		httpClient: seer_common.NewHTTPClient(url, time.Duration(timeout)*time.Second),
+		hasher:     seer_common.GetHasher("aptos"),
+	}, nil
+}
+
+// Client is a wrapper around the Aptos fullnode REST API, url is expected with version prefix,
+// e.g. https://fullnode.mainnet.aptoslabs.com/v1
+type Client struct {
+	baseURL    string
+	httpClient *http.Client
+	hasher     seer_common.Hasher
+}
+
+// Client common
+
+// ChainType returns the chain type.
+func (c *Client) ChainType() string {
+	return "aptos"
+}
+
+// Close closes idle connections of the underlying HTTP client.
+func (c *Client) Close() {
+	c.httpClient.CloseIdleConnections()
+}
+
+func (c *Client) get(ctx context.Context, path string, result interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request %s failed with status %d: %s", path, resp.StatusCode, string(body))
+	}
+
+	return json.Unmarshal(body, result)
+}
+
+// GetLatestBlockNumber returns the latest block number.
+func (c *Client) GetLatestBlockNumber(ctx context.Context) (*big.Int, error) {
+	var ledgerInfo LedgerInfoJson
+	if err := c.get(ctx, "", &ledgerInfo); err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetUint64(ledgerInfo.BlockHeight), nil
+}
+
+// GetBlockByNumber returns the block with the given height with all transactions. Blocks with
+// more transactions than page limit are completed with requests to transactions endpoint.
+func (c *Client) GetBlockByNumber(ctx context.Context, number uint64) (*BlockJson, error) {
+	var block BlockJson
+	if err := c.get(ctx, fmt.Sprintf("/blocks/by_height/%d?with_transactions=true", number), &block); err != nil {
+		return nil, err
+	}
+
+	for uint64(len(block.Transactions)) < block.LastVersion-block.FirstVersion+1 {
+		start := block.FirstVersion + uint64(len(block.Transactions))
+		limit := block.LastVersion - start + 1
+		if limit > transactionsPageLimit {
+			limit = transactionsPageLimit
+		}
+
+		var transactions []TransactionJson
+		if err := c.get(ctx, fmt.Sprintf("/transactions?start=%d&limit=%d", start, limit), &transactions); err != nil {
+			return nil, err
+		}
+		if len(transactions) == 0 {
+			return nil, fmt.Errorf("no transactions returned for block %d from version %d", number, start)
+		}
+
+		block.Transactions = append(block.Transactions, transactions...)
+	}
+
+	return &block, nil
+}
+
+// FetchBlocksInRange fetches blocks within a specified range, with up to maxRequests concurrent requests.
+// Blocks are returned in ascending order.
+func (c *Client) FetchBlocksInRange(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]*BlockJson, error) {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	fromNumber := from.Uint64()
+	toNumber := to.Uint64()
+	if toNumber < fromNumber {
+		return nil, nil
+	}
+
+	blocks := make([]*BlockJson, toNumber-fromNumber+1)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	sem := make(chan struct{}, maxRequests)
+
+	for number := fromNumber; number <= toNumber; number++ {
+		wg.Add(1)
+		go func(number uint64) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			block, err := c.GetBlockByNumber(ctx, number)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			blocks[number-fromNumber] = block
+
+			if debug {
+				fmt.Printf("Fetched block number: %d\n", number)
+			}
+		}(number)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return blocks, nil
+}
+
+// FetchAsProtoBlocksWithEvents fetches blocks with transactions and events. Transactions are
+// indexed by entry function id and events by Move type tag, addresses are in 32 bytes form.
+func (c *Client) FetchAsProtoBlocksWithEvents(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error) {
+	blocksJson, err := c.FetchBlocksInRange(ctx, from, to, debug, maxRequests)
+	if err != nil {
+		return nil, nil, nil, nil, 0, err
+	}
+
+	var blocksSize uint64
+	var blocksProto []proto.Message
+	var blocksIndex []indexer.BlockIndex
+	var txsIndex []indexer.TransactionIndex
+	var eventsIndex []indexer.LogIndex
+
+	for bI, blockJson := range blocksJson {
+		block, convErr := ToProtoSingleBlock(blockJson)
+		if convErr != nil {
+			return nil, nil, nil, nil, 0, convErr
+		}
+
+		for txI, tx := range block.Transactions {
+			var moduleAddress string
+			if tx.Function != "" {
+				moduleAddress = c.hasher.NormalizeAddress(strings.SplitN(tx.Function, "::", 2)[0])
+			}
+
+			var sender string
+			if tx.Sender != "" {
+				sender = c.hasher.NormalizeAddress(tx.Sender)
+			}
+
+			txsIndex = append(txsIndex, indexer.TransactionIndex{
+				BlockNumber:      tx.BlockNumber,
+				BlockHash:        tx.BlockHash,
+				BlockTimestamp:   tx.BlockTimestamp,
+				FromAddress:      sender,
+				ToAddress:        moduleAddress,
+				RowID:            uint64(txI),
+				Selector:         c.hasher.MethodSelector(tx.Function), // Entry function id instead of 4 bytes selector
+				TransactionHash:  tx.Hash,
+				TransactionIndex: tx.TransactionIndex,
+				Type:             TransactionTypeCode(tx.Type),
+				Path:             "",
+			})
+
+			for _, event := range tx.Events {
+				eventType := c.hasher.EventTopic(event.Type)
+				eventsIndex = append(eventsIndex, indexer.LogIndex{
+					Address:         c.hasher.NormalizeAddress(event.TypeAddress),
+					BlockNumber:     event.BlockNumber,
+					BlockHash:       event.BlockHash,
+					BlockTimestamp:  block.Timestamp,
+					TransactionHash: event.TransactionHash,
+					Selector:        &eventType, // Move type tag instead of topic0
+					RowID:           event.EventIndex,
+					LogIndex:        event.EventIndex,
+					Path:            "",
+				})
+			}
+		}
+
+		blocksIndex = append(blocksIndex, indexer.NewBlockIndex("aptos",
+			block.BlockNumber,
+			block.Hash,
+			block.Timestamp,
+			"",
+			uint64(bI),
+			"",
+			0,
+		))
+
+		blocksSize += uint64(proto.Size(block))
+		blocksProto = append(blocksProto, block)
+	}
+
+	return blocksProto, blocksIndex, txsIndex, eventsIndex, blocksSize, nil
+}
+
+func (c *Client) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
+	var blocks []*AptosBlock
+	for _, msg := range msgs {
+		block, ok := msg.(*AptosBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *AptosBlock")
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &AptosBlocksBatch{
+		Blocks:      blocks,
+		SeerVersion: version.SeerVersion,
+	}, nil
+}
+
+func (c *Client) decodeBlocksBatch(rawData *bytes.Buffer) (*AptosBlocksBatch, error) {
+	var protoBlocksBatch AptosBlocksBatch
+
+	if err := proto.Unmarshal(rawData.Bytes(), &protoBlocksBatch); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	return &protoBlocksBatch, nil
+}
+
+// DecodeProtoEntireBlockToJson returns blocks, transactions and events in EVM oriented JSON structure,
+// event type tag is placed as the first topic.
+func (c *Client) DecodeProtoEntireBlockToJson(rawData *bytes.Buffer) (*seer_common.BlocksBatchJson, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksBatchJson := seer_common.BlocksBatchJson{
+		Blocks:      []seer_common.BlockJson{},
+		SeerVersion: protoBlocksBatch.SeerVersion,
+	}
+
+	for _, b := range protoBlocksBatch.Blocks {
+		var txs []seer_common.TransactionJson
+		for _, tx := range b.Transactions {
+			var events []seer_common.EventJson
+			for _, e := range tx.Events {
+				events = append(events, seer_common.EventJson{
+					Address:          e.TypeAddress,
+					Topics:           []string{e.Type},
+					Data:             e.Data,
+					BlockNumber:      fmt.Sprintf("%d", e.BlockNumber),
+					TransactionHash:  e.TransactionHash,
+					BlockHash:        e.BlockHash,
+					LogIndex:         fmt.Sprintf("%d", e.EventIndex),
+					TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+				})
+			}
+
+			txs = append(txs, seer_common.TransactionJson{
+				BlockHash:        tx.BlockHash,
+				BlockNumber:      fmt.Sprintf("%d", tx.BlockNumber),
+				FromAddress:      tx.Sender,
+				Gas:              fmt.Sprintf("%d", tx.MaxGasAmount),
+				GasPrice:         fmt.Sprintf("%d", tx.GasUnitPrice),
+				Hash:             tx.Hash,
+				Input:            tx.Arguments,
+				Nonce:            fmt.Sprintf("%d", tx.SequenceNumber),
+				ToAddress:        tx.Function,
+				TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+				TransactionType:  fmt.Sprintf("%d", TransactionTypeCode(tx.Type)),
+				IndexedAt:        fmt.Sprintf("%d", tx.IndexedAt),
+				BlockTimestamp:   fmt.Sprintf("%d", tx.BlockTimestamp),
+
+				Events: events,
+			})
+		}
+
+		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
+			Hash:         b.Hash,
+			BlockNumber:  fmt.Sprintf("%d", b.BlockNumber),
+			Timestamp:    fmt.Sprintf("%d", b.Timestamp),
+			IndexedAt:    fmt.Sprintf("%d", b.IndexedAt),
+			Transactions: txs,
+		})
+	}
+
+	return &blocksBatchJson, nil
+}
+
+// DecodeProtoEntireBlockToProtoJson returns batch with all fields, including Move specific ones.
+func (c *Client) DecodeProtoEntireBlockToProtoJson(rawData *bytes.Buffer) ([]byte, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	return protojson.Marshal(protoBlocksBatch)
+}
+
+// DecodeProtoEntireBlockToLabels converts entry function calls and events matched with ABI jobs
+// to labels. Fullnode returns arguments and event data already decoded to JSON, so ABI is only
+// used to match address with function id or event type tag.
+func (c *Client) DecodeProtoEntireBlockToLabels(ctx context.Context, rawData *bytes.Buffer, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var labels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
+
+	for _, b := range protoBlocksBatch.Blocks {
+		for _, tx := range b.Transactions {
+			if tx.Function != "" {
+				moduleAddress := c.hasher.NormalizeAddress(strings.SplitN(tx.Function, "::", 2)[0])
+				selector := c.hasher.MethodSelector(tx.Function)
+
+				if abiMap[moduleAddress] != nil && abiMap[moduleAddress][selector] != nil {
+					txLabel, labelErr := c.transactionToLabel(b, tx, moduleAddress, abiMap[moduleAddress][selector]["abi_name"])
+					if labelErr != nil {
+						return nil, nil, labelErr
+					}
+					txLabels = append(txLabels, txLabel)
+				}
+			}
+
+			for _, e := range tx.Events {
+				typeAddress := c.hasher.NormalizeAddress(e.TypeAddress)
+				eventType := c.hasher.EventTopic(e.Type)
+
+				if abiMap[typeAddress] == nil || abiMap[typeAddress][eventType] == nil {
+					continue
+				}
+
+				var eventData interface{}
+				label := indexer.SeerCrawlerLabel
+				if unmarshalErr := json.Unmarshal([]byte(e.Data), &eventData); unmarshalErr != nil {
+					eventData = e.Data
+					label = indexer.SeerCrawlerRawLabel
+				}
+
+				labelDataBytes, err := json.Marshal(map[string]interface{}{
+					"type": "event",
+					"name": e.TypeName,
+					"args": eventData,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+
+				labels = append(labels, indexer.EventLabel{
+					Label:           label,
+					LabelName:       abiMap[typeAddress][eventType]["abi_name"],
+					LabelType:       "event",
+					BlockNumber:     e.BlockNumber,
+					BlockHash:       e.BlockHash,
+					Address:         typeAddress,
+					OriginAddress:   tx.Sender,
+					TransactionHash: e.TransactionHash,
+					LabelData:       string(labelDataBytes),
+					BlockTimestamp:  b.Timestamp,
+					LogIndex:        e.EventIndex,
+				})
+			}
+		}
+	}
+
+	return labels, txLabels, nil
+}
+
+func (c *Client) transactionToLabel(b *AptosBlock, tx *AptosTransaction, moduleAddress, abiName string) (indexer.TransactionLabel, error) {
+	label := indexer.SeerCrawlerLabel
+
+	var arguments interface{}
+	if err := json.Unmarshal([]byte(tx.Arguments), &arguments); err != nil {
+		arguments = tx.Arguments
+		label = indexer.SeerCrawlerRawLabel
+	}
+
+	functionParts := strings.Split(tx.Function, "::")
+	labelDataBytes, err := json.Marshal(map[string]interface{}{
+		"type":     "tx_call",
+		"name":     functionParts[len(functionParts)-1],
+		"gas_used": tx.GasUsed,
+		"status":   tx.Success,
+		"args": map[string]interface{}{
+			"type_arguments": tx.TypeArguments,
+			"arguments":      arguments,
+		},
+	})
+	if err != nil {
+		return indexer.TransactionLabel{}, err
+	}
+
+	return indexer.TransactionLabel{
+		Address:         moduleAddress,
+		BlockNumber:     tx.BlockNumber,
+		BlockHash:       tx.BlockHash,
+		CallerAddress:   tx.Sender,
+		LabelName:       abiName,
+		LabelType:       "tx_call",
+		OriginAddress:   tx.Sender,
+		Label:           label,
+		TransactionHash: tx.Hash,
+		LabelData:       string(labelDataBytes),
+		BlockTimestamp:  b.Timestamp,
+	}, nil
+}
+
+func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error) {
+	var labels []indexer.TransactionLabel
+
+	for _, data := range transactions {
+		var tx AptosTransaction
+		if err := proto.Unmarshal([]byte(data), &tx); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal transaction: %v", err)
+		}
+		if tx.Function == "" {
+			continue
+		}
+
+		moduleAddress := c.hasher.NormalizeAddress(strings.SplitN(tx.Function, "::", 2)[0])
+		selector := c.hasher.MethodSelector(tx.Function)
+		if abiMap[moduleAddress] == nil || abiMap[moduleAddress][selector] == nil {
+			continue
+		}
+
+		txLabel, err := c.transactionToLabel(&AptosBlock{Timestamp: blocksCache[tx.BlockNumber]}, &tx, moduleAddress, abiMap[moduleAddress][selector]["abi_name"])
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, txLabel)
+	}
+
+	return labels, nil
+}
+
+// Fullnode REST API structures, u64 values are returned as strings
+
+type LedgerInfoJson struct {
+	ChainId       uint64 `json:"chain_id"`
+	LedgerVersion uint64 `json:"ledger_version,string"`
+	BlockHeight   uint64 `json:"block_height,string"`
+}
+
+type EventGuidJson struct {
+	CreationNumber uint64 `json:"creation_number,string"`
+	AccountAddress string `json:"account_address"`
+}
+
+type EventJson struct {
+	Guid           EventGuidJson   `json:"guid"`
+	SequenceNumber uint64          `json:"sequence_number,string"`
+	Type           string          `json:"type"`
+	Data           json.RawMessage `json:"data"`
+}
+
+type PayloadJson struct {
+	Type          string          `json:"type"`
+	Function      string          `json:"function"`
+	TypeArguments []string        `json:"type_arguments"`
+	Arguments     json.RawMessage `json:"arguments"`
+}
+
+type TransactionJson struct {
+	Type                    string          `json:"type"`
+	Version                 uint64          `json:"version,string"`
+	Hash                    string          `json:"hash"`
+	StateChangeHash         string          `json:"state_change_hash"`
+	EventRootHash           string          `json:"event_root_hash"`
+	AccumulatorRootHash     string          `json:"accumulator_root_hash"`
+	GasUsed                 uint64          `json:"gas_used,string"`
+	Success                 bool            `json:"success"`
+	VmStatus                string          `json:"vm_status"`
+	Sender                  string          `json:"sender"`
+	SequenceNumber          uint64          `json:"sequence_number,string"`
+	MaxGasAmount            uint64          `json:"max_gas_amount,string"`
+	GasUnitPrice            uint64          `json:"gas_unit_price,string"`
+	ExpirationTimestampSecs uint64          `json:"expiration_timestamp_secs,string"`
+	Payload                 *PayloadJson    `json:"payload"`
+	Signature               json.RawMessage `json:"signature"`
+	Changes                 json.RawMessage `json:"changes"`
+	Events                  []EventJson     `json:"events"`
+}
+
+type BlockJson struct {
+	BlockHeight    uint64            `json:"block_height,string"`
+	BlockHash      string            `json:"block_hash"`
+	BlockTimestamp uint64            `json:"block_timestamp,string"` // microseconds
+	FirstVersion   uint64            `json:"first_version,string"`
+	LastVersion    uint64            `json:"last_version,string"`
+	Transactions   []TransactionJson `json:"transactions"`
+}
+
+// TransactionTypeCode maps transaction type name to number stored in transactions index.
+func TransactionTypeCode(transactionType string) uint64 {
+	switch transactionType {
+	case "user_transaction":
+		return 0
+	case "block_metadata_transaction":
+		return 1
+	case "state_checkpoint_transaction":
+		return 2
+	case "genesis_transaction":
+		return 3
+	case "validator_transaction":
+		return 4
+	case "block_epilogue_transaction":
+		return 5
+	default:
+		return 255
+	}
+}
+
+func rawJsonToString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	return string(raw)
+}
+
+func ToProtoSingleBlock(obj *BlockJson) (*AptosBlock, error) {
+	indexedAt := uint64(time.Now().Unix())
+	timestamp := obj.BlockTimestamp / 1_000_000
+
+	block := &AptosBlock{
+		BlockNumber:    obj.BlockHeight,
+		Hash:           obj.BlockHash,
+		Timestamp:      timestamp,
+		TimestampUsecs: obj.BlockTimestamp,
+		FirstVersion:   obj.FirstVersion,
+		LastVersion:    obj.LastVersion,
+		IndexedAt:      indexedAt,
+	}
+
+	for txI, txJson := range obj.Transactions {
+		tx := &AptosTransaction{
+			Hash:                    txJson.Hash,
+			Version:                 txJson.Version,
+			Type:                    txJson.Type,
+			Sender:                  txJson.Sender,
+			SequenceNumber:          txJson.SequenceNumber,
+			MaxGasAmount:            txJson.MaxGasAmount,
+			GasUnitPrice:            txJson.GasUnitPrice,
+			GasUsed:                 txJson.GasUsed,
+			Success:                 txJson.Success,
+			VmStatus:                txJson.VmStatus,
+			ExpirationTimestampSecs: txJson.ExpirationTimestampSecs,
+			StateChangeHash:         txJson.StateChangeHash,
+			EventRootHash:           txJson.EventRootHash,
+			AccumulatorRootHash:     txJson.AccumulatorRootHash,
+			Signature:               rawJsonToString(txJson.Signature),
+			Changes:                 rawJsonToString(txJson.Changes),
+			BlockNumber:             obj.BlockHeight,
+			BlockHash:               obj.BlockHash,
+			BlockTimestamp:          timestamp,
+			TransactionIndex:        uint64(txI),
+			IndexedAt:               indexedAt,
+		}
+
+		if txJson.Payload != nil {
+			tx.PayloadType = txJson.Payload.Type
+			tx.Function = txJson.Payload.Function
+			tx.TypeArguments = txJson.Payload.TypeArguments
+			tx.Arguments = rawJsonToString(txJson.Payload.Arguments)
+		}
+
+		for eI, eventJson := range txJson.Events {
+			typeAddress, typeModule, typeName := seer_common.ParseMoveTypeTag(eventJson.Type)
+			tx.Events = append(tx.Events, &AptosEvent{
+				Type:               eventJson.Type,
+				TypeAddress:        typeAddress,
+				TypeModule:         typeModule,
+				TypeName:           typeName,
+				AccountAddress:     eventJson.Guid.AccountAddress,
+				CreationNumber:     eventJson.Guid.CreationNumber,
+				SequenceNumber:     eventJson.SequenceNumber,
+				Data:               rawJsonToString(eventJson.Data),
+				EventIndex:         uint64(eI),
+				TransactionHash:    txJson.Hash,
+				TransactionVersion: txJson.Version,
+				BlockNumber:        obj.BlockHeight,
+				BlockHash:          obj.BlockHash,
+			})
+		}
+
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	return block, nil
+}
@@ -6,40 +6,192 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"math/big"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
+	"google.golang.org/protobuf/encoding/protowire"
 	"google.golang.org/protobuf/proto"
 
 	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/blockchain/traces"
 	"github.com/moonstream-to/seer/indexer"
 	"github.com/moonstream-to/seer/version"
 )
 
+// WithdrawalsIndexKind is kind of custom index with EIP-4895 withdrawals, stored in arbitrum_sepolia_withdrawals table
+const WithdrawalsIndexKind = "withdrawals"
+
+// ContractDeploymentsIndexKind is kind of custom index with contracts created by transactions,
+// stored in arbitrum_sepolia_contract_deployments table
+const ContractDeploymentsIndexKind = indexer.ContractDeploymentsIndexKind
+
+// UserOperationsIndexKind is kind of custom index with ERC-4337 user operations bundled in
+// handleOps calls of EntryPoint, stored in arbitrum_sepolia_user_operations table
+const UserOperationsIndexKind = "user_operations"
+
+func init() {
+	indexer.RegisterBlockchainWithL1Chain("arbitrum_sepolia")
+
+	indexer.RegisterCustomIndexTable("arbitrum_sepolia", indexer.CustomIndexTable{
+		Kind: WithdrawalsIndexKind,
+		Columns: []indexer.CustomIndexColumn{
+			{Name: "withdrawal_index", Type: "BIGINT"},
+			{Name: "block_number", Type: "BIGINT"},
+			{Name: "block_hash", Type: "TEXT"},
+			{Name: "block_timestamp", Type: "BIGINT"},
+			{Name: "validator_index", Type: "BIGINT"},
+			{Name: "address", Type: "TEXT"},
+			{Name: "amount", Type: "NUMERIC"},
+		},
+		ConflictClause: "ON CONFLICT (withdrawal_index) DO NOTHING",
+	})
+
+	indexer.RegisterCustomIndexTable("arbitrum_sepolia", indexer.CustomIndexTable{
+		Kind: ContractDeploymentsIndexKind,
+		Columns: []indexer.CustomIndexColumn{
+			{Name: "transaction_hash", Type: "TEXT"},
+			{Name: "block_number", Type: "BIGINT"},
+			{Name: "block_hash", Type: "TEXT"},
+			{Name: "block_timestamp", Type: "BIGINT"},
+			{Name: "deployer", Type: "TEXT"},
+			{Name: "address", Type: "TEXT"},
+			{Name: "init_code_hash", Type: "TEXT"},
+		},
+		ConflictClause: "ON CONFLICT (transaction_hash) DO NOTHING",
+	})
+
+	indexer.RegisterCustomIndexTable("arbitrum_sepolia", indexer.CustomIndexTable{
+		Kind: UserOperationsIndexKind,
+		Columns: []indexer.CustomIndexColumn{
+			{Name: "transaction_hash", Type: "TEXT"},
+			{Name: "op_index", Type: "BIGINT"},
+			{Name: "block_number", Type: "BIGINT"},
+			{Name: "block_hash", Type: "TEXT"},
+			{Name: "block_timestamp", Type: "BIGINT"},
+			{Name: "entry_point", Type: "TEXT"},
+			{Name: "bundler", Type: "TEXT"},
+			{Name: "sender", Type: "TEXT"},
+			{Name: "nonce", Type: "NUMERIC"},
+			{Name: "paymaster", Type: "TEXT"},
+			{Name: "selector", Type: "TEXT"},
+		},
+		ConflictClause: "ON CONFLICT (transaction_hash, op_index) DO NOTHING",
+	})
+}
+
 func NewClient(url string, timeout int) (*Client, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
 	defer cancel()
 
-	rpcClient, err := rpc.DialContext(ctx, url)
+	rpcClient, err := seer_common.DialRPC(ctx, url)
 	if err != nil {
 		return nil, err
 	}
-	return &Client{rpcClient: rpcClient}, nil
+	return &Client{rpcClient: rpcClient, hasher: seer_common.GetHasher("arbitrum_sepolia"), fetchReceipts: true}, nil
 }
 
 // Client is a wrapper around the Ethereum JSON-RPC client.
 
 type Client struct {
-	rpcClient *rpc.Client
+	rpcClient     *seer_common.RPCClient
+	hasher        seer_common.Hasher
+	fetchReceipts bool
+	// blocksBatchSize is number of blocks requested in single batch call by FetchBlocksInRangeAsync
+	blocksBatchSize int
+	// logsFilter narrows crawled events, blocks keep only transactions which emitted matching events
+	logsFilter seer_common.LogsFilter
+	// transactionsAddresses are addresses of allowlist, blocks keep also transactions from or to them
+	transactionsAddresses map[string]bool
+	// logsFromReceipts makes events to be taken from receipts of crawled blocks instead of eth_getLogs
+	logsFromReceipts bool
+	// proxies resolves implementations of proxies for decoding, nil disables resolution
+	proxies *seer_common.ProxyResolver
+	// expandMulticalls enables decoding of calls batched in multicall transactions
+	expandMulticalls bool
+	// revertReasons re-executes failed transactions for decoding, nil disables it
+	revertReasons *seer_common.RevertReasonResolver
+
+	// blockReceiptsUnsupported is set when node does not implement eth_getBlockReceipts
+	blockReceiptsUnsupported atomic.Bool
+}
+
+// SetBlocksBatchSize sets number of blocks requested in single JSON-RPC batch call, 1 disables batching.
+func (c *Client) SetBlocksBatchSize(blocksBatchSize int) {
+	c.blocksBatchSize = blocksBatchSize
+}
+
+// SetLogsFilter sets filter of events crawled by FetchAsProtoBlocksWithEvents, empty filter
+// crawls all events and transactions.
+func (c *Client) SetLogsFilter(filter seer_common.LogsFilter) {
+	c.logsFilter = filter
+}
+
+// SetTransactionsAddresses sets allowlist of addresses, blocks crawled by
+// FetchAsProtoBlocksWithEvents keep only transactions sent from or to them and transactions which
+// emitted filtered events.
+func (c *Client) SetTransactionsAddresses(addresses []string) {
+	c.transactionsAddresses = make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		c.transactionsAddresses[strings.ToLower(address)] = true
+	}
+}
+
+// SetLogsFromReceipts makes FetchAsProtoBlocksWithEvents take events from receipts of fetched
+// blocks instead of eth_getLogs, so stored events always belong to stored blocks. Receipts are
+// fetched with blocks in this mode.
+func (c *Client) SetLogsFromReceipts(logsFromReceipts bool) {
+	c.logsFromReceipts = logsFromReceipts
+	if logsFromReceipts {
+		c.fetchReceipts = true
+	}
+}
+
+// SetResolveProxies enables decoding of transactions and events of EIP-1967 proxies with ABIs of
+// their implementations, when selector is missing in ABIs of proxy.
+func (c *Client) SetResolveProxies(resolveProxies bool) {
+	c.proxies = nil
+	if resolveProxies {
+		c.proxies = seer_common.NewProxyResolver(c.rpcClient)
+	}
+}
+
+// SetExpandMulticalls enables decoding of calls batched in multicall(bytes[]) and Multicall3
+// aggregate transactions, of calls made by Safe transactions and of ERC-4337 user operations into
+// sub-call labels.
+func (c *Client) SetExpandMulticalls(expandMulticalls bool) {
+	c.expandMulticalls = expandMulticalls
+}
+
+// SetRevertReasons enables re-execution of failed transactions to add their revert reasons to
+// labels, transactions are failed by status of receipt, so blocks must be crawled with receipts.
+func (c *Client) SetRevertReasons(revertReasons bool) {
+	c.revertReasons = nil
+	if revertReasons {
+		c.revertReasons = seer_common.NewRevertReasonResolver(c.rpcClient)
+	}
+}
+
+// NameResolver returns resolver of primary names of addresses in name service of chain.
+func (c *Client) NameResolver(service seer_common.NameService) *seer_common.NameResolver {
+	return seer_common.NewNameResolver(c.rpcClient, service)
+}
+
+// SetFetchReceipts enables or disables fetching of transaction receipts with blocks.
+func (c *Client) SetFetchReceipts(fetchReceipts bool) {
+	c.fetchReceipts = fetchReceipts
 }
 
 // Client common
@@ -55,9 +207,9 @@ func (c *Client) Close() {
 }
 
 // GetLatestBlockNumber returns the latest block number.
-func (c *Client) GetLatestBlockNumber() (*big.Int, error) {
+func (c *Client) GetLatestBlockNumber(ctx context.Context) (*big.Int, error) {
 	var result string
-	if err := c.rpcClient.CallContext(context.Background(), &result, "eth_blockNumber"); err != nil {
+	if err := c.rpcClient.CallContext(ctx, &result, "eth_blockNumber"); err != nil {
 		return nil, err
 	}
 
@@ -70,6 +222,44 @@ func (c *Client) GetLatestBlockNumber() (*big.Int, error) {
 	return blockNumber, nil
 }
 
+// ProbeCapabilities probes optional methods supported by node, receipts are not requested with
+// eth_getBlockReceipts from node which does not support it.
+func (c *Client) ProbeCapabilities(ctx context.Context) (*seer_common.NodeCapabilities, error) {
+	capabilities, err := seer_common.ProbeNodeCapabilities(ctx, c.rpcClient)
+	if err != nil {
+		return nil, err
+	}
+	c.blockReceiptsUnsupported.Store(!capabilities.BlockReceipts)
+
+	return capabilities, nil
+}
+
+// GetBlockNumberByTag returns number of block with tag, e.g. "safe" or "finalized",
+// seer_common.ErrBlockTagUnsupported is returned if node does not support the tag.
+func (c *Client) GetBlockNumberByTag(ctx context.Context, tag string) (*big.Int, error) {
+	var header *struct {
+		Number string `json:"number"`
+	}
+	err := c.rpcClient.CallContext(ctx, &header, "eth_getBlockByNumber", tag, false)
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			return nil, fmt.Errorf("%w: %v", seer_common.ErrBlockTagUnsupported, err)
+		}
+		return nil, err
+	}
+	if header == nil {
+		return nil, seer_common.ErrBlockTagUnsupported
+	}
+
+	blockNumber, ok := new(big.Int).SetString(header.Number, 0)
+	if !ok {
+		return nil, fmt.Errorf("invalid block number format: %s", header.Number)
+	}
+
+	return blockNumber, nil
+}
+
 // BlockByNumber returns the block with the given number.
 func (c *Client) GetBlockByNumber(ctx context.Context, number *big.Int) (*seer_common.BlockJson, error) {
 
@@ -88,9 +278,150 @@ func (c *Client) GetBlockByNumber(ctx context.Context, number *big.Int) (*seer_c
 
 	var block *seer_common.BlockJson
 	err = c.rpcClient.CallContext(ctx, &block, "eth_getBlockByNumber", "0x"+number.Text(16), true) // true to include transactions
+	if err != nil || block == nil || !c.fetchReceipts {
+		return block, err
+	}
+
+	err = c.FetchReceipts(ctx, block)
 	return block, err
 }
 
+// GetBlocksByNumbers returns blocks with the given numbers requested in single batch call.
+func (c *Client) GetBlocksByNumbers(ctx context.Context, numbers []*big.Int) ([]*seer_common.BlockJson, error) {
+	blocks := make([]*seer_common.BlockJson, len(numbers))
+	batch := make([]rpc.BatchElem, len(numbers))
+	for i, number := range numbers {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getBlockByNumber",
+			Args:   []interface{}{"0x" + number.Text(16), true}, // true to include transactions
+			Result: &blocks[i],
+		}
+	}
+
+	if err := c.rpcClient.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	for i := range batch {
+		if batch[i].Error != nil {
+			return nil, batch[i].Error
+		}
+		if blocks[i] == nil {
+			return nil, fmt.Errorf("block %d not found", numbers[i])
+		}
+
+		if c.fetchReceipts {
+			if err := c.FetchReceipts(ctx, blocks[i]); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return blocks, nil
+}
+
+// FetchReceipts fills transactions of block with receipt fields: status, gas used, effective gas
+// price and address of created contract. Receipts are requested with eth_getBlockReceipts, if node
+// does not support it, with single batch call of eth_getTransactionReceipt.
+func (c *Client) FetchReceipts(ctx context.Context, block *seer_common.BlockJson) error {
+	if len(block.Transactions) == 0 {
+		return nil
+	}
+
+	receipts, err := c.GetBlockReceipts(ctx, block)
+	if err != nil {
+		if !c.blockReceiptsUnsupported.Load() {
+			log.Printf("Failed to get receipts of block %s with eth_getBlockReceipts, fallback to eth_getTransactionReceipt: %v", block.BlockNumber, err)
+		}
+		receipts, err = c.GetTransactionReceipts(ctx, block)
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range block.Transactions {
+		if c.logsFromReceipts {
+			// Receipt requested by transaction hash could be taken from other fork than block
+			if receipts[i].BlockHash != "" && receipts[i].BlockHash != block.Hash {
+				return fmt.Errorf("receipt of transaction %s belongs to block %s instead of block %s", block.Transactions[i].Hash, receipts[i].BlockHash, block.Hash)
+			}
+			block.Transactions[i].Events = make([]seer_common.EventJson, len(receipts[i].Logs))
+			for j, receiptLog := range receipts[i].Logs {
+				block.Transactions[i].Events[j] = *receiptLog
+			}
+		}
+
+		block.Transactions[i].Status = receipts[i].Status
+		block.Transactions[i].GasUsed = receipts[i].GasUsed
+		block.Transactions[i].CumulativeGasUsed = receipts[i].CumulativeGasUsed
+		block.Transactions[i].EffectiveGasPrice = receipts[i].EffectiveGasPrice
+		block.Transactions[i].ContractAddress = receipts[i].ContractAddress
+		block.Transactions[i].GasUsedForL1 = receipts[i].GasUsedForL1
+		block.Transactions[i].L1BlockNumber = receipts[i].L1BlockNumber
+	}
+
+	return nil
+}
+
+// GetBlockReceipts returns receipts of all transactions of block in transactions order using
+// eth_getBlockReceipts, the method is not requested again once node reports it is not supported.
+func (c *Client) GetBlockReceipts(ctx context.Context, block *seer_common.BlockJson) ([]*seer_common.ReceiptJson, error) {
+	if c.blockReceiptsUnsupported.Load() {
+		return nil, errors.New("eth_getBlockReceipts is not supported by node")
+	}
+
+	var receipts []*seer_common.ReceiptJson
+	err := c.rpcClient.CallContext(ctx, &receipts, "eth_getBlockReceipts", block.Hash)
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 { // method not found
+			c.blockReceiptsUnsupported.Store(true)
+			log.Printf("Node does not support eth_getBlockReceipts, receipts will be fetched with eth_getTransactionReceipt")
+		}
+		return nil, err
+	}
+
+	if len(receipts) != len(block.Transactions) {
+		return nil, fmt.Errorf("got %d receipts for %d transactions of block %s", len(receipts), len(block.Transactions), block.BlockNumber)
+	}
+	for i, receipt := range receipts {
+		if receipt == nil || !strings.EqualFold(receipt.TransactionHash, block.Transactions[i].Hash) {
+			return nil, fmt.Errorf("receipts of block %s do not match transactions order", block.BlockNumber)
+		}
+	}
+
+	return receipts, nil
+}
+
+// GetTransactionReceipts returns receipts of all transactions of block in transactions order
+// requested in single batch call of eth_getTransactionReceipt.
+func (c *Client) GetTransactionReceipts(ctx context.Context, block *seer_common.BlockJson) ([]*seer_common.ReceiptJson, error) {
+	receipts := make([]*seer_common.ReceiptJson, len(block.Transactions))
+	batch := make([]rpc.BatchElem, len(block.Transactions))
+	for i, tx := range block.Transactions {
+		batch[i] = rpc.BatchElem{
+			Method: "eth_getTransactionReceipt",
+			Args:   []interface{}{tx.Hash},
+			Result: &receipts[i],
+		}
+	}
+
+	if err := c.rpcClient.BatchCallContext(ctx, batch); err != nil {
+		return nil, err
+	}
+
+	for i := range block.Transactions {
+		if batch[i].Error != nil {
+			return nil, batch[i].Error
+		}
+		if receipts[i] == nil {
+			return nil, fmt.Errorf("receipt of transaction %s not found", block.Transactions[i].Hash)
+		}
+	}
+
+	return receipts, nil
+}
+
 // BlockByHash returns the block with the given hash.
 func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*seer_common.BlockJson, error) {
 	var block *seer_common.BlockJson
@@ -105,63 +436,84 @@ func (c *Client) TransactionReceipt(ctx context.Context, hash common.Hash) (*typ
 	return receipt, err
 }
 
+// ClientFilterLogs fetches logs matching query. Range is requested in windows of MaxBlockSpan of
+// provider limits, queries which are still too large are split by block range and queries of
+// single block by addresses and topics, so blocks are never skipped.
 func (c *Client) ClientFilterLogs(ctx context.Context, q ethereum.FilterQuery, debug bool) ([]*seer_common.EventJson, error) {
+	limits := c.rpcClient.LogsLimits()
+
 	var logs []*seer_common.EventJson
-	fromBlock := q.FromBlock
-	toBlock := q.ToBlock
-	batchStep := new(big.Int).Sub(toBlock, fromBlock) // Calculate initial batch step
-
-	for {
-		// Calculate the next "lastBlock" within the batch step or adjust to "toBlock" if exceeding
-		nextBlock := new(big.Int).Add(fromBlock, batchStep)
-		if nextBlock.Cmp(toBlock) > 0 {
-			nextBlock = new(big.Int).Set(toBlock)
-		}
-
-		var result []*seer_common.EventJson
-		err := c.rpcClient.CallContext(ctx, &result, "eth_getLogs", struct {
-			FromBlock string           `json:"fromBlock"`
-			ToBlock   string           `json:"toBlock"`
-			Addresses []common.Address `json:"addresses"`
-			Topics    [][]common.Hash  `json:"topics"`
-		}{
-			FromBlock: toHex(fromBlock),
-			ToBlock:   toHex(nextBlock),
-			Addresses: q.Addresses,
-			Topics:    q.Topics,
-		})
+	for fromBlock := new(big.Int).Set(q.FromBlock); fromBlock.Cmp(q.ToBlock) <= 0; {
+		toBlock := new(big.Int).Set(q.ToBlock)
+		if limits.MaxBlockSpan > 0 {
+			windowEnd := new(big.Int).Add(fromBlock, new(big.Int).SetUint64(limits.MaxBlockSpan-1))
+			if windowEnd.Cmp(toBlock) < 0 {
+				toBlock = windowEnd
+			}
+		}
 
+		windowQuery := q
+		windowQuery.FromBlock, windowQuery.ToBlock = fromBlock, toBlock
+		windowLogs, err := c.filterLogs(ctx, windowQuery, limits, debug)
 		if err != nil {
-			if strings.Contains(err.Error(), "query returned more than 10000 results") {
-				// Halve the batch step if too many results and retry
-				batchStep.Div(batchStep, big.NewInt(2))
-				if batchStep.Cmp(big.NewInt(1)) < 0 {
-					// If the batch step is too small we will skip that block
-					fromBlock = new(big.Int).Add(nextBlock, big.NewInt(1))
-					if fromBlock.Cmp(toBlock) > 0 {
-						break
-					}
-					continue
-				}
-				continue
-			} else {
-				// For any other error, return immediately
-				return nil, err
-			}
+			return nil, err
 		}
+		logs = append(logs, windowLogs...)
 
-		// Append the results and adjust "fromBlock" for the next batch
-		logs = append(logs, result...)
-		fromBlock = new(big.Int).Add(nextBlock, big.NewInt(1))
+		fromBlock = new(big.Int).Add(toBlock, big.NewInt(1))
+	}
 
+	return logs, nil
+}
+
+// filterLogs requests logs of query, query rejected or possibly truncated by provider is split
+// in two with seer_common.SplitFilterQuery and both parts are requested the same way.
+func (c *Client) filterLogs(ctx context.Context, q ethereum.FilterQuery, limits seer_common.LogsLimits, debug bool) ([]*seer_common.EventJson, error) {
+	var result []*seer_common.EventJson
+	err := c.rpcClient.CallContext(ctx, &result, "eth_getLogs", struct {
+		FromBlock string           `json:"fromBlock"`
+		ToBlock   string           `json:"toBlock"`
+		Addresses []common.Address `json:"address,omitempty"`
+		Topics    [][]common.Hash  `json:"topics,omitempty"`
+	}{
+		FromBlock: toHex(q.FromBlock),
+		ToBlock:   toHex(q.ToBlock),
+		Addresses: q.Addresses,
+		Topics:    q.Topics,
+	})
+	if err != nil && !limits.IsLimitError(err) {
+		return nil, err
+	}
+	if err == nil && !limits.IsTruncated(len(result)) {
 		if debug {
-			log.Printf("Fetched logs: %d", len(result))
+			log.Printf("Fetched logs of blocks %d-%d: %d", q.FromBlock, q.ToBlock, len(result))
 		}
+		return result, nil
+	}
 
-		// Break the loop if we've reached or exceeded "toBlock"
-		if fromBlock.Cmp(toBlock) > 0 {
-			break
+	subQueries := seer_common.SplitFilterQuery(q)
+	if subQueries == nil {
+		if err != nil {
+			return nil, fmt.Errorf("logs query of block %d could not be split further: %w", q.FromBlock, err)
 		}
+		return nil, fmt.Errorf("logs of block %d could be truncated at %d results and query could not be split further", q.FromBlock, len(result))
+	}
+	if debug {
+		log.Printf("Logs query of blocks %d-%d is too large for provider, splitting it", q.FromBlock, q.ToBlock)
+	}
+
+	var logs []*seer_common.EventJson
+	for _, subQuery := range subQueries {
+		subLogs, subErr := c.filterLogs(ctx, subQuery, limits, debug)
+		if subErr != nil {
+			return nil, subErr
+		}
+		logs = append(logs, subLogs...)
+	}
+
+	// Logs of queries split by addresses or topics are interleaved
+	if q.FromBlock.Cmp(q.ToBlock) == 0 {
+		seer_common.SortEventsByPosition(logs)
 	}
 
 	return logs, nil
@@ -172,17 +524,10 @@ func toHex(number *big.Int) string {
 	return fmt.Sprintf("0x%x", number)
 }
 
-func fromHex(hex string) *big.Int {
-	number := new(big.Int)
-	number.SetString(hex, 0)
-	return number
-}
-
 // FetchBlocksInRange fetches blocks within a specified range.
 // This could be useful for batch processing or analysis.
-func (c *Client) FetchBlocksInRange(from, to *big.Int, debug bool) ([]*seer_common.BlockJson, error) {
+func (c *Client) FetchBlocksInRange(ctx context.Context, from, to *big.Int, debug bool) ([]*seer_common.BlockJson, error) {
 	var blocks []*seer_common.BlockJson
-	ctx := context.Background() // For simplicity, using a background context; consider timeouts for production.
 
 	for i := new(big.Int).Set(from); i.Cmp(to) <= 0; i.Add(i, big.NewInt(1)) {
 		block, err := c.GetBlockByNumber(ctx, i)
@@ -199,111 +544,220 @@ func (c *Client) FetchBlocksInRange(from, to *big.Int, debug bool) ([]*seer_comm
 	return blocks, nil
 }
 
-// FetchBlocksInRangeAsync fetches blocks within a specified range concurrently.
-func (c *Client) FetchBlocksInRangeAsync(from, to *big.Int, debug bool, maxRequests int) ([]*seer_common.BlockJson, error) {
+// FetchBlocksInRangeAsync fetches blocks within a specified range concurrently. Blocks are
+// returned ordered by number, blocks failed in batches are retried one by one and if some of
+// them still could not be fetched *seer_common.MissingBlocksError with all their numbers is
+// returned, so result never has gaps.
+func (c *Client) FetchBlocksInRangeAsync(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]*seer_common.BlockJson, error) {
+	if to.Cmp(from) < 0 {
+		return nil, nil
+	}
+
 	var (
-		blocks []*seer_common.BlockJson
+		// blocks[i] is block with number from+i, filled by goroutines at their own positions
+		blocks = make([]*seer_common.BlockJson, new(big.Int).Sub(to, from).Uint64()+1)
 
-		mu  sync.Mutex
-		wg  sync.WaitGroup
-		ctx = context.Background()
+		failed   []uint64
+		firstErr error
+
+		mu sync.Mutex
+		wg sync.WaitGroup
 	)
 
-	var blockNumbersRange []*big.Int
+	// setBlock puts block at its position if it is the requested one
+	setBlock := func(number *big.Int, block *seer_common.BlockJson) error {
+		if block == nil {
+			return fmt.Errorf("block %d not found", number)
+		}
+		blockNumber, err := seer_common.ParseQuantity(block.BlockNumber)
+		if err != nil {
+			return fmt.Errorf("invalid number %q of block %d: %w", block.BlockNumber, number, err)
+		}
+		if blockNumber != number.Uint64() {
+			return fmt.Errorf("node returned block %d instead of %d", blockNumber, number)
+		}
+		blocks[new(big.Int).Sub(number, from).Uint64()] = block
+		return nil
+	}
+
+	batchSize := c.blocksBatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	// Blocks are requested in batches of blocksBatchSize, each batch is single JSON-RPC batch call
+	var blockNumbersBatches [][]*big.Int
 	for i := new(big.Int).Set(from); i.Cmp(to) <= 0; i.Add(i, big.NewInt(1)) {
-		blockNumbersRange = append(blockNumbersRange, new(big.Int).Set(i))
+		if len(blockNumbersBatches) == 0 || len(blockNumbersBatches[len(blockNumbersBatches)-1]) == batchSize {
+			blockNumbersBatches = append(blockNumbersBatches, []*big.Int{})
+		}
+		blockNumbersBatches[len(blockNumbersBatches)-1] = append(blockNumbersBatches[len(blockNumbersBatches)-1], new(big.Int).Set(i))
 	}
 
-	sem := make(chan struct{}, maxRequests)             // Semaphore to control concurrency
-	errChan := make(chan error, len(blockNumbersRange)) // Handle errors to stop corrupted processing
+	sem := make(chan struct{}, maxRequests) // Semaphore to control concurrency
 
-	for _, b := range blockNumbersRange {
+	for _, numbers := range blockNumbersBatches {
 		wg.Add(1)
-		go func(b *big.Int) {
+		go func(numbers []*big.Int) {
 			defer wg.Done()
 
-			sem <- struct{}{} // Acquire semaphore
+			sem <- struct{}{}        // Acquire semaphore
+			defer func() { <-sem }() // Release semaphore also when request fails or context is cancelled
+
+			var batchBlocks []*seer_common.BlockJson
+			var getErr error
+			if len(numbers) == 1 {
+				var block *seer_common.BlockJson
+				block, getErr = c.GetBlockByNumber(ctx, numbers[0])
+				batchBlocks = []*seer_common.BlockJson{block}
+			} else {
+				batchBlocks, getErr = c.GetBlocksByNumbers(ctx, numbers)
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
 
-			block, getErr := c.GetBlockByNumber(ctx, b)
 			if getErr != nil {
-				log.Printf("Failed to fetch block number: %d, error: %v", b, getErr)
-				errChan <- getErr
+				log.Printf("Failed to fetch blocks: %d-%d, error: %v", numbers[0], numbers[len(numbers)-1], getErr)
+				for _, number := range numbers {
+					failed = append(failed, number.Uint64())
+				}
+				if firstErr == nil {
+					firstErr = getErr
+				}
 				return
 			}
 
-			mu.Lock()
-			blocks = append(blocks, block)
-			mu.Unlock()
+			for i, number := range numbers {
+				if setErr := setBlock(number, batchBlocks[i]); setErr != nil {
+					log.Printf("Failed to fetch block %d, error: %v", number, setErr)
+					failed = append(failed, number.Uint64())
+					if firstErr == nil {
+						firstErr = setErr
+					}
+				}
+			}
 
 			if debug {
-				log.Printf("Fetched block number: %d", b)
+				log.Printf("Fetched blocks: %d-%d", numbers[0], numbers[len(numbers)-1])
 			}
-
-			<-sem
-		}(b)
+		}(numbers)
 	}
 
 	wg.Wait()
-	close(sem)
-	close(errChan)
 
-	for err := range errChan {
-		if err != nil {
-			return nil, err
+	// Failed blocks are retried one by one, so single bad block does not fail its whole batch
+	sort.Slice(failed, func(i, j int) bool { return failed[i] < failed[j] })
+	for _, blockNumber := range failed {
+		if ctx.Err() != nil {
+			break
+		}
+
+		number := new(big.Int).SetUint64(blockNumber)
+		block, getErr := c.GetBlockByNumber(ctx, number)
+		if getErr == nil {
+			getErr = setBlock(number, block)
+		}
+		if getErr != nil {
+			log.Printf("Failed to fetch block %d on retry, error: %v", blockNumber, getErr)
+			continue
+		}
+		if debug {
+			log.Printf("Fetched block %d on retry", blockNumber)
 		}
 	}
 
+	var missing []uint64
+	for i, block := range blocks {
+		if block == nil {
+			missing = append(missing, from.Uint64()+uint64(i))
+		}
+	}
+	if len(missing) > 0 {
+		if firstErr == nil {
+			firstErr = ctx.Err()
+		}
+		return nil, &seer_common.MissingBlocksError{BlockNumbers: missing, Err: firstErr}
+	}
+
 	return blocks, nil
 }
 
 // ParseBlocksWithTransactions parses blocks and their transactions into custom data structure.
 // This method showcases how to handle and transform detailed block and transaction data.
-func (c *Client) ParseBlocksWithTransactions(from, to *big.Int, debug bool, maxRequests int) ([]*ArbitrumSepoliaBlock, error) {
+func (c *Client) ParseBlocksWithTransactions(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]*ArbitrumSepoliaBlock, error) {
+	parsedBlocks, _, err := c.parseBlocksWithReceiptLogs(ctx, from, to, debug, maxRequests)
+	return parsedBlocks, err
+}
+
+// parseBlocksWithReceiptLogs parses blocks with transactions and returns logs of their receipts,
+// logs are returned only when events are taken from receipts.
+func (c *Client) parseBlocksWithReceiptLogs(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]*ArbitrumSepoliaBlock, []*seer_common.EventJson, error) {
 	var blocksWithTxsJson []*seer_common.BlockJson
 	var fetchErr error
-	if maxRequests > 1 {
-		blocksWithTxsJson, fetchErr = c.FetchBlocksInRangeAsync(from, to, debug, maxRequests)
+	if maxRequests > 1 || c.blocksBatchSize > 1 {
+		blocksWithTxsJson, fetchErr = c.FetchBlocksInRangeAsync(ctx, from, to, debug, maxRequests)
 	} else {
-		blocksWithTxsJson, fetchErr = c.FetchBlocksInRange(from, to, debug)
+		blocksWithTxsJson, fetchErr = c.FetchBlocksInRange(ctx, from, to, debug)
 	}
 	if fetchErr != nil {
-		return nil, fetchErr
+		return nil, nil, fetchErr
 	}
 
 	var parsedBlocks []*ArbitrumSepoliaBlock
+	var receiptLogs []*seer_common.EventJson
 	for _, blockAndTxsJson := range blocksWithTxsJson {
 		// Convert BlockJson to Block and Transactions as required.
-		parsedBlock := ToProtoSingleBlock(blockAndTxsJson)
+		parsedBlock, err := ToProtoSingleBlock(blockAndTxsJson)
+		if err != nil {
+			return nil, nil, err
+		}
 
 		for _, txJson := range blockAndTxsJson.Transactions {
 			txJson.BlockTimestamp = blockAndTxsJson.Timestamp
 
-			parsedTransaction := ToProtoSingleTransaction(&txJson)
+			parsedTransaction, err := ToProtoSingleTransaction(&txJson)
+			if err != nil {
+				return nil, nil, err
+			}
 			parsedBlock.Transactions = append(parsedBlock.Transactions, parsedTransaction)
+
+			for i := range txJson.Events {
+				receiptLogs = append(receiptLogs, &txJson.Events[i])
+			}
 		}
 
 		parsedBlocks = append(parsedBlocks, parsedBlock)
 	}
 
-	return parsedBlocks, nil
+	return parsedBlocks, receiptLogs, nil
 }
 
-func (c *Client) ParseEvents(from, to *big.Int, blocksCache map[uint64]indexer.BlockCache, debug bool) ([]*ArbitrumSepoliaEventLog, []indexer.LogIndex, error) {
-	logs, err := c.ClientFilterLogs(context.Background(), ethereum.FilterQuery{
-		FromBlock: from,
-		ToBlock:   to,
-	}, debug)
+// ParseEvents fetches events of blocks from-to matching filter, empty filter matches all events.
+func (c *Client) ParseEvents(ctx context.Context, from, to *big.Int, filter seer_common.LogsFilter, blocksCache map[uint64]indexer.BlockCache, debug bool) ([]*ArbitrumSepoliaEventLog, []indexer.LogIndex, error) {
+	logs, err := c.ClientFilterLogs(ctx, filter.FilterQuery(from, to), debug)
 
 	if err != nil {
 		fmt.Println("Error fetching logs: ", err)
 		return nil, nil, err
 	}
 
+	return parseEventLogs(logs, blocksCache)
+}
+
+// parseEventLogs converts logs to events and their index.
+func parseEventLogs(logs []*seer_common.EventJson, blocksCache map[uint64]indexer.BlockCache) ([]*ArbitrumSepoliaEventLog, []indexer.LogIndex, error) {
 	var parsedEvents []*ArbitrumSepoliaEventLog
 	var eventsIndex []indexer.LogIndex
 
 	for i, log := range logs {
-		parsedEvent := ToProtoSingleEventLog(log)
+		parsedEvent, err := ToProtoSingleEventLog(log)
+		if err != nil {
+			return nil, nil, err
+		}
 		parsedEvents = append(parsedEvents, parsedEvent)
 
 		// Prepare events to index
@@ -347,8 +801,134 @@ func (c *Client) ParseEvents(from, to *big.Int, blocksCache map[uint64]indexer.B
 	return parsedEvents, eventsIndex, nil
 }
 
-func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error) {
-	blocks, err := c.ParseBlocksWithTransactions(from, to, debug, maxRequests)
+// FetchAsProtoEvents fetches events of blocks from-to matching filter without blocks and
+// transactions, for event-only crawls of contracts. Block timestamps of index are taken from
+// headers of blocks with events.
+func (c *Client) FetchAsProtoEvents(ctx context.Context, from, to *big.Int, filter seer_common.LogsFilter, debug bool) ([]proto.Message, []indexer.LogIndex, error) {
+	events, eventsIndex, err := c.ParseEvents(ctx, from, to, filter, nil, debug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var blockNumbers []uint64
+	seen := make(map[uint64]bool)
+	for _, event := range events {
+		if !seen[event.BlockNumber] {
+			seen[event.BlockNumber] = true
+			blockNumbers = append(blockNumbers, event.BlockNumber)
+		}
+	}
+
+	timestamps, err := c.GetBlocksTimestamps(ctx, blockNumbers)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range eventsIndex {
+		eventsIndex[i].BlockTimestamp = timestamps[eventsIndex[i].BlockNumber]
+	}
+
+	eventsProto := make([]proto.Message, len(events))
+	for i, event := range events {
+		eventsProto[i] = event
+	}
+
+	return eventsProto, eventsIndex, nil
+}
+
+// GetBlocksTimestamps returns timestamps of blocks with the given numbers.
+func (c *Client) GetBlocksTimestamps(ctx context.Context, numbers []uint64) (map[uint64]uint64, error) {
+	headersFields, err := c.GetBlocksHeaderFields(ctx, numbers, []string{"timestamp"})
+	if err != nil {
+		return nil, err
+	}
+
+	timestamps := make(map[uint64]uint64, len(numbers))
+	for number, fields := range headersFields {
+		timestamp, err := seer_common.ParseQuantity(fields["timestamp"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q of block %d: %w", fields["timestamp"], number, err)
+		}
+		timestamps[number] = timestamp
+	}
+
+	return timestamps, nil
+}
+
+// GetBlocksHeaderFields returns values of fields of headers of blocks with the given numbers as
+// returned by node, also fields which are not decoded into blocks, such as nonstandard fee fields
+// of custom L1s. Missing fields are empty, headers without transactions are requested in batch
+// calls of blocksBatchSize.
+func (c *Client) GetBlocksHeaderFields(ctx context.Context, numbers []uint64, fields []string) (map[uint64]map[string]string, error) {
+	headersFields := make(map[uint64]map[string]string, len(numbers))
+
+	batchSize := c.blocksBatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	for start := 0; start < len(numbers); start += batchSize {
+		end := start + batchSize
+		if end > len(numbers) {
+			end = len(numbers)
+		}
+
+		headers := make([]map[string]json.RawMessage, end-start)
+		batch := make([]rpc.BatchElem, end-start)
+		for i, number := range numbers[start:end] {
+			batch[i] = rpc.BatchElem{
+				Method: "eth_getBlockByNumber",
+				Args:   []interface{}{fmt.Sprintf("0x%x", number), false},
+				Result: &headers[i],
+			}
+		}
+
+		if err := c.rpcClient.BatchCallContext(ctx, batch); err != nil {
+			return nil, err
+		}
+
+		for i, number := range numbers[start:end] {
+			if batch[i].Error != nil {
+				return nil, batch[i].Error
+			}
+			if headers[i] == nil {
+				return nil, fmt.Errorf("block %d not found", number)
+			}
+
+			values := make(map[string]string, len(fields))
+			for _, field := range fields {
+				raw, ok := headers[i][field]
+				if !ok || string(raw) == "null" {
+					continue
+				}
+				var value string
+				if err := json.Unmarshal(raw, &value); err != nil {
+					value = string(raw)
+				}
+				values[field] = value
+			}
+			headersFields[number] = values
+		}
+	}
+
+	return headersFields, nil
+}
+
+// GetChainID returns chain ID reported by node with eth_chainId.
+func (c *Client) GetChainID(ctx context.Context) (*big.Int, error) {
+	var chainID string
+	if err := c.rpcClient.CallContext(ctx, &chainID, "eth_chainId"); err != nil {
+		return nil, err
+	}
+
+	id, ok := new(big.Int).SetString(strings.TrimPrefix(chainID, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid chain ID %q", chainID)
+	}
+	return id, nil
+}
+
+func (c *Client) FetchAsProtoBlocksWithEvents(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error) {
+	blocks, receiptLogs, err := c.parseBlocksWithReceiptLogs(ctx, from, to, debug, maxRequests)
 	if err != nil {
 		return nil, nil, nil, nil, 0, err
 	}
@@ -365,23 +945,49 @@ func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, max
 		} // Assuming block.BlockNumber is int64 and block.Hash is string
 	}
 
-	events, eventsIndex, err := c.ParseEvents(from, to, blocksCache, debug)
+	var events []*ArbitrumSepoliaEventLog
+	var eventsIndex []indexer.LogIndex
+	if c.logsFromReceipts {
+		// Events of receipts are taken from the same blocks, eth_getLogs could see other fork
+		var logs []*seer_common.EventJson
+		for _, receiptLog := range receiptLogs {
+			if c.logsFilter.Matches(receiptLog) {
+				logs = append(logs, receiptLog)
+			}
+		}
+		events, eventsIndex, err = parseEventLogs(logs, blocksCache)
+	} else {
+		events, eventsIndex, err = c.ParseEvents(ctx, from, to, c.logsFilter, blocksCache, debug)
+	}
 	if err != nil {
 		return nil, nil, nil, nil, 0, err
 	}
+	filtered := !c.logsFilter.IsEmpty() || len(c.transactionsAddresses) > 0
 
 	var blocksProto []proto.Message
 	var blocksIndex []indexer.BlockIndex
 	var txsIndex []indexer.TransactionIndex
 
 	for bI, block := range blocks {
-		for txI, tx := range block.Transactions {
+		var blockTransactions []*ArbitrumSepoliaTransaction
+		for _, tx := range block.Transactions {
 			for _, event := range events {
 				if tx.Hash == event.TransactionHash {
 					tx.Logs = append(tx.Logs, event)
 				}
 			}
 
+			// Targeted crawl keeps only transactions which emitted filtered events or were sent
+			// from or to addresses of allowlist
+			if filtered && len(tx.Logs) == 0 && !c.transactionsAddresses[strings.ToLower(tx.FromAddress)] && !c.transactionsAddresses[strings.ToLower(tx.ToAddress)] {
+				continue
+			}
+			blockTransactions = append(blockTransactions, tx)
+		}
+		block.Transactions = blockTransactions
+
+		for txI, tx := range block.Transactions {
+
 			// Prepare transactions to index
 			txSelector := "0x"
 
@@ -389,6 +995,14 @@ func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, max
 				txSelector = tx.Input[:10]
 			}
 
+			// Gas used is known only for transactions crawled with receipts
+			var txGasUsed *uint64
+			if tx.GasUsed != "" {
+				if gasUsed, gasUsedErr := seer_common.ParseQuantity(tx.GasUsed); gasUsedErr == nil {
+					txGasUsed = &gasUsed
+				}
+			}
+
 			txsIndex = append(txsIndex, indexer.TransactionIndex{
 				BlockNumber:      tx.BlockNumber,
 				BlockHash:        tx.BlockHash,
@@ -401,25 +1015,217 @@ func (c *Client) FetchAsProtoBlocksWithEvents(from, to *big.Int, debug bool, max
 				TransactionIndex: tx.TransactionIndex,
 				Type:             tx.TransactionType,
 				Path:             "",
+				GasUsed:          txGasUsed,
+			})
+		}
+
+		// Prepare blocks to index
+		blocksIndex = append(blocksIndex, indexer.NewBlockIndex("arbitrum_sepolia",
+			block.BlockNumber,
+			block.Hash,
+			block.Timestamp,
+			block.ParentHash,
+			uint64(bI),
+			"",
+			block.L1BlockNumber,
+		))
+
+		blocksSize += uint64(proto.Size(block))
+		blocksProto = append(blocksProto, block) // Assuming block is already a proto.Message
+	}
+
+	return blocksProto, blocksIndex, txsIndex, eventsIndex, blocksSize, nil
+}
+
+// CustomIndexesFromProtoBlocks returns EIP-4895 withdrawals, contract deployments and ERC-4337
+// user operations of blocks for their index tables.
+func (c *Client) CustomIndexesFromProtoBlocks(blocks []proto.Message) ([]indexer.CustomIndex, error) {
+	var indexes []indexer.CustomIndex
+	for _, msg := range blocks {
+		block, ok := msg.(*ArbitrumSepoliaBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *ArbitrumSepoliaBlock")
+		}
+
+		for _, w := range block.Withdrawals {
+			indexes = append(indexes, indexer.CustomIndex{
+				Kind: WithdrawalsIndexKind,
+				Values: map[string]interface{}{
+					"withdrawal_index": w.Index,
+					"block_number":     block.BlockNumber,
+					"block_hash":       block.Hash,
+					"block_timestamp":  block.Timestamp,
+					"validator_index":  w.ValidatorIndex,
+					"address":          strings.ToLower(w.Address),
+					"amount":           fmt.Sprintf("%d", w.Amount),
+				},
 			})
 		}
 
-		// Prepare blocks to index
-		blocksIndex = append(blocksIndex, indexer.NewBlockIndex("arbitrum_sepolia",
-			block.BlockNumber,
-			block.Hash,
-			block.Timestamp,
-			block.ParentHash,
-			uint64(bI),
-			"",
-			block.L1BlockNumber,
-		))
+		for _, tx := range block.Transactions {
+			address, err := deployedContractAddress(tx)
+			if err != nil {
+				return nil, err
+			}
+			if address == "" {
+				continue
+			}
+
+			indexes = append(indexes, indexer.CustomIndex{
+				Kind: ContractDeploymentsIndexKind,
+				Values: map[string]interface{}{
+					"transaction_hash": tx.Hash,
+					"block_number":     block.BlockNumber,
+					"block_hash":       block.Hash,
+					"block_timestamp":  block.Timestamp,
+					"deployer":         strings.ToLower(tx.FromAddress),
+					"address":          address,
+					"init_code_hash":   crypto.Keccak256Hash(common.FromHex(tx.Input)).Hex(),
+				},
+			})
+		}
+
+		for _, tx := range block.Transactions {
+			userOps, ok, err := seer_common.DecodeUserOperations(tx.ToAddress, tx.Input)
+			if !ok {
+				continue
+			}
+			if err != nil {
+				log.Printf("Failed to decode user operations of transaction %s: %v", tx.Hash, err)
+				continue
+			}
+
+			for _, op := range userOps {
+				var selector string
+				if len(op.CallData) >= 10 {
+					selector = op.CallData[:10]
+				}
+				indexes = append(indexes, indexer.CustomIndex{
+					Kind: UserOperationsIndexKind,
+					Values: map[string]interface{}{
+						"transaction_hash": tx.Hash,
+						"op_index":         op.Index,
+						"block_number":     block.BlockNumber,
+						"block_hash":       block.Hash,
+						"block_timestamp":  block.Timestamp,
+						"entry_point":      op.EntryPoint,
+						"bundler":          strings.ToLower(tx.FromAddress),
+						"sender":           op.Sender,
+						"nonce":            op.Nonce.String(),
+						"paymaster":        op.Paymaster,
+						"selector":         selector,
+					},
+				})
+			}
+		}
+	}
+
+	return indexes, nil
+}
+
+// deployedContractAddress returns address of contract created by transaction, empty if
+// transaction does not create contract. Address is taken from receipt if it was fetched,
+// otherwise it is derived from deployer address and nonce of transaction without recipient.
+func deployedContractAddress(tx *ArbitrumSepoliaTransaction) (string, error) {
+	if tx.ContractAddress != "" && common.HexToAddress(tx.ContractAddress) != (common.Address{}) {
+		return strings.ToLower(tx.ContractAddress), nil
+	}
+	if tx.ToAddress != "" {
+		return "", nil
+	}
+
+	nonce, err := seer_common.ParseQuantity(tx.Nonce)
+	if err != nil {
+		return "", fmt.Errorf("invalid nonce %q of transaction %s: %w", tx.Nonce, tx.Hash, err)
+	}
+
+	return strings.ToLower(crypto.CreateAddress(common.HexToAddress(tx.FromAddress), nonce).Hex()), nil
+}
+
+// transactionFailed reports whether receipt of transaction was fetched and has failed status.
+func transactionFailed(tx *ArbitrumSepoliaTransaction) bool {
+	return tx.GasUsed != "" && tx.Status == 0
+}
+
+// BlobTransactionsFromProtoBlocks returns EIP-4844 transactions of blocks in order of blocks.
+func (c *Client) BlobTransactionsFromProtoBlocks(blocks []proto.Message) ([]seer_common.BlobTransaction, error) {
+	var blobTxs []seer_common.BlobTransaction
+	for _, msg := range blocks {
+		block, ok := msg.(*ArbitrumSepoliaBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *ArbitrumSepoliaBlock")
+		}
+
+		for _, tx := range block.Transactions {
+			if len(tx.BlobVersionedHashes) == 0 {
+				continue
+			}
+			blobTxs = append(blobTxs, seer_common.BlobTransaction{
+				BlockNumber:         block.BlockNumber,
+				BlockHash:           block.Hash,
+				BlockTimestamp:      block.Timestamp,
+				Hash:                tx.Hash,
+				BlobVersionedHashes: tx.BlobVersionedHashes,
+			})
+		}
+	}
+
+	return blobTxs, nil
+}
+
+// CallTracesFromProtoBlocks traces transactions of blocks with debug_traceBlockByNumber and
+// callTracer, returns call trees in order of blocks and transactions.
+func (c *Client) CallTracesFromProtoBlocks(ctx context.Context, blocks []proto.Message) ([]*traces.TransactionCallTrace, error) {
+	var callTraces []*traces.TransactionCallTrace
+	for _, msg := range blocks {
+		block, ok := msg.(*ArbitrumSepoliaBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *ArbitrumSepoliaBlock")
+		}
+
+		var transactionHashes []string
+		for _, tx := range block.Transactions {
+			transactionHashes = append(transactionHashes, tx.Hash)
+		}
+
+		blockTraces, err := traces.TraceBlockCalls(ctx, c.rpcClient, traces.Block{
+			Number:            block.BlockNumber,
+			Hash:              block.Hash,
+			Timestamp:         block.Timestamp,
+			TransactionHashes: transactionHashes,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to trace block %d: %w", block.BlockNumber, err)
+		}
+
+		callTraces = append(callTraces, blockTraces...)
+	}
 
-		blocksSize += uint64(proto.Size(block))
-		blocksProto = append(blocksProto, block) // Assuming block is already a proto.Message
+	return callTraces, nil
+}
+
+// FlatTracesFromProtoBlocks returns flat traces of blocks from trace_block in order of blocks.
+func (c *Client) FlatTracesFromProtoBlocks(ctx context.Context, blocks []proto.Message) ([]*traces.FlatTrace, error) {
+	var flatTraces []*traces.FlatTrace
+	for _, msg := range blocks {
+		block, ok := msg.(*ArbitrumSepoliaBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *ArbitrumSepoliaBlock")
+		}
+
+		blockTraces, err := traces.TraceBlockFlat(ctx, c.rpcClient, traces.Block{
+			Number:    block.BlockNumber,
+			Hash:      block.Hash,
+			Timestamp: block.Timestamp,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to trace block %d: %w", block.BlockNumber, err)
+		}
+
+		flatTraces = append(flatTraces, blockTraces...)
 	}
 
-	return blocksProto, blocksIndex, txsIndex, eventsIndex, blocksSize, nil
+	return flatTraces, nil
 }
 
 func (c *Client) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
@@ -492,10 +1298,44 @@ func ToEntireBlocksBatchFromLogProto(obj *ArbitrumSepoliaBlocksBatch) *seer_comm
 				AccessList:           accessList,
 				YParity:              tx.YParity,
 
+				MaxFeePerBlobGas:    tx.MaxFeePerBlobGas,
+				BlobVersionedHashes: tx.BlobVersionedHashes,
+
+				RequestId:           tx.RequestId,
+				TicketId:            tx.TicketId,
+				MaxRefund:           tx.MaxRefund,
+				SubmissionFeeRefund: tx.SubmissionFeeRefund,
+				RefundTo:            tx.RefundTo,
+				L1BaseFee:           tx.L1BaseFee,
+				DepositValue:        tx.DepositValue,
+				RetryTo:             tx.RetryTo,
+				RetryValue:          tx.RetryValue,
+				RetryData:           tx.RetryData,
+				Beneficiary:         tx.Beneficiary,
+				MaxSubmissionFee:    tx.MaxSubmissionFee,
+				GasUsedForL1:        tx.GasUsedForL1,
+				L1BlockNumber:       fmt.Sprintf("%d", tx.L1BlockNumber),
+
+				Status:            fmt.Sprintf("%d", tx.Status),
+				GasUsed:           tx.GasUsed,
+				CumulativeGasUsed: tx.CumulativeGasUsed,
+				EffectiveGasPrice: tx.EffectiveGasPrice,
+				ContractAddress:   tx.ContractAddress,
+
 				Events: events,
 			})
 		}
 
+		var withdrawals []seer_common.WithdrawalJson
+		for _, w := range b.Withdrawals {
+			withdrawals = append(withdrawals, seer_common.WithdrawalJson{
+				Index:          fmt.Sprintf("%d", w.Index),
+				ValidatorIndex: fmt.Sprintf("%d", w.ValidatorIndex),
+				Address:        w.Address,
+				Amount:         fmt.Sprintf("%d", w.Amount),
+			})
+		}
+
 		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
 			Difficulty:       fmt.Sprintf("%d", b.Difficulty),
 			ExtraData:        b.ExtraData,
@@ -517,6 +1357,12 @@ func ToEntireBlocksBatchFromLogProto(obj *ArbitrumSepoliaBlocksBatch) *seer_comm
 			BaseFeePerGas:    b.BaseFeePerGas,
 			IndexedAt:        fmt.Sprintf("%d", b.IndexedAt),
 
+			BlobGasUsed:   fmt.Sprintf("%d", b.BlobGasUsed),
+			ExcessBlobGas: fmt.Sprintf("%d", b.ExcessBlobGas),
+
+			WithdrawalsRoot: b.WithdrawalsRoot,
+			Withdrawals:     withdrawals,
+
 			MixHash:       b.MixHash,
 			SendCount:     b.SendCount,
 			SendRoot:      b.SendRoot,
@@ -529,13 +1375,25 @@ func ToEntireBlocksBatchFromLogProto(obj *ArbitrumSepoliaBlocksBatch) *seer_comm
 	return &blocksBatchJson
 }
 
-func ToProtoSingleBlock(obj *seer_common.BlockJson) *ArbitrumSepoliaBlock {
-	return &ArbitrumSepoliaBlock{
-		BlockNumber:      fromHex(obj.BlockNumber).Uint64(),
-		Difficulty:       fromHex(obj.Difficulty).Uint64(),
+func ToProtoSingleBlock(obj *seer_common.BlockJson) (*ArbitrumSepoliaBlock, error) {
+	var q seer_common.QuantityParser
+
+	var withdrawals []*ArbitrumSepoliaWithdrawal
+	for _, w := range obj.Withdrawals {
+		withdrawals = append(withdrawals, &ArbitrumSepoliaWithdrawal{
+			Index:          q.Parse("index", w.Index),
+			ValidatorIndex: q.Parse("validatorIndex", w.ValidatorIndex),
+			Address:        w.Address,
+			Amount:         q.Parse("amount", w.Amount),
+		})
+	}
+
+	block := &ArbitrumSepoliaBlock{
+		BlockNumber:      q.Parse("number", obj.BlockNumber),
+		Difficulty:       q.Parse("difficulty", obj.Difficulty),
 		ExtraData:        obj.ExtraData,
-		GasLimit:         fromHex(obj.GasLimit).Uint64(),
-		GasUsed:          fromHex(obj.GasUsed).Uint64(),
+		GasLimit:         q.Parse("gasLimit", obj.GasLimit),
+		GasUsed:          q.Parse("gasUsed", obj.GasUsed),
 		BaseFeePerGas:    obj.BaseFeePerGas,
 		Hash:             obj.Hash,
 		LogsBloom:        obj.LogsBloom,
@@ -544,21 +1402,34 @@ func ToProtoSingleBlock(obj *seer_common.BlockJson) *ArbitrumSepoliaBlock {
 		ParentHash:       obj.ParentHash,
 		ReceiptsRoot:     obj.ReceiptsRoot,
 		Sha3Uncles:       obj.Sha3Uncles,
-		Size:             fromHex(obj.Size).Uint64(),
+		Size:             q.Parse("size", obj.Size),
 		StateRoot:        obj.StateRoot,
-		Timestamp:        fromHex(obj.Timestamp).Uint64(),
+		Timestamp:        q.Parse("timestamp", obj.Timestamp),
 		TotalDifficulty:  obj.TotalDifficulty,
 		TransactionsRoot: obj.TransactionsRoot,
-		IndexedAt:        fromHex(obj.IndexedAt).Uint64(),
+		IndexedAt:        q.Parse("indexed_at", obj.IndexedAt),
+
+		BlobGasUsed:   q.Parse("blobGasUsed", obj.BlobGasUsed),
+		ExcessBlobGas: q.Parse("excessBlobGas", obj.ExcessBlobGas),
+
+		Withdrawals:     withdrawals,
+		WithdrawalsRoot: obj.WithdrawalsRoot,
 
 		MixHash:       obj.MixHash,
 		SendCount:     obj.SendCount,
 		SendRoot:      obj.SendRoot,
-		L1BlockNumber: fromHex(obj.L1BlockNumber).Uint64(),
+		L1BlockNumber: q.Parse("l1BlockNumber", obj.L1BlockNumber),
+	}
+	if err := q.Err(); err != nil {
+		return nil, fmt.Errorf("block %s: %w", obj.Hash, err)
 	}
+
+	return block, nil
 }
 
-func ToProtoSingleTransaction(obj *seer_common.TransactionJson) *ArbitrumSepoliaTransaction {
+func ToProtoSingleTransaction(obj *seer_common.TransactionJson) (*ArbitrumSepoliaTransaction, error) {
+	var q seer_common.QuantityParser
+
 	var accessList []*ArbitrumSepoliaTransactionAccessList
 	for _, al := range obj.AccessList {
 		accessList = append(accessList, &ArbitrumSepoliaTransactionAccessList{
@@ -567,9 +1438,9 @@ func ToProtoSingleTransaction(obj *seer_common.TransactionJson) *ArbitrumSepolia
 		})
 	}
 
-	return &ArbitrumSepoliaTransaction{
+	transaction := &ArbitrumSepoliaTransaction{
 		Hash:                 obj.Hash,
-		BlockNumber:          fromHex(obj.BlockNumber).Uint64(),
+		BlockNumber:          q.Parse("blockNumber", obj.BlockNumber),
 		BlockHash:            obj.BlockHash,
 		FromAddress:          obj.FromAddress,
 		ToAddress:            obj.ToAddress,
@@ -579,11 +1450,11 @@ func ToProtoSingleTransaction(obj *seer_common.TransactionJson) *ArbitrumSepolia
 		MaxPriorityFeePerGas: obj.MaxPriorityFeePerGas,
 		Input:                obj.Input,
 		Nonce:                obj.Nonce,
-		TransactionIndex:     fromHex(obj.TransactionIndex).Uint64(),
-		TransactionType:      fromHex(obj.TransactionType).Uint64(),
+		TransactionIndex:     q.Parse("transactionIndex", obj.TransactionIndex),
+		TransactionType:      q.Parse("type", obj.TransactionType),
 		Value:                obj.Value,
-		IndexedAt:            fromHex(obj.IndexedAt).Uint64(),
-		BlockTimestamp:       fromHex(obj.BlockTimestamp).Uint64(),
+		IndexedAt:            q.Parse("indexed_at", obj.IndexedAt),
+		BlockTimestamp:       q.Parse("block_timestamp", obj.BlockTimestamp),
 
 		ChainId: obj.ChainId,
 		V:       obj.V,
@@ -592,7 +1463,36 @@ func ToProtoSingleTransaction(obj *seer_common.TransactionJson) *ArbitrumSepolia
 
 		AccessList: accessList,
 		YParity:    obj.YParity,
+
+		MaxFeePerBlobGas:    obj.MaxFeePerBlobGas,
+		BlobVersionedHashes: obj.BlobVersionedHashes,
+
+		RequestId:           obj.RequestId,
+		TicketId:            obj.TicketId,
+		MaxRefund:           obj.MaxRefund,
+		SubmissionFeeRefund: obj.SubmissionFeeRefund,
+		RefundTo:            obj.RefundTo,
+		L1BaseFee:           obj.L1BaseFee,
+		DepositValue:        obj.DepositValue,
+		RetryTo:             obj.RetryTo,
+		RetryValue:          obj.RetryValue,
+		RetryData:           obj.RetryData,
+		Beneficiary:         obj.Beneficiary,
+		MaxSubmissionFee:    obj.MaxSubmissionFee,
+		GasUsedForL1:        obj.GasUsedForL1,
+		L1BlockNumber:       q.Parse("l1BlockNumber", obj.L1BlockNumber),
+
+		Status:            q.Parse("status", obj.Status),
+		GasUsed:           obj.GasUsed,
+		CumulativeGasUsed: obj.CumulativeGasUsed,
+		EffectiveGasPrice: obj.EffectiveGasPrice,
+		ContractAddress:   obj.ContractAddress,
+	}
+	if err := q.Err(); err != nil {
+		return nil, fmt.Errorf("transaction %s: %w", obj.Hash, err)
 	}
+
+	return transaction, nil
 }
 
 func ToEvenFromLogProto(obj *ArbitrumSepoliaEventLog) *seer_common.EventJson {
@@ -608,51 +1508,76 @@ func ToEvenFromLogProto(obj *ArbitrumSepoliaEventLog) *seer_common.EventJson {
 	}
 }
 
-func ToProtoSingleEventLog(obj *seer_common.EventJson) *ArbitrumSepoliaEventLog {
-	return &ArbitrumSepoliaEventLog{
+func ToProtoSingleEventLog(obj *seer_common.EventJson) (*ArbitrumSepoliaEventLog, error) {
+	var q seer_common.QuantityParser
+
+	event := &ArbitrumSepoliaEventLog{
 		Address:         obj.Address,
 		Topics:          obj.Topics,
 		Data:            obj.Data,
-		BlockNumber:     fromHex(obj.BlockNumber).Uint64(),
+		BlockNumber:     q.Parse("blockNumber", obj.BlockNumber),
 		TransactionHash: obj.TransactionHash,
-		LogIndex:        fromHex(obj.LogIndex).Uint64(),
+		LogIndex:        q.Parse("logIndex", obj.LogIndex),
 		BlockHash:       obj.BlockHash,
 		Removed:         obj.Removed,
 	}
+	if err := q.Err(); err != nil {
+		return nil, fmt.Errorf("log %s of transaction %s: %w", obj.LogIndex, obj.TransactionHash, err)
+	}
+
+	return event, nil
 }
 
 func (c *Client) DecodeProtoEventLogs(data []string) ([]*ArbitrumSepoliaEventLog, error) {
-	var events []*ArbitrumSepoliaEventLog
+	events := make([]*ArbitrumSepoliaEventLog, 0, len(data))
 	for _, d := range data {
-		var event ArbitrumSepoliaEventLog
-		base64Decoded, err := base64.StdEncoding.DecodeString(d)
+		event, err := decodeProtoEventLog(d)
 		if err != nil {
 			return nil, err
 		}
-		if err := proto.Unmarshal(base64Decoded, &event); err != nil {
-			return nil, err
-		}
-		events = append(events, &event)
+		events = append(events, event)
 	}
 	return events, nil
 }
 
+// decodeProtoEventLog decodes single base64 encoded proto event log.
+func decodeProtoEventLog(data string) (*ArbitrumSepoliaEventLog, error) {
+	var event ArbitrumSepoliaEventLog
+	base64Decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(base64Decoded, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
 func (c *Client) DecodeProtoTransactions(data []string) ([]*ArbitrumSepoliaTransaction, error) {
-	var transactions []*ArbitrumSepoliaTransaction
+	transactions := make([]*ArbitrumSepoliaTransaction, 0, len(data))
 	for _, d := range data {
-		var transaction ArbitrumSepoliaTransaction
-		base64Decoded, err := base64.StdEncoding.DecodeString(d)
+		transaction, err := decodeProtoTransaction(d)
 		if err != nil {
 			return nil, err
 		}
-		if err := proto.Unmarshal(base64Decoded, &transaction); err != nil {
-			return nil, err
-		}
-		transactions = append(transactions, &transaction)
+		transactions = append(transactions, transaction)
 	}
 	return transactions, nil
 }
 
+// decodeProtoTransaction decodes single base64 encoded proto transaction.
+func decodeProtoTransaction(data string) (*ArbitrumSepoliaTransaction, error) {
+	var transaction ArbitrumSepoliaTransaction
+	base64Decoded, err := base64.StdEncoding.DecodeString(data)
+	if err != nil {
+		return nil, err
+	}
+	if err := proto.Unmarshal(base64Decoded, &transaction); err != nil {
+		return nil, err
+	}
+	return &transaction, nil
+}
+
 func (c *Client) DecodeProtoBlocks(data []string) ([]*ArbitrumSepoliaBlock, error) {
 	var blocks []*ArbitrumSepoliaBlock
 	for _, d := range data {
@@ -684,169 +1609,569 @@ func (c *Client) DecodeProtoEntireBlockToJson(rawData *bytes.Buffer) (*seer_comm
 	return blocksBatchJson, nil
 }
 
-func (c *Client) DecodeProtoEntireBlockToLabels(rawData *bytes.Buffer, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
-	var protoBlocksBatch ArbitrumSepoliaBlocksBatch
-
-	dataBytes := rawData.Bytes()
+// DecodeProtoEntireBlockToLabels decodes labels of all blocks of proto batch.
+func (c *Client) DecodeProtoEntireBlockToLabels(ctx context.Context, rawData *bytes.Buffer, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
+	var labels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
 
-	err := proto.Unmarshal(dataBytes, &protoBlocksBatch)
+	err := c.StreamProtoEntireBlockToLabels(ctx, rawData, blocksCache, abiMap, func(blockLabels []indexer.EventLabel, blockTxLabels []indexer.TransactionLabel) error {
+		labels = append(labels, blockLabels...)
+		txLabels = append(txLabels, blockTxLabels...)
+		return nil
+	})
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to unmarshal data: %v", err)
+		return nil, nil, err
+	}
+
+	return labels, txLabels, nil
+}
+
+// StreamProtoEntireBlockToLabels decodes proto batch block by block and passes labels of every
+// block to emit, so decoded blocks of batch are not kept in memory together. Decoding stops at the
+// first error of emit.
+func (c *Client) StreamProtoEntireBlockToLabels(ctx context.Context, rawData *bytes.Buffer, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string, emit func([]indexer.EventLabel, []indexer.TransactionLabel) error) error {
+	return forEachProtoBlock(rawData.Bytes(), func(b *ArbitrumSepoliaBlock) error {
+		labels, txLabels, err := c.decodeBlockToLabels(ctx, b, abiMap)
+		if err != nil {
+			return err
+		}
+		if len(labels) == 0 && len(txLabels) == 0 {
+			return nil
+		}
+		return emit(labels, txLabels)
+	})
+}
+
+// forEachProtoBlock unmarshals blocks of proto batch one at a time and calls fn with every block,
+// other fields of batch are skipped.
+func forEachProtoBlock(data []byte, fn func(*ArbitrumSepoliaBlock) error) error {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return fmt.Errorf("failed to unmarshal data: %v", protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		// Blocks are field 1 of ArbitrumSepoliaBlocksBatch
+		if num != 1 || typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return fmt.Errorf("failed to unmarshal data: %v", protowire.ParseError(n))
+			}
+			data = data[n:]
+			continue
+		}
+
+		blockData, n := protowire.ConsumeBytes(data)
+		if n < 0 {
+			return fmt.Errorf("failed to unmarshal data: %v", protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		var block ArbitrumSepoliaBlock
+		if err := proto.Unmarshal(blockData, &block); err != nil {
+			return fmt.Errorf("failed to unmarshal data: %v", err)
+		}
+		if err := fn(&block); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
+// decodeBlockToLabels decodes labels of transactions and events of block.
+func (c *Client) decodeBlockToLabels(ctx context.Context, b *ArbitrumSepoliaBlock, abiMap map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
 	var labels []indexer.EventLabel
 	var txLabels []indexer.TransactionLabel
 	var decodeErr error
 
-	for _, b := range protoBlocksBatch.Blocks {
-		for _, tx := range b.Transactions {
-			var decodedArgsTx map[string]interface{}
+	for _, tx := range b.Transactions {
+		var decodedArgsTx map[string]interface{}
 
-			label := indexer.SeerCrawlerLabel
+		label := indexer.SeerCrawlerLabel
 
-			if len(tx.Input) < 10 { // If input is less than 3 characters then it direct transfer
-				continue
+		if tx.ToAddress == "" {
+			deploymentLabel, err := c.decodeDeployment(abiMap, b, tx)
+			if err != nil {
+				return nil, nil, err
+			}
+			if deploymentLabel != nil {
+				txLabels = append(txLabels, *deploymentLabel)
+			}
+		}
+
+		if len(tx.Input) < 10 { // If input is less than 3 characters then it direct transfer
+			continue
+		}
+
+		// Meta-transactions of trusted forwarders are attributed to the real sender
+		target, input, sender, relayer, forwarder := tx.ToAddress, tx.Input, tx.FromAddress, "", ""
+		if seer_common.IsTrustedForwarder(c.ChainType(), tx.ToAddress) {
+			// Forwarder contract executes request signed by the real sender
+			if request, ok := seer_common.DecodeForwardRequest(tx.Input); ok && len(request.Data) >= 10 {
+				target, input, sender, relayer, forwarder = request.To, request.Data, request.From, tx.FromAddress, tx.ToAddress
 			}
+		} else if seer_common.IsTrustedForwarder(c.ChainType(), tx.FromAddress) {
+			// Relayer calls recipient directly with address of the real sender appended to calldata
+			if calldata, realSender, ok := seer_common.SplitMetaTransaction(tx.Input); ok {
+				input, sender, relayer = calldata, realSender, tx.FromAddress
+			}
+		}
 
-			// Process transaction labels
-			selector := tx.Input[:10]
+		// Process transaction labels
+		selector := input[:10]
+		toAddress := c.hasher.NormalizeAddress(target)
+
+		txAbi, txAbiName, txSignature := c.abiForSelector(ctx, abiMap, toAddress, selector, seer_common.FunctionSelectorKind, 0, tx.BlockNumber)
+		hookName, hookArgs, hooked, hookErr := decodeWithHook(abiMap, seer_common.DecoderCall{
+			Chain:    c.ChainType(),
+			Kind:     seer_common.FunctionSelectorKind,
+			Address:  toAddress,
+			Selector: selector,
+			Input:    input,
+		})
+		if hooked || txAbi != "" {
+			if hooked {
+				txAbiName, decodedArgsTx = hookName, hookArgs
+				if hookErr != nil {
+					fmt.Println("Error decoding transaction with decoder hook: ", tx.Hash, hookErr)
+					decodedArgsTx = map[string]interface{}{
+						"input_raw": tx,
+						"selector":  selector,
+						"error":     hookErr.Error(),
+					}
+					label = indexer.SeerCrawlerRawLabel
+				}
+			} else {
+				if txSignature != "" {
+					label = indexer.SeerCrawlerPartialLabel
+				}
 
-			if abiMap[tx.ToAddress] != nil && abiMap[tx.ToAddress][selector] != nil {
-				txContractAbi, err := abi.JSON(strings.NewReader(abiMap[tx.ToAddress][selector]["abi"]))
+				txContractAbi, err := abi.JSON(strings.NewReader(txAbi))
 				if err != nil {
 					fmt.Println("Error initializing contract ABI transactions: ", err)
 					return nil, nil, err
 				}
 
-				inputData, err := hex.DecodeString(tx.Input[2:])
+				inputData, err := hex.DecodeString(input[2:])
 				if err != nil {
 					fmt.Println("Error decoding input data: ", err)
 					return nil, nil, err
 				}
 
-				decodedArgsTx, decodeErr = seer_common.DecodeTransactionInputDataToInterface(&txContractAbi, inputData)
+				decodedArgsTx, decodeErr = seer_common.DecodeTransactionInputDataToInterface(c.hasher, &txContractAbi, inputData)
 				if decodeErr != nil {
 					fmt.Println("Error decoding transaction not decoded data: ", tx.Hash, decodeErr)
 					decodedArgsTx = map[string]interface{}{
 						"input_raw": tx,
-						"abi":       abiMap[tx.ToAddress][selector]["abi"],
+						"abi":       txAbi,
 						"selector":  selector,
 						"error":     decodeErr,
 					}
 					label = indexer.SeerCrawlerRawLabel
 				}
-
-				txLabelDataBytes, err := json.Marshal(decodedArgsTx)
-				if err != nil {
-					fmt.Println("Error converting decodedArgsTx to JSON: ", err)
-					return nil, nil, err
+				if txSignature != "" {
+					decodedArgsTx["signature"] = txSignature
 				}
+			}
+			if relayer != "" {
+				decodedArgsTx["relayer"] = relayer
+			}
+			if forwarder != "" {
+				decodedArgsTx["forwarder"] = forwarder
+			}
 
-				// Convert transaction to label
-				transactionLabel := indexer.TransactionLabel{
-					Address:         tx.ToAddress,
-					BlockNumber:     tx.BlockNumber,
-					BlockHash:       tx.BlockHash,
-					CallerAddress:   tx.FromAddress,
-					LabelName:       abiMap[tx.ToAddress][selector]["abi_name"],
-					LabelType:       "tx_call",
-					OriginAddress:   tx.FromAddress,
-					Label:           label,
-					TransactionHash: tx.Hash,
-					LabelData:       string(txLabelDataBytes), // Convert JSON byte slice to string
-					BlockTimestamp:  b.Timestamp,
+			labelType := "tx_call"
+			if c.revertReasons != nil && transactionFailed(tx) {
+				labelType = "tx_call_failed"
+				revertReason := c.revertReasons.Resolve(ctx, seer_common.FailedTransaction{
+					Hash:        tx.Hash,
+					BlockNumber: tx.BlockNumber,
+					From:        tx.FromAddress,
+					To:          tx.ToAddress,
+					Input:       tx.Input,
+					Value:       tx.Value,
+					Gas:         tx.Gas,
+				})
+				for key, value := range revertReason.LabelData() {
+					decodedArgsTx[key] = value
 				}
+			}
 
-				txLabels = append(txLabels, transactionLabel)
+			txLabelDataBytes, err := json.Marshal(decodedArgsTx)
+			if err != nil {
+				fmt.Println("Error converting decodedArgsTx to JSON: ", err)
+				return nil, nil, err
 			}
 
-			// Process events
-			for _, e := range tx.Logs {
-				var decodedArgsLogs map[string]interface{}
-				label = indexer.SeerCrawlerLabel
+			// Convert transaction to label
+			transactionLabel := indexer.TransactionLabel{
+				Address:         target,
+				BlockNumber:     tx.BlockNumber,
+				BlockHash:       tx.BlockHash,
+				CallerAddress:   sender,
+				LabelName:       txAbiName,
+				LabelType:       labelType,
+				OriginAddress:   sender,
+				Label:           label,
+				TransactionHash: tx.Hash,
+				LabelData:       string(txLabelDataBytes), // Convert JSON byte slice to string
+				BlockTimestamp:  b.Timestamp,
+			}
+
+			txLabels = append(txLabels, transactionLabel)
+		}
 
-				var topicSelector string
+		if c.expandMulticalls {
+			subCallLabels, err := c.decodeSubCalls(ctx, abiMap, b, tx, toAddress, input, sender)
+			if err != nil {
+				return nil, nil, err
+			}
+			txLabels = append(txLabels, subCallLabels...)
+		}
 
-				if len(e.Topics) > 0 {
-					topicSelector = e.Topics[0]
-				} else {
-					// 0x0 is the default topic selector
-					topicSelector = "0x0"
-				}
+		// Process events
+		for _, e := range tx.Logs {
+			var decodedArgsLogs map[string]interface{}
+			label = indexer.SeerCrawlerLabel
 
-				if abiMap[e.Address] == nil || abiMap[e.Address][topicSelector] == nil {
-					continue
+			var topicSelector string
+
+			if len(e.Topics) > 0 {
+				topicSelector = e.Topics[0]
+			} else {
+				// 0x0 is the default topic selector
+				topicSelector = "0x0"
+			}
+
+			eventAddress := c.hasher.NormalizeAddress(e.Address)
+
+			eventAbi, eventAbiName, eventSignature := c.abiForSelector(ctx, abiMap, eventAddress, topicSelector, seer_common.EventSelectorKind, len(e.Topics)-1, e.BlockNumber)
+			hookName, hookArgs, hooked, hookErr := decodeWithHook(abiMap, seer_common.DecoderCall{
+				Chain:    c.ChainType(),
+				Kind:     seer_common.EventSelectorKind,
+				Address:  eventAddress,
+				Selector: topicSelector,
+				Topics:   e.Topics,
+				Data:     e.Data,
+			})
+			if !hooked && eventAbi == "" {
+				continue
+			}
+
+			if hooked {
+				eventAbiName, decodedArgsLogs = hookName, hookArgs
+				if hookErr != nil {
+					fmt.Println("Error decoding event with decoder hook: ", e.TransactionHash, hookErr)
+					decodedArgsLogs = map[string]interface{}{
+						"input_raw": e,
+						"selector":  topicSelector,
+						"error":     hookErr.Error(),
+					}
+					label = indexer.SeerCrawlerRawLabel
+				}
+			} else {
+				if eventSignature != "" {
+					label = indexer.SeerCrawlerPartialLabel
 				}
 
 				// Get the ABI string
-				contractAbi, err := abi.JSON(strings.NewReader(abiMap[e.Address][topicSelector]["abi"]))
+				contractAbi, err := abi.JSON(strings.NewReader(eventAbi))
 				if err != nil {
 					fmt.Println("Error initializing contract ABI: ", err)
 					return nil, nil, err
 				}
 
 				// Decode the event data
-				decodedArgsLogs, decodeErr = seer_common.DecodeLogArgsToLabelData(&contractAbi, e.Topics, e.Data)
+				decodedArgsLogs, decodeErr = seer_common.DecodeLogArgsToLabelData(c.hasher, &contractAbi, e.Topics, e.Data)
 				if decodeErr != nil {
 					fmt.Println("Error decoding event not decoded data: ", e.TransactionHash, decodeErr)
 					decodedArgsLogs = map[string]interface{}{
 						"input_raw": e,
-						"abi":       abiMap[e.Address][topicSelector]["abi"],
+						"abi":       eventAbi,
 						"selector":  topicSelector,
 						"error":     decodeErr,
 					}
 					label = indexer.SeerCrawlerRawLabel
 				}
-
-				// Convert decodedArgsLogs map to JSON
-				labelDataBytes, err := json.Marshal(decodedArgsLogs)
-				if err != nil {
-					fmt.Println("Error converting decodedArgsLogs to JSON: ", err)
-					return nil, nil, err
+				if eventSignature != "" {
+					decodedArgsLogs["signature"] = eventSignature
 				}
+			}
 
-				// Convert event to label
-				eventLabel := indexer.EventLabel{
-					Label:           label,
-					LabelName:       abiMap[e.Address][topicSelector]["abi_name"],
-					LabelType:       "event",
-					BlockNumber:     e.BlockNumber,
-					BlockHash:       e.BlockHash,
-					Address:         e.Address,
-					OriginAddress:   tx.FromAddress,
-					TransactionHash: e.TransactionHash,
-					LabelData:       string(labelDataBytes), // Convert JSON byte slice to string
-					BlockTimestamp:  b.Timestamp,
-					LogIndex:        e.LogIndex,
-				}
+			// Convert decodedArgsLogs map to JSON
+			labelDataBytes, err := json.Marshal(decodedArgsLogs)
+			if err != nil {
+				fmt.Println("Error converting decodedArgsLogs to JSON: ", err)
+				return nil, nil, err
+			}
 
-				labels = append(labels, eventLabel)
+			// Convert event to label
+			eventLabel := indexer.EventLabel{
+				Label:           label,
+				LabelName:       eventAbiName,
+				LabelType:       "event",
+				BlockNumber:     e.BlockNumber,
+				BlockHash:       e.BlockHash,
+				Address:         e.Address,
+				OriginAddress:   tx.FromAddress,
+				TransactionHash: e.TransactionHash,
+				LabelData:       string(labelDataBytes), // Convert JSON byte slice to string
+				BlockTimestamp:  b.Timestamp,
+				LogIndex:        e.LogIndex,
 			}
+
+			labels = append(labels, eventLabel)
 		}
 	}
 
 	return labels, txLabels, nil
 }
 
-func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error) {
+// decodeDeployment decodes constructor arguments of contract created by transaction into deployment
+// label, nil if there is no constructor ABI job for created contract.
+func (c *Client) decodeDeployment(abiMap map[string]map[string]map[string]string, b *ArbitrumSepoliaBlock, tx *ArbitrumSepoliaTransaction) (*indexer.TransactionLabel, error) {
+	address, err := deployedContractAddress(tx)
+	if err != nil {
+		return nil, err
+	}
+	if address == "" {
+		return nil, nil
+	}
+	address = c.hasher.NormalizeAddress(address)
+
+	job := abiMap[address][seer_common.ConstructorSelector]
+	if job == nil {
+		return nil, nil
+	}
+
+	label := indexer.SeerCrawlerLabel
+
+	constructorAbi, err := abi.JSON(strings.NewReader(job["abi"]))
+	if err != nil {
+		fmt.Println("Error initializing contract ABI constructor: ", err)
+		return nil, err
+	}
+
+	inputData, err := hex.DecodeString(strings.TrimPrefix(tx.Input, "0x"))
+	if err != nil {
+		fmt.Println("Error decoding input data: ", err)
+		return nil, err
+	}
+
+	bytecodeLength, err := seer_common.ConstructorBytecodeLength(job["abi"])
+	if err != nil {
+		return nil, err
+	}
 
-	decodedTransactions, err := c.DecodeProtoTransactions(transactions)
+	decodedArgs, decodeErr := seer_common.DecodeConstructorArgsToLabelData(&constructorAbi, inputData, bytecodeLength)
+	if decodeErr != nil {
+		fmt.Println("Error decoding constructor arguments: ", tx.Hash, decodeErr)
+		decodedArgs = map[string]interface{}{
+			"input_raw": tx,
+			"abi":       job["abi"],
+			"selector":  seer_common.ConstructorSelector,
+			"error":     decodeErr.Error(),
+		}
+		label = indexer.SeerCrawlerRawLabel
+	}
 
+	labelDataBytes, err := json.Marshal(decodedArgs)
 	if err != nil {
+		fmt.Println("Error converting decodedArgs to JSON: ", err)
 		return nil, err
 	}
 
+	return &indexer.TransactionLabel{
+		Address:         address,
+		BlockNumber:     tx.BlockNumber,
+		BlockHash:       tx.BlockHash,
+		CallerAddress:   tx.FromAddress,
+		LabelName:       seer_common.ConstructorSelector,
+		LabelType:       "deployment",
+		OriginAddress:   tx.FromAddress,
+		Label:           label,
+		TransactionHash: tx.Hash,
+		LabelData:       string(labelDataBytes),
+		BlockTimestamp:  b.Timestamp,
+	}, nil
+}
+
+// decodeSubCalls decodes calls to contracts with ABI jobs batched in multicall transaction into
+// sub-call labels with hash of multicall transaction, nested multicalls are expanded too.
+func (c *Client) decodeSubCalls(ctx context.Context, abiMap map[string]map[string]map[string]string, b *ArbitrumSepoliaBlock, tx *ArbitrumSepoliaTransaction, toAddress, input, sender string) ([]indexer.TransactionLabel, error) {
+	subCalls, ok, err := seer_common.ExpandMulticall(toAddress, sender, input)
+	if !ok {
+		return nil, nil
+	}
+	if err != nil {
+		fmt.Println("Error expanding multicall: ", tx.Hash, err)
+		return nil, nil
+	}
+
+	var labels []indexer.TransactionLabel
+	for _, call := range subCalls {
+		if len(call.Input) < 10 {
+			continue
+		}
+
+		target := c.hasher.NormalizeAddress(call.Target)
+		selector := call.Input[:10]
+
+		callAbi, callAbiName, callSignature := c.abiForSelector(ctx, abiMap, target, selector, seer_common.FunctionSelectorKind, 0, tx.BlockNumber)
+		if callAbi == "" {
+			continue
+		}
+
+		label := indexer.SeerCrawlerLabel
+		if callSignature != "" {
+			label = indexer.SeerCrawlerPartialLabel
+		}
+
+		callContractAbi, err := abi.JSON(strings.NewReader(callAbi))
+		if err != nil {
+			fmt.Println("Error initializing contract ABI sub-calls: ", err)
+			return nil, err
+		}
+
+		inputData, err := hex.DecodeString(call.Input[2:])
+		if err != nil {
+			fmt.Println("Error decoding input data: ", err)
+			return nil, err
+		}
+
+		decodedArgs, decodeErr := seer_common.DecodeTransactionInputDataToInterface(c.hasher, &callContractAbi, inputData)
+		if decodeErr != nil {
+			fmt.Println("Error decoding sub-call not decoded data: ", tx.Hash, call.Index, decodeErr)
+			decodedArgs = map[string]interface{}{
+				"input_raw": call.Input,
+				"abi":       callAbi,
+				"selector":  selector,
+				"error":     decodeErr.Error(),
+			}
+			label = indexer.SeerCrawlerRawLabel
+		}
+		if callSignature != "" {
+			decodedArgs["signature"] = callSignature
+		}
+		decodedArgs["parent_transaction_hash"] = tx.Hash
+		decodedArgs["call_index"] = call.Index
+
+		labelType, origin := "tx_subcall", sender
+		if call.Origin != "" {
+			origin = call.Origin
+		}
+		if op := call.UserOperation; op != nil {
+			labelType = "user_operation"
+			decodedArgs["entry_point"] = op.EntryPoint
+			decodedArgs["user_operation_index"] = op.Index
+			decodedArgs["nonce"] = op.Nonce.String()
+			decodedArgs["paymaster"] = op.Paymaster
+		}
+
+		labelDataBytes, err := json.Marshal(decodedArgs)
+		if err != nil {
+			fmt.Println("Error converting decodedArgs to JSON: ", err)
+			return nil, err
+		}
+
+		labels = append(labels, indexer.TransactionLabel{
+			Address:         target,
+			BlockNumber:     tx.BlockNumber,
+			BlockHash:       tx.BlockHash,
+			CallerAddress:   call.Caller,
+			LabelName:       callAbiName,
+			LabelType:       labelType,
+			OriginAddress:   origin,
+			Label:           label,
+			TransactionHash: tx.Hash,
+			LabelData:       string(labelDataBytes),
+			BlockTimestamp:  b.Timestamp,
+			CallIndex:       call.Index,
+		})
+	}
+
+	return labels, nil
+}
+
+// abiForSelector returns ABI and its name for selector of contract with ABI jobs. Selector missing
+// in ABIs of proxy is looked up in ABIs of its implementation at block when proxies are resolved,
+// then it is resolved with signature databases when resolver is set and signature text is
+// returned too.
+func (c *Client) abiForSelector(ctx context.Context, abiMap map[string]map[string]map[string]string, address, selector, kind string, indexedArgs int, blockNumber uint64) (string, string, string) {
+	if abiMap[address] == nil {
+		return "", "", ""
+	}
+	if abiMap[address][selector] != nil {
+		return abiMap[address][selector]["abi"], abiMap[address][selector]["abi_name"], ""
+	}
+
+	if c.proxies != nil {
+		implementation := c.proxies.Implementation(ctx, address, blockNumber)
+		if implementation != "" {
+			implementation = c.hasher.NormalizeAddress(implementation)
+			if abiMap[implementation][selector] != nil {
+				return abiMap[implementation][selector]["abi"], abiMap[implementation][selector]["abi_name"], ""
+			}
+		}
+	}
+
+	abiJSON, name, signature, ok := seer_common.ResolveUnknownSelector(kind, selector, indexedArgs)
+	if !ok {
+		return "", "", ""
+	}
+	return abiJSON, name, signature
+}
+
+// decodeWithHook decodes call of contract with ABI jobs with decoder hook registered for it. It
+// returns false when there is no hook or hook does not handle call.
+func decodeWithHook(abiMap map[string]map[string]map[string]string, call seer_common.DecoderCall) (string, map[string]interface{}, bool, error) {
+	if abiMap[call.Address] == nil {
+		return "", nil, false, nil
+	}
+
+	decoder := seer_common.LookupDecoder(call.Chain, call.Address, call.Selector)
+	if decoder == nil {
+		return "", nil, false, nil
+	}
+
+	if job := abiMap[call.Address][call.Selector]; job != nil {
+		call.ABI, call.ABIName = job["abi"], job["abi_name"]
+	}
+
+	name, data, err := decoder.Decode(call)
+	if err != nil {
+		return call.ABIName, nil, true, err
+	}
+	if name == "" {
+		return "", nil, false, nil
+	}
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+
+	return name, data, true, nil
+}
+
+// DecodeProtoTransactionsToLabels decodes labels of base64 encoded proto transactions, transactions
+// are unmarshalled one at a time as they are labeled.
+func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error) {
 	var labels []indexer.TransactionLabel
 	var decodedArgs map[string]interface{}
 	var decodeErr error
 
-	for _, transaction := range decodedTransactions {
+	for _, data := range transactions {
+		transaction, err := decodeProtoTransaction(data)
+		if err != nil {
+			return nil, err
+		}
 
 		label := indexer.SeerCrawlerLabel
 
 		selector := transaction.Input[:10]
+		toAddress := c.hasher.NormalizeAddress(transaction.ToAddress)
 
-		contractAbi, err := abi.JSON(strings.NewReader(abiMap[transaction.ToAddress][selector]["abi"]))
+		contractAbi, err := abi.JSON(strings.NewReader(abiMap[toAddress][selector]["abi"]))
 
 		if err != nil {
 			return nil, err
@@ -858,13 +2183,13 @@ func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCa
 			return nil, err
 		}
 
-		decodedArgs, decodeErr = seer_common.DecodeTransactionInputDataToInterface(&contractAbi, inputData)
+		decodedArgs, decodeErr = seer_common.DecodeTransactionInputDataToInterface(c.hasher, &contractAbi, inputData)
 
 		if decodeErr != nil {
 			fmt.Println("Error decoding transaction not decoded data: ", transaction.Hash, decodeErr)
 			decodedArgs = map[string]interface{}{
 				"input_raw": transaction,
-				"abi":       abiMap[transaction.ToAddress][selector]["abi"],
+				"abi":       abiMap[toAddress][selector]["abi"],
 				"selector":  selector,
 				"error":     decodeErr,
 			}
@@ -886,7 +2211,7 @@ func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCa
 			BlockNumber:     transaction.BlockNumber,
 			BlockHash:       transaction.BlockHash,
 			CallerAddress:   transaction.FromAddress,
-			LabelName:       abiMap[transaction.ToAddress][selector]["abi_name"],
+			LabelName:       abiMap[toAddress][selector]["abi_name"],
 			LabelType:       "tx_call",
 			OriginAddress:   transaction.FromAddress,
 			Label:           label,
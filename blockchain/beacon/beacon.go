@@ -0,0 +1,501 @@
+package beacon
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/version"
+)
+
+// BlobSidecarsIndexKind is kind of custom index with blob sidecars, stored in <chain>_blob_sidecars table
+const BlobSidecarsIndexKind = "blob_sidecars"
+
+// blobCommitmentVersionKZG is version byte of blob versioned hashes
+const blobCommitmentVersionKZG = 0x01
+
+// ErrNotFound is returned by beacon API for slots without block and for pruned sidecars.
+var ErrNotFound = errors.New("not found")
+
+// BlobSidecarsIndexTable describes index table of blob sidecars, registered by crawler for chains
+// with beacon node configured.
+var BlobSidecarsIndexTable = indexer.CustomIndexTable{
+	Kind: BlobSidecarsIndexKind,
+	Columns: []indexer.CustomIndexColumn{
+		{Name: "block_number", Type: "BIGINT"},
+		{Name: "block_hash", Type: "TEXT"},
+		{Name: "block_timestamp", Type: "BIGINT"},
+		{Name: "slot", Type: "BIGINT"},
+		{Name: "blob_index", Type: "BIGINT"},
+		{Name: "transaction_hash", Type: "TEXT"},
+		{Name: "versioned_hash", Type: "TEXT"},
+		{Name: "kzg_commitment", Type: "TEXT"},
+	},
+	ConflictClause: "ON CONFLICT (block_hash, blob_index) DO NOTHING",
+}
+
+func NewClient(rawURL string, timeout int) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(rawURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: seer_common.NewHTTPClient(rawURL, time.Duration(timeout)*time.Second),
+	}, nil
+}
+
+// Client is a wrapper around the beacon node API, used to crawl beacon chain and to fetch blob
+// sidecars of EIP-4844 transactions before they are pruned by consensus clients.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+
+	mu             sync.Mutex
+	genesisTime    uint64
+	secondsPerSlot uint64
+	slotsPerEpoch  uint64
+}
+
+func (c *Client) get(ctx context.Context, path string, result interface{}) error {
+	requestURL := *c.baseURL
+	requestPath, rawQuery, _ := strings.Cut(path, "?")
+	requestURL.Path += requestPath
+	requestURL.RawQuery = rawQuery
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("request %s: %w", path, ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request %s failed with status %d: %s", path, resp.StatusCode, string(body))
+	}
+
+	return json.Unmarshal(body, result)
+}
+
+// loadChainParameters fetches genesis time and slot duration once, they are required to map
+// execution block timestamps to beacon slots.
+func (c *Client) loadChainParameters(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.secondsPerSlot != 0 {
+		return nil
+	}
+
+	var genesis GenesisResponseJson
+	if err := c.get(ctx, "/eth/v1/beacon/genesis", &genesis); err != nil {
+		return err
+	}
+	genesisTime, err := strconv.ParseUint(genesis.Data.GenesisTime, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid genesis time %q: %v", genesis.Data.GenesisTime, err)
+	}
+
+	var spec SpecResponseJson
+	if err := c.get(ctx, "/eth/v1/config/spec", &spec); err != nil {
+		return err
+	}
+	secondsPerSlot, err := strconv.ParseUint(spec.Data.SecondsPerSlot, 10, 64)
+	if err != nil || secondsPerSlot == 0 {
+		return fmt.Errorf("invalid SECONDS_PER_SLOT %q", spec.Data.SecondsPerSlot)
+	}
+	slotsPerEpoch, err := strconv.ParseUint(spec.Data.SlotsPerEpoch, 10, 64)
+	if err != nil || slotsPerEpoch == 0 {
+		return fmt.Errorf("invalid SLOTS_PER_EPOCH %q", spec.Data.SlotsPerEpoch)
+	}
+
+	c.genesisTime = genesisTime
+	c.secondsPerSlot = secondsPerSlot
+	c.slotsPerEpoch = slotsPerEpoch
+
+	return nil
+}
+
+// SlotAtTimestamp returns beacon slot of execution block with timestamp.
+func (c *Client) SlotAtTimestamp(ctx context.Context, timestamp uint64) (uint64, error) {
+	if err := c.loadChainParameters(ctx); err != nil {
+		return 0, err
+	}
+	if timestamp < c.genesisTime {
+		return 0, fmt.Errorf("timestamp %d is before beacon genesis %d", timestamp, c.genesisTime)
+	}
+
+	return (timestamp - c.genesisTime) / c.secondsPerSlot, nil
+}
+
+// TimestampAtSlot returns start time of beacon slot.
+func (c *Client) TimestampAtSlot(ctx context.Context, slot uint64) (uint64, error) {
+	if err := c.loadChainParameters(ctx); err != nil {
+		return 0, err
+	}
+
+	return c.genesisTime + slot*c.secondsPerSlot, nil
+}
+
+// SlotsPerEpoch returns number of slots in epoch of beacon chain.
+func (c *Client) SlotsPerEpoch(ctx context.Context) (uint64, error) {
+	if err := c.loadChainParameters(ctx); err != nil {
+		return 0, err
+	}
+
+	return c.slotsPerEpoch, nil
+}
+
+// GetBlockHeader returns header of beacon block with id: slot, block root, "head" or "finalized".
+// ErrNotFound is returned for slots without block.
+func (c *Client) GetBlockHeader(ctx context.Context, blockID string) (*BlockHeaderJson, error) {
+	var response BlockHeaderResponseJson
+	if err := c.get(ctx, fmt.Sprintf("/eth/v1/beacon/headers/%s", blockID), &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// GetBlock returns signed beacon block with id, ErrNotFound is returned for slots without block.
+func (c *Client) GetBlock(ctx context.Context, blockID string) (*SignedBeaconBlockJson, error) {
+	var response BlockResponseJson
+	if err := c.get(ctx, fmt.Sprintf("/eth/v2/beacon/blocks/%s", blockID), &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Data, nil
+}
+
+// GetValidatorBalances returns balances of validators with indexes or public keys in state at
+// slot.
+func (c *Client) GetValidatorBalances(ctx context.Context, slot uint64, validators []string) ([]ValidatorBalanceJson, error) {
+	query := url.Values{}
+	for _, validator := range validators {
+		query.Add("id", validator)
+	}
+
+	var response ValidatorBalancesResponseJson
+	if err := c.get(ctx, fmt.Sprintf("/eth/v1/beacon/states/%d/validator_balances?%s", slot, query.Encode()), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// GetBlobSidecars returns all blob sidecars of beacon block at slot.
+func (c *Client) GetBlobSidecars(ctx context.Context, slot uint64) ([]BlobSidecarJson, error) {
+	var response BlobSidecarsResponseJson
+	if err := c.get(ctx, fmt.Sprintf("/eth/v1/beacon/blob_sidecars/%d", slot), &response); err != nil {
+		return nil, err
+	}
+
+	return response.Data, nil
+}
+
+// FetchBlobSidecars fetches sidecars of blobs referenced by transactions. Blocks with pruned
+// sidecars are skipped with warning, sidecars are returned in order of blocks and blob indexes.
+func (c *Client) FetchBlobSidecars(ctx context.Context, blobTxs []seer_common.BlobTransaction) ([]*BlobSidecar, error) {
+	var sidecars []*BlobSidecar
+
+	for start := 0; start < len(blobTxs); {
+		end := start
+		for end < len(blobTxs) && blobTxs[end].BlockHash == blobTxs[start].BlockHash {
+			end++
+		}
+		blockTxs := blobTxs[start:end]
+		start = end
+
+		blockSidecars, err := c.fetchBlockBlobSidecars(ctx, blockTxs)
+		if err != nil {
+			if errors.Is(err, ErrNotFound) {
+				log.Printf("Blob sidecars of block %d are not available: %v", blockTxs[0].BlockNumber, err)
+				continue
+			}
+			return nil, err
+		}
+
+		sidecars = append(sidecars, blockSidecars...)
+	}
+
+	return sidecars, nil
+}
+
+func (c *Client) fetchBlockBlobSidecars(ctx context.Context, blockTxs []seer_common.BlobTransaction) ([]*BlobSidecar, error) {
+	block := blockTxs[0]
+
+	slot, err := c.SlotAtTimestamp(ctx, block.BlockTimestamp)
+	if err != nil {
+		return nil, err
+	}
+
+	sidecarsJson, err := c.GetBlobSidecars(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+
+	transactionByVersionedHash := make(map[string]string)
+	for _, tx := range blockTxs {
+		for _, versionedHash := range tx.BlobVersionedHashes {
+			transactionByVersionedHash[strings.ToLower(versionedHash)] = tx.Hash
+		}
+	}
+
+	indexedAt := uint64(time.Now().Unix())
+
+	var sidecars []*BlobSidecar
+	for _, sidecarJson := range sidecarsJson {
+		versionedHash, err := KzgToVersionedHash(sidecarJson.KzgCommitment)
+		if err != nil {
+			return nil, err
+		}
+
+		transactionHash, ok := transactionByVersionedHash[versionedHash]
+		if !ok {
+			continue
+		}
+
+		sidecar, err := ToProtoSingleSidecar(&sidecarJson)
+		if err != nil {
+			return nil, err
+		}
+		sidecar.BlockNumber = block.BlockNumber
+		sidecar.BlockHash = block.BlockHash
+		sidecar.BlockTimestamp = block.BlockTimestamp
+		sidecar.TransactionHash = transactionHash
+		sidecar.VersionedHash = versionedHash
+		sidecar.IndexedAt = indexedAt
+
+		sidecars = append(sidecars, sidecar)
+	}
+
+	if len(sidecars) == 0 {
+		return nil, fmt.Errorf("no blob sidecars at slot %d match transactions of block %d", slot, block.BlockNumber)
+	}
+
+	return sidecars, nil
+}
+
+// KzgToVersionedHash returns versioned hash of blob: version byte followed by sha256 of commitment.
+func KzgToVersionedHash(kzgCommitment string) (string, error) {
+	commitment, err := hex.DecodeString(strings.TrimPrefix(kzgCommitment, "0x"))
+	if err != nil {
+		return "", fmt.Errorf("invalid kzg commitment: %v", err)
+	}
+
+	hash := sha256.Sum256(commitment)
+	hash[0] = blobCommitmentVersionKZG
+
+	return "0x" + hex.EncodeToString(hash[:]), nil
+}
+
+func ToProtoSingleSidecar(obj *BlobSidecarJson) (*BlobSidecar, error) {
+	blob, err := hex.DecodeString(strings.TrimPrefix(obj.Blob, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob %s: %v", obj.Index, err)
+	}
+	blobIndex, err := strconv.ParseUint(obj.Index, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob index %q: %v", obj.Index, err)
+	}
+	slot, err := strconv.ParseUint(obj.SignedBlockHeader.Message.Slot, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid slot %q: %v", obj.SignedBlockHeader.Message.Slot, err)
+	}
+
+	return &BlobSidecar{
+		Slot:          slot,
+		BodyRoot:      obj.SignedBlockHeader.Message.BodyRoot,
+		BlobIndex:     blobIndex,
+		KzgCommitment: obj.KzgCommitment,
+		KzgProof:      obj.KzgProof,
+		Blob:          blob,
+	}, nil
+}
+
+// ProcessSidecarsToBatch returns batch of sidecars stored next to blocks batch.
+func ProcessSidecarsToBatch(sidecars []*BlobSidecar) proto.Message {
+	return &BlobSidecarsBatch{
+		Sidecars:    sidecars,
+		SeerVersion: version.SeerVersion,
+	}
+}
+
+// SidecarsToCustomIndexes returns rows of blob sidecars index table.
+func SidecarsToCustomIndexes(sidecars []*BlobSidecar) []indexer.CustomIndex {
+	var indexes []indexer.CustomIndex
+	for _, sidecar := range sidecars {
+		indexes = append(indexes, indexer.CustomIndex{
+			Kind: BlobSidecarsIndexKind,
+			Values: map[string]interface{}{
+				"block_number":     sidecar.BlockNumber,
+				"block_hash":       sidecar.BlockHash,
+				"block_timestamp":  sidecar.BlockTimestamp,
+				"slot":             sidecar.Slot,
+				"blob_index":       sidecar.BlobIndex,
+				"transaction_hash": sidecar.TransactionHash,
+				"versioned_hash":   sidecar.VersionedHash,
+				"kzg_commitment":   sidecar.KzgCommitment,
+			},
+		})
+	}
+
+	return indexes
+}
+
+// Beacon API structures
+
+type GenesisResponseJson struct {
+	Data struct {
+		GenesisTime string `json:"genesis_time"`
+	} `json:"data"`
+}
+
+type SpecResponseJson struct {
+	Data struct {
+		SecondsPerSlot string `json:"SECONDS_PER_SLOT"`
+		SlotsPerEpoch  string `json:"SLOTS_PER_EPOCH"`
+	} `json:"data"`
+}
+
+type BeaconBlockHeaderJson struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+type SignedBeaconBlockHeaderJson struct {
+	Message   BeaconBlockHeaderJson `json:"message"`
+	Signature string                `json:"signature"`
+}
+
+type BlobSidecarJson struct {
+	Index             string                      `json:"index"`
+	Blob              string                      `json:"blob"`
+	KzgCommitment     string                      `json:"kzg_commitment"`
+	KzgProof          string                      `json:"kzg_proof"`
+	SignedBlockHeader SignedBeaconBlockHeaderJson `json:"signed_block_header"`
+}
+
+type BlobSidecarsResponseJson struct {
+	Data []BlobSidecarJson `json:"data"`
+}
+
+type BlockHeaderJson struct {
+	Root      string                      `json:"root"`
+	Canonical bool                        `json:"canonical"`
+	Header    SignedBeaconBlockHeaderJson `json:"header"`
+}
+
+type BlockHeaderResponseJson struct {
+	Data BlockHeaderJson `json:"data"`
+}
+
+type CheckpointJson struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type AttestationDataJson struct {
+	Slot            string         `json:"slot"`
+	Index           string         `json:"index"`
+	BeaconBlockRoot string         `json:"beacon_block_root"`
+	Source          CheckpointJson `json:"source"`
+	Target          CheckpointJson `json:"target"`
+}
+
+type AttestationJson struct {
+	AggregationBits string              `json:"aggregation_bits"`
+	Data            AttestationDataJson `json:"data"`
+	Signature       string              `json:"signature"`
+}
+
+type Eth1DataJson struct {
+	DepositRoot  string `json:"deposit_root"`
+	DepositCount string `json:"deposit_count"`
+	BlockHash    string `json:"block_hash"`
+}
+
+type ExecutionPayloadJson struct {
+	BlockNumber  string   `json:"block_number"`
+	BlockHash    string   `json:"block_hash"`
+	FeeRecipient string   `json:"fee_recipient"`
+	GasUsed      string   `json:"gas_used"`
+	Transactions []string `json:"transactions"`
+	Withdrawals  []struct {
+		Index          string `json:"index"`
+		ValidatorIndex string `json:"validator_index"`
+		Address        string `json:"address"`
+		Amount         string `json:"amount"`
+	} `json:"withdrawals"`
+}
+
+type BeaconBlockBodyJson struct {
+	RandaoReveal      string                `json:"randao_reveal"`
+	Eth1Data          Eth1DataJson          `json:"eth1_data"`
+	Graffiti          string                `json:"graffiti"`
+	ProposerSlashings []json.RawMessage     `json:"proposer_slashings"`
+	AttesterSlashings []json.RawMessage     `json:"attester_slashings"`
+	Attestations      []AttestationJson     `json:"attestations"`
+	Deposits          []json.RawMessage     `json:"deposits"`
+	VoluntaryExits    []json.RawMessage     `json:"voluntary_exits"`
+	ExecutionPayload  *ExecutionPayloadJson `json:"execution_payload"`
+}
+
+type BeaconBlockJson struct {
+	Slot          string              `json:"slot"`
+	ProposerIndex string              `json:"proposer_index"`
+	ParentRoot    string              `json:"parent_root"`
+	StateRoot     string              `json:"state_root"`
+	Body          BeaconBlockBodyJson `json:"body"`
+}
+
+type SignedBeaconBlockJson struct {
+	Message   BeaconBlockJson `json:"message"`
+	Signature string          `json:"signature"`
+}
+
+type BlockResponseJson struct {
+	Version string                `json:"version"`
+	Data    SignedBeaconBlockJson `json:"data"`
+}
+
+type ValidatorBalanceJson struct {
+	Index   string `json:"index"`
+	Balance string `json:"balance"`
+}
+
+type ValidatorBalancesResponseJson struct {
+	Data []ValidatorBalanceJson `json:"data"`
+}
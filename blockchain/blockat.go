@@ -0,0 +1,58 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+// BlockTimestamp returns timestamp of block, it is read with BlocksTimestampsReader if client
+// implements it, otherwise block is fetched with transactions and events.
+func BlockTimestamp(ctx context.Context, client BlockchainClient, blockNumber uint64) (uint64, error) {
+	if timestampsReader, ok := client.(BlocksTimestampsReader); ok {
+		timestamps, err := timestampsReader.GetBlocksTimestamps(ctx, []uint64{blockNumber})
+		if err != nil {
+			return 0, err
+		}
+		timestamp, ok := timestamps[blockNumber]
+		if !ok {
+			return 0, fmt.Errorf("block %d is not found", blockNumber)
+		}
+		return timestamp, nil
+	}
+
+	number := new(big.Int).SetUint64(blockNumber)
+	_, blocksIndex, _, _, _, err := client.FetchAsProtoBlocksWithEvents(ctx, number, number, false, 1)
+	if err != nil {
+		return 0, err
+	}
+	for _, block := range blocksIndex {
+		if block.BlockNumber == blockNumber {
+			return block.BlockTimestamp, nil
+		}
+	}
+
+	return 0, fmt.Errorf("block %d is not found", blockNumber)
+}
+
+// BlockAtTimestamp returns the last block with timestamp at or before timestamp by binary search
+// over blocks from lowBlock to highBlock, timestamp of lowBlock should be at or before timestamp.
+// Number of RPC calls is logarithm of range.
+func BlockAtTimestamp(ctx context.Context, client BlockchainClient, timestamp, lowBlock, highBlock uint64) (uint64, error) {
+	for lowBlock < highBlock {
+		middleBlock := lowBlock + (highBlock-lowBlock+1)/2
+
+		middleTimestamp, err := BlockTimestamp(ctx, client, middleBlock)
+		if err != nil {
+			return 0, err
+		}
+
+		if middleTimestamp <= timestamp {
+			lowBlock = middleBlock
+		} else {
+			highBlock = middleBlock - 1
+		}
+	}
+
+	return lowBlock, nil
+}
@@ -0,0 +1,154 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// probeBatchSizes are batch sizes tried from the largest while probing batch size limit of node
+var probeBatchSizes = []int{100, 50, 20, 10, 5, 2}
+
+// NodeCapabilities is profile of optional JSON-RPC methods supported by EVM node.
+type NodeCapabilities struct {
+	BlockReceipts bool `json:"block_receipts"` // eth_getBlockReceipts
+	DebugTrace    bool `json:"debug_trace"`    // debug_traceBlockByNumber with callTracer
+	ParityTrace   bool `json:"parity_trace"`   // trace_block
+	SafeTag       bool `json:"safe_tag"`       // "safe" block tag
+	FinalizedTag  bool `json:"finalized_tag"`  // "finalized" block tag
+	MaxBatchSize  int  `json:"max_batch_size"` // batch size limit of node, 0 if the largest probed batch is accepted
+}
+
+// SupportsBlockTag reports if node returns blocks by "safe" or "finalized" tag.
+func (nc *NodeCapabilities) SupportsBlockTag(tag string) bool {
+	switch tag {
+	case "safe":
+		return nc.SafeTag
+	case "finalized":
+		return nc.FinalizedTag
+	}
+	return false
+}
+
+func (nc *NodeCapabilities) String() string {
+	var supported []string
+	for _, method := range []struct {
+		name      string
+		supported bool
+	}{
+		{"eth_getBlockReceipts", nc.BlockReceipts},
+		{"debug_traceBlockByNumber", nc.DebugTrace},
+		{"trace_block", nc.ParityTrace},
+		{"safe tag", nc.SafeTag},
+		{"finalized tag", nc.FinalizedTag},
+	} {
+		if method.supported {
+			supported = append(supported, method.name)
+		}
+	}
+
+	maxBatchSize := "unlimited"
+	if nc.MaxBatchSize > 0 {
+		maxBatchSize = fmt.Sprintf("%d", nc.MaxBatchSize)
+	}
+
+	return fmt.Sprintf("supported: [%s], max batch size: %s", strings.Join(supported, ", "), maxBatchSize)
+}
+
+// ProbeNodeCapabilities calls optional methods on the latest block to find which of them node
+// supports. Method is considered unsupported if node responds with JSON-RPC error, transport
+// errors are returned. With several endpoints probes are sent to the healthiest one.
+func ProbeNodeCapabilities(ctx context.Context, client *RPCClient) (*NodeCapabilities, error) {
+	var latest *struct {
+		Number string `json:"number"`
+		Hash   string `json:"hash"`
+	}
+	if err := client.CallContext(ctx, &latest, "eth_getBlockByNumber", "latest", false); err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return nil, errors.New("node returned no latest block")
+	}
+
+	var capabilities NodeCapabilities
+	var err error
+
+	capabilities.BlockReceipts, err = probeMethod(ctx, client, "eth_getBlockReceipts", latest.Hash)
+	if err != nil {
+		return nil, err
+	}
+	capabilities.DebugTrace, err = probeMethod(ctx, client, "debug_traceBlockByNumber", latest.Number, map[string]interface{}{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]interface{}{"onlyTopCall": true},
+	})
+	if err != nil {
+		return nil, err
+	}
+	capabilities.ParityTrace, err = probeMethod(ctx, client, "trace_block", latest.Number)
+	if err != nil {
+		return nil, err
+	}
+	capabilities.SafeTag, err = probeMethod(ctx, client, "eth_getBlockByNumber", "safe", false)
+	if err != nil {
+		return nil, err
+	}
+	capabilities.FinalizedTag, err = probeMethod(ctx, client, "eth_getBlockByNumber", "finalized", false)
+	if err != nil {
+		return nil, err
+	}
+
+	capabilities.MaxBatchSize = probeMaxBatchSize(ctx, client)
+
+	return &capabilities, nil
+}
+
+// probeMethod calls method and reports if node responded with non-empty result.
+func probeMethod(ctx context.Context, client *RPCClient, method string, args ...interface{}) (bool, error) {
+	var result json.RawMessage
+	err := client.CallContext(ctx, &result, method, args...)
+	if err != nil {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return len(result) > 0 && string(result) != "null", nil
+}
+
+// probeMaxBatchSize returns the largest of probeBatchSizes node answers without errors, 0 if
+// node accepts all of them. Batch size limits are reported differently by providers: as HTTP
+// error, JSON-RPC error of the whole batch or errors of elements over limit.
+func probeMaxBatchSize(ctx context.Context, client *RPCClient) int {
+	for i, size := range probeBatchSizes {
+		batch := make([]rpc.BatchElem, size)
+		for j := range batch {
+			batch[j] = rpc.BatchElem{Method: "eth_blockNumber", Result: new(string)}
+		}
+
+		if err := client.BatchCallContext(ctx, batch); err != nil {
+			continue
+		}
+
+		accepted := true
+		for _, elem := range batch {
+			if elem.Error != nil {
+				accepted = false
+				break
+			}
+		}
+		if accepted {
+			if i == 0 {
+				return 0
+			}
+			return size
+		}
+	}
+
+	return 1
+}
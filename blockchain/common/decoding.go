@@ -7,10 +7,12 @@ import (
 	"log"
 	"math/big"
 	"os"
+	"strconv"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/math"
 )
 
 type BlocksBatchJson struct {
@@ -39,11 +41,22 @@ type BlockJson struct {
 	BaseFeePerGas    string `json:"baseFeePerGas"`
 	IndexedAt        string `json:"indexed_at"`
 
+	BlobGasUsed   string `json:"blobGasUsed,omitempty"`
+	ExcessBlobGas string `json:"excessBlobGas,omitempty"`
+
+	WithdrawalsRoot string           `json:"withdrawalsRoot,omitempty"`
+	Withdrawals     []WithdrawalJson `json:"withdrawals,omitempty"`
+
 	MixHash       string `json:"mixHash,omitempty"`
 	SendCount     string `json:"sendCount,omitempty"`
 	SendRoot      string `json:"sendRoot,omitempty"`
 	L1BlockNumber string `json:"l1BlockNumber,omitempty"`
 
+	L1BatchNumber    string `json:"l1BatchNumber,omitempty"`
+	L1BatchTimestamp string `json:"l1BatchTimestamp,omitempty"`
+
+	L1Attributes *L1BlockAttributes `json:"l1Attributes,omitempty"`
+
 	Transactions []TransactionJson `json:"transactions,omitempty"`
 }
 
@@ -72,14 +85,94 @@ type TransactionJson struct {
 	AccessList []AccessList `json:"accessList,omitempty"`
 	YParity    string       `json:"yParity,omitempty"`
 
+	MaxFeePerBlobGas    string   `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes []string `json:"blobVersionedHashes,omitempty"`
+
+	L1BatchNumber  string      `json:"l1BatchNumber,omitempty"`
+	L1BatchTxIndex string      `json:"l1BatchTxIndex,omitempty"`
+	Eip712Meta     *Eip712Meta `json:"eip712Meta,omitempty"`
+
+	SourceHash string `json:"sourceHash,omitempty"`
+	Mint       string `json:"mint,omitempty"`
+	IsSystemTx bool   `json:"isSystemTx,omitempty"`
+
+	RequestId           string `json:"requestId,omitempty"`
+	TicketId            string `json:"ticketId,omitempty"`
+	MaxRefund           string `json:"maxRefund,omitempty"`
+	SubmissionFeeRefund string `json:"submissionFeeRefund,omitempty"`
+	RefundTo            string `json:"refundTo,omitempty"`
+	L1BaseFee           string `json:"l1BaseFee,omitempty"`
+	DepositValue        string `json:"depositValue,omitempty"`
+	RetryTo             string `json:"retryTo,omitempty"`
+	RetryValue          string `json:"retryValue,omitempty"`
+	RetryData           string `json:"retryData,omitempty"`
+	Beneficiary         string `json:"beneficiary,omitempty"`
+	MaxSubmissionFee    string `json:"maxSubmissionFee,omitempty"`
+
+	// Receipt fields, filled when crawler fetches receipts
+	Status            string `json:"status,omitempty"`
+	GasUsed           string `json:"gasUsed,omitempty"`
+	CumulativeGasUsed string `json:"cumulativeGasUsed,omitempty"`
+	EffectiveGasPrice string `json:"effectiveGasPrice,omitempty"`
+	ContractAddress   string `json:"contractAddress,omitempty"`
+	GasUsedForL1      string `json:"gasUsedForL1,omitempty"`
+	L1BlockNumber     string `json:"l1BlockNumber,omitempty"`
+
 	Events []EventJson `json:"events,omitempty"`
 }
 
+// WithdrawalJson is EIP-4895 withdrawal of validator, amount is in Gwei
+type WithdrawalJson struct {
+	Index          string `json:"index"`
+	ValidatorIndex string `json:"validatorIndex"`
+	Address        string `json:"address"`
+	Amount         string `json:"amount"`
+}
+
 type AccessList struct {
 	Address     string   `json:"address"`
 	StorageKeys []string `json:"storageKeys"`
 }
 
+// BlobTransaction is EIP-4844 transaction with versioned hashes of blobs it carries, used to
+// match blob sidecars fetched from beacon node
+type BlobTransaction struct {
+	BlockNumber         uint64
+	BlockHash           string
+	BlockTimestamp      uint64
+	Hash                string
+	BlobVersionedHashes []string
+}
+
+// ReceiptJson holds transaction receipt fields stored with crawled transactions, gasUsedForL1
+// and l1BlockNumber are returned only by Arbitrum nodes
+type ReceiptJson struct {
+	TransactionHash   string `json:"transactionHash"`
+	BlockHash         string `json:"blockHash"`
+	Status            string `json:"status"`
+	GasUsed           string `json:"gasUsed"`
+	CumulativeGasUsed string `json:"cumulativeGasUsed"`
+	EffectiveGasPrice string `json:"effectiveGasPrice"`
+	ContractAddress   string `json:"contractAddress"`
+	GasUsedForL1      string `json:"gasUsedForL1"`
+	L1BlockNumber     string `json:"l1BlockNumber"`
+
+	Logs []*EventJson `json:"logs"`
+}
+
+// Eip712Meta holds zkSync Era fields of EIP-712 (0x71) transactions
+type Eip712Meta struct {
+	GasPerPubdata   string           `json:"gasPerPubdata"`
+	FactoryDeps     []string         `json:"factoryDeps,omitempty"`
+	CustomSignature string           `json:"customSignature,omitempty"`
+	PaymasterParams *PaymasterParams `json:"paymasterParams,omitempty"`
+}
+
+type PaymasterParams struct {
+	Paymaster      string `json:"paymaster"`
+	PaymasterInput string `json:"paymasterInput"`
+}
+
 // SingleEvent represents a single event within a transaction
 type EventJson struct {
 	Address          string   `json:"address"`
@@ -163,12 +256,47 @@ func ReadJsonEventLogs() []*EventJson {
 	return eventLogs
 }
 
-func DecodeTransactionInputDataToInterface(contractABI *abi.ABI, data []byte) (map[string]interface{}, error) {
+// ParseQuantity parses unsigned quantity of RPC payload: 0x prefixed hex as returned by nodes or
+// decimal as restored from proto. Empty string is zero, nodes omit optional fields.
+func ParseQuantity(value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	if digits, ok := strings.CutPrefix(value, "0x"); ok {
+		return strconv.ParseUint(digits, 16, 64)
+	}
+	return strconv.ParseUint(value, 10, 64)
+}
+
+// QuantityParser parses quantities of RPC payload field by field, the first malformed field is
+// kept and reported by Err, so struct literals could be filled without check of every field.
+type QuantityParser struct {
+	err error
+}
+
+// Parse returns value of field parsed with ParseQuantity, zero if value is malformed.
+func (p *QuantityParser) Parse(field, value string) uint64 {
+	quantity, err := ParseQuantity(value)
+	if err != nil && p.err == nil {
+		p.err = fmt.Errorf("invalid %s %q: %w", field, value, err)
+	}
+	return quantity
+}
+
+// Err returns error of the first malformed field.
+func (p *QuantityParser) Err() error {
+	return p.err
+}
+
+func DecodeTransactionInputDataToInterface(hasher Hasher, contractABI *abi.ABI, data []byte) (map[string]interface{}, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("input data is too short: %d bytes", len(data))
+	}
 	methodSigData := data[:4]
 	inputsSigData := data[4:]
-	method, err := contractABI.MethodById(methodSigData)
+	method, err := MethodBySelector(hasher, contractABI, methodSigData)
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 	inputsMap := make(map[string]interface{})
 	if err := method.Inputs.UnpackIntoMap(inputsMap, inputsSigData); err != nil {
@@ -191,24 +319,68 @@ func DecodeTransactionInputDataToInterface(contractABI *abi.ABI, data []byte) (m
 	return labelData, nil
 }
 
-func DecodeLogArgsToLabelData(contractABI *abi.ABI, topics []string, data string) (map[string]interface{}, error) {
+// ConstructorSelector is abi_selector of ABI jobs with constructor of contract. Constructor ABI
+// entry keeps length of contract creation bytecode in bytes in its bytecode_length field.
+const ConstructorSelector = "constructor"
+
+// ConstructorBytecodeLength returns bytecode_length of constructor ABI entries, 0 if it is not set.
+func ConstructorBytecodeLength(constructorABI string) (int, error) {
+	var entries []struct {
+		BytecodeLength int `json:"bytecode_length"`
+	}
+	if err := json.Unmarshal([]byte(constructorABI), &entries); err != nil {
+		return 0, fmt.Errorf("failed to decode constructor ABI: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.BytecodeLength > 0 {
+			return entry.BytecodeLength, nil
+		}
+	}
+	return 0, nil
+}
 
-	topic0 := topics[0]
+// DecodeConstructorArgsToLabelData decodes constructor arguments appended to creation bytecode of
+// contract in input of deployment transaction.
+func DecodeConstructorArgsToLabelData(contractABI *abi.ABI, input []byte, bytecodeLength int) (map[string]interface{}, error) {
+	if bytecodeLength <= 0 {
+		return nil, fmt.Errorf("bytecode length of contract is not stored with constructor ABI")
+	}
+	if len(input) < bytecodeLength {
+		return nil, fmt.Errorf("input of %d bytes is shorter than bytecode of %d bytes", len(input), bytecodeLength)
+	}
 
-	// Convert the topic0 string to common.Hash
-	topic0Hash := common.HexToHash(topic0)
+	inputsMap := make(map[string]interface{})
+	if err := contractABI.Constructor.Inputs.UnpackIntoMap(inputsMap, input[bytecodeLength:]); err != nil {
+		return nil, fmt.Errorf("cannot unpack constructor arguments: %w", err)
+	}
 
-	event, err := contractABI.EventByID(
-		topic0Hash,
-	)
+	labelData := make(map[string]interface{})
+	labelData["type"] = "deployment"
+	labelData["gas_used"] = 0
+	labelData["args"] = inputsMap
+
+	if _, err := json.Marshal(labelData); err != nil {
+		return nil, err
+	}
+
+	return labelData, nil
+}
+
+func DecodeLogArgsToLabelData(hasher Hasher, contractABI *abi.ABI, topics []string, data string) (map[string]interface{}, error) {
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("event has no topics")
+	}
+
+	event, err := EventByTopic(hasher, contractABI, topics[0])
 	if err != nil {
-		log.Fatal(err)
+		return nil, err
 	}
 
 	// Decode the data string from hex to bytes
 	dataBytes, err := hex.DecodeString(strings.TrimPrefix(data, "0x"))
 	if err != nil {
-		log.Fatalf("Failed to decode data string: %v", err)
+		return nil, fmt.Errorf("failed to decode data string: %v", err)
 	}
 
 	// Prepare the map to hold the input data
@@ -223,6 +395,9 @@ func DecodeLogArgsToLabelData(contractABI *abi.ABI, topics []string, data string
 		var arg interface{}
 		if input.Indexed {
 			// Note: topic[0] is the event signature, so indexed params start from topic[1]
+			if i >= len(topics) {
+				return nil, fmt.Errorf("event %s has %d topics, indexed argument %s is missing", event.Name, len(topics), input.Name)
+			}
 			switch input.Type.T {
 			case abi.AddressTy:
 				arg = common.HexToAddress(topics[i]).Hex()
@@ -244,8 +419,10 @@ func DecodeLogArgsToLabelData(contractABI *abi.ABI, topics []string, data string
 					return nil, fmt.Errorf("failed to decode hex string to normal string: %v", err)
 				}
 				arg = string(argBytes)
+			case abi.IntTy:
+				arg = math.S256(new(big.Int).SetBytes(common.Hex2Bytes(topics[i][2:])))
 			default:
-				log.Fatalf("Unsupported indexed type: %s", input.Type.String())
+				return nil, fmt.Errorf("unsupported indexed type: %s", input.Type.String())
 			}
 			i++
 		} else {
@@ -0,0 +1,142 @@
+package common
+
+import (
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	trustedForwardersMu sync.RWMutex
+	trustedForwarders   = map[string]map[string]bool{}
+)
+
+// RegisterTrustedForwarder makes synchronizer treat transactions of address on chain as EIP-2771
+// meta-transactions. Requests which transactions sent to forwarder contract execute are decoded
+// as calls of their targets by their signers, transactions sent by relayer directly to recipient
+// are decoded without address of the real sender appended to calldata and attributed to it.
+func RegisterTrustedForwarder(chain, address string) {
+	trustedForwardersMu.Lock()
+	defer trustedForwardersMu.Unlock()
+
+	if _, ok := trustedForwarders[chain]; !ok {
+		trustedForwarders[chain] = make(map[string]bool)
+	}
+	trustedForwarders[chain][strings.ToLower(address)] = true
+}
+
+// IsTrustedForwarder returns true if address is registered as trusted forwarder of chain.
+func IsTrustedForwarder(chain, address string) bool {
+	trustedForwardersMu.RLock()
+	defer trustedForwardersMu.RUnlock()
+
+	return trustedForwarders[chain][strings.ToLower(address)]
+}
+
+// SplitMetaTransaction splits hex encoded input of EIP-2771 meta-transaction sent by relayer
+// directly to recipient into calldata and lowercase address of the real sender from its last 20
+// bytes. Calldata of ABI encoded call is selector followed by 32 bytes words, so input which does
+// not end with 20 extra bytes is not split and false is returned.
+func SplitMetaTransaction(input string) (string, string, bool) {
+	data := strings.TrimPrefix(input, "0x")
+	// Hex characters of selector and appended address
+	if len(data) < 8+40 || (len(data)-8)%64 != 40 {
+		return "", "", false
+	}
+
+	return "0x" + data[:len(data)-40], "0x" + strings.ToLower(data[len(data)-40:]), true
+}
+
+// forwarderABI has methods of forwarder contracts which execute request signed by the real sender:
+// execute of OpenZeppelin MinimalForwarder and ERC2771Forwarder and of GSN Forwarder,
+// executeEIP712 and executePersonalSign of Biconomy forwarder and sponsoredCallERC2771 of Gelato
+// relay. Request is the first argument of every method.
+const forwarderABI = `[
+{"type":"function","name":"execute","stateMutability":"payable","inputs":[{"name":"req","type":"tuple","components":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"gas","type":"uint256"},{"name":"nonce","type":"uint256"},{"name":"data","type":"bytes"}]},{"name":"signature","type":"bytes"}],"outputs":[]},
+{"type":"function","name":"execute","stateMutability":"payable","inputs":[{"name":"request","type":"tuple","components":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"gas","type":"uint256"},{"name":"deadline","type":"uint48"},{"name":"data","type":"bytes"},{"name":"signature","type":"bytes"}]}],"outputs":[]},
+{"type":"function","name":"execute","stateMutability":"payable","inputs":[{"name":"req","type":"tuple","components":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"gas","type":"uint256"},{"name":"nonce","type":"uint256"},{"name":"data","type":"bytes"},{"name":"validUntil","type":"uint256"}]},{"name":"domainSeparator","type":"bytes32"},{"name":"requestTypeHash","type":"bytes32"},{"name":"suffixData","type":"bytes"},{"name":"sig","type":"bytes"}],"outputs":[]},
+{"type":"function","name":"executeEIP712","stateMutability":"nonpayable","inputs":[{"name":"req","type":"tuple","components":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"token","type":"address"},{"name":"txGas","type":"uint256"},{"name":"tokenGasPrice","type":"uint256"},{"name":"batchId","type":"uint256"},{"name":"batchNonce","type":"uint256"},{"name":"deadline","type":"uint256"},{"name":"data","type":"bytes"}]},{"name":"domainSeparator","type":"bytes32"},{"name":"sig","type":"bytes"}],"outputs":[]},
+{"type":"function","name":"executePersonalSign","stateMutability":"nonpayable","inputs":[{"name":"req","type":"tuple","components":[{"name":"from","type":"address"},{"name":"to","type":"address"},{"name":"token","type":"address"},{"name":"txGas","type":"uint256"},{"name":"tokenGasPrice","type":"uint256"},{"name":"batchId","type":"uint256"},{"name":"batchNonce","type":"uint256"},{"name":"deadline","type":"uint256"},{"name":"data","type":"bytes"}]},{"name":"sig","type":"bytes"}],"outputs":[]},
+{"type":"function","name":"sponsoredCallERC2771","stateMutability":"nonpayable","inputs":[{"name":"_call","type":"tuple","components":[{"name":"chainId","type":"uint256"},{"name":"target","type":"address"},{"name":"data","type":"bytes"},{"name":"user","type":"address"},{"name":"userNonce","type":"uint256"},{"name":"userDeadline","type":"uint256"}]},{"name":"_sponsor","type":"address"},{"name":"_feeToken","type":"address"},{"name":"_oneBalanceChainId","type":"uint256"},{"name":"_userSignature","type":"bytes"},{"name":"_nativeToFeeTokenXRateNumerator","type":"uint256"},{"name":"_nativeToFeeTokenXRateDenominator","type":"uint256"},{"name":"_correlationId","type":"bytes32"}],"outputs":[]}
+]`
+
+var forwarderMethods map[string]abi.Method
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(forwarderABI))
+	if err != nil {
+		panic(fmt.Sprintf("invalid forwarder ABI: %v", err))
+	}
+
+	forwarderMethods = make(map[string]abi.Method)
+	for _, method := range parsed.Methods {
+		forwarderMethods["0x"+hex.EncodeToString(method.ID)] = method
+	}
+}
+
+// ForwardRequest is call of target contract which forwarder contract executes on behalf of signer
+// of request.
+type ForwardRequest struct {
+	// From is lowercase address of signer of request, the real sender of meta-transaction
+	From string
+	// To is lowercase address of target contract
+	To string
+	// Data is hex encoded calldata of call of target, without address of sender forwarder
+	// appends to it
+	Data string
+}
+
+// DecodeForwardRequest decodes request executed by hex encoded input of call of forwarder
+// contract. False is returned when input is not call of known forwarder method.
+func DecodeForwardRequest(input string) (ForwardRequest, bool) {
+	if len(input) < 10 {
+		return ForwardRequest{}, false
+	}
+	method, ok := forwarderMethods[strings.ToLower(input[:10])]
+	if !ok {
+		return ForwardRequest{}, false
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x")[8:])
+	if err != nil {
+		return ForwardRequest{}, false
+	}
+	args, err := method.Inputs.Unpack(data)
+	if err != nil || len(args) == 0 {
+		return ForwardRequest{}, false
+	}
+
+	// Requests are unpacked into anonymous structs which differ between forwarders, Gelato names
+	// signer and target user and target
+	request := reflect.ValueOf(args[0])
+	if request.Kind() != reflect.Struct {
+		return ForwardRequest{}, false
+	}
+	from, fromOk := requestField(request, "From", "User").(common.Address)
+	to, toOk := requestField(request, "To", "Target").(common.Address)
+	callData, dataOk := requestField(request, "Data").([]byte)
+	if !fromOk || !toOk || !dataOk {
+		return ForwardRequest{}, false
+	}
+
+	return ForwardRequest{
+		From: strings.ToLower(from.Hex()),
+		To:   strings.ToLower(to.Hex()),
+		Data: "0x" + hex.EncodeToString(callData),
+	}, true
+}
+
+// requestField returns value of the first of fields request has, nil if it has none of them.
+func requestField(request reflect.Value, names ...string) interface{} {
+	for _, name := range names {
+		if field := request.FieldByName(name); field.IsValid() {
+			return field.Interface()
+		}
+	}
+	return nil
+}
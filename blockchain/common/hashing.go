@@ -0,0 +1,330 @@
+package common
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"hash/crc32"
+	"math/big"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Hasher abstracts chain specific computation of function selectors, event topics and
+// addresses. Decoders use it instead of assuming EVM defaults, so chains with different
+// derivation rules (Tron, zk chains) produce correct labels.
+type Hasher interface {
+	// MethodSelector returns the 0x-prefixed selector for a method signature, e.g. "transfer(address,uint256)".
+	MethodSelector(signature string) string
+	// EventTopic returns the 0x-prefixed topic0 for an event signature.
+	EventTopic(signature string) string
+	// NormalizeAddress converts an address into the form used as key in ABI jobs and labels.
+	NormalizeAddress(address string) string
+	// CreateAddress returns the address of a contract deployed by deployer with the given nonce.
+	CreateAddress(deployer string, nonce uint64) string
+}
+
+// EVMHasher implements Hasher with standard Ethereum rules: keccak256 selectors and topics,
+// lowercase 0x-prefixed hex addresses and RLP based CREATE derivation.
+type EVMHasher struct{}
+
+func (h EVMHasher) MethodSelector(signature string) string {
+	return "0x" + hex.EncodeToString(crypto.Keccak256([]byte(signature))[:4])
+}
+
+func (h EVMHasher) EventTopic(signature string) string {
+	return "0x" + hex.EncodeToString(crypto.Keccak256([]byte(signature)))
+}
+
+func (h EVMHasher) NormalizeAddress(address string) string {
+	return strings.ToLower(address)
+}
+
+func (h EVMHasher) CreateAddress(deployer string, nonce uint64) string {
+	return strings.ToLower(crypto.CreateAddress(common.HexToAddress(deployer), nonce).Hex())
+}
+
+// zkSyncCreatePrefix is keccak256("zksyncCreate"), used by zkSync Era for CREATE addresses.
+var zkSyncCreatePrefix = crypto.Keccak256([]byte("zksyncCreate"))
+
+// ZkSyncHasher implements Hasher for zkSync Era, which derives CREATE addresses
+// from the deployer and its deployment nonce instead of RLP encoding.
+type ZkSyncHasher struct {
+	EVMHasher
+}
+
+func (h ZkSyncHasher) CreateAddress(deployer string, nonce uint64) string {
+	nonceBytes := common.LeftPadBytes(new(big.Int).SetUint64(nonce).Bytes(), 32)
+	deployerBytes := common.LeftPadBytes(common.HexToAddress(deployer).Bytes(), 32)
+	hash := crypto.Keccak256(zkSyncCreatePrefix, deployerBytes, nonceBytes)
+	return strings.ToLower(common.BytesToAddress(hash[12:]).Hex())
+}
+
+// TronHasher implements Hasher for Tron. Selectors and topics match EVM, but addresses
+// may be given in base58check ("T...") or 0x41-prefixed hex form, both are normalized
+// to the 20 byte hex form used by the JSON-RPC interface. Base58 addresses with invalid
+// checksum are kept as is.
+type TronHasher struct {
+	EVMHasher
+}
+
+func (h TronHasher) NormalizeAddress(address string) string {
+	if strings.HasPrefix(address, "T") {
+		decoded, err := base58CheckDecode(address)
+		if err == nil && len(decoded) == 21 && decoded[0] == 0x41 {
+			return "0x" + hex.EncodeToString(decoded[1:21])
+		}
+		return address
+	}
+
+	trimmed := strings.TrimPrefix(strings.ToLower(address), "0x")
+	if len(trimmed) == 42 && strings.HasPrefix(trimmed, "41") {
+		trimmed = trimmed[2:]
+	}
+	return "0x" + trimmed
+}
+
+// TonHasher implements Hasher for TON. Messages are identified by 32 bit operation codes,
+// crc32 of TL-B scheme as described in TEP-81, and addresses may be given in raw ("0:ab..")
+// or user friendly base64 form. Both are normalized to 0x-prefixed hex of workchain byte
+// followed by account id, so they could be stored as bytes in indexes.
+type TonHasher struct {
+	EVMHasher
+}
+
+func (h TonHasher) MethodSelector(signature string) string {
+	if strings.HasPrefix(signature, "0x") && len(signature) == 10 {
+		return strings.ToLower(signature)
+	}
+	// Scheme is normalized as in TL-B: parentheses are dropped and whitespaces collapsed
+	scheme := strings.Join(strings.Fields(strings.NewReplacer("(", "", ")", "").Replace(signature)), " ")
+	return fmt.Sprintf("0x%08x", crc32.ChecksumIEEE([]byte(scheme))&0x7fffffff)
+}
+
+func (h TonHasher) EventTopic(signature string) string {
+	return h.MethodSelector(signature)
+}
+
+func (h TonHasher) NormalizeAddress(address string) string {
+	if workchain, accountId, found := strings.Cut(address, ":"); found {
+		wc, err := strconv.ParseInt(workchain, 10, 8)
+		if err == nil && len(accountId) == 64 {
+			return fmt.Sprintf("0x%02x%s", uint8(int8(wc)), strings.ToLower(accountId))
+		}
+		return address
+	}
+
+	if len(address) == 48 {
+		decoded, err := base64.URLEncoding.DecodeString(strings.NewReplacer("+", "-", "/", "_").Replace(address))
+		if err == nil && len(decoded) == 36 {
+			return "0x" + hex.EncodeToString(decoded[1:34])
+		}
+	}
+
+	return strings.ToLower(address)
+}
+
+// NearHasher implements Hasher for NEAR. Function calls are identified by method name and
+// events by NEP-297 "standard:event" name. Accounts are human readable names, they are
+// normalized to 0x-prefixed hex of account id bytes so they could be stored as bytes in indexes.
+type NearHasher struct {
+	EVMHasher
+}
+
+func (h NearHasher) MethodSelector(signature string) string {
+	return signature
+}
+
+func (h NearHasher) EventTopic(signature string) string {
+	return signature
+}
+
+func (h NearHasher) NormalizeAddress(address string) string {
+	return "0x" + hex.EncodeToString([]byte(strings.ToLower(address)))
+}
+
+// SubstrateHasher implements Hasher for Substrate based chains. Calls and events are identified
+// by "Pallet.name" and pallet plays role of contract address. Pallet names are normalized to
+// 0x-prefixed hex of name bytes, account ids are kept in hex form.
+type SubstrateHasher struct {
+	EVMHasher
+}
+
+func (h SubstrateHasher) MethodSelector(signature string) string {
+	return signature
+}
+
+func (h SubstrateHasher) EventTopic(signature string) string {
+	return signature
+}
+
+func (h SubstrateHasher) NormalizeAddress(address string) string {
+	if strings.HasPrefix(address, "0x") {
+		return strings.ToLower(address)
+	}
+	return "0x" + hex.EncodeToString([]byte(address))
+}
+
+// MoveHasher implements Hasher for Move based chains (Aptos, Sui). Functions and events are
+// identified by fully qualified names (0x1::coin::transfer) instead of hashes, addresses
+// are 32 bytes and could be returned by nodes in short form (0x1).
+type MoveHasher struct {
+	EVMHasher
+}
+
+func (h MoveHasher) MethodSelector(signature string) string {
+	return NormalizeMoveTypeTag(signature)
+}
+
+func (h MoveHasher) EventTopic(signature string) string {
+	return NormalizeMoveTypeTag(signature)
+}
+
+func (h MoveHasher) NormalizeAddress(address string) string {
+	trimmed := strings.TrimPrefix(strings.ToLower(address), "0x")
+	if len(trimmed) < 64 {
+		trimmed = strings.Repeat("0", 64-len(trimmed)) + trimmed
+	}
+	return "0x" + trimmed
+}
+
+// NormalizeMoveTypeTag expands address of Move type tag or function id to 32 bytes form,
+// e.g. 0x1::coin::transfer to 0x00..01::coin::transfer.
+func NormalizeMoveTypeTag(typeTag string) string {
+	parts := strings.SplitN(typeTag, "::", 2)
+	if len(parts) != 2 || !strings.HasPrefix(parts[0], "0x") {
+		return typeTag
+	}
+	return MoveHasher{}.NormalizeAddress(parts[0]) + "::" + parts[1]
+}
+
+// ParseMoveTypeTag splits Move type tag 0x1::coin::CoinStore<0x1::aptos_coin::AptosCoin> into
+// address, module and struct name without generic type parameters.
+func ParseMoveTypeTag(typeTag string) (string, string, string) {
+	parts := strings.SplitN(typeTag, "::", 3)
+	if len(parts) != 3 {
+		return "", "", typeTag
+	}
+
+	name := parts[2]
+	if i := strings.Index(name, "<"); i >= 0 {
+		name = name[:i]
+	}
+
+	return parts[0], parts[1], name
+}
+
+const base58Alphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+func base58Decode(input string) ([]byte, error) {
+	result := big.NewInt(0)
+	radix := big.NewInt(58)
+	for _, r := range input {
+		index := strings.IndexRune(base58Alphabet, r)
+		if index < 0 {
+			return nil, fmt.Errorf("invalid base58 character: %q", r)
+		}
+		result.Mul(result, radix)
+		result.Add(result, big.NewInt(int64(index)))
+	}
+
+	decoded := result.Bytes()
+	leadingZeros := 0
+	for leadingZeros < len(input) && input[leadingZeros] == base58Alphabet[0] {
+		leadingZeros++
+	}
+
+	return append(make([]byte, leadingZeros), decoded...), nil
+}
+
+// base58CheckDecode decodes base58check input and verifies its checksum, first 4 bytes of
+// double sha256 of payload.
+func base58CheckDecode(input string) ([]byte, error) {
+	decoded, err := base58Decode(input)
+	if err != nil {
+		return nil, err
+	}
+	if len(decoded) < 5 {
+		return nil, fmt.Errorf("base58check input is too short: %s", input)
+	}
+
+	payload, checksum := decoded[:len(decoded)-4], decoded[len(decoded)-4:]
+	first := sha256.Sum256(payload)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:4], checksum) {
+		return nil, fmt.Errorf("invalid base58check checksum: %s", input)
+	}
+
+	return payload, nil
+}
+
+var (
+	hashersMu sync.RWMutex
+	hashers   = map[string]Hasher{}
+)
+
+// RegisterHasher sets the hashing strategy for a blockchain.
+func RegisterHasher(blockchain string, hasher Hasher) {
+	hashersMu.Lock()
+	defer hashersMu.Unlock()
+	hashers[blockchain] = hasher
+}
+
+// GetHasher returns the hashing strategy for a blockchain, EVMHasher if none is registered.
+func GetHasher(blockchain string) Hasher {
+	hashersMu.RLock()
+	defer hashersMu.RUnlock()
+	if hasher, ok := hashers[blockchain]; ok {
+		return hasher
+	}
+	return EVMHasher{}
+}
+
+// keccakSelectors reports whether hasher derives selectors and topics as EVM does, so methods
+// and events could be looked up by IDs which go-ethereum computed when ABI was parsed.
+func keccakSelectors(hasher Hasher) bool {
+	switch hasher.(type) {
+	case EVMHasher, ZkSyncHasher, TronHasher:
+		return true
+	}
+	return false
+}
+
+// MethodBySelector looks up an ABI method by its selector computed with the given hasher.
+func MethodBySelector(hasher Hasher, contractABI *abi.ABI, selector []byte) (*abi.Method, error) {
+	if keccakSelectors(hasher) {
+		return contractABI.MethodById(selector)
+	}
+
+	selectorHex := "0x" + hex.EncodeToString(selector)
+	for _, method := range contractABI.Methods {
+		if hasher.MethodSelector(method.Sig) == selectorHex {
+			m := method
+			return &m, nil
+		}
+	}
+	return nil, fmt.Errorf("no method with selector %s", selectorHex)
+}
+
+// EventByTopic looks up an ABI event by its topic0 computed with the given hasher.
+func EventByTopic(hasher Hasher, contractABI *abi.ABI, topic string) (*abi.Event, error) {
+	if keccakSelectors(hasher) {
+		return contractABI.EventByID(common.HexToHash(topic))
+	}
+
+	topicHex := common.HexToHash(topic).Hex()
+	for _, event := range contractABI.Events {
+		if hasher.EventTopic(event.Sig) == topicHex {
+			e := event
+			return &e, nil
+		}
+	}
+	return nil, fmt.Errorf("no event with topic %s", topicHex)
+}
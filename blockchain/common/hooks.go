@@ -0,0 +1,101 @@
+package common
+
+import (
+	"log"
+	"strings"
+	"sync"
+)
+
+// DecoderCall is transaction or event of contract passed to decoder hook.
+type DecoderCall struct {
+	Chain    string
+	Kind     string // FunctionSelectorKind for transactions, EventSelectorKind for events
+	Address  string
+	Selector string
+
+	// Input is hex encoded input of transaction, empty for events
+	Input string
+	// Topics and Data are hex encoded topics and data of event, empty for transactions
+	Topics []string
+	Data   string
+
+	// ABI and ABIName are ABI of job for selector, empty if contract has no job for it
+	ABI     string
+	ABIName string
+}
+
+// Decoder produces label data for transactions and events of contracts which layout could not be
+// decoded with ABI, for example packed custom encodings or proxies with dispatch tables. Decode
+// returns name and data of label. Empty name means decoder does not handle call and it is
+// decoded with ABI, error is written to raw label as failed ABI decoding.
+type Decoder interface {
+	Decode(call DecoderCall) (string, map[string]interface{}, error)
+}
+
+// DecoderFunc is function used as Decoder.
+type DecoderFunc func(call DecoderCall) (string, map[string]interface{}, error)
+
+// Decode calls f(call).
+func (f DecoderFunc) Decode(call DecoderCall) (string, map[string]interface{}, error) {
+	return f(call)
+}
+
+type decoderKey struct {
+	chain    string
+	address  string
+	selector string
+}
+
+var (
+	decodersMu sync.RWMutex
+	decoders   = map[decoderKey]Decoder{}
+)
+
+// RegisterDecoder makes decoder hook used by synchronizer for transactions and events of address
+// with selector on chain. Empty address matches every contract and empty selector every selector of
+// contract, decoder of exact address and selector takes precedence over decoder of address, which
+// takes precedence over decoder of selector. Hooks are called only for contracts with ABI jobs of
+// customer. It is intended to be called from init functions, including Go plugins listed in
+// SEER_BLOCKCHAIN_PLUGINS.
+func RegisterDecoder(chain, address, selector string, decoder Decoder) {
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+
+	if decoder == nil {
+		log.Fatalf("Decoder for %s %s %s is nil", chain, address, selector)
+	}
+	if address == "" && selector == "" {
+		log.Fatalf("Decoder for %s requires address or selector", chain)
+	}
+
+	key := decoderKey{chain: chain, address: strings.ToLower(address), selector: strings.ToLower(selector)}
+	if _, exists := decoders[key]; exists {
+		log.Fatalf("Decoder for %s %s %s is already registered", chain, address, selector)
+	}
+	decoders[key] = decoder
+}
+
+// LookupDecoder returns decoder hook registered for address and selector on chain, nil if there
+// is no one.
+func LookupDecoder(chain, address, selector string) Decoder {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+
+	if len(decoders) == 0 {
+		return nil
+	}
+
+	address = strings.ToLower(address)
+	selector = strings.ToLower(selector)
+	for _, key := range []decoderKey{
+		{chain: chain, address: address, selector: selector},
+		{chain: chain, address: address},
+		{chain: chain, selector: selector},
+	} {
+		if decoder, ok := decoders[key]; ok {
+			return decoder
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+package common
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+)
+
+// DefaultLogsLimitErrorPatterns are parts of errors returned by providers when eth_getLogs query
+// covers too many blocks or results, matched case-insensitively.
+var DefaultLogsLimitErrorPatterns = []string{
+	"query returned more than 10000 results", // geth, Infura
+	"log response size exceeded",             // Alchemy
+	"block range is too wide",                // QuickNode
+	"block range too large",
+	"exceed maximum block range", // Ankr, public nodes
+	"range is too large",
+	"query exceeds max results",
+	"response size exceeded",
+	"too many logs",
+}
+
+// LogsLimits describes how provider limits eth_getLogs queries. Queries are split by block range
+// when provider returns limit error or MaxResults logs, single block queries are split by
+// addresses and topics.
+type LogsLimits struct {
+	ErrorPatterns []string `json:"error_patterns,omitempty"` // added to DefaultLogsLimitErrorPatterns
+	MaxBlockSpan  uint64   `json:"max_block_span,omitempty"` // blocks in single query, 0 if unlimited
+	MaxResults    int      `json:"max_results,omitempty"`    // logs returned before provider truncates result, 0 if unlimited
+}
+
+// IsLimitError reports if err is returned by provider because query is too large.
+func (l LogsLimits) IsLimitError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, patterns := range [][]string{DefaultLogsLimitErrorPatterns, l.ErrorPatterns} {
+		for _, pattern := range patterns {
+			if pattern != "" && strings.Contains(message, strings.ToLower(pattern)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsTruncated reports if result of logsCount logs could be truncated by provider.
+func (l LogsLimits) IsTruncated(logsCount int) bool {
+	return l.MaxResults > 0 && logsCount >= l.MaxResults
+}
+
+var (
+	rpcEndpointsLogsLimitsMux sync.RWMutex
+	rpcEndpointsLogsLimits    = make(map[string]LogsLimits)
+)
+
+// SetRPCEndpointLogsLimits sets eth_getLogs limits of endpoint with url.
+func SetRPCEndpointLogsLimits(url string, limits LogsLimits) {
+	rpcEndpointsLogsLimitsMux.Lock()
+	rpcEndpointsLogsLimits[strings.TrimSpace(url)] = limits
+	rpcEndpointsLogsLimitsMux.Unlock()
+}
+
+// LogsLimits returns eth_getLogs limits which hold for all endpoints of client, as queries fail
+// over between them: the smallest block span and results limit and all error patterns.
+func (c *RPCClient) LogsLimits() LogsLimits {
+	rpcEndpointsLogsLimitsMux.RLock()
+	defer rpcEndpointsLogsLimitsMux.RUnlock()
+
+	var limits LogsLimits
+	for _, endpoint := range c.endpoints {
+		endpointLimits, ok := rpcEndpointsLogsLimits[endpoint.url]
+		if !ok {
+			continue
+		}
+		limits.ErrorPatterns = append(limits.ErrorPatterns, endpointLimits.ErrorPatterns...)
+		if endpointLimits.MaxBlockSpan > 0 && (limits.MaxBlockSpan == 0 || endpointLimits.MaxBlockSpan < limits.MaxBlockSpan) {
+			limits.MaxBlockSpan = endpointLimits.MaxBlockSpan
+		}
+		if endpointLimits.MaxResults > 0 && (limits.MaxResults == 0 || endpointLimits.MaxResults < limits.MaxResults) {
+			limits.MaxResults = endpointLimits.MaxResults
+		}
+	}
+
+	return limits
+}
+
+// SplitFilterQuery splits eth_getLogs query into two queries which together match the same logs:
+// by halves of block range, for single block by halves of addresses and then of alternatives of
+// the first topic position which has several. Nil is returned if query could not be split.
+func SplitFilterQuery(q ethereum.FilterQuery) []ethereum.FilterQuery {
+	if span := new(big.Int).Sub(q.ToBlock, q.FromBlock); span.Sign() > 0 {
+		middle := new(big.Int).Add(q.FromBlock, span.Div(span, big.NewInt(2)))
+		first, second := q, q
+		first.ToBlock = middle
+		second.FromBlock = new(big.Int).Add(middle, big.NewInt(1))
+		return []ethereum.FilterQuery{first, second}
+	}
+
+	if len(q.Addresses) > 1 {
+		first, second := q, q
+		first.Addresses = q.Addresses[:len(q.Addresses)/2]
+		second.Addresses = q.Addresses[len(q.Addresses)/2:]
+		return []ethereum.FilterQuery{first, second}
+	}
+
+	for position, topics := range q.Topics {
+		if len(topics) < 2 {
+			continue
+		}
+		first, second := q, q
+		first.Topics = append(q.Topics[:0:0], q.Topics...)
+		first.Topics[position] = topics[:len(topics)/2]
+		second.Topics = append(q.Topics[:0:0], q.Topics...)
+		second.Topics[position] = topics[len(topics)/2:]
+		return []ethereum.FilterQuery{first, second}
+	}
+
+	return nil
+}
+
+// SortEventsByPosition orders logs by block and log index, as node returns them, logs of queries
+// split by addresses or topics are merged back with it.
+func SortEventsByPosition(events []*EventJson) {
+	sort.SliceStable(events, func(i, j int) bool {
+		blockI, _ := ParseQuantity(events[i].BlockNumber)
+		blockJ, _ := ParseQuantity(events[j].BlockNumber)
+		if blockI != blockJ {
+			return blockI < blockJ
+		}
+		logI, _ := ParseQuantity(events[i].LogIndex)
+		logJ, _ := ParseQuantity(events[j].LogIndex)
+		return logI < logJ
+	})
+}
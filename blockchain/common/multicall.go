@@ -0,0 +1,250 @@
+package common
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// MaxMulticallDepth is depth of nested multicalls expanded into sub-calls
+const MaxMulticallDepth = 4
+
+// multicallABI has batching methods of multicall(bytes[]) contracts, which delegate calls to
+// themselves, of Multicall2 and Multicall3 aggregators, which call targets, and execTransaction of
+// Safe with multiSend of MultiSend and MultiSendCallOnly contracts it delegates batches to,
+// handleOps of ERC-4337 EntryPoint v0.6 and v0.7 and execute methods of smart accounts.
+const multicallABI = `[
+{"type":"function","name":"multicall","stateMutability":"payable","inputs":[{"name":"data","type":"bytes[]"}],"outputs":[]},
+{"type":"function","name":"multicall","stateMutability":"payable","inputs":[{"name":"deadline","type":"uint256"},{"name":"data","type":"bytes[]"}],"outputs":[]},
+{"type":"function","name":"multicall","stateMutability":"payable","inputs":[{"name":"previousBlockhash","type":"bytes32"},{"name":"data","type":"bytes[]"}],"outputs":[]},
+{"type":"function","name":"aggregate","stateMutability":"payable","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}],"outputs":[]},
+{"type":"function","name":"tryAggregate","stateMutability":"payable","inputs":[{"name":"requireSuccess","type":"bool"},{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}],"outputs":[]},
+{"type":"function","name":"blockAndAggregate","stateMutability":"payable","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}],"outputs":[]},
+{"type":"function","name":"tryBlockAndAggregate","stateMutability":"payable","inputs":[{"name":"requireSuccess","type":"bool"},{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"callData","type":"bytes"}]}],"outputs":[]},
+{"type":"function","name":"aggregate3","stateMutability":"payable","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"callData","type":"bytes"}]}],"outputs":[]},
+{"type":"function","name":"aggregate3Value","stateMutability":"payable","inputs":[{"name":"calls","type":"tuple[]","components":[{"name":"target","type":"address"},{"name":"allowFailure","type":"bool"},{"name":"value","type":"uint256"},{"name":"callData","type":"bytes"}]}],"outputs":[]},
+{"type":"function","name":"execTransaction","stateMutability":"payable","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"},{"name":"data","type":"bytes"},{"name":"operation","type":"uint8"},{"name":"safeTxGas","type":"uint256"},{"name":"baseGas","type":"uint256"},{"name":"gasPrice","type":"uint256"},{"name":"gasToken","type":"address"},{"name":"refundReceiver","type":"address"},{"name":"signatures","type":"bytes"}],"outputs":[]},
+{"type":"function","name":"multiSend","stateMutability":"payable","inputs":[{"name":"transactions","type":"bytes"}],"outputs":[]},
+{"type":"function","name":"handleOps","stateMutability":"nonpayable","inputs":[{"name":"ops","type":"tuple[]","components":[{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},{"name":"callGasLimit","type":"uint256"},{"name":"verificationGasLimit","type":"uint256"},{"name":"preVerificationGas","type":"uint256"},{"name":"maxFeePerGas","type":"uint256"},{"name":"maxPriorityFeePerGas","type":"uint256"},{"name":"paymasterAndData","type":"bytes"},{"name":"signature","type":"bytes"}]},{"name":"beneficiary","type":"address"}],"outputs":[]},
+{"type":"function","name":"handleOps","stateMutability":"nonpayable","inputs":[{"name":"ops","type":"tuple[]","components":[{"name":"sender","type":"address"},{"name":"nonce","type":"uint256"},{"name":"initCode","type":"bytes"},{"name":"callData","type":"bytes"},{"name":"accountGasLimits","type":"bytes32"},{"name":"preVerificationGas","type":"uint256"},{"name":"gasFees","type":"bytes32"},{"name":"paymasterAndData","type":"bytes"},{"name":"signature","type":"bytes"}]},{"name":"beneficiary","type":"address"}],"outputs":[]},
+{"type":"function","name":"execute","stateMutability":"nonpayable","inputs":[{"name":"dest","type":"address"},{"name":"value","type":"uint256"},{"name":"func","type":"bytes"}],"outputs":[]},
+{"type":"function","name":"executeBatch","stateMutability":"nonpayable","inputs":[{"name":"dest","type":"address[]"},{"name":"func","type":"bytes[]"}],"outputs":[]},
+{"type":"function","name":"executeBatch","stateMutability":"nonpayable","inputs":[{"name":"dest","type":"address[]"},{"name":"value","type":"uint256[]"},{"name":"func","type":"bytes[]"}],"outputs":[]}
+]`
+
+// safeDelegateCall is operation of Safe transactions which executes code of target in context of
+// Safe
+const safeDelegateCall = 1
+
+var multicallMethods map[string]abi.Method
+
+func init() {
+	parsed, err := abi.JSON(strings.NewReader(multicallABI))
+	if err != nil {
+		panic(fmt.Sprintf("invalid multicall ABI: %v", err))
+	}
+
+	multicallMethods = make(map[string]abi.Method)
+	for _, method := range parsed.Methods {
+		multicallMethods["0x"+hex.EncodeToString(method.ID)] = method
+	}
+}
+
+// SubCall is call batched in multicall transaction.
+type SubCall struct {
+	// Index is position of call in multicall, positions of nested calls are joined with dots
+	Index string
+	// Target is lowercase address of called contract
+	Target string
+	// Caller is lowercase address call is made from, aggregator contract for Multicall3
+	// aggregates, sender of multicall for multicall(bytes[]) which delegates calls to itself
+	Caller string
+	// Input is hex encoded calldata of call
+	Input string
+
+	// Origin is lowercase address of smart account for calls of ERC-4337 user operations, empty
+	// for calls of transaction sender
+	Origin string
+	// UserOperation is set for calls of accounts made by EntryPoint
+	UserOperation *UserOperation
+
+	// context is address code of call is executed as, Caller for delegate calls of Safe
+	context string
+}
+
+// MulticallSelectors returns selectors of batching methods expanded into sub-calls.
+func MulticallSelectors() []string {
+	selectors := make([]string, 0, len(multicallMethods))
+	for selector := range multicallMethods {
+		selectors = append(selectors, selector)
+	}
+	sort.Strings(selectors)
+	return selectors
+}
+
+// ExpandMulticall returns calls batched in hex encoded input of call from caller to address when
+// it is one of batching methods, Safe transaction or bundle of ERC-4337 user operations, nested
+// multicalls are expanded up to MaxMulticallDepth levels. False is returned when input is not
+// multicall.
+func ExpandMulticall(address, caller, input string) ([]SubCall, bool, error) {
+	return expandMulticall(strings.ToLower(address), strings.ToLower(caller), input, "", 1)
+}
+
+func expandMulticall(address, caller, input, prefix string, depth int) ([]SubCall, bool, error) {
+	if len(input) < 10 {
+		return nil, false, nil
+	}
+	method, ok := multicallMethods[strings.ToLower(input[:10])]
+	if !ok {
+		return nil, false, nil
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x")[8:])
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to decode input of %s: %w", method.RawName, err)
+	}
+	args, err := method.Inputs.Unpack(data)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to unpack arguments of %s: %w", method.RawName, err)
+	}
+
+	var calls []SubCall
+	switch method.RawName {
+	case "handleOps":
+		userOps, _, err := DecodeUserOperations(address, input)
+		if err != nil {
+			return nil, true, err
+		}
+		for i := range userOps {
+			calls = append(calls, SubCall{Target: userOps[i].Sender, Caller: address, Input: userOps[i].CallData, Index: fmt.Sprintf("%s%d", prefix, i), Origin: userOps[i].Sender, UserOperation: &userOps[i], context: userOps[i].Sender})
+		}
+	case "execute":
+		dest, _ := args[0].(common.Address)
+		callData, _ := args[2].([]byte)
+		target := strings.ToLower(dest.Hex())
+		calls = append(calls, SubCall{Target: target, Caller: address, Input: "0x" + hex.EncodeToString(callData), Index: prefix + "0", context: target})
+	case "executeBatch":
+		dests, _ := args[0].([]common.Address)
+		callsData, _ := args[len(args)-1].([][]byte)
+		if len(dests) != len(callsData) {
+			return nil, true, fmt.Errorf("executeBatch has %d targets and %d calls", len(dests), len(callsData))
+		}
+		for i := range dests {
+			target := strings.ToLower(dests[i].Hex())
+			calls = append(calls, SubCall{Target: target, Caller: address, Input: "0x" + hex.EncodeToString(callsData[i]), Index: fmt.Sprintf("%s%d", prefix, i), context: target})
+		}
+	case "execTransaction":
+		to, _ := args[0].(common.Address)
+		callData, _ := args[2].([]byte)
+		operation, _ := args[3].(uint8)
+		call := SubCall{Target: strings.ToLower(to.Hex()), Caller: address, Input: "0x" + hex.EncodeToString(callData), Index: prefix + "0"}
+		call.context = call.Target
+		// Delegate call runs code of target as Safe with sender of Safe transaction
+		if operation == safeDelegateCall {
+			call.Caller, call.context = caller, address
+		}
+		calls = append(calls, call)
+	case "multiSend":
+		transactions, _ := args[0].([]byte)
+		calls, err = multiSendCalls(address, caller, transactions, prefix)
+		if err != nil {
+			return nil, true, err
+		}
+	default:
+		// Calls are the last argument of every batching method
+		switch batch := args[len(args)-1].(type) {
+		case [][]byte:
+			for i, callData := range batch {
+				calls = append(calls, SubCall{Target: address, Caller: caller, Input: "0x" + hex.EncodeToString(callData), Index: fmt.Sprintf("%s%d", prefix, i), context: address})
+			}
+		default:
+			targets, inputs, err := aggregateCalls(batch)
+			if err != nil {
+				return nil, true, fmt.Errorf("failed to read calls of %s: %w", method.RawName, err)
+			}
+			for i := range targets {
+				calls = append(calls, SubCall{Target: targets[i], Caller: address, Input: inputs[i], Index: fmt.Sprintf("%s%d", prefix, i), context: targets[i]})
+			}
+		}
+	}
+
+	var expanded []SubCall
+	for _, call := range calls {
+		expanded = append(expanded, call)
+		if depth >= MaxMulticallDepth {
+			continue
+		}
+		nested, ok, err := expandMulticall(call.context, call.Caller, call.Input, call.Index+".", depth+1)
+		if ok && err == nil {
+			// Calls made while user operation is executed belong to its account
+			if call.Origin != "" {
+				for i := range nested {
+					if nested[i].Origin == "" {
+						nested[i].Origin = call.Origin
+					}
+				}
+			}
+			expanded = append(expanded, nested...)
+		}
+	}
+
+	return expanded, true, nil
+}
+
+// aggregateCalls reads targets and calldata from slice of tuples unpacked from aggregator calls.
+// Tuples are unpacked into anonymous structs which differ between methods, so fields are read by
+// name.
+func aggregateCalls(batch interface{}) ([]string, []string, error) {
+	calls := reflect.ValueOf(batch)
+	if calls.Kind() != reflect.Slice || calls.Type().Elem().Kind() != reflect.Struct {
+		return nil, nil, fmt.Errorf("unexpected calls type %T", batch)
+	}
+
+	var targets, inputs []string
+	for i := 0; i < calls.Len(); i++ {
+		target, ok := calls.Index(i).FieldByName("Target").Interface().(common.Address)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected target type of call %d", i)
+		}
+		callData, ok := calls.Index(i).FieldByName("CallData").Interface().([]byte)
+		if !ok {
+			return nil, nil, fmt.Errorf("unexpected calldata type of call %d", i)
+		}
+		targets = append(targets, strings.ToLower(target.Hex()))
+		inputs = append(inputs, "0x"+hex.EncodeToString(callData))
+	}
+	return targets, inputs, nil
+}
+
+// multiSendCalls reads calls packed by MultiSend as operation (1 byte), target (20 bytes), value
+// (32 bytes), length of calldata (32 bytes) and calldata. Calls are made by address MultiSend code
+// is executed as, it is Safe which delegates batch to MultiSend.
+func multiSendCalls(address, caller string, transactions []byte, prefix string) ([]SubCall, error) {
+	var calls []SubCall
+	for offset := 0; offset < len(transactions); {
+		if len(transactions) < offset+85 {
+			return nil, fmt.Errorf("multiSend call %d is truncated", len(calls))
+		}
+		operation := transactions[offset]
+		target := strings.ToLower(common.BytesToAddress(transactions[offset+1 : offset+21]).Hex())
+		length := new(big.Int).SetBytes(transactions[offset+53 : offset+85])
+		if !length.IsInt64() || length.Int64() > int64(len(transactions)-offset-85) {
+			return nil, fmt.Errorf("calldata of multiSend call %d is truncated", len(calls))
+		}
+		end := offset + 85 + int(length.Int64())
+
+		call := SubCall{Target: target, Caller: address, Input: "0x" + hex.EncodeToString(transactions[offset+85:end]), Index: fmt.Sprintf("%s%d", prefix, len(calls)), context: target}
+		if operation == safeDelegateCall {
+			call.Caller, call.context = caller, address
+		}
+		calls = append(calls, call)
+		offset = end
+	}
+	return calls, nil
+}
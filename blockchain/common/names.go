@@ -0,0 +1,173 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// ENSRegistryAddress is address of ENS registry on Ethereum mainnet and testnets.
+const ENSRegistryAddress = "0x00000000000C2E074eC69A0dFb2997BA6C7d2e1e"
+
+// nameReadTimeout is timeout of resolution of name of one address
+const nameReadTimeout = 15 * time.Second
+
+var nameResolutionsTotal = metrics.NewCounterVec("seer_name_resolutions_total",
+	"Reverse resolutions of addresses to names by name service and result", "service", "result")
+
+// NameService is ENS compatible registry of names of chain. Reverse record of address is name of
+// node <address>.<ReverseSuffix>, address is lowercase hex without 0x prefix.
+type NameService struct {
+	Name          string
+	Registry      string
+	ReverseSuffix string
+}
+
+var nameServices = map[string]NameService{
+	"ethereum": {Name: "ens", Registry: ENSRegistryAddress, ReverseSuffix: "addr.reverse"},
+	"sepolia":  {Name: "ens", Registry: ENSRegistryAddress, ReverseSuffix: "addr.reverse"},
+}
+
+// GetNameService returns name service of chain, false if name service of chain is not known.
+func GetNameService(chain string) (NameService, bool) {
+	service, ok := nameServices[chain]
+	return service, ok
+}
+
+// nameServiceABI has methods of registry and resolvers used for reverse resolution.
+var nameServiceABI = func() abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(`[
+		{"type":"function","name":"resolver","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]},
+		{"type":"function","name":"name","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"string"}]},
+		{"type":"function","name":"addr","stateMutability":"view","inputs":[{"name":"node","type":"bytes32"}],"outputs":[{"name":"","type":"address"}]}
+	]`))
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// Namehash returns EIP-137 namehash of name. Labels are lowercased, other UTS-46 normalization is
+// not applied, so names with other characters which are not normalized do not resolve.
+func Namehash(name string) common.Hash {
+	var node common.Hash
+	if name == "" {
+		return node
+	}
+
+	labels := strings.Split(strings.ToLower(name), ".")
+	for i := len(labels) - 1; i >= 0; i-- {
+		labelHash := crypto.Keccak256([]byte(labels[i]))
+		node = common.BytesToHash(crypto.Keccak256(node[:], labelHash))
+	}
+	return node
+}
+
+// NameResolver reads primary names of addresses from reverse records of name service. Reverse
+// records are set by owners of addresses to any name, so name is used only when it resolves
+// back to the same address.
+type NameResolver struct {
+	client  *RPCClient
+	service NameService
+}
+
+// NewNameResolver creates resolver which reads records of name service with client.
+func NewNameResolver(client *RPCClient, service NameService) *NameResolver {
+	return &NameResolver{
+		client:  client,
+		service: service,
+	}
+}
+
+// Service returns name service of resolver.
+func (r *NameResolver) Service() NameService {
+	return r.service
+}
+
+// ReverseName returns primary name of address, empty name if address has no reverse record or
+// its name does not resolve to address.
+func (r *NameResolver) ReverseName(ctx context.Context, address string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, nameReadTimeout)
+	defer cancel()
+
+	address = strings.ToLower(address)
+	reverseNode := Namehash(strings.TrimPrefix(address, "0x") + "." + r.service.ReverseSuffix)
+
+	var name string
+	found, err := r.resolve(ctx, reverseNode, "name", &name)
+	if err != nil {
+		nameResolutionsTotal.Inc(r.service.Name, "error")
+		return "", err
+	}
+	if !found || name == "" {
+		nameResolutionsTotal.Inc(r.service.Name, "no_record")
+		return "", nil
+	}
+
+	var resolved common.Address
+	found, err = r.resolve(ctx, Namehash(name), "addr", &resolved)
+	if err != nil {
+		nameResolutionsTotal.Inc(r.service.Name, "error")
+		return "", err
+	}
+	if !found || !strings.EqualFold(resolved.Hex(), address) {
+		nameResolutionsTotal.Inc(r.service.Name, "mismatch")
+		return "", nil
+	}
+
+	nameResolutionsTotal.Inc(r.service.Name, "resolved")
+	return name, nil
+}
+
+// resolve calls method of resolver of node set in registry, false is returned if node has no
+// resolver or resolver does not implement method.
+func (r *NameResolver) resolve(ctx context.Context, node common.Hash, method string, result interface{}) (bool, error) {
+	var resolver common.Address
+	found, err := r.call(ctx, common.HexToAddress(r.service.Registry), "resolver", node, &resolver)
+	if err != nil || !found || resolver == (common.Address{}) {
+		return false, err
+	}
+
+	return r.call(ctx, resolver, method, node, result)
+}
+
+// call calls view method of name service contract with node, false is returned when contract
+// returns no data or reverts.
+func (r *NameResolver) call(ctx context.Context, contract common.Address, method string, node common.Hash, result interface{}) (bool, error) {
+	input, err := nameServiceABI.Pack(method, node)
+	if err != nil {
+		return false, err
+	}
+
+	var output hexutil.Bytes
+	err = r.client.CallContext(ctx, &output, "eth_call", map[string]interface{}{
+		"to":   contract.Hex(),
+		"data": hexutil.Encode(input),
+	}, "latest")
+	if err != nil {
+		// Errors reported by node, like reverts of resolvers without method, are result of call
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to call %s of %s: %w", method, contract.Hex(), err)
+	}
+	if len(output) == 0 {
+		return false, nil
+	}
+
+	if err := nameServiceABI.UnpackIntoInterface(result, method, output); err != nil {
+		return false, nil
+	}
+	return true, nil
+}
@@ -0,0 +1,109 @@
+package common
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// DepositTransactionType is type of OP-stack deposit transactions, the first deposit transaction
+// of every block sets L1 block attributes on L1Block predeploy.
+const DepositTransactionType = "0x7e"
+
+// Selectors of L1Block predeploy methods called by L1 attributes deposit transaction
+const (
+	setL1BlockValuesSelector        = "015d8eb9" // Bedrock, ABI encoded arguments
+	setL1BlockValuesEcotoneSelector = "440a5e20" // Ecotone, packed arguments
+	setL1BlockValuesIsthmusSelector = "098999be" // Isthmus, packed arguments with operator fee
+)
+
+// L1BlockAttributes is L1 origin of OP-stack block with fee parameters used for rollup accounting.
+type L1BlockAttributes struct {
+	Number              string `json:"number"`
+	Hash                string `json:"hash"`
+	Timestamp           string `json:"timestamp"`
+	BaseFee             string `json:"baseFee"`
+	BlobBaseFee         string `json:"blobBaseFee,omitempty"`
+	SequenceNumber      string `json:"sequenceNumber"`
+	BatcherHash         string `json:"batcherHash"`
+	L1FeeOverhead       string `json:"l1FeeOverhead,omitempty"`
+	L1FeeScalar         string `json:"l1FeeScalar,omitempty"`
+	BaseFeeScalar       string `json:"baseFeeScalar,omitempty"`
+	BlobBaseFeeScalar   string `json:"blobBaseFeeScalar,omitempty"`
+	OperatorFeeScalar   string `json:"operatorFeeScalar,omitempty"`
+	OperatorFeeConstant string `json:"operatorFeeConstant,omitempty"`
+}
+
+// L1BlockAttributesFromTransactions decodes L1 block attributes from the first deposit transaction
+// of the block, nil if block has no L1 attributes transaction.
+func L1BlockAttributesFromTransactions(transactions []TransactionJson) (*L1BlockAttributes, error) {
+	if len(transactions) == 0 || !strings.EqualFold(transactions[0].TransactionType, DepositTransactionType) {
+		return nil, nil
+	}
+
+	return DecodeL1BlockAttributes(transactions[0].Input)
+}
+
+// DecodeL1BlockAttributes decodes input of L1 attributes deposit transaction, Bedrock, Ecotone
+// and Isthmus formats are supported. Numbers are returned as decimal strings.
+func DecodeL1BlockAttributes(input string) (*L1BlockAttributes, error) {
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode L1 attributes input: %v", err)
+	}
+	if len(data) < 4 {
+		return nil, fmt.Errorf("L1 attributes input is too short: %d bytes", len(data))
+	}
+
+	selector := hex.EncodeToString(data[:4])
+	args := data[4:]
+
+	switch selector {
+	case setL1BlockValuesSelector:
+		if len(args) < 8*32 {
+			return nil, fmt.Errorf("bedrock L1 attributes input is too short: %d bytes", len(args))
+		}
+		word := func(i int) []byte { return args[i*32 : (i+1)*32] }
+
+		return &L1BlockAttributes{
+			Number:         new(big.Int).SetBytes(word(0)).String(),
+			Timestamp:      new(big.Int).SetBytes(word(1)).String(),
+			BaseFee:        new(big.Int).SetBytes(word(2)).String(),
+			Hash:           "0x" + hex.EncodeToString(word(3)),
+			SequenceNumber: new(big.Int).SetBytes(word(4)).String(),
+			BatcherHash:    "0x" + hex.EncodeToString(word(5)),
+			L1FeeOverhead:  new(big.Int).SetBytes(word(6)).String(),
+			L1FeeScalar:    new(big.Int).SetBytes(word(7)).String(),
+		}, nil
+	case setL1BlockValuesEcotoneSelector, setL1BlockValuesIsthmusSelector:
+		expectedLength := 160
+		if selector == setL1BlockValuesIsthmusSelector {
+			expectedLength = 172
+		}
+		if len(args) < expectedLength {
+			return nil, fmt.Errorf("L1 attributes input is too short: %d bytes, expected %d", len(args), expectedLength)
+		}
+
+		attributes := &L1BlockAttributes{
+			BaseFeeScalar:     fmt.Sprintf("%d", binary.BigEndian.Uint32(args[0:4])),
+			BlobBaseFeeScalar: fmt.Sprintf("%d", binary.BigEndian.Uint32(args[4:8])),
+			SequenceNumber:    fmt.Sprintf("%d", binary.BigEndian.Uint64(args[8:16])),
+			Timestamp:         fmt.Sprintf("%d", binary.BigEndian.Uint64(args[16:24])),
+			Number:            fmt.Sprintf("%d", binary.BigEndian.Uint64(args[24:32])),
+			BaseFee:           new(big.Int).SetBytes(args[32:64]).String(),
+			BlobBaseFee:       new(big.Int).SetBytes(args[64:96]).String(),
+			Hash:              "0x" + hex.EncodeToString(args[96:128]),
+			BatcherHash:       "0x" + hex.EncodeToString(args[128:160]),
+		}
+		if selector == setL1BlockValuesIsthmusSelector {
+			attributes.OperatorFeeScalar = fmt.Sprintf("%d", binary.BigEndian.Uint32(args[160:164]))
+			attributes.OperatorFeeConstant = fmt.Sprintf("%d", binary.BigEndian.Uint64(args[164:172]))
+		}
+
+		return attributes, nil
+	default:
+		return nil, fmt.Errorf("unknown L1 attributes selector 0x%s", selector)
+	}
+}
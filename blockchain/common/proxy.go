@@ -0,0 +1,97 @@
+package common
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// EIP1967ImplementationSlot is storage slot of EIP-1967 proxies with address of implementation,
+// keccak256("eip1967.proxy.implementation") - 1.
+const EIP1967ImplementationSlot = "0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc"
+
+const (
+	// proxyCacheBlocks is size of block ranges implementation of proxy is read once for
+	proxyCacheBlocks = 10000
+	// proxyReadTimeout is timeout of storage slot read
+	proxyReadTimeout = 10 * time.Second
+)
+
+var proxyResolutionsTotal = metrics.NewCounterVec("seer_proxy_resolutions_total",
+	"Reads of implementation slot of contracts by result", "result")
+
+type proxyCacheKey struct {
+	address string
+	blocks  uint64
+}
+
+// ProxyResolver reads implementations of EIP-1967 proxies from node. Implementation is read at the
+// first requested block of range of 10000 blocks and cached for the whole range, so upgrades are
+// picked up with delay of at most one range.
+type ProxyResolver struct {
+	client *RPCClient
+
+	mux   sync.Mutex
+	cache map[proxyCacheKey]string
+}
+
+// NewProxyResolver creates resolver which reads storage of contracts with client.
+func NewProxyResolver(client *RPCClient) *ProxyResolver {
+	return &ProxyResolver{
+		client: client,
+		cache:  make(map[proxyCacheKey]string),
+	}
+}
+
+// Implementation returns lowercase address of implementation of proxy at block, empty if
+// contract is not EIP-1967 proxy. Failed reads, for example of blocks pruned by node which is not
+// archive one, are logged and cached as contract is not proxy.
+func (r *ProxyResolver) Implementation(ctx context.Context, address string, blockNumber uint64) string {
+	key := proxyCacheKey{address: strings.ToLower(address), blocks: blockNumber / proxyCacheBlocks}
+
+	r.mux.Lock()
+	implementation, ok := r.cache[key]
+	r.mux.Unlock()
+	if ok {
+		return implementation
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, proxyReadTimeout)
+	defer cancel()
+
+	var slot string
+	err := r.client.CallContext(ctx, &slot, "eth_getStorageAt", address, EIP1967ImplementationSlot, hexutil.EncodeUint64(blockNumber))
+	switch {
+	case err != nil:
+		log.Printf("Failed to read implementation slot of %s at block %d: %v", address, blockNumber, err)
+		proxyResolutionsTotal.Inc("error")
+	default:
+		implementation = slotAddress(slot)
+		if implementation == "" {
+			proxyResolutionsTotal.Inc("not_proxy")
+		} else {
+			proxyResolutionsTotal.Inc("implementation")
+		}
+	}
+
+	r.mux.Lock()
+	r.cache[key] = implementation
+	r.mux.Unlock()
+
+	return implementation
+}
+
+// slotAddress returns address stored in the last 20 bytes of storage slot, empty if it is zero.
+func slotAddress(slot string) string {
+	slot = strings.ToLower(strings.TrimPrefix(slot, "0x"))
+	if len(slot) < 40 || strings.Trim(slot, "0") == "" {
+		return ""
+	}
+	return "0x" + slot[len(slot)-40:]
+}
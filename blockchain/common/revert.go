@@ -0,0 +1,234 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"log"
+	"math/big"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"github.com/moonstream-to/seer/metrics"
+)
+
+const (
+	// revertReadTimeout is timeout of re-execution of failed transaction
+	revertReadTimeout = 30 * time.Second
+	// revertCacheSize is number of transactions which revert reasons are kept for decoding of
+	// the same blocks for other customers
+	revertCacheSize = 10000
+)
+
+var revertResolutionsTotal = metrics.NewCounterVec("seer_revert_resolutions_total",
+	"Re-executions of failed transactions by method and result", "method", "result")
+
+// FailedTransaction is transaction which receipt has failed status, fields are used to repeat
+// it with eth_call when node does not support debug_traceTransaction.
+type FailedTransaction struct {
+	Hash        string
+	BlockNumber uint64
+	From        string
+	To          string
+	Input       string
+	Value       string
+	Gas         string
+}
+
+// RevertReason is result of re-execution of failed transaction. Reason is message of Error(string)
+// or description of Panic(uint256) revert, Data is raw revert data, for custom errors Selector is
+// selector of error. Error is error reported by node, for example "execution reverted" or
+// "out of gas".
+type RevertReason struct {
+	Reason   string
+	Selector string
+	Data     string
+	Error    string
+}
+
+// LabelData returns fields of revert reason added to label data of failed transaction.
+func (r RevertReason) LabelData() map[string]interface{} {
+	data := map[string]interface{}{}
+	if r.Reason != "" {
+		data["revert_reason"] = r.Reason
+	}
+	if r.Selector != "" {
+		data["revert_selector"] = r.Selector
+	}
+	if r.Data != "" {
+		data["revert_data"] = r.Data
+	}
+	if r.Error != "" {
+		data["revert_error"] = r.Error
+	}
+	return data
+}
+
+// RevertReasonResolver re-executes failed transactions to read their revert reasons. Transactions
+// are traced with debug_traceTransaction and callTracer, if node does not support it they are
+// repeated with eth_call on state of previous block, which could differ from state transaction
+// was executed on.
+type RevertReasonResolver struct {
+	client *RPCClient
+
+	debugUnsupported atomic.Bool
+
+	mux   sync.Mutex
+	cache map[string]RevertReason
+}
+
+// NewRevertReasonResolver creates resolver which re-executes transactions with client.
+func NewRevertReasonResolver(client *RPCClient) *RevertReasonResolver {
+	return &RevertReasonResolver{
+		client: client,
+		cache:  make(map[string]RevertReason),
+	}
+}
+
+// Resolve returns revert reason of failed transaction. Failed re-executions, for example of blocks
+// pruned by node which is not archive one, are logged and returned with error of node as Error.
+func (r *RevertReasonResolver) Resolve(ctx context.Context, tx FailedTransaction) RevertReason {
+	hash := strings.ToLower(tx.Hash)
+
+	r.mux.Lock()
+	reason, ok := r.cache[hash]
+	r.mux.Unlock()
+	if ok {
+		return reason
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, revertReadTimeout)
+	defer cancel()
+
+	var err error
+	if !r.debugUnsupported.Load() {
+		reason, err = r.trace(ctx, tx)
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) && rpcErr.ErrorCode() == -32601 { // method not found
+			r.debugUnsupported.Store(true)
+			log.Printf("Node does not support debug_traceTransaction, failed transactions will be repeated with eth_call")
+		}
+	}
+	if r.debugUnsupported.Load() {
+		reason, err = r.call(ctx, tx)
+	}
+	if err != nil {
+		// Failed re-executions are not cached, decoding of block for next customer tries again
+		log.Printf("Failed to read revert reason of transaction %s: %v", tx.Hash, err)
+		return RevertReason{Error: err.Error()}
+	}
+
+	r.mux.Lock()
+	if len(r.cache) >= revertCacheSize {
+		r.cache = make(map[string]RevertReason)
+	}
+	r.cache[hash] = reason
+	r.mux.Unlock()
+
+	return reason
+}
+
+// trace reads revert data of top level call of transaction with debug_traceTransaction.
+func (r *RevertReasonResolver) trace(ctx context.Context, tx FailedTransaction) (RevertReason, error) {
+	var frame struct {
+		Output string `json:"output,omitempty"`
+		Error  string `json:"error,omitempty"`
+	}
+	err := r.client.CallContext(ctx, &frame, "debug_traceTransaction", tx.Hash, map[string]interface{}{
+		"tracer":       "callTracer",
+		"tracerConfig": map[string]interface{}{"onlyTopCall": true},
+	})
+	if err != nil {
+		revertResolutionsTotal.Inc("debug_traceTransaction", "error")
+		return RevertReason{}, err
+	}
+
+	revertResolutionsTotal.Inc("debug_traceTransaction", "ok")
+	reason := DecodeRevertData(frame.Output)
+	reason.Error = frame.Error
+	return reason, nil
+}
+
+// call repeats transaction with eth_call on state of previous block, revert data is taken from
+// error of node.
+func (r *RevertReasonResolver) call(ctx context.Context, tx FailedTransaction) (RevertReason, error) {
+	args := map[string]interface{}{
+		"from": tx.From,
+		"data": tx.Input,
+	}
+	if tx.To != "" {
+		args["to"] = tx.To
+	}
+	if value, ok := parseBigQuantity(tx.Value); ok {
+		args["value"] = hexutil.EncodeBig(value)
+	}
+	if gas, ok := parseBigQuantity(tx.Gas); ok {
+		args["gas"] = hexutil.EncodeBig(gas)
+	}
+
+	blockNumber := tx.BlockNumber
+	if blockNumber > 0 {
+		blockNumber--
+	}
+
+	var output string
+	err := r.client.CallContext(ctx, &output, "eth_call", args, hexutil.EncodeUint64(blockNumber))
+	if err == nil {
+		// Transaction succeeds on state of previous block, it was failed by preceding
+		// transactions of its block
+		revertResolutionsTotal.Inc("eth_call", "not_reverted")
+		return RevertReason{}, nil
+	}
+
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		var rpcErr rpc.Error
+		if errors.As(err, &rpcErr) {
+			// Errors without data, for example out of gas, are result of execution
+			revertResolutionsTotal.Inc("eth_call", "ok")
+			return RevertReason{Error: rpcErr.Error()}, nil
+		}
+		revertResolutionsTotal.Inc("eth_call", "error")
+		return RevertReason{}, err
+	}
+
+	revertResolutionsTotal.Inc("eth_call", "ok")
+	data, _ := dataErr.ErrorData().(string)
+	reason := DecodeRevertData(data)
+	reason.Error = dataErr.Error()
+	return reason, nil
+}
+
+// parseBigQuantity parses quantity which could exceed uint64, like value of transaction, as 0x
+// prefixed hex or decimal.
+func parseBigQuantity(value string) (*big.Int, bool) {
+	if value == "" {
+		return nil, false
+	}
+	if digits, ok := strings.CutPrefix(value, "0x"); ok {
+		return new(big.Int).SetString(digits, 16)
+	}
+	return new(big.Int).SetString(value, 10)
+}
+
+// DecodeRevertData decodes hex encoded revert data of Error(string) and Panic(uint256) reverts,
+// selector of custom errors is returned without decoding.
+func DecodeRevertData(data string) RevertReason {
+	reason := RevertReason{Data: data}
+	raw, err := hexutil.Decode(data)
+	if err != nil || len(raw) < 4 {
+		return reason
+	}
+
+	if message, err := abi.UnpackRevert(raw); err == nil {
+		reason.Reason = message
+	} else {
+		reason.Selector = hexutil.Encode(raw[:4])
+	}
+	return reason
+}
@@ -0,0 +1,351 @@
+package common
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// Kinds of selectors resolved to signatures
+const (
+	FunctionSelectorKind = "function"
+	EventSelectorKind    = "event"
+)
+
+// Public signature databases queried for unknown selectors
+const (
+	DefaultOpenchainURL = "https://api.openchain.xyz/signature-database/v1/lookup"
+	DefaultFourByteURL  = "https://www.4byte.directory/api/v1"
+)
+
+// unknownSignatureTTL is time selector which is not found in signature databases is not queried again.
+const unknownSignatureTTL = time.Hour
+
+var signatureLookupsTotal = metrics.NewCounterVec("seer_signature_lookups_total",
+	"Lookups of unknown selectors in signature databases by source and result", "source", "result")
+
+type cachedSignature struct {
+	signature string
+	expiresAt time.Time // Zero for found signatures, they are cached forever
+}
+
+// SignatureResolver resolves function selectors and event topics to text signatures with
+// openchain and 4byte.directory signature databases. Results are cached in memory, selectors
+// which are not found are queried again after an hour.
+type SignatureResolver struct {
+	OpenchainURL string
+	FourByteURL  string
+
+	httpClient *http.Client
+
+	mux   sync.Mutex
+	cache map[string]cachedSignature
+}
+
+// NewSignatureResolver creates resolver which queries default signature databases.
+func NewSignatureResolver(timeout time.Duration) *SignatureResolver {
+	return &SignatureResolver{
+		OpenchainURL: DefaultOpenchainURL,
+		FourByteURL:  DefaultFourByteURL,
+		httpClient:   NewHTTPClient(DefaultOpenchainURL, timeout),
+		cache:        make(map[string]cachedSignature),
+	}
+}
+
+var (
+	signatureResolverMu sync.RWMutex
+	signatureResolver   *SignatureResolver
+)
+
+// SetSignatureResolver sets resolver used by decoders for selectors missing in ABIs, nil disables
+// resolution of unknown selectors.
+func SetSignatureResolver(resolver *SignatureResolver) {
+	signatureResolverMu.Lock()
+	defer signatureResolverMu.Unlock()
+	signatureResolver = resolver
+}
+
+// ResolveUnknownSelector returns ABI with single function or event resolved from selector by
+// signature resolver set with SetSignatureResolver. Argument names are not known, arguments are
+// named arg0, arg1, ... and indexedArgs first arguments of event are treated as indexed. It
+// returns false when resolution is disabled or selector is not found.
+func ResolveUnknownSelector(kind, selector string, indexedArgs int) (string, string, string, bool) {
+	signatureResolverMu.RLock()
+	resolver := signatureResolver
+	signatureResolverMu.RUnlock()
+
+	if resolver == nil {
+		return "", "", "", false
+	}
+
+	// Function selector is 4 bytes and event topic is 32 bytes
+	selectorLength := 10
+	if kind == EventSelectorKind {
+		selectorLength = 66
+	}
+	if len(selector) != selectorLength || !strings.HasPrefix(selector, "0x") {
+		return "", "", "", false
+	}
+
+	signature, err := resolver.Lookup(context.Background(), kind, selector)
+	if err != nil || signature == "" {
+		return "", "", "", false
+	}
+
+	abiJSON, name, err := SignatureABI(kind, signature, indexedArgs)
+	if err != nil {
+		return "", "", "", false
+	}
+
+	return abiJSON, name, signature, true
+}
+
+// Lookup returns text signature of selector, for example "transfer(address,uint256)", empty if
+// it is not found. Openchain is queried first, 4byte.directory when openchain does not know it.
+func (r *SignatureResolver) Lookup(ctx context.Context, kind, selector string) (string, error) {
+	selector = strings.ToLower(selector)
+	key := kind + ":" + selector
+
+	r.mux.Lock()
+	cached, ok := r.cache[key]
+	r.mux.Unlock()
+	if ok && (cached.expiresAt.IsZero() || time.Now().Before(cached.expiresAt)) {
+		return cached.signature, nil
+	}
+
+	var signature string
+	var lookupErr error
+	for _, source := range []struct {
+		name   string
+		lookup func(context.Context, string, string) (string, error)
+	}{
+		{"openchain", r.lookupOpenchain},
+		{"4byte", r.lookupFourByte},
+	} {
+		found, err := source.lookup(ctx, kind, selector)
+		switch {
+		case err != nil:
+			signatureLookupsTotal.Inc(source.name, "error")
+			lookupErr = err
+			continue
+		case found == "":
+			signatureLookupsTotal.Inc(source.name, "not_found")
+			continue
+		}
+		signatureLookupsTotal.Inc(source.name, "found")
+		signature = found
+		break
+	}
+
+	// Failed lookups are not cached, selector is queried again with next batch
+	if signature == "" && lookupErr != nil {
+		return "", lookupErr
+	}
+
+	r.mux.Lock()
+	if signature == "" {
+		r.cache[key] = cachedSignature{expiresAt: time.Now().Add(unknownSignatureTTL)}
+	} else {
+		r.cache[key] = cachedSignature{signature: signature}
+	}
+	r.mux.Unlock()
+
+	return signature, nil
+}
+
+func (r *SignatureResolver) lookupOpenchain(ctx context.Context, kind, selector string) (string, error) {
+	query := url.Values{}
+	query.Set(kind, selector)
+	query.Set("filter", "true")
+
+	var response struct {
+		Ok     bool `json:"ok"`
+		Result map[string]map[string][]struct {
+			Name string `json:"name"`
+		} `json:"result"`
+	}
+	if err := r.getJSON(ctx, r.OpenchainURL+"?"+query.Encode(), &response); err != nil {
+		return "", err
+	}
+	if !response.Ok {
+		return "", fmt.Errorf("openchain lookup of %s failed", selector)
+	}
+
+	for _, candidate := range response.Result[kind][selector] {
+		if candidate.Name != "" {
+			return candidate.Name, nil
+		}
+	}
+	return "", nil
+}
+
+func (r *SignatureResolver) lookupFourByte(ctx context.Context, kind, selector string) (string, error) {
+	endpoint := "signatures"
+	if kind == EventSelectorKind {
+		endpoint = "event-signatures"
+	}
+
+	var response struct {
+		Results []struct {
+			ID            int64  `json:"id"`
+			TextSignature string `json:"text_signature"`
+		} `json:"results"`
+	}
+	if err := r.getJSON(ctx, fmt.Sprintf("%s/%s/?hex_signature=%s", r.FourByteURL, endpoint, url.QueryEscape(selector)), &response); err != nil {
+		return "", err
+	}
+
+	// Colliding selectors are registered later, the first registered signature is the most likely one
+	var signature string
+	var signatureID int64
+	for _, result := range response.Results {
+		if signature == "" || result.ID < signatureID {
+			signature, signatureID = result.TextSignature, result.ID
+		}
+	}
+	return signature, nil
+}
+
+func (r *SignatureResolver) getJSON(ctx context.Context, requestURL string, value interface{}) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := r.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("signature lookup %s failed with status %s", RedactURL(requestURL), response.Status)
+	}
+
+	return json.NewDecoder(response.Body).Decode(value)
+}
+
+// SignatureABI returns JSON ABI with single function or event of text signature and its name.
+// Arguments are named arg0, arg1, ..., tuple components field0, field1, ..., and indexedArgs
+// first arguments of event are indexed.
+func SignatureABI(kind, signature string, indexedArgs int) (string, string, error) {
+	open := strings.Index(signature, "(")
+	if open <= 0 || !strings.HasSuffix(signature, ")") {
+		return "", "", fmt.Errorf("invalid signature %s", signature)
+	}
+	name := signature[:open]
+
+	types, err := splitSignatureTypes(signature[open+1 : len(signature)-1])
+	if err != nil {
+		return "", "", fmt.Errorf("invalid signature %s: %w", signature, err)
+	}
+	if indexedArgs > len(types) {
+		return "", "", fmt.Errorf("signature %s has %d arguments, %d are indexed", signature, len(types), indexedArgs)
+	}
+
+	inputs := make([]map[string]interface{}, 0, len(types))
+	for i, argType := range types {
+		input, err := signatureArgument(fmt.Sprintf("arg%d", i), argType)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid signature %s: %w", signature, err)
+		}
+		if kind == EventSelectorKind {
+			input["indexed"] = i < indexedArgs
+		}
+		inputs = append(inputs, input)
+	}
+
+	entry := map[string]interface{}{
+		"type":   kind,
+		"name":   name,
+		"inputs": inputs,
+	}
+	switch kind {
+	case FunctionSelectorKind:
+		entry["outputs"] = []interface{}{}
+		entry["stateMutability"] = "nonpayable"
+	case EventSelectorKind:
+		entry["anonymous"] = false
+	default:
+		return "", "", fmt.Errorf("unknown selector kind %s", kind)
+	}
+
+	abiJSON, err := json.Marshal([]interface{}{entry})
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(abiJSON), name, nil
+}
+
+// signatureArgument returns JSON ABI argument of type from text signature, tuples are expanded
+// to components.
+func signatureArgument(name, argType string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(argType, "(") {
+		return map[string]interface{}{"name": name, "type": argType}, nil
+	}
+
+	end := strings.LastIndex(argType, ")")
+	if end < 0 {
+		return nil, fmt.Errorf("unbalanced tuple %s", argType)
+	}
+
+	componentTypes, err := splitSignatureTypes(argType[1:end])
+	if err != nil {
+		return nil, err
+	}
+
+	components := make([]map[string]interface{}, 0, len(componentTypes))
+	for i, componentType := range componentTypes {
+		component, err := signatureArgument(fmt.Sprintf("field%d", i), componentType)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, component)
+	}
+
+	return map[string]interface{}{
+		"name":       name,
+		"type":       "tuple" + argType[end+1:],
+		"components": components,
+	}, nil
+}
+
+// splitSignatureTypes splits comma separated types of signature, commas inside tuples are kept.
+func splitSignatureTypes(types string) ([]string, error) {
+	if types == "" {
+		return nil, nil
+	}
+
+	var result []string
+	var depth, start int
+	for i, char := range types {
+		switch char {
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced parentheses in %s", types)
+			}
+		case ',':
+			if depth == 0 {
+				result = append(result, types[start:i])
+				start = i + 1
+			}
+		}
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses in %s", types)
+	}
+
+	return append(result, types[start:]), nil
+}
@@ -0,0 +1,161 @@
+package common
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// LogsFilter is filter of eth_getLogs requests and eth_subscribe logs subscription: logs emitted
+// by any of addresses with topics matching at every position any of listed topics, nil position
+// matches any topic.
+type LogsFilter struct {
+	Address []string   `json:"address,omitempty"`
+	Topics  [][]string `json:"topics,omitempty"`
+}
+
+// ParseLogsFilter parses filter from comma separated addresses and topics, positions of topics
+// are separated with semicolon, empty position matches any topic, for example
+// "0xddf2...;;0x0000..." filters by the first and the third topics.
+func ParseLogsFilter(addresses, topics string) (LogsFilter, error) {
+	var filter LogsFilter
+
+	for _, address := range strings.Split(addresses, ",") {
+		address = strings.TrimSpace(address)
+		if address == "" {
+			continue
+		}
+		if !common.IsHexAddress(address) {
+			return LogsFilter{}, fmt.Errorf("invalid address %q", address)
+		}
+		filter.Address = append(filter.Address, strings.ToLower(address))
+	}
+
+	if strings.TrimSpace(topics) != "" {
+		for _, position := range strings.Split(topics, ";") {
+			var positionTopics []string
+			for _, topic := range strings.Split(position, ",") {
+				topic = strings.TrimSpace(topic)
+				if topic == "" {
+					continue
+				}
+				if len(topic) != 66 || !strings.HasPrefix(topic, "0x") {
+					return LogsFilter{}, fmt.Errorf("invalid topic %q, expected 0x prefixed 32 bytes hash", topic)
+				}
+				positionTopics = append(positionTopics, strings.ToLower(topic))
+			}
+			filter.Topics = append(filter.Topics, positionTopics)
+		}
+		if len(filter.Topics) > 4 {
+			return LogsFilter{}, fmt.Errorf("logs have at most 4 topics, %d positions given", len(filter.Topics))
+		}
+	}
+
+	return filter, nil
+}
+
+// IsEmpty reports if filter matches all logs.
+func (f LogsFilter) IsEmpty() bool {
+	if len(f.Address) > 0 {
+		return false
+	}
+	for _, positionTopics := range f.Topics {
+		if len(positionTopics) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Matches reports if log matches filter the same way as eth_getLogs matches it.
+func (f LogsFilter) Matches(event *EventJson) bool {
+	if len(f.Address) > 0 {
+		var matched bool
+		for _, address := range f.Address {
+			if strings.EqualFold(address, event.Address) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	for position, positionTopics := range f.Topics {
+		if len(positionTopics) == 0 {
+			continue
+		}
+		if position >= len(event.Topics) {
+			return false
+		}
+		var matched bool
+		for _, topic := range positionTopics {
+			if strings.EqualFold(topic, event.Topics[position]) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
+	return true
+}
+
+// FilterQuery returns eth_getLogs query of logs matching filter in blocks from-to.
+func (f LogsFilter) FilterQuery(from, to *big.Int) ethereum.FilterQuery {
+	query := ethereum.FilterQuery{
+		FromBlock: from,
+		ToBlock:   to,
+	}
+	for _, address := range f.Address {
+		query.Addresses = append(query.Addresses, common.HexToAddress(address))
+	}
+	for _, positionTopics := range f.Topics {
+		var hashes []common.Hash
+		for _, topic := range positionTopics {
+			hashes = append(hashes, common.HexToHash(topic))
+		}
+		query.Topics = append(query.Topics, hashes)
+	}
+	return query
+}
+
+// Subscriber is a wrapper around JSON-RPC client connected to WebSocket (or IPC) endpoint of
+// EVM node, it delivers new heads and logs with eth_subscribe instead of polling.
+type Subscriber struct {
+	rpcClient *rpc.Client
+}
+
+func NewSubscriber(ctx context.Context, url string) (*Subscriber, error) {
+	rpcClient, err := dialRPCEndpoint(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Subscriber{rpcClient: rpcClient}, nil
+}
+
+// Close closes the underlying RPC client, active subscriptions receive error.
+func (s *Subscriber) Close() {
+	s.rpcClient.Close()
+}
+
+// SubscribeNewHeads delivers header of every new block, headers are decoded into BlockJson
+// without transactions.
+func (s *Subscriber) SubscribeNewHeads(ctx context.Context, ch chan<- *BlockJson) (*rpc.ClientSubscription, error) {
+	return s.rpcClient.EthSubscribe(ctx, ch, "newHeads")
+}
+
+// SubscribeLogs delivers logs matching filter as they are included in blocks, logs of blocks
+// removed by reorganization are delivered again with removed set.
+func (s *Subscriber) SubscribeLogs(ctx context.Context, ch chan<- *EventJson, filter LogsFilter) (*rpc.ClientSubscription, error) {
+	return s.rpcClient.EthSubscribe(ctx, ch, "logs", filter)
+}
@@ -0,0 +1,79 @@
+package common
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// UserOperation is ERC-4337 user operation bundled in handleOps call of EntryPoint.
+type UserOperation struct {
+	// Index is position of user operation in bundle
+	Index      int
+	EntryPoint string
+	// Sender is lowercase address of smart account which executes user operation
+	Sender string
+	Nonce  *big.Int
+	// Paymaster is lowercase address of paymaster which pays for user operation, empty if
+	// account pays itself
+	Paymaster string
+	// CallData is hex encoded call of account made by EntryPoint
+	CallData string
+}
+
+// DecodeUserOperations returns user operations bundled in hex encoded input of handleOps call of
+// EntryPoint v0.6 or v0.7 and later at address. False is returned when input is not handleOps.
+func DecodeUserOperations(entryPoint, input string) ([]UserOperation, bool, error) {
+	if len(input) < 10 {
+		return nil, false, nil
+	}
+	method, ok := multicallMethods[strings.ToLower(input[:10])]
+	if !ok || method.RawName != "handleOps" {
+		return nil, false, nil
+	}
+
+	data, err := hex.DecodeString(strings.TrimPrefix(input, "0x")[8:])
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to decode input of handleOps: %w", err)
+	}
+	args, err := method.Inputs.Unpack(data)
+	if err != nil {
+		return nil, true, fmt.Errorf("failed to unpack arguments of handleOps: %w", err)
+	}
+
+	// User operations of v0.6 and packed user operations of v0.7 are unpacked into different
+	// structs, fields used here have the same names in both of them
+	ops := reflect.ValueOf(args[0])
+	if ops.Kind() != reflect.Slice || ops.Type().Elem().Kind() != reflect.Struct {
+		return nil, true, fmt.Errorf("unexpected user operations type %T", args[0])
+	}
+
+	userOps := make([]UserOperation, ops.Len())
+	for i := 0; i < ops.Len(); i++ {
+		op := ops.Index(i)
+		sender, okSender := op.FieldByName("Sender").Interface().(common.Address)
+		nonce, okNonce := op.FieldByName("Nonce").Interface().(*big.Int)
+		callData, okCallData := op.FieldByName("CallData").Interface().([]byte)
+		paymasterAndData, okPaymaster := op.FieldByName("PaymasterAndData").Interface().([]byte)
+		if !okSender || !okNonce || !okCallData || !okPaymaster {
+			return nil, true, fmt.Errorf("unexpected fields of user operation %d", i)
+		}
+
+		userOps[i] = UserOperation{
+			Index:      i,
+			EntryPoint: strings.ToLower(entryPoint),
+			Sender:     strings.ToLower(sender.Hex()),
+			Nonce:      nonce,
+			CallData:   "0x" + hex.EncodeToString(callData),
+		}
+		if len(paymasterAndData) >= common.AddressLength {
+			userOps[i].Paymaster = strings.ToLower(common.BytesToAddress(paymasterAndData[:common.AddressLength]).Hex())
+		}
+	}
+
+	return userOps, true, nil
+}
@@ -0,0 +1,200 @@
+package common
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// Public sources of ABIs of verified contracts
+const (
+	DefaultSourcifyURL  = "https://sourcify.dev/server"
+	DefaultEtherscanURL = "https://api.etherscan.io/v2/api"
+)
+
+var verifiedABILookupsTotal = metrics.NewCounterVec("seer_verified_abi_lookups_total",
+	"Lookups of ABIs of verified contracts by source and result", "source", "result")
+
+// VerifiedABIFetcher fetches ABIs of verified contracts from Sourcify and Etherscan compatible
+// explorer API. Explorer is queried only when API key is set.
+type VerifiedABIFetcher struct {
+	SourcifyURL     string
+	EtherscanURL    string
+	EtherscanAPIKey string
+
+	httpClient *http.Client
+}
+
+// NewVerifiedABIFetcher creates fetcher which queries Sourcify and Etherscan API v2.
+func NewVerifiedABIFetcher(etherscanAPIKey string, timeout time.Duration) *VerifiedABIFetcher {
+	return &VerifiedABIFetcher{
+		SourcifyURL:     DefaultSourcifyURL,
+		EtherscanURL:    DefaultEtherscanURL,
+		EtherscanAPIKey: etherscanAPIKey,
+		httpClient:      NewHTTPClient(DefaultSourcifyURL, timeout),
+	}
+}
+
+// Fetch returns JSON ABI of verified contract at address of chain with chainID and name of source
+// it was fetched from. ABI is empty if contract is not verified at any source.
+func (f *VerifiedABIFetcher) Fetch(ctx context.Context, chainID uint64, address string) (string, string, error) {
+	address = strings.ToLower(address)
+
+	type abiSource struct {
+		name  string
+		fetch func(context.Context, uint64, string) (string, error)
+	}
+	sources := []abiSource{{"sourcify", f.fetchSourcify}}
+	if f.EtherscanAPIKey != "" {
+		sources = append(sources, abiSource{"etherscan", f.fetchEtherscan})
+	}
+
+	var fetchErr error
+	for _, source := range sources {
+		contractABI, err := source.fetch(ctx, chainID, address)
+		switch {
+		case err != nil:
+			verifiedABILookupsTotal.Inc(source.name, "error")
+			fetchErr = err
+			continue
+		case contractABI == "":
+			verifiedABILookupsTotal.Inc(source.name, "not_found")
+			continue
+		}
+		verifiedABILookupsTotal.Inc(source.name, "found")
+		return contractABI, source.name, nil
+	}
+
+	return "", "", fetchErr
+}
+
+func (f *VerifiedABIFetcher) fetchSourcify(ctx context.Context, chainID uint64, address string) (string, error) {
+	requestURL := fmt.Sprintf("%s/v2/contract/%d/%s?fields=abi", f.SourcifyURL, chainID, address)
+
+	var response struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	found, err := f.getJSON(ctx, requestURL, &response)
+	if err != nil || !found || len(response.ABI) == 0 || string(response.ABI) == "null" {
+		return "", err
+	}
+
+	return string(response.ABI), nil
+}
+
+func (f *VerifiedABIFetcher) fetchEtherscan(ctx context.Context, chainID uint64, address string) (string, error) {
+	query := url.Values{}
+	query.Set("chainid", fmt.Sprint(chainID))
+	query.Set("module", "contract")
+	query.Set("action", "getabi")
+	query.Set("address", address)
+	query.Set("apikey", f.EtherscanAPIKey)
+
+	var response struct {
+		Status  string `json:"status"`
+		Message string `json:"message"`
+		Result  string `json:"result"`
+	}
+	if _, err := f.getJSON(ctx, f.EtherscanURL+"?"+query.Encode(), &response); err != nil {
+		return "", err
+	}
+
+	if response.Status != "1" {
+		// Explorer reports unverified contracts and failed requests with the same status
+		if strings.Contains(strings.ToLower(response.Result), "not verified") {
+			return "", nil
+		}
+		return "", fmt.Errorf("etherscan lookup of %s failed: %s %s", address, response.Message, response.Result)
+	}
+
+	return response.Result, nil
+}
+
+// getJSON decodes response of GET request to value, it returns false when resource is not found.
+func (f *VerifiedABIFetcher) getJSON(ctx context.Context, requestURL string, value interface{}) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return false, err
+	}
+	request.Header.Set("Accept", "application/json")
+
+	response, err := f.httpClient.Do(request)
+	if err != nil {
+		// Errors of HTTP client contain request URL with API key
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return false, fmt.Errorf("ABI lookup %s failed: %w", RedactURL(requestURL), err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("ABI lookup %s failed with status %s", RedactURL(requestURL), response.Status)
+	}
+
+	return true, json.NewDecoder(response.Body).Decode(value)
+}
+
+// ABIEntry is function or event of contract ABI with its selector, for events selector is topic.
+type ABIEntry struct {
+	Type     string
+	Name     string
+	Selector string
+	ABI      string
+}
+
+// SplitABI returns functions which could be called by transactions and non anonymous events of
+// JSON ABI, each with JSON of its own entry. View and pure functions are skipped.
+func SplitABI(contractABI string) ([]ABIEntry, error) {
+	var items []json.RawMessage
+	if err := json.Unmarshal([]byte(contractABI), &items); err != nil {
+		return nil, fmt.Errorf("failed to decode ABI: %w", err)
+	}
+
+	var entries []ABIEntry
+	for _, item := range items {
+		parsed, err := abi.JSON(strings.NewReader("[" + string(item) + "]"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI entry %s: %w", string(item), err)
+		}
+
+		for _, method := range parsed.Methods {
+			if method.StateMutability == "view" || method.StateMutability == "pure" || method.Constant {
+				continue
+			}
+			entries = append(entries, ABIEntry{
+				Type:     "function",
+				Name:     method.RawName,
+				Selector: "0x" + hex.EncodeToString(method.ID),
+				ABI:      string(item),
+			})
+		}
+		for _, event := range parsed.Events {
+			if event.Anonymous {
+				continue
+			}
+			entries = append(entries, ABIEntry{
+				Type:     "event",
+				Name:     event.RawName,
+				Selector: event.ID.Hex(),
+				ABI:      string(item),
+			})
+		}
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,251 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/blockchain/ethereum"
+	"github.com/moonstream-to/seer/indexer"
+	"google.golang.org/protobuf/proto"
+)
+
+// BlockFeesIndexKind is kind of custom index with nonstandard fee fields of blocks of custom
+// chains, stored in <chain>_block_fees table
+const BlockFeesIndexKind = "block_fees"
+
+var customChainNameRe = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+var (
+	customChainsMu sync.RWMutex
+	customChains   = map[string]CustomChain{}
+)
+
+// CustomChain describes EVM L1 which is crawled with Ethereum client under own name, such as
+// Avalanche subnet, so it does not need own client package.
+type CustomChain struct {
+	Name         string `json:"name"`
+	ChainID      uint64 `json:"chain_id,omitempty"`      // checked with eth_chainId on connection if set
+	BlockchainID string `json:"blockchain_id,omitempty"` // Avalanche blockchain ID of subnet
+	// RPCPath is appended to node URLs, {blockchain_id} and {chain_id} are replaced with chain
+	// parameters, e.g. /ext/bc/{blockchain_id}/rpc for Avalanche nodes
+	RPCPath string `json:"rpc_path,omitempty"`
+	// FeeFields are nonstandard fee fields of block headers, e.g. blockGasCost and
+	// extDataGasUsed of Avalanche, indexed in <chain>_block_fees table
+	FeeFields []string `json:"fee_fields,omitempty"`
+}
+
+// NodeURL returns URLs of chain endpoints for comma separated node URLs with RPC path appended.
+func (cc CustomChain) NodeURL(urls string) string {
+	if cc.RPCPath == "" {
+		return urls
+	}
+
+	path := strings.NewReplacer("{blockchain_id}", cc.BlockchainID, "{chain_id}", fmt.Sprintf("%d", cc.ChainID)).Replace(cc.RPCPath)
+	var endpoints []string
+	for _, url := range strings.Split(urls, ",") {
+		url = strings.TrimSpace(url)
+		if url == "" {
+			continue
+		}
+		endpoints = append(endpoints, strings.TrimRight(url, "/")+"/"+strings.TrimLeft(path, "/"))
+	}
+
+	return strings.Join(endpoints, ",")
+}
+
+// RegisterCustomChain registers Ethereum client and index tables under name of custom chain.
+func RegisterCustomChain(cc CustomChain) error {
+	if !customChainNameRe.MatchString(cc.Name) {
+		return fmt.Errorf("invalid custom chain name %q, lowercase letters, digits and underscores are allowed", cc.Name)
+	}
+	if _, exists := getFactory(cc.Name); exists {
+		return fmt.Errorf("blockchain client for %s is already registered", cc.Name)
+	}
+	if strings.Contains(cc.RPCPath, "{blockchain_id}") && cc.BlockchainID == "" {
+		return fmt.Errorf("rpc_path of custom chain %s requires blockchain_id", cc.Name)
+	}
+
+	seer_common.RegisterHasher(cc.Name, seer_common.GetHasher("ethereum"))
+	for _, table := range indexer.GetCustomIndexTables("ethereum") {
+		indexer.RegisterCustomIndexTable(cc.Name, table)
+	}
+	if len(cc.FeeFields) > 0 {
+		columns := []indexer.CustomIndexColumn{
+			{Name: "block_number", Type: "BIGINT"},
+			{Name: "block_hash", Type: "TEXT"},
+			{Name: "block_timestamp", Type: "BIGINT"},
+		}
+		for _, field := range cc.FeeFields {
+			columns = append(columns, indexer.CustomIndexColumn{Name: feeFieldColumn(field), Type: "NUMERIC"})
+		}
+		indexer.RegisterCustomIndexTable(cc.Name, indexer.CustomIndexTable{
+			Kind:           BlockFeesIndexKind,
+			Columns:        columns,
+			ConflictClause: "ON CONFLICT (block_hash) DO NOTHING",
+		})
+	}
+
+	customChainsMu.Lock()
+	customChains[cc.Name] = cc
+	customChainsMu.Unlock()
+
+	Register(cc.Name, func(url string, timeout int) (BlockchainClient, error) {
+		return newCustomChainClient(cc, url, timeout)
+	})
+
+	return nil
+}
+
+// CustomChainNodeURL returns endpoint URLs for node URLs of chain, RPC path is appended for
+// custom chains and URLs of other chains are returned as is. Settings apply it before URLs are
+// used as keys of endpoint credentials and proxies.
+func CustomChainNodeURL(chain, urls string) string {
+	customChainsMu.RLock()
+	cc, ok := customChains[chain]
+	customChainsMu.RUnlock()
+	if !ok {
+		return urls
+	}
+
+	return cc.NodeURL(urls)
+}
+
+// LoadCustomChainsFromEnv registers custom chains listed in JSON file at SEER_CUSTOM_CHAINS path.
+func LoadCustomChainsFromEnv() error {
+	SEER_CUSTOM_CHAINS := os.Getenv("SEER_CUSTOM_CHAINS")
+	if SEER_CUSTOM_CHAINS == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(SEER_CUSTOM_CHAINS)
+	if err != nil {
+		return fmt.Errorf("failed to read custom chains config: %w", err)
+	}
+
+	var chains []CustomChain
+	if err := json.Unmarshal(data, &chains); err != nil {
+		return fmt.Errorf("invalid custom chains config %s: %w", SEER_CUSTOM_CHAINS, err)
+	}
+
+	for _, cc := range chains {
+		if err := RegisterCustomChain(cc); err != nil {
+			return err
+		}
+		log.Printf("Registered custom chain %s", cc.Name)
+	}
+
+	return nil
+}
+
+// feeFieldColumn converts camelCase JSON field of header to snake_case column name.
+func feeFieldColumn(field string) string {
+	var column strings.Builder
+	for i, r := range field {
+		if unicode.IsUpper(r) {
+			if i > 0 {
+				column.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		column.WriteRune(r)
+	}
+	return column.String()
+}
+
+// customChainClient is Ethereum client which reports name of custom chain and indexes its
+// nonstandard fee fields.
+type customChainClient struct {
+	*ethereum.Client
+	chain   CustomChain
+	timeout time.Duration
+}
+
+func newCustomChainClient(cc CustomChain, url string, timeout int) (*customChainClient, error) {
+	client, err := ethereum.NewClient(url, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	if cc.ChainID != 0 {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+		defer cancel()
+
+		chainID, err := client.GetChainID(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get chain ID of %s: %w", cc.Name, err)
+		}
+		if chainID.Uint64() != cc.ChainID {
+			return nil, fmt.Errorf("node of %s reports chain ID %d instead of %d", cc.Name, chainID, cc.ChainID)
+		}
+	}
+
+	return &customChainClient{Client: client, chain: cc, timeout: time.Duration(timeout) * time.Second}, nil
+}
+
+// ChainType returns name of custom chain.
+func (c *customChainClient) ChainType() string {
+	return c.chain.Name
+}
+
+// CustomIndexesFromProtoBlocks returns indexes of Ethereum client and fee fields of blocks. Fee
+// fields are not part of blocks protos, they are requested with headers of blocks.
+func (c *customChainClient) CustomIndexesFromProtoBlocks(blocks []proto.Message) ([]indexer.CustomIndex, error) {
+	indexes, err := c.Client.CustomIndexesFromProtoBlocks(blocks)
+	if err != nil || len(c.chain.FeeFields) == 0 || len(blocks) == 0 {
+		return indexes, err
+	}
+
+	numbers := make([]uint64, len(blocks))
+	for i, msg := range blocks {
+		block, ok := msg.(*ethereum.EthereumBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *ethereum.EthereumBlock")
+		}
+		numbers[i] = block.BlockNumber
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	headersFields, err := c.GetBlocksHeaderFields(ctx, numbers, c.chain.FeeFields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fee fields of blocks: %w", err)
+	}
+
+	for _, msg := range blocks {
+		block := msg.(*ethereum.EthereumBlock)
+		values := map[string]interface{}{
+			"block_number":    block.BlockNumber,
+			"block_hash":      block.Hash,
+			"block_timestamp": block.Timestamp,
+		}
+		for _, field := range c.chain.FeeFields {
+			var value interface{}
+			if raw := headersFields[block.BlockNumber][field]; raw != "" {
+				quantity, ok := new(big.Int).SetString(raw, 0)
+				if !ok {
+					return nil, fmt.Errorf("invalid %s %q of block %d", field, raw, block.BlockNumber)
+				}
+				value = quantity.String()
+			}
+			values[feeFieldColumn(field)] = value
+		}
+
+		indexes = append(indexes, indexer.CustomIndex{
+			Kind:   BlockFeesIndexKind,
+			Values: values,
+		})
+	}
+
+	return indexes, nil
+}
@@ -0,0 +1,518 @@
+package ethereum_beacon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/moonstream-to/seer/blockchain/beacon"
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/version"
+)
+
+const (
+	// AttestationsIndexKind is custom index with attestations, stored at ethereum_beacon_attestations table
+	AttestationsIndexKind = "attestations"
+	// ValidatorBalancesIndexKind is custom index with balances of tracked validators, stored at
+	// ethereum_beacon_validator_balances table
+	ValidatorBalancesIndexKind = "validator_balances"
+)
+
+func init() {
+	indexer.RegisterCustomIndexTable("ethereum_beacon", indexer.CustomIndexTable{
+		Kind: AttestationsIndexKind,
+		Columns: []indexer.CustomIndexColumn{
+			{Name: "block_number", Type: "BIGINT"},
+			{Name: "block_hash", Type: "TEXT"},
+			{Name: "block_timestamp", Type: "BIGINT"},
+			{Name: "attestation_index", Type: "BIGINT"},
+			{Name: "slot", Type: "BIGINT"},
+			{Name: "committee_index", Type: "BIGINT"},
+			{Name: "beacon_block_root", Type: "TEXT"},
+			{Name: "source_epoch", Type: "BIGINT"},
+			{Name: "target_epoch", Type: "BIGINT"},
+		},
+		ConflictClause: "ON CONFLICT (block_hash, attestation_index) DO NOTHING",
+	})
+	indexer.RegisterCustomIndexTable("ethereum_beacon", indexer.CustomIndexTable{
+		Kind: ValidatorBalancesIndexKind,
+		Columns: []indexer.CustomIndexColumn{
+			{Name: "epoch", Type: "BIGINT"},
+			{Name: "validator_index", Type: "BIGINT"},
+			{Name: "balance", Type: "BIGINT"},
+			{Name: "slot", Type: "BIGINT"},
+			{Name: "block_number", Type: "BIGINT"},
+			{Name: "block_hash", Type: "TEXT"},
+		},
+		ConflictClause: "ON CONFLICT (epoch, validator_index) DO NOTHING",
+	})
+}
+
+func NewClient(url string, timeout int) (*Client, error) {
+	beaconClient, err := beacon.NewClient(url, timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{beaconClient: beaconClient}, nil
+}
+
+// Client crawls consensus layer of Ethereum with beacon node REST API. Slots are blocks: block
+// number is slot and block hash is block root, slots without block are skipped.
+type Client struct {
+	beaconClient *beacon.Client
+
+	// validators are indexes or public keys of validators which balances are snapshotted at
+	// every epoch
+	validators []string
+}
+
+// Client common
+
+// ChainType returns the chain type.
+func (c *Client) ChainType() string {
+	return "ethereum_beacon"
+}
+
+// SetValidators sets validators which balances are stored at the first slot of every epoch.
+func (c *Client) SetValidators(validators []string) {
+	c.validators = validators
+}
+
+// GetLatestBlockNumber returns slot of head block.
+func (c *Client) GetLatestBlockNumber(ctx context.Context) (*big.Int, error) {
+	header, err := c.beaconClient.GetBlockHeader(ctx, "head")
+	if err != nil {
+		return nil, err
+	}
+
+	slot, err := parseUint("slot", header.Header.Message.Slot)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetUint64(slot), nil
+}
+
+// GetBlocksTimestamps returns timestamps of slots, they are known also for slots without block.
+func (c *Client) GetBlocksTimestamps(ctx context.Context, numbers []uint64) (map[uint64]uint64, error) {
+	timestamps := make(map[uint64]uint64, len(numbers))
+	for _, slot := range numbers {
+		timestamp, err := c.beaconClient.TimestampAtSlot(ctx, slot)
+		if err != nil {
+			return nil, err
+		}
+		timestamps[slot] = timestamp
+	}
+
+	return timestamps, nil
+}
+
+// GetBlockBySlot returns block at slot with its root, nil block is returned for slot without
+// block.
+func (c *Client) GetBlockBySlot(ctx context.Context, slot uint64) (*BeaconBlock, error) {
+	slotID := strconv.FormatUint(slot, 10)
+
+	header, err := c.beaconClient.GetBlockHeader(ctx, slotID)
+	if errors.Is(err, beacon.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get header of slot %d: %w", slot, err)
+	}
+
+	block, err := c.beaconClient.GetBlock(ctx, header.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %s of slot %d: %w", header.Root, slot, err)
+	}
+
+	timestamp, err := c.beaconClient.TimestampAtSlot(ctx, slot)
+	if err != nil {
+		return nil, err
+	}
+	slotsPerEpoch, err := c.beaconClient.SlotsPerEpoch(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ToProtoSingleBlock(header, block, timestamp, slotsPerEpoch)
+}
+
+// FetchBlocksInRange fetches blocks of slots within a specified range, with up to maxRequests
+// concurrent requests. Blocks are returned in ascending order, slots without block are skipped.
+func (c *Client) FetchBlocksInRange(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]*BeaconBlock, error) {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	fromSlot := from.Uint64()
+	toSlot := to.Uint64()
+	if toSlot < fromSlot {
+		return nil, nil
+	}
+
+	slotBlocks := make([]*BeaconBlock, toSlot-fromSlot+1)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	sem := make(chan struct{}, maxRequests)
+
+	for slot := fromSlot; slot <= toSlot; slot++ {
+		wg.Add(1)
+		go func(slot uint64) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			block, err := c.GetBlockBySlot(ctx, slot)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			slotBlocks[slot-fromSlot] = block
+
+			if debug {
+				fmt.Printf("Fetched slot: %d\n", slot)
+			}
+		}(slot)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var blocks []*BeaconBlock
+	for _, block := range slotBlocks {
+		if block != nil {
+			blocks = append(blocks, block)
+		}
+	}
+
+	return blocks, nil
+}
+
+// fetchValidatorBalances adds snapshot of tracked validators balances to the first block of
+// every epoch which first slot is within range. Balances are taken from state at the first slot
+// of epoch, also if that slot has no block.
+func (c *Client) fetchValidatorBalances(ctx context.Context, blocks []*BeaconBlock, fromSlot uint64) error {
+	if len(c.validators) == 0 {
+		return nil
+	}
+
+	slotsPerEpoch, err := c.beaconClient.SlotsPerEpoch(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i, block := range blocks {
+		epochSlot := block.Epoch * slotsPerEpoch
+		if epochSlot < fromSlot || (i > 0 && blocks[i-1].Epoch == block.Epoch) {
+			continue
+		}
+
+		balances, err := c.beaconClient.GetValidatorBalances(ctx, epochSlot, c.validators)
+		if err != nil {
+			return fmt.Errorf("failed to get validator balances at slot %d: %w", epochSlot, err)
+		}
+
+		for _, balanceJson := range balances {
+			validatorIndex, err := parseUint("validator index", balanceJson.Index)
+			if err != nil {
+				return err
+			}
+			balance, err := parseUint("balance", balanceJson.Balance)
+			if err != nil {
+				return err
+			}
+
+			block.ValidatorBalances = append(block.ValidatorBalances, &BeaconValidatorBalance{
+				ValidatorIndex: validatorIndex,
+				Balance:        balance,
+				Epoch:          block.Epoch,
+				Slot:           epochSlot,
+			})
+		}
+	}
+
+	return nil
+}
+
+// FetchAsProtoBlocksWithEvents fetches beacon blocks of slots within range and converts them into
+// proto messages with indexes. Beacon chain has no transactions and events, attestations and
+// validator balances are indexed with CustomIndexesFromProtoBlocks.
+func (c *Client) FetchAsProtoBlocksWithEvents(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error) {
+	blocks, err := c.FetchBlocksInRange(ctx, from, to, debug, maxRequests)
+	if err != nil {
+		return nil, nil, nil, nil, 0, err
+	}
+
+	if err := c.fetchValidatorBalances(ctx, blocks, from.Uint64()); err != nil {
+		return nil, nil, nil, nil, 0, err
+	}
+
+	var blocksSize uint64
+	var blocksProto []proto.Message
+	var blocksIndex []indexer.BlockIndex
+
+	for bI, block := range blocks {
+		blocksIndex = append(blocksIndex, indexer.NewBlockIndex("ethereum_beacon",
+			block.BlockNumber,
+			block.Hash,
+			block.Timestamp,
+			block.ParentHash,
+			uint64(bI),
+			"",
+			0,
+		))
+
+		blocksSize += uint64(proto.Size(block))
+		blocksProto = append(blocksProto, block)
+	}
+
+	if len(blocks) == 0 {
+		log.Printf("No beacon blocks at slots %d-%d", from.Uint64(), to.Uint64())
+	}
+
+	return blocksProto, blocksIndex, []indexer.TransactionIndex{}, []indexer.LogIndex{}, blocksSize, nil
+}
+
+// CustomIndexesFromProtoBlocks builds attestations and validator balances indexes.
+func (c *Client) CustomIndexesFromProtoBlocks(msgs []proto.Message) ([]indexer.CustomIndex, error) {
+	var customIndexes []indexer.CustomIndex
+	for _, msg := range msgs {
+		block, ok := msg.(*BeaconBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *BeaconBlock")
+		}
+
+		for _, attestation := range block.Attestations {
+			customIndexes = append(customIndexes, indexer.CustomIndex{
+				Kind: AttestationsIndexKind,
+				Values: map[string]interface{}{
+					"block_number":      block.BlockNumber,
+					"block_hash":        block.Hash,
+					"block_timestamp":   block.Timestamp,
+					"attestation_index": attestation.AttestationIndex,
+					"slot":              attestation.Slot,
+					"committee_index":   attestation.CommitteeIndex,
+					"beacon_block_root": attestation.BeaconBlockRoot,
+					"source_epoch":      attestation.SourceEpoch,
+					"target_epoch":      attestation.TargetEpoch,
+				},
+			})
+		}
+
+		for _, balance := range block.ValidatorBalances {
+			customIndexes = append(customIndexes, indexer.CustomIndex{
+				Kind: ValidatorBalancesIndexKind,
+				Values: map[string]interface{}{
+					"epoch":           balance.Epoch,
+					"validator_index": balance.ValidatorIndex,
+					"balance":         balance.Balance,
+					"slot":            balance.Slot,
+					"block_number":    block.BlockNumber,
+					"block_hash":      block.Hash,
+				},
+			})
+		}
+	}
+
+	return customIndexes, nil
+}
+
+func (c *Client) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
+	var blocks []*BeaconBlock
+	for _, msg := range msgs {
+		block, ok := msg.(*BeaconBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *BeaconBlock")
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &BeaconBlocksBatch{
+		Blocks:      blocks,
+		SeerVersion: version.SeerVersion,
+	}, nil
+}
+
+func (c *Client) decodeBlocksBatch(rawData *bytes.Buffer) (*BeaconBlocksBatch, error) {
+	var protoBlocksBatch BeaconBlocksBatch
+
+	if err := proto.Unmarshal(rawData.Bytes(), &protoBlocksBatch); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	return &protoBlocksBatch, nil
+}
+
+// DecodeProtoEntireBlockToJson returns blocks in EVM oriented JSON structure, attestations and
+// validator balances are available with DecodeProtoEntireBlockToProtoJson.
+func (c *Client) DecodeProtoEntireBlockToJson(rawData *bytes.Buffer) (*seer_common.BlocksBatchJson, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksBatchJson := seer_common.BlocksBatchJson{
+		Blocks:      []seer_common.BlockJson{},
+		SeerVersion: protoBlocksBatch.SeerVersion,
+	}
+
+	for _, b := range protoBlocksBatch.Blocks {
+		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
+			Hash:        b.Hash,
+			BlockNumber: fmt.Sprintf("%d", b.BlockNumber),
+			ParentHash:  b.ParentHash,
+			StateRoot:   b.StateRoot,
+			Miner:       fmt.Sprintf("%d", b.ProposerIndex),
+			ExtraData:   b.Graffiti,
+			Timestamp:   fmt.Sprintf("%d", b.Timestamp),
+			IndexedAt:   fmt.Sprintf("%d", b.IndexedAt),
+		})
+	}
+
+	return &blocksBatchJson, nil
+}
+
+// DecodeProtoEntireBlockToProtoJson returns batch with all fields, including attestations and
+// validator balances.
+func (c *Client) DecodeProtoEntireBlockToProtoJson(rawData *bytes.Buffer) ([]byte, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	return protojson.Marshal(protoBlocksBatch)
+}
+
+// DecodeProtoEntireBlockToLabels returns no labels, beacon blocks have no contract calls to
+// decode. Analytics use attestations and validator balances indexes instead.
+func (c *Client) DecodeProtoEntireBlockToLabels(ctx context.Context, rawData *bytes.Buffer, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
+	return nil, nil, nil
+}
+
+func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error) {
+	return nil, nil
+}
+
+func parseUint(name, value string) (uint64, error) {
+	if value == "" {
+		return 0, nil
+	}
+
+	number, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q: %v", name, value, err)
+	}
+
+	return number, nil
+}
+
+func ToProtoSingleBlock(header *beacon.BlockHeaderJson, signedBlock *beacon.SignedBeaconBlockJson, timestamp, slotsPerEpoch uint64) (*BeaconBlock, error) {
+	message := signedBlock.Message
+	body := message.Body
+
+	slot, err := parseUint("slot", message.Slot)
+	if err != nil {
+		return nil, err
+	}
+	proposerIndex, err := parseUint("proposer index", message.ProposerIndex)
+	if err != nil {
+		return nil, err
+	}
+	depositCount, err := parseUint("deposit count", body.Eth1Data.DepositCount)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &BeaconBlock{
+		BlockNumber:            slot,
+		Hash:                   header.Root,
+		ParentHash:             message.ParentRoot,
+		Timestamp:              timestamp,
+		Epoch:                  slot / slotsPerEpoch,
+		ProposerIndex:          proposerIndex,
+		StateRoot:              message.StateRoot,
+		BodyRoot:               header.Header.Message.BodyRoot,
+		RandaoReveal:           body.RandaoReveal,
+		Graffiti:               body.Graffiti,
+		Signature:              signedBlock.Signature,
+		Eth1DepositRoot:        body.Eth1Data.DepositRoot,
+		Eth1DepositCount:       depositCount,
+		Eth1BlockHash:          body.Eth1Data.BlockHash,
+		ProposerSlashingsCount: uint64(len(body.ProposerSlashings)),
+		AttesterSlashingsCount: uint64(len(body.AttesterSlashings)),
+		DepositsCount:          uint64(len(body.Deposits)),
+		VoluntaryExitsCount:    uint64(len(body.VoluntaryExits)),
+		IndexedAt:              uint64(time.Now().Unix()),
+	}
+
+	if payload := body.ExecutionPayload; payload != nil {
+		executionBlockNumber, err := parseUint("execution block number", payload.BlockNumber)
+		if err != nil {
+			return nil, err
+		}
+		block.ExecutionBlockNumber = executionBlockNumber
+		block.ExecutionBlockHash = payload.BlockHash
+		block.FeeRecipient = payload.FeeRecipient
+		block.ExecutionTransactionsCount = uint64(len(payload.Transactions))
+		block.WithdrawalsCount = uint64(len(payload.Withdrawals))
+	}
+
+	for aI, attestationJson := range body.Attestations {
+		attestedSlot, err := parseUint("attestation slot", attestationJson.Data.Slot)
+		if err != nil {
+			return nil, err
+		}
+		committeeIndex, err := parseUint("committee index", attestationJson.Data.Index)
+		if err != nil {
+			return nil, err
+		}
+		sourceEpoch, err := parseUint("source epoch", attestationJson.Data.Source.Epoch)
+		if err != nil {
+			return nil, err
+		}
+		targetEpoch, err := parseUint("target epoch", attestationJson.Data.Target.Epoch)
+		if err != nil {
+			return nil, err
+		}
+
+		block.Attestations = append(block.Attestations, &BeaconAttestation{
+			AttestationIndex: uint64(aI),
+			AggregationBits:  attestationJson.AggregationBits,
+			Slot:             attestedSlot,
+			CommitteeIndex:   committeeIndex,
+			BeaconBlockRoot:  attestationJson.Data.BeaconBlockRoot,
+			SourceEpoch:      sourceEpoch,
+			SourceRoot:       attestationJson.Data.Source.Root,
+			TargetEpoch:      targetEpoch,
+			TargetRoot:       attestationJson.Data.Target.Root,
+			Signature:        attestationJson.Signature,
+		})
+	}
+
+	return block, nil
+}
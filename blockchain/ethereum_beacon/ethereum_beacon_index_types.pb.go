@@ -0,0 +1,751 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.6.1
+// source: blockchain/ethereum_beacon/ethereum_beacon_index_types.proto
+
+package ethereum_beacon
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents aggregated attestation included into beacon block
+type BeaconAttestation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AttestationIndex uint64 `protobuf:"varint,1,opt,name=attestation_index,json=attestationIndex,proto3" json:"attestation_index,omitempty"` // Index of attestation in block
+	AggregationBits  string `protobuf:"bytes,2,opt,name=aggregation_bits,json=aggregationBits,proto3" json:"aggregation_bits,omitempty"`
+	Slot             uint64 `protobuf:"varint,3,opt,name=slot,proto3" json:"slot,omitempty"` // Attested slot
+	CommitteeIndex   uint64 `protobuf:"varint,4,opt,name=committee_index,json=committeeIndex,proto3" json:"committee_index,omitempty"`
+	BeaconBlockRoot  string `protobuf:"bytes,5,opt,name=beacon_block_root,json=beaconBlockRoot,proto3" json:"beacon_block_root,omitempty"` // Block root attesters voted for
+	SourceEpoch      uint64 `protobuf:"varint,6,opt,name=source_epoch,json=sourceEpoch,proto3" json:"source_epoch,omitempty"`
+	SourceRoot       string `protobuf:"bytes,7,opt,name=source_root,json=sourceRoot,proto3" json:"source_root,omitempty"`
+	TargetEpoch      uint64 `protobuf:"varint,8,opt,name=target_epoch,json=targetEpoch,proto3" json:"target_epoch,omitempty"`
+	TargetRoot       string `protobuf:"bytes,9,opt,name=target_root,json=targetRoot,proto3" json:"target_root,omitempty"`
+	Signature        string `protobuf:"bytes,10,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *BeaconAttestation) Reset() {
+	*x = BeaconAttestation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeaconAttestation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeaconAttestation) ProtoMessage() {}
+
+func (x *BeaconAttestation) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeaconAttestation.ProtoReflect.Descriptor instead.
+func (*BeaconAttestation) Descriptor() ([]byte, []int) {
+	return file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *BeaconAttestation) GetAttestationIndex() uint64 {
+	if x != nil {
+		return x.AttestationIndex
+	}
+	return 0
+}
+
+func (x *BeaconAttestation) GetAggregationBits() string {
+	if x != nil {
+		return x.AggregationBits
+	}
+	return ""
+}
+
+func (x *BeaconAttestation) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+func (x *BeaconAttestation) GetCommitteeIndex() uint64 {
+	if x != nil {
+		return x.CommitteeIndex
+	}
+	return 0
+}
+
+func (x *BeaconAttestation) GetBeaconBlockRoot() string {
+	if x != nil {
+		return x.BeaconBlockRoot
+	}
+	return ""
+}
+
+func (x *BeaconAttestation) GetSourceEpoch() uint64 {
+	if x != nil {
+		return x.SourceEpoch
+	}
+	return 0
+}
+
+func (x *BeaconAttestation) GetSourceRoot() string {
+	if x != nil {
+		return x.SourceRoot
+	}
+	return ""
+}
+
+func (x *BeaconAttestation) GetTargetEpoch() uint64 {
+	if x != nil {
+		return x.TargetEpoch
+	}
+	return 0
+}
+
+func (x *BeaconAttestation) GetTargetRoot() string {
+	if x != nil {
+		return x.TargetRoot
+	}
+	return ""
+}
+
+func (x *BeaconAttestation) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+// Represents balance of tracked validator at the first slot of epoch
+type BeaconValidatorBalance struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ValidatorIndex uint64 `protobuf:"varint,1,opt,name=validator_index,json=validatorIndex,proto3" json:"validator_index,omitempty"`
+	Balance        uint64 `protobuf:"varint,2,opt,name=balance,proto3" json:"balance,omitempty"` // Balance in Gwei
+	Epoch          uint64 `protobuf:"varint,3,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	Slot           uint64 `protobuf:"varint,4,opt,name=slot,proto3" json:"slot,omitempty"` // Slot of state balance is taken from
+}
+
+func (x *BeaconValidatorBalance) Reset() {
+	*x = BeaconValidatorBalance{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeaconValidatorBalance) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeaconValidatorBalance) ProtoMessage() {}
+
+func (x *BeaconValidatorBalance) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeaconValidatorBalance.ProtoReflect.Descriptor instead.
+func (*BeaconValidatorBalance) Descriptor() ([]byte, []int) {
+	return file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BeaconValidatorBalance) GetValidatorIndex() uint64 {
+	if x != nil {
+		return x.ValidatorIndex
+	}
+	return 0
+}
+
+func (x *BeaconValidatorBalance) GetBalance() uint64 {
+	if x != nil {
+		return x.Balance
+	}
+	return 0
+}
+
+func (x *BeaconValidatorBalance) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *BeaconValidatorBalance) GetSlot() uint64 {
+	if x != nil {
+		return x.Slot
+	}
+	return 0
+}
+
+// Represents a beacon block, block number is slot and block hash is block root
+type BeaconBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber                uint64                    `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"` // Slot of block
+	Hash                       string                    `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`                                   // Block root
+	ParentHash                 string                    `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`     // Parent block root
+	Timestamp                  uint64                    `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                        // Start time of slot
+	Epoch                      uint64                    `protobuf:"varint,5,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	ProposerIndex              uint64                    `protobuf:"varint,6,opt,name=proposer_index,json=proposerIndex,proto3" json:"proposer_index,omitempty"`
+	StateRoot                  string                    `protobuf:"bytes,7,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	BodyRoot                   string                    `protobuf:"bytes,8,opt,name=body_root,json=bodyRoot,proto3" json:"body_root,omitempty"`
+	RandaoReveal               string                    `protobuf:"bytes,9,opt,name=randao_reveal,json=randaoReveal,proto3" json:"randao_reveal,omitempty"`
+	Graffiti                   string                    `protobuf:"bytes,10,opt,name=graffiti,proto3" json:"graffiti,omitempty"`
+	Signature                  string                    `protobuf:"bytes,11,opt,name=signature,proto3" json:"signature,omitempty"`
+	Eth1DepositRoot            string                    `protobuf:"bytes,12,opt,name=eth1_deposit_root,json=eth1DepositRoot,proto3" json:"eth1_deposit_root,omitempty"`
+	Eth1DepositCount           uint64                    `protobuf:"varint,13,opt,name=eth1_deposit_count,json=eth1DepositCount,proto3" json:"eth1_deposit_count,omitempty"`
+	Eth1BlockHash              string                    `protobuf:"bytes,14,opt,name=eth1_block_hash,json=eth1BlockHash,proto3" json:"eth1_block_hash,omitempty"`
+	ExecutionBlockNumber       uint64                    `protobuf:"varint,15,opt,name=execution_block_number,json=executionBlockNumber,proto3" json:"execution_block_number,omitempty"` // Number of execution payload block, 0 before merge
+	ExecutionBlockHash         string                    `protobuf:"bytes,16,opt,name=execution_block_hash,json=executionBlockHash,proto3" json:"execution_block_hash,omitempty"`
+	FeeRecipient               string                    `protobuf:"bytes,17,opt,name=fee_recipient,json=feeRecipient,proto3" json:"fee_recipient,omitempty"`
+	ExecutionTransactionsCount uint64                    `protobuf:"varint,18,opt,name=execution_transactions_count,json=executionTransactionsCount,proto3" json:"execution_transactions_count,omitempty"`
+	WithdrawalsCount           uint64                    `protobuf:"varint,19,opt,name=withdrawals_count,json=withdrawalsCount,proto3" json:"withdrawals_count,omitempty"`
+	ProposerSlashingsCount     uint64                    `protobuf:"varint,20,opt,name=proposer_slashings_count,json=proposerSlashingsCount,proto3" json:"proposer_slashings_count,omitempty"`
+	AttesterSlashingsCount     uint64                    `protobuf:"varint,21,opt,name=attester_slashings_count,json=attesterSlashingsCount,proto3" json:"attester_slashings_count,omitempty"`
+	DepositsCount              uint64                    `protobuf:"varint,22,opt,name=deposits_count,json=depositsCount,proto3" json:"deposits_count,omitempty"`
+	VoluntaryExitsCount        uint64                    `protobuf:"varint,23,opt,name=voluntary_exits_count,json=voluntaryExitsCount,proto3" json:"voluntary_exits_count,omitempty"`
+	Fork                       string                    `protobuf:"bytes,24,opt,name=fork,proto3" json:"fork,omitempty"` // Fork version of block, for example deneb
+	Attestations               []*BeaconAttestation      `protobuf:"bytes,25,rep,name=attestations,proto3" json:"attestations,omitempty"`
+	ValidatorBalances          []*BeaconValidatorBalance `protobuf:"bytes,26,rep,name=validator_balances,json=validatorBalances,proto3" json:"validator_balances,omitempty"` // Snapshot of tracked validators, set for the first block crawled in epoch
+	IndexedAt                  uint64                    `protobuf:"varint,27,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`                        // When the block was indexed by crawler
+}
+
+func (x *BeaconBlock) Reset() {
+	*x = BeaconBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeaconBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeaconBlock) ProtoMessage() {}
+
+func (x *BeaconBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeaconBlock.ProtoReflect.Descriptor instead.
+func (*BeaconBlock) Descriptor() ([]byte, []int) {
+	return file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BeaconBlock) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetParentHash() string {
+	if x != nil {
+		return x.ParentHash
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetProposerIndex() uint64 {
+	if x != nil {
+		return x.ProposerIndex
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetStateRoot() string {
+	if x != nil {
+		return x.StateRoot
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetBodyRoot() string {
+	if x != nil {
+		return x.BodyRoot
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetRandaoReveal() string {
+	if x != nil {
+		return x.RandaoReveal
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetGraffiti() string {
+	if x != nil {
+		return x.Graffiti
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetEth1DepositRoot() string {
+	if x != nil {
+		return x.Eth1DepositRoot
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetEth1DepositCount() uint64 {
+	if x != nil {
+		return x.Eth1DepositCount
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetEth1BlockHash() string {
+	if x != nil {
+		return x.Eth1BlockHash
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetExecutionBlockNumber() uint64 {
+	if x != nil {
+		return x.ExecutionBlockNumber
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetExecutionBlockHash() string {
+	if x != nil {
+		return x.ExecutionBlockHash
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetFeeRecipient() string {
+	if x != nil {
+		return x.FeeRecipient
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetExecutionTransactionsCount() uint64 {
+	if x != nil {
+		return x.ExecutionTransactionsCount
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetWithdrawalsCount() uint64 {
+	if x != nil {
+		return x.WithdrawalsCount
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetProposerSlashingsCount() uint64 {
+	if x != nil {
+		return x.ProposerSlashingsCount
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetAttesterSlashingsCount() uint64 {
+	if x != nil {
+		return x.AttesterSlashingsCount
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetDepositsCount() uint64 {
+	if x != nil {
+		return x.DepositsCount
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetVoluntaryExitsCount() uint64 {
+	if x != nil {
+		return x.VoluntaryExitsCount
+	}
+	return 0
+}
+
+func (x *BeaconBlock) GetFork() string {
+	if x != nil {
+		return x.Fork
+	}
+	return ""
+}
+
+func (x *BeaconBlock) GetAttestations() []*BeaconAttestation {
+	if x != nil {
+		return x.Attestations
+	}
+	return nil
+}
+
+func (x *BeaconBlock) GetValidatorBalances() []*BeaconValidatorBalance {
+	if x != nil {
+		return x.ValidatorBalances
+	}
+	return nil
+}
+
+func (x *BeaconBlock) GetIndexedAt() uint64 {
+	if x != nil {
+		return x.IndexedAt
+	}
+	return 0
+}
+
+type BeaconBlocksBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blocks      []*BeaconBlock `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	SeerVersion string         `protobuf:"bytes,2,opt,name=seer_version,json=seerVersion,proto3" json:"seer_version,omitempty"`
+}
+
+func (x *BeaconBlocksBatch) Reset() {
+	*x = BeaconBlocksBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BeaconBlocksBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeaconBlocksBatch) ProtoMessage() {}
+
+func (x *BeaconBlocksBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeaconBlocksBatch.ProtoReflect.Descriptor instead.
+func (*BeaconBlocksBatch) Descriptor() ([]byte, []int) {
+	return file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BeaconBlocksBatch) GetBlocks() []*BeaconBlock {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *BeaconBlocksBatch) GetSeerVersion() string {
+	if x != nil {
+		return x.SeerVersion
+	}
+	return ""
+}
+
+var File_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto protoreflect.FileDescriptor
+
+var file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDesc = []byte{
+	0x0a, 0x3c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x65, 0x74, 0x68,
+	0x65, 0x72, 0x65, 0x75, 0x6d, 0x5f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x2f, 0x65, 0x74, 0x68,
+	0x65, 0x72, 0x65, 0x75, 0x6d, 0x5f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xfa,
+	0x02, 0x0a, 0x11, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x10, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65,
+	0x78, 0x12, 0x29, 0x0a, 0x10, 0x61, 0x67, 0x67, 0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x62, 0x69, 0x74, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x61, 0x67, 0x67,
+	0x72, 0x65, 0x67, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x69, 0x74, 0x73, 0x12, 0x12, 0x0a, 0x04,
+	0x73, 0x6c, 0x6f, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73, 0x6c, 0x6f, 0x74,
+	0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x74, 0x65, 0x65, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x74, 0x65, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x2a, 0x0a, 0x11, 0x62, 0x65, 0x61,
+	0x63, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x5f,
+	0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x73, 0x6f, 0x75,
+	0x72, 0x63, 0x65, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x73,
+	0x6f, 0x75, 0x72, 0x63, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x61, 0x72,
+	0x67, 0x65, 0x74, 0x5f, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0b, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x45, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x1f, 0x0a, 0x0b,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x85, 0x01, 0x0a, 0x16,
+	0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x56, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x27, 0x0a, 0x0f, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61,
+	0x74, 0x6f, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0e, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x18, 0x0a, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x07, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12,
+	0x12, 0x0a, 0x04, 0x73, 0x6c, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x04, 0x73,
+	0x6c, 0x6f, 0x74, 0x22, 0xdb, 0x08, 0x0a, 0x0b, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61,
+	0x72, 0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f,
+	0x63, 0x68, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12,
+	0x25, 0x0a, 0x0e, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x6e, 0x64, 0x65,
+	0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65,
+	0x72, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x65, 0x5f,
+	0x72, 0x6f, 0x6f, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x6f, 0x64, 0x79, 0x5f, 0x72, 0x6f,
+	0x6f, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x6f, 0x64, 0x79, 0x52, 0x6f,
+	0x6f, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x61, 0x6e, 0x64, 0x61, 0x6f, 0x5f, 0x72, 0x65, 0x76,
+	0x65, 0x61, 0x6c, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x61, 0x6e, 0x64, 0x61,
+	0x6f, 0x52, 0x65, 0x76, 0x65, 0x61, 0x6c, 0x12, 0x1a, 0x0a, 0x08, 0x67, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x74, 0x69, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x72, 0x61, 0x66, 0x66,
+	0x69, 0x74, 0x69, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65,
+	0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x12, 0x2a, 0x0a, 0x11, 0x65, 0x74, 0x68, 0x31, 0x5f, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x65, 0x74,
+	0x68, 0x31, 0x44, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x2c, 0x0a,
+	0x12, 0x65, 0x74, 0x68, 0x31, 0x5f, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x65, 0x74, 0x68, 0x31, 0x44,
+	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x26, 0x0a, 0x0f, 0x65,
+	0x74, 0x68, 0x31, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x74, 0x68, 0x31, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x34, 0x0a, 0x16, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x0f, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x14, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x30, 0x0a, 0x14, 0x65, 0x78, 0x65,
+	0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73,
+	0x68, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69,
+	0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x23, 0x0a, 0x0d, 0x66,
+	0x65, 0x65, 0x5f, 0x72, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74, 0x18, 0x11, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0c, 0x66, 0x65, 0x65, 0x52, 0x65, 0x63, 0x69, 0x70, 0x69, 0x65, 0x6e, 0x74,
+	0x12, 0x40, 0x0a, 0x1c, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x1a, 0x65, 0x78, 0x65, 0x63, 0x75, 0x74, 0x69, 0x6f,
+	0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x43, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x2b, 0x0a, 0x11, 0x77, 0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c,
+	0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x77,
+	0x69, 0x74, 0x68, 0x64, 0x72, 0x61, 0x77, 0x61, 0x6c, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12,
+	0x38, 0x0a, 0x18, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x5f, 0x73, 0x6c, 0x61, 0x73,
+	0x68, 0x69, 0x6e, 0x67, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x14, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x16, 0x70, 0x72, 0x6f, 0x70, 0x6f, 0x73, 0x65, 0x72, 0x53, 0x6c, 0x61, 0x73, 0x68,
+	0x69, 0x6e, 0x67, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x38, 0x0a, 0x18, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x65, 0x72, 0x5f, 0x73, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28, 0x04, 0x52, 0x16, 0x61, 0x74, 0x74,
+	0x65, 0x73, 0x74, 0x65, 0x72, 0x53, 0x6c, 0x61, 0x73, 0x68, 0x69, 0x6e, 0x67, 0x73, 0x43, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x25, 0x0a, 0x0e, 0x64, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x73, 0x5f,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x16, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x64, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x32, 0x0a, 0x15, 0x76, 0x6f,
+	0x6c, 0x75, 0x6e, 0x74, 0x61, 0x72, 0x79, 0x5f, 0x65, 0x78, 0x69, 0x74, 0x73, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x17, 0x20, 0x01, 0x28, 0x04, 0x52, 0x13, 0x76, 0x6f, 0x6c, 0x75, 0x6e,
+	0x74, 0x61, 0x72, 0x79, 0x45, 0x78, 0x69, 0x74, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x66, 0x6f, 0x72, 0x6b, 0x18, 0x18, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x66, 0x6f,
+	0x72, 0x6b, 0x12, 0x36, 0x0a, 0x0c, 0x61, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x18, 0x19, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f,
+	0x6e, 0x41, 0x74, 0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x61, 0x74,
+	0x74, 0x65, 0x73, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x46, 0x0a, 0x12, 0x76, 0x61,
+	0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x73,
+	0x18, 0x1a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x56,
+	0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x52,
+	0x11, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63,
+	0x65, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x5f, 0x61, 0x74,
+	0x18, 0x1b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x41,
+	0x74, 0x22, 0x5c, 0x0a, 0x11, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12, 0x24, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0c, 0x2e, 0x42, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x42,
+	0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x65, 0x65, 0x72, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0b, 0x73, 0x65, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42,
+	0x3a, 0x5a, 0x38, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x6f,
+	0x6f, 0x6e, 0x73, 0x74, 0x72, 0x65, 0x61, 0x6d, 0x2d, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x65, 0x72,
+	0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x65, 0x74, 0x68, 0x65,
+	0x72, 0x65, 0x75, 0x6d, 0x5f, 0x62, 0x65, 0x61, 0x63, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x33,
+}
+
+var (
+	file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescOnce sync.Once
+	file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescData = file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDesc
+)
+
+func file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescGZIP() []byte {
+	file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescOnce.Do(func() {
+		file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescData = protoimpl.X.CompressGZIP(file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescData)
+	})
+	return file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDescData
+}
+
+var file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_goTypes = []any{
+	(*BeaconAttestation)(nil),      // 0: BeaconAttestation
+	(*BeaconValidatorBalance)(nil), // 1: BeaconValidatorBalance
+	(*BeaconBlock)(nil),            // 2: BeaconBlock
+	(*BeaconBlocksBatch)(nil),      // 3: BeaconBlocksBatch
+}
+var file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_depIdxs = []int32{
+	0, // 0: BeaconBlock.attestations:type_name -> BeaconAttestation
+	1, // 1: BeaconBlock.validator_balances:type_name -> BeaconValidatorBalance
+	2, // 2: BeaconBlocksBatch.blocks:type_name -> BeaconBlock
+	3, // [3:3] is the sub-list for method output_type
+	3, // [3:3] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_init() }
+func file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_init() {
+	if File_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*BeaconAttestation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*BeaconValidatorBalance); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*BeaconBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*BeaconBlocksBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_goTypes,
+		DependencyIndexes: file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_depIdxs,
+		MessageInfos:      file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_msgTypes,
+	}.Build()
+	File_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto = out.File
+	file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_rawDesc = nil
+	file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_goTypes = nil
+	file_blockchain_ethereum_beacon_ethereum_beacon_index_types_proto_depIdxs = nil
+}
@@ -2,73 +2,158 @@ package blockchain
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"log"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/moonstream-to/seer/blockchain/aptos"
 	"github.com/moonstream-to/seer/blockchain/arbitrum_one"
 	"github.com/moonstream-to/seer/blockchain/arbitrum_sepolia"
+	"github.com/moonstream-to/seer/blockchain/base"
+	"github.com/moonstream-to/seer/blockchain/base_sepolia"
+	"github.com/moonstream-to/seer/blockchain/bitcoin"
 	seer_common "github.com/moonstream-to/seer/blockchain/common"
 	"github.com/moonstream-to/seer/blockchain/ethereum"
+	"github.com/moonstream-to/seer/blockchain/ethereum_beacon"
 	"github.com/moonstream-to/seer/blockchain/game7_orbit_arbitrum_sepolia"
 	"github.com/moonstream-to/seer/blockchain/game7_testnet"
 	"github.com/moonstream-to/seer/blockchain/imx_zkevm"
 	"github.com/moonstream-to/seer/blockchain/imx_zkevm_sepolia"
 	"github.com/moonstream-to/seer/blockchain/mantle"
 	"github.com/moonstream-to/seer/blockchain/mantle_sepolia"
+	"github.com/moonstream-to/seer/blockchain/near"
+	"github.com/moonstream-to/seer/blockchain/optimism"
 	"github.com/moonstream-to/seer/blockchain/polygon"
 	"github.com/moonstream-to/seer/blockchain/sepolia"
+	"github.com/moonstream-to/seer/blockchain/substrate"
+	"github.com/moonstream-to/seer/blockchain/sui"
+	"github.com/moonstream-to/seer/blockchain/ton"
+	"github.com/moonstream-to/seer/blockchain/traces"
 	"github.com/moonstream-to/seer/blockchain/xai"
 	"github.com/moonstream-to/seer/blockchain/xai_sepolia"
+	"github.com/moonstream-to/seer/blockchain/zksync_era"
+	"github.com/moonstream-to/seer/blockchain/zksync_era_sepolia"
 	"github.com/moonstream-to/seer/indexer"
 	"google.golang.org/protobuf/proto"
 )
 
-func NewClient(chain, url string, timeout int) (BlockchainClient, error) {
-	if chain == "ethereum" {
+func init() {
+	Register("ethereum", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := ethereum.NewClient(url, timeout)
 		return client, err
-	} else if chain == "sepolia" {
+	})
+	Register("sepolia", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := sepolia.NewClient(url, timeout)
 		return client, err
-	} else if chain == "polygon" {
+	})
+	Register("polygon", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := polygon.NewClient(url, timeout)
 		return client, err
-	} else if chain == "arbitrum_one" {
+	})
+	Register("arbitrum_one", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := arbitrum_one.NewClient(url, timeout)
 		return client, err
-	} else if chain == "arbitrum_sepolia" {
+	})
+	Register("arbitrum_sepolia", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := arbitrum_sepolia.NewClient(url, timeout)
 		return client, err
-	} else if chain == "game7_orbit_arbitrum_sepolia" {
+	})
+	Register("game7_orbit_arbitrum_sepolia", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := game7_orbit_arbitrum_sepolia.NewClient(url, timeout)
 		return client, err
-	} else if chain == "game7_testnet" {
+	})
+	Register("game7_testnet", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := game7_testnet.NewClient(url, timeout)
 		return client, err
-	} else if chain == "mantle" {
+	})
+	Register("mantle", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := mantle.NewClient(url, timeout)
 		return client, err
-	} else if chain == "mantle_sepolia" {
+	})
+	Register("mantle_sepolia", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := mantle_sepolia.NewClient(url, timeout)
 		return client, err
-	} else if chain == "xai" {
+	})
+	Register("xai", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := xai.NewClient(url, timeout)
 		return client, err
-	} else if chain == "xai_sepolia" {
+	})
+	Register("xai_sepolia", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := xai_sepolia.NewClient(url, timeout)
 		return client, err
-	} else if chain == "imx_zkevm" {
+	})
+	Register("imx_zkevm", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := imx_zkevm.NewClient(url, timeout)
 		return client, err
-	} else if chain == "imx_zkevm_sepolia" {
+	})
+	Register("imx_zkevm_sepolia", func(url string, timeout int) (BlockchainClient, error) {
 		client, err := imx_zkevm_sepolia.NewClient(url, timeout)
 		return client, err
-	} else {
+	})
+	Register("optimism", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := optimism.NewClient(url, timeout)
+		return client, err
+	})
+	Register("base", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := base.NewClient(url, timeout)
+		return client, err
+	})
+	Register("base_sepolia", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := base_sepolia.NewClient(url, timeout)
+		return client, err
+	})
+	Register("zksync_era", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := zksync_era.NewClient(url, timeout)
+		return client, err
+	})
+	Register("zksync_era_sepolia", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := zksync_era_sepolia.NewClient(url, timeout)
+		return client, err
+	})
+	Register("bitcoin", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := bitcoin.NewClient(url, timeout)
+		return client, err
+	})
+	Register("aptos", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := aptos.NewClient(url, timeout)
+		return client, err
+	})
+	Register("near", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := near.NewClient(url, timeout)
+		return client, err
+	})
+	for _, chain := range substrate.Chains {
+		chain := chain
+		Register(chain, func(url string, timeout int) (BlockchainClient, error) {
+			client, err := substrate.NewClient(chain, url, timeout)
+			return client, err
+		})
+	}
+	Register("sui", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := sui.NewClient(url, timeout)
+		return client, err
+	})
+	Register("ton", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := ton.NewClient(url, timeout)
+		return client, err
+	})
+	Register("ethereum_beacon", func(url string, timeout int) (BlockchainClient, error) {
+		client, err := ethereum_beacon.NewClient(url, timeout)
+		return client, err
+	})
+}
+
+// NewClient creates client for the chain from registered client factories.
+func NewClient(chain, url string, timeout int) (BlockchainClient, error) {
+	factory, ok := getFactory(chain)
+	if !ok {
 		return nil, errors.New("unsupported chain type")
 	}
+
+	return factory(url, timeout)
 }
 
 type BlockData struct {
@@ -80,17 +165,17 @@ type BlockData struct {
 }
 
 type BlockchainClient interface {
-	GetLatestBlockNumber() (*big.Int, error)
-	FetchAsProtoBlocksWithEvents(*big.Int, *big.Int, bool, int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error)
+	GetLatestBlockNumber(context.Context) (*big.Int, error)
+	FetchAsProtoBlocksWithEvents(context.Context, *big.Int, *big.Int, bool, int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error)
 	ProcessBlocksToBatch([]proto.Message) (proto.Message, error)
 	DecodeProtoEntireBlockToJson(*bytes.Buffer) (*seer_common.BlocksBatchJson, error)
-	DecodeProtoEntireBlockToLabels(*bytes.Buffer, map[uint64]uint64, map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error)
+	DecodeProtoEntireBlockToLabels(context.Context, *bytes.Buffer, map[uint64]uint64, map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error)
 	DecodeProtoTransactionsToLabels([]string, map[uint64]uint64, map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error)
 	ChainType() string
 }
 
-func CrawlEntireBlocks(client BlockchainClient, startBlock *big.Int, endBlock *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error) {
-	blocks, blocksIndex, txsIndex, eventsIndex, blocksSize, pBlockErr := client.FetchAsProtoBlocksWithEvents(startBlock, endBlock, debug, maxRequests)
+func CrawlEntireBlocks(ctx context.Context, client BlockchainClient, startBlock *big.Int, endBlock *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error) {
+	blocks, blocksIndex, txsIndex, eventsIndex, blocksSize, pBlockErr := client.FetchAsProtoBlocksWithEvents(ctx, startBlock, endBlock, debug, maxRequests)
 	if pBlockErr != nil {
 		return nil, nil, nil, nil, 0, pBlockErr
 	}
@@ -98,6 +183,16 @@ func CrawlEntireBlocks(client BlockchainClient, startBlock *big.Int, endBlock *b
 	return blocks, blocksIndex, txsIndex, eventsIndex, blocksSize, nil
 }
 
+// CrawlCustomIndexes builds chain specific indexes for fetched blocks, if client supports them.
+func CrawlCustomIndexes(client BlockchainClient, blocks []proto.Message) ([]indexer.CustomIndex, error) {
+	customIndexer, ok := client.(CustomIndexer)
+	if !ok {
+		return nil, nil
+	}
+
+	return customIndexer.CustomIndexesFromProtoBlocks(blocks)
+}
+
 func DecodeTransactionInputData(contractABI *abi.ABI, data []byte) {
 	methodSigData := data[:4]
 	inputsSigData := data[4:]
@@ -114,3 +209,124 @@ func DecodeTransactionInputData(contractABI *abi.ABI, data []byte) {
 	fmt.Printf("Method Name: %s\n", method.Name)
 	fmt.Printf("Method inputs: %v\n", inputsMap)
 }
+
+// CustomIndexer is implemented by clients of chains which need index tables besides
+// blocks, transactions and logs (tables registered with indexer.RegisterCustomIndexTable).
+type CustomIndexer interface {
+	CustomIndexesFromProtoBlocks([]proto.Message) ([]indexer.CustomIndex, error)
+}
+
+// ProtoLabelsStreamer is implemented by clients of EVM chains, synchronizer uses it to decode
+// labels of proto batch block by block instead of unmarshalling the whole batch at once.
+type ProtoLabelsStreamer interface {
+	StreamProtoEntireBlockToLabels(context.Context, *bytes.Buffer, map[uint64]uint64, map[string]map[string]map[string]string, func([]indexer.EventLabel, []indexer.TransactionLabel) error) error
+}
+
+// ChainIDReader is implemented by clients of EVM chains, chain ID identifies chain in services
+// which index contracts of many chains, for example in explorers.
+type ChainIDReader interface {
+	GetChainID(context.Context) (*big.Int, error)
+}
+
+// NameResolverProvider is implemented by clients of EVM chains, name enricher uses it to read
+// reverse records of ENS compatible name services.
+type NameResolverProvider interface {
+	NameResolver(seer_common.NameService) *seer_common.NameResolver
+}
+
+// ProtoJsonDecoder is implemented by clients of chains which data model does not fit
+// EVM oriented BlocksBatchJson, inspector uses it to print batches with all fields.
+type ProtoJsonDecoder interface {
+	DecodeProtoEntireBlockToProtoJson(*bytes.Buffer) ([]byte, error)
+}
+
+// BlobTransactionsProvider is implemented by clients of EVM chains, crawler uses it to fetch
+// blob sidecars of EIP-4844 transactions from beacon node.
+type BlobTransactionsProvider interface {
+	BlobTransactionsFromProtoBlocks([]proto.Message) ([]seer_common.BlobTransaction, error)
+}
+
+// CallTracer is implemented by clients of EVM chains, crawler uses it to store call trees of
+// transactions traced with debug_traceBlockByNumber.
+type CallTracer interface {
+	CallTracesFromProtoBlocks(context.Context, []proto.Message) ([]*traces.TransactionCallTrace, error)
+}
+
+// FlatTracer is implemented by clients of EVM chains, crawler uses it to store flat traces from
+// trace_block on nodes with Parity trace module.
+type FlatTracer interface {
+	FlatTracesFromProtoBlocks(context.Context, []proto.Message) ([]*traces.FlatTrace, error)
+}
+
+// ReceiptsFetcher is implemented by clients of EVM chains, crawler uses it to enable fetching
+// of transaction receipts with blocks.
+type ReceiptsFetcher interface {
+	SetFetchReceipts(bool)
+}
+
+// CapabilitiesProber is implemented by clients of EVM chains, crawler probes optional methods
+// supported by node on start and adapts to them.
+type CapabilitiesProber interface {
+	ProbeCapabilities(context.Context) (*seer_common.NodeCapabilities, error)
+}
+
+// BlockTagProvider is implemented by clients of EVM chains, crawler uses it to follow "safe" or
+// "finalized" block instead of latest block minus confirmations.
+type BlockTagProvider interface {
+	GetBlockNumberByTag(context.Context, string) (*big.Int, error)
+}
+
+// BlocksBatchSizer is implemented by clients of EVM chains, crawler uses it to request several
+// blocks in single JSON-RPC batch call.
+type BlocksBatchSizer interface {
+	SetBlocksBatchSize(int)
+}
+
+// TransactionsFilterer is implemented by clients of EVM chains, crawler uses it to keep
+// transactions sent from or to allowlist of addresses.
+type TransactionsFilterer interface {
+	SetTransactionsAddresses([]string)
+}
+
+// ReceiptLogsReader is implemented by clients of EVM chains, crawler uses it to take events from
+// receipts of crawled blocks instead of eth_getLogs.
+type ReceiptLogsReader interface {
+	SetLogsFromReceipts(bool)
+}
+
+// ProxiesResolver is implemented by clients of EVM chains, synchronizer uses it to decode
+// transactions and events of EIP-1967 proxies with ABIs of their implementations.
+type ProxiesResolver interface {
+	SetResolveProxies(bool)
+}
+
+// MulticallsExpander is implemented by clients of EVM chains, synchronizer uses it to decode
+// calls batched in multicall transactions into sub-call labels.
+type MulticallsExpander interface {
+	SetExpandMulticalls(bool)
+}
+
+// RevertReasonsResolver is implemented by clients of EVM chains, synchronizer uses it to label
+// failed transactions with revert reasons read by their re-execution.
+type RevertReasonsResolver interface {
+	SetRevertReasons(bool)
+}
+
+// ValidatorsTracker is implemented by client of beacon chain, crawler uses it to snapshot
+// balances of set of validators at every epoch.
+type ValidatorsTracker interface {
+	SetValidators([]string)
+}
+
+// BlocksTimestampsReader is implemented by clients which read timestamps of blocks without their
+// transactions, block-at utility uses it to search block by time.
+type BlocksTimestampsReader interface {
+	GetBlocksTimestamps(context.Context, []uint64) (map[uint64]uint64, error)
+}
+
+// EventsFilterer is implemented by clients of EVM chains, crawler uses it for targeted crawls of
+// events emitted by set of contracts.
+type EventsFilterer interface {
+	SetLogsFilter(seer_common.LogsFilter)
+	FetchAsProtoEvents(context.Context, *big.Int, *big.Int, seer_common.LogsFilter, bool) ([]proto.Message, []indexer.LogIndex, error)
+}
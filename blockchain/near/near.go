@@ -0,0 +1,883 @@
+package near
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/version"
+)
+
+// eventLogPrefix is prefix of logs with NEP-297 events
+const eventLogPrefix = "EVENT_JSON:"
+
+// ErrUnknownBlock is returned for heights skipped by the chain.
+var ErrUnknownBlock = errors.New("unknown block")
+
+func init() {
+	seer_common.RegisterHasher("near", seer_common.NearHasher{})
+}
+
+func NewClient(url string, timeout int) (*Client, error) {
+	return &Client{
+		url:        url,
+		httpClient: seer_common.NewHTTPClient(url, time.Duration(timeout)*time.Second),
+		hasher:     seer_common.GetHasher("near"),
+	}, nil
+}
+
+// Client is a wrapper around the NEAR JSON-RPC API. NEAR RPC accepts params as object,
+// so requests are made directly instead of go-ethereum RPC client.
+type Client struct {
+	url        string
+	httpClient *http.Client
+	hasher     seer_common.Hasher
+}
+
+// Client common
+
+// ChainType returns the chain type.
+func (c *Client) ChainType() string {
+	return "near"
+}
+
+// Close closes idle connections of the underlying HTTP client.
+func (c *Client) Close() {
+	c.httpClient.CloseIdleConnections()
+}
+
+type rpcRequest struct {
+	JsonRpc string      `json:"jsonrpc"`
+	Id      string      `json:"id"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+type rpcErrorCause struct {
+	Name string `json:"name"`
+}
+
+type rpcError struct {
+	Name    string          `json:"name"`
+	Cause   rpcErrorCause   `json:"cause"`
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *rpcError       `json:"error"`
+}
+
+func (c *Client) call(ctx context.Context, method string, params interface{}, result interface{}) error {
+	body, err := json.Marshal(rpcRequest{JsonRpc: "2.0", Id: "seer", Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var response rpcResponse
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return fmt.Errorf("failed to decode %s response with status %d: %v", method, resp.StatusCode, err)
+	}
+
+	if response.Error != nil {
+		if response.Error.Cause.Name == "UNKNOWN_BLOCK" {
+			return ErrUnknownBlock
+		}
+		return fmt.Errorf("%s failed: %s %s: %s", method, response.Error.Name, response.Error.Cause.Name, string(response.Error.Data))
+	}
+
+	return json.Unmarshal(response.Result, result)
+}
+
+// GetLatestBlockNumber returns the latest block height.
+func (c *Client) GetLatestBlockNumber(ctx context.Context) (*big.Int, error) {
+	var status StatusJson
+	if err := c.call(ctx, "status", []interface{}{}, &status); err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetUint64(status.SyncInfo.LatestBlockHeight), nil
+}
+
+// GetBlockByNumber returns block with new chunks, transactions and execution outcomes of receipts
+// produced by transactions. Receipts are attributed to block of originating transaction, like
+// receipts of EVM transactions, block of execution is kept in outcome.
+func (c *Client) GetBlockByNumber(ctx context.Context, height uint64) (*BlockJson, error) {
+	var block BlockJson
+	if err := c.call(ctx, "block", map[string]interface{}{"block_id": height}, &block); err != nil {
+		return nil, err
+	}
+
+	for _, chunkHeader := range block.Chunks {
+		if chunkHeader.HeightIncluded != block.Header.Height {
+			continue
+		}
+
+		var chunk ChunkJson
+		if err := c.call(ctx, "chunk", map[string]interface{}{"chunk_id": chunkHeader.ChunkHash}, &chunk); err != nil {
+			return nil, err
+		}
+
+		for _, tx := range chunk.Transactions {
+			var status TransactionStatusJson
+			params := map[string]interface{}{
+				"tx_hash":           tx.Hash,
+				"sender_account_id": tx.SignerId,
+				"wait_until":        "EXECUTED",
+			}
+			if err := c.call(ctx, "EXPERIMENTAL_tx_status", params, &status); err != nil {
+				return nil, fmt.Errorf("failed to get status of transaction %s: %v", tx.Hash, err)
+			}
+
+			block.Transactions = append(block.Transactions, ChunkTransactionJson{
+				Transaction: tx,
+				ShardId:     chunkHeader.ShardId,
+				ChunkHash:   chunkHeader.ChunkHash,
+				Status:      status,
+			})
+		}
+	}
+
+	return &block, nil
+}
+
+// FetchBlocksInRange fetches blocks within a specified range, with up to maxRequests concurrent requests.
+// Blocks are returned in ascending order, heights skipped by the chain are omitted.
+func (c *Client) FetchBlocksInRange(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]*BlockJson, error) {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	fromNumber := from.Uint64()
+	toNumber := to.Uint64()
+	if toNumber < fromNumber {
+		return nil, nil
+	}
+
+	blocks := make([]*BlockJson, toNumber-fromNumber+1)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	sem := make(chan struct{}, maxRequests)
+
+	for number := fromNumber; number <= toNumber; number++ {
+		wg.Add(1)
+		go func(number uint64) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			block, err := c.GetBlockByNumber(ctx, number)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if errors.Is(err, ErrUnknownBlock) {
+				if debug {
+					fmt.Printf("Skipped block number: %d\n", number)
+				}
+				return
+			}
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			blocks[number-fromNumber] = block
+
+			if debug {
+				fmt.Printf("Fetched block number: %d\n", number)
+			}
+		}(number)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	var fetchedBlocks []*BlockJson
+	for _, block := range blocks {
+		if block != nil {
+			fetchedBlocks = append(fetchedBlocks, block)
+		}
+	}
+
+	return fetchedBlocks, nil
+}
+
+// FetchAsProtoBlocksWithEvents fetches blocks with transactions and receipt execution outcomes.
+// Transactions are indexed by method name of the first function call action and NEP-297 events
+// by "standard:event" name.
+func (c *Client) FetchAsProtoBlocksWithEvents(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error) {
+	blocksJson, err := c.FetchBlocksInRange(ctx, from, to, debug, maxRequests)
+	if err != nil {
+		return nil, nil, nil, nil, 0, err
+	}
+
+	var blocksSize uint64
+	var blocksProto []proto.Message
+	var blocksIndex []indexer.BlockIndex
+	var txsIndex []indexer.TransactionIndex
+	var eventsIndex []indexer.LogIndex
+
+	for bI, blockJson := range blocksJson {
+		block, convErr := ToProtoSingleBlock(blockJson)
+		if convErr != nil {
+			return nil, nil, nil, nil, 0, convErr
+		}
+
+		for _, tx := range block.Transactions {
+			var selector string
+			for _, action := range tx.Actions {
+				if action.Type == "FunctionCall" {
+					selector = c.hasher.MethodSelector(action.MethodName) // Method name instead of 4 bytes selector
+					break
+				}
+			}
+
+			txsIndex = append(txsIndex, indexer.TransactionIndex{
+				BlockNumber:      tx.BlockNumber,
+				BlockHash:        tx.BlockHash,
+				BlockTimestamp:   tx.BlockTimestamp,
+				FromAddress:      c.hasher.NormalizeAddress(tx.SignerId),
+				ToAddress:        c.hasher.NormalizeAddress(tx.ReceiverId),
+				RowID:            tx.TransactionIndex,
+				Selector:         selector,
+				TransactionHash:  tx.Hash,
+				TransactionIndex: tx.TransactionIndex,
+				Type:             0,
+				Path:             "",
+			})
+
+			var logIndex uint64
+			for _, receipt := range tx.Receipts {
+				for _, event := range receipt.Events {
+					eventName := c.hasher.EventTopic(event.Standard + ":" + event.Event)
+					eventsIndex = append(eventsIndex, indexer.LogIndex{
+						Address:         c.hasher.NormalizeAddress(receipt.ExecutorId),
+						BlockNumber:     tx.BlockNumber,
+						BlockHash:       tx.BlockHash,
+						BlockTimestamp:  tx.BlockTimestamp,
+						TransactionHash: tx.Hash,
+						Selector:        &eventName, // NEP-297 standard:event instead of topic0
+						RowID:           logIndex,
+						LogIndex:        logIndex,
+						Path:            "",
+					})
+					logIndex++
+				}
+			}
+		}
+
+		blocksIndex = append(blocksIndex, indexer.NewBlockIndex("near",
+			block.Height,
+			block.Hash,
+			block.Timestamp,
+			block.PrevHash,
+			uint64(bI),
+			"",
+			0,
+		))
+
+		blocksSize += uint64(proto.Size(block))
+		blocksProto = append(blocksProto, block)
+	}
+
+	return blocksProto, blocksIndex, txsIndex, eventsIndex, blocksSize, nil
+}
+
+func (c *Client) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
+	var blocks []*NearBlock
+	for _, msg := range msgs {
+		block, ok := msg.(*NearBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *NearBlock")
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &NearBlocksBatch{
+		Blocks:      blocks,
+		SeerVersion: version.SeerVersion,
+	}, nil
+}
+
+func (c *Client) decodeBlocksBatch(rawData *bytes.Buffer) (*NearBlocksBatch, error) {
+	var protoBlocksBatch NearBlocksBatch
+
+	if err := proto.Unmarshal(rawData.Bytes(), &protoBlocksBatch); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	return &protoBlocksBatch, nil
+}
+
+// DecodeProtoEntireBlockToJson returns blocks, transactions and NEP-297 events in EVM oriented
+// JSON structure, "standard:event" name is placed as the first topic.
+func (c *Client) DecodeProtoEntireBlockToJson(rawData *bytes.Buffer) (*seer_common.BlocksBatchJson, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksBatchJson := seer_common.BlocksBatchJson{
+		Blocks:      []seer_common.BlockJson{},
+		SeerVersion: protoBlocksBatch.SeerVersion,
+	}
+
+	for _, b := range protoBlocksBatch.Blocks {
+		var txs []seer_common.TransactionJson
+		for _, tx := range b.Transactions {
+			var events []seer_common.EventJson
+			var logIndex uint64
+			for _, receipt := range tx.Receipts {
+				for _, e := range receipt.Events {
+					events = append(events, seer_common.EventJson{
+						Address:          receipt.ExecutorId,
+						Topics:           []string{e.Standard + ":" + e.Event},
+						Data:             e.Data,
+						BlockNumber:      fmt.Sprintf("%d", tx.BlockNumber),
+						TransactionHash:  tx.Hash,
+						BlockHash:        tx.BlockHash,
+						LogIndex:         fmt.Sprintf("%d", logIndex),
+						TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+					})
+					logIndex++
+				}
+			}
+
+			var input string
+			if len(tx.Actions) > 0 {
+				input = tx.Actions[0].Args
+			}
+
+			txs = append(txs, seer_common.TransactionJson{
+				BlockHash:        tx.BlockHash,
+				BlockNumber:      fmt.Sprintf("%d", tx.BlockNumber),
+				FromAddress:      tx.SignerId,
+				Gas:              fmt.Sprintf("%d", tx.GasBurnt),
+				Hash:             tx.Hash,
+				Input:            input,
+				Nonce:            fmt.Sprintf("%d", tx.Nonce),
+				ToAddress:        tx.ReceiverId,
+				TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+				IndexedAt:        fmt.Sprintf("%d", tx.IndexedAt),
+				BlockTimestamp:   fmt.Sprintf("%d", tx.BlockTimestamp),
+
+				Events: events,
+			})
+		}
+
+		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
+			Hash:         b.Hash,
+			BlockNumber:  fmt.Sprintf("%d", b.Height),
+			ParentHash:   b.PrevHash,
+			Miner:        b.Author,
+			Timestamp:    fmt.Sprintf("%d", b.Timestamp),
+			IndexedAt:    fmt.Sprintf("%d", b.IndexedAt),
+			Transactions: txs,
+		})
+	}
+
+	return &blocksBatchJson, nil
+}
+
+// DecodeProtoEntireBlockToProtoJson returns batch with all fields, including receipt execution outcomes.
+func (c *Client) DecodeProtoEntireBlockToProtoJson(rawData *bytes.Buffer) ([]byte, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	return protojson.Marshal(protoBlocksBatch)
+}
+
+// DecodeProtoEntireBlockToLabels converts function calls of receipts and NEP-297 events matched with
+// ABI jobs by method name and "standard:event" name to labels. Arguments encoded as JSON are decoded,
+// other arguments are kept in base64.
+func (c *Client) DecodeProtoEntireBlockToLabels(ctx context.Context, rawData *bytes.Buffer, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var labels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
+
+	for _, b := range protoBlocksBatch.Blocks {
+		for _, tx := range b.Transactions {
+			functionCallLabels, labelsErr := c.functionCallsToLabels(tx, abiMap)
+			if labelsErr != nil {
+				return nil, nil, labelsErr
+			}
+			txLabels = append(txLabels, functionCallLabels...)
+
+			var logIndex uint64
+			for _, receipt := range tx.Receipts {
+				executor := c.hasher.NormalizeAddress(receipt.ExecutorId)
+				for _, e := range receipt.Events {
+					eventName := c.hasher.EventTopic(e.Standard + ":" + e.Event)
+					currentLogIndex := logIndex
+					logIndex++
+
+					if abiMap[executor] == nil || abiMap[executor][eventName] == nil {
+						continue
+					}
+
+					var eventData interface{}
+					label := indexer.SeerCrawlerLabel
+					if unmarshalErr := json.Unmarshal([]byte(e.Data), &eventData); unmarshalErr != nil {
+						eventData = e.Data
+						label = indexer.SeerCrawlerRawLabel
+					}
+
+					labelDataBytes, err := json.Marshal(map[string]interface{}{
+						"type":    "event",
+						"name":    e.Event,
+						"version": e.Version,
+						"args":    eventData,
+					})
+					if err != nil {
+						return nil, nil, err
+					}
+
+					labels = append(labels, indexer.EventLabel{
+						Label:           label,
+						LabelName:       abiMap[executor][eventName]["abi_name"],
+						LabelType:       "event",
+						BlockNumber:     tx.BlockNumber,
+						BlockHash:       tx.BlockHash,
+						Address:         executor,
+						CallerAddress:   receipt.PredecessorId,
+						OriginAddress:   tx.SignerId,
+						TransactionHash: tx.Hash,
+						LabelData:       string(labelDataBytes),
+						BlockTimestamp:  tx.BlockTimestamp,
+						LogIndex:        currentLogIndex,
+					})
+				}
+			}
+		}
+	}
+
+	return labels, txLabels, nil
+}
+
+func (c *Client) functionCallsToLabels(tx *NearTransaction, abiMap map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error) {
+	var labels []indexer.TransactionLabel
+
+	for _, receipt := range tx.Receipts {
+		receiver := c.hasher.NormalizeAddress(receipt.ReceiverId)
+		if abiMap[receiver] == nil {
+			continue
+		}
+
+		for _, action := range receipt.Actions {
+			if action.Type != "FunctionCall" {
+				continue
+			}
+
+			selector := c.hasher.MethodSelector(action.MethodName)
+			if abiMap[receiver][selector] == nil {
+				continue
+			}
+
+			label := indexer.SeerCrawlerLabel
+
+			var args interface{} = action.Args
+			argsBytes, decodeErr := base64.StdEncoding.DecodeString(action.Args)
+			if decodeErr != nil || json.Unmarshal(argsBytes, &args) != nil {
+				args = action.Args
+				label = indexer.SeerCrawlerRawLabel
+			}
+
+			labelDataBytes, err := json.Marshal(map[string]interface{}{
+				"type":       "tx_call",
+				"name":       action.MethodName,
+				"gas":        action.Gas,
+				"deposit":    action.Deposit,
+				"receipt_id": receipt.ReceiptId,
+				"status":     receipt.Status,
+				"args":       args,
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			labels = append(labels, indexer.TransactionLabel{
+				Address:         receiver,
+				BlockNumber:     tx.BlockNumber,
+				BlockHash:       tx.BlockHash,
+				CallerAddress:   receipt.PredecessorId,
+				LabelName:       abiMap[receiver][selector]["abi_name"],
+				LabelType:       "tx_call",
+				OriginAddress:   tx.SignerId,
+				Label:           label,
+				TransactionHash: tx.Hash,
+				LabelData:       string(labelDataBytes),
+				BlockTimestamp:  tx.BlockTimestamp,
+			})
+		}
+	}
+
+	return labels, nil
+}
+
+func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error) {
+	var labels []indexer.TransactionLabel
+
+	for _, data := range transactions {
+		var tx NearTransaction
+		if err := proto.Unmarshal([]byte(data), &tx); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal transaction: %v", err)
+		}
+
+		functionCallLabels, err := c.functionCallsToLabels(&tx, abiMap)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, functionCallLabels...)
+	}
+
+	return labels, nil
+}
+
+// NEAR JSON-RPC structures
+
+type SyncInfoJson struct {
+	LatestBlockHash   string `json:"latest_block_hash"`
+	LatestBlockHeight uint64 `json:"latest_block_height"`
+}
+
+type StatusJson struct {
+	ChainId  string       `json:"chain_id"`
+	SyncInfo SyncInfoJson `json:"sync_info"`
+}
+
+type BlockHeaderJson struct {
+	Height           uint64 `json:"height"`
+	Hash             string `json:"hash"`
+	PrevHash         string `json:"prev_hash"`
+	Timestamp        uint64 `json:"timestamp"` // Nanoseconds
+	TimestampNanosec string `json:"timestamp_nanosec"`
+	EpochId          string `json:"epoch_id"`
+	GasPrice         string `json:"gas_price"`
+}
+
+type ChunkHeaderJson struct {
+	ChunkHash      string `json:"chunk_hash"`
+	ShardId        uint64 `json:"shard_id"`
+	HeightCreated  uint64 `json:"height_created"`
+	HeightIncluded uint64 `json:"height_included"`
+	GasUsed        uint64 `json:"gas_used"`
+	GasLimit       uint64 `json:"gas_limit"`
+}
+
+type TransactionJson struct {
+	Hash       string            `json:"hash"`
+	SignerId   string            `json:"signer_id"`
+	PublicKey  string            `json:"public_key"`
+	Nonce      uint64            `json:"nonce"`
+	ReceiverId string            `json:"receiver_id"`
+	Signature  string            `json:"signature"`
+	Actions    []json.RawMessage `json:"actions"`
+}
+
+type ChunkJson struct {
+	Author       string            `json:"author"`
+	Header       ChunkHeaderJson   `json:"header"`
+	Transactions []TransactionJson `json:"transactions"`
+}
+
+type ExecutionOutcomeJson struct {
+	Logs        []string                   `json:"logs"`
+	ReceiptIds  []string                   `json:"receipt_ids"`
+	GasBurnt    uint64                     `json:"gas_burnt"`
+	TokensBurnt string                     `json:"tokens_burnt"`
+	ExecutorId  string                     `json:"executor_id"`
+	Status      map[string]json.RawMessage `json:"status"`
+}
+
+type ExecutionOutcomeWithIdJson struct {
+	Id        string               `json:"id"`
+	BlockHash string               `json:"block_hash"`
+	Outcome   ExecutionOutcomeJson `json:"outcome"`
+}
+
+type ActionReceiptJson struct {
+	SignerId string            `json:"signer_id"`
+	Actions  []json.RawMessage `json:"actions"`
+}
+
+type ReceiptBodyJson struct {
+	Action *ActionReceiptJson `json:"Action"`
+}
+
+type ReceiptJson struct {
+	PredecessorId string          `json:"predecessor_id"`
+	ReceiverId    string          `json:"receiver_id"`
+	ReceiptId     string          `json:"receipt_id"`
+	Receipt       ReceiptBodyJson `json:"receipt"`
+}
+
+type TransactionStatusJson struct {
+	Status             map[string]json.RawMessage   `json:"status"`
+	TransactionOutcome ExecutionOutcomeWithIdJson   `json:"transaction_outcome"`
+	ReceiptsOutcome    []ExecutionOutcomeWithIdJson `json:"receipts_outcome"`
+	Receipts           []ReceiptJson                `json:"receipts"`
+}
+
+// ChunkTransactionJson is transaction of chunk with its status and receipts.
+type ChunkTransactionJson struct {
+	Transaction TransactionJson
+	ShardId     uint64
+	ChunkHash   string
+	Status      TransactionStatusJson
+}
+
+type BlockJson struct {
+	Author string            `json:"author"`
+	Header BlockHeaderJson   `json:"header"`
+	Chunks []ChunkHeaderJson `json:"chunks"`
+
+	Transactions []ChunkTransactionJson `json:"-"`
+}
+
+type EventJson struct {
+	Standard string          `json:"standard"`
+	Version  string          `json:"version"`
+	Event    string          `json:"event"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// parseAction converts RPC action, either "CreateAccount" string or {"FunctionCall": {...}} object, to proto.
+func parseAction(raw json.RawMessage, actionIndex uint64) (*NearAction, error) {
+	action := &NearAction{Raw: string(raw), ActionIndex: actionIndex}
+
+	var actionType string
+	if err := json.Unmarshal(raw, &actionType); err == nil {
+		action.Type = actionType
+		return action, nil
+	}
+
+	var actionObject map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &actionObject); err != nil {
+		return nil, fmt.Errorf("failed to decode action: %v", err)
+	}
+
+	for actionType, body := range actionObject {
+		action.Type = actionType
+
+		var fields struct {
+			MethodName string `json:"method_name"`
+			Args       string `json:"args"`
+			Gas        uint64 `json:"gas"`
+			Deposit    string `json:"deposit"`
+		}
+		if err := json.Unmarshal(body, &fields); err == nil {
+			action.MethodName = fields.MethodName
+			action.Args = fields.Args
+			action.Gas = fields.Gas
+			action.Deposit = fields.Deposit
+		}
+	}
+
+	return action, nil
+}
+
+func parseActions(raws []json.RawMessage) ([]*NearAction, error) {
+	var actions []*NearAction
+	for aI, raw := range raws {
+		action, err := parseAction(raw, uint64(aI))
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, action)
+	}
+	return actions, nil
+}
+
+// parseStatus returns status name and JSON encoded value, e.g. SuccessValue and "" for {"SuccessValue": ""}.
+func parseStatus(status map[string]json.RawMessage) (string, string) {
+	for name, value := range status {
+		return name, string(value)
+	}
+	return "Unknown", ""
+}
+
+// parseEvents returns NEP-297 events from EVENT_JSON logs.
+func parseEvents(logs []string) []*NearEvent {
+	var events []*NearEvent
+	for lI, log := range logs {
+		if !strings.HasPrefix(log, eventLogPrefix) {
+			continue
+		}
+
+		var event EventJson
+		if err := json.Unmarshal([]byte(strings.TrimPrefix(log, eventLogPrefix)), &event); err != nil {
+			continue
+		}
+
+		events = append(events, &NearEvent{
+			Standard: event.Standard,
+			Version:  event.Version,
+			Event:    event.Event,
+			Data:     string(event.Data),
+			LogIndex: uint64(lI),
+		})
+	}
+	return events
+}
+
+func ToProtoSingleBlock(obj *BlockJson) (*NearBlock, error) {
+	indexedAt := uint64(time.Now().Unix())
+	timestamp := obj.Header.Timestamp / 1_000_000_000
+
+	block := &NearBlock{
+		Height:           obj.Header.Height,
+		Hash:             obj.Header.Hash,
+		PrevHash:         obj.Header.PrevHash,
+		Timestamp:        timestamp,
+		TimestampNanosec: obj.Header.TimestampNanosec,
+		EpochId:          obj.Header.EpochId,
+		Author:           obj.Author,
+		GasPrice:         obj.Header.GasPrice,
+		IndexedAt:        indexedAt,
+	}
+
+	for _, chunk := range obj.Chunks {
+		block.Chunks = append(block.Chunks, &NearChunk{
+			ChunkHash:      chunk.ChunkHash,
+			ShardId:        chunk.ShardId,
+			HeightCreated:  chunk.HeightCreated,
+			HeightIncluded: chunk.HeightIncluded,
+			GasUsed:        chunk.GasUsed,
+			GasLimit:       chunk.GasLimit,
+		})
+	}
+
+	for txI, chunkTx := range obj.Transactions {
+		txJson := chunkTx.Transaction
+
+		actions, err := parseActions(txJson.Actions)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse actions of transaction %s: %v", txJson.Hash, err)
+		}
+
+		status, _ := parseStatus(chunkTx.Status.Status)
+		txOutcome := chunkTx.Status.TransactionOutcome.Outcome
+
+		var convertedIntoReceiptId string
+		if len(txOutcome.ReceiptIds) > 0 {
+			convertedIntoReceiptId = txOutcome.ReceiptIds[0]
+		}
+
+		tx := &NearTransaction{
+			Hash:                   txJson.Hash,
+			SignerId:               txJson.SignerId,
+			PublicKey:              txJson.PublicKey,
+			Nonce:                  txJson.Nonce,
+			ReceiverId:             txJson.ReceiverId,
+			Signature:              txJson.Signature,
+			ShardId:                chunkTx.ShardId,
+			ChunkHash:              chunkTx.ChunkHash,
+			Status:                 status,
+			GasBurnt:               txOutcome.GasBurnt,
+			TokensBurnt:            txOutcome.TokensBurnt,
+			ConvertedIntoReceiptId: convertedIntoReceiptId,
+			BlockNumber:            obj.Header.Height,
+			BlockHash:              obj.Header.Hash,
+			BlockTimestamp:         timestamp,
+			TransactionIndex:       uint64(txI),
+			IndexedAt:              indexedAt,
+			Actions:                actions,
+		}
+
+		receipts := make(map[string]ReceiptJson)
+		for _, receipt := range chunkTx.Status.Receipts {
+			receipts[receipt.ReceiptId] = receipt
+		}
+
+		for rI, outcome := range chunkTx.Status.ReceiptsOutcome {
+			receiptStatus, statusValue := parseStatus(outcome.Outcome.Status)
+
+			receiptOutcome := &NearReceiptExecutionOutcome{
+				ReceiptId:          outcome.Id,
+				ExecutorId:         outcome.Outcome.ExecutorId,
+				ExecutionBlockHash: outcome.BlockHash,
+				Status:             receiptStatus,
+				StatusValue:        statusValue,
+				GasBurnt:           outcome.Outcome.GasBurnt,
+				TokensBurnt:        outcome.Outcome.TokensBurnt,
+				Logs:               outcome.Outcome.Logs,
+				ProducedReceiptIds: outcome.Outcome.ReceiptIds,
+				Events:             parseEvents(outcome.Outcome.Logs),
+				ReceiptIndex:       uint64(rI),
+				TransactionHash:    txJson.Hash,
+				BlockNumber:        obj.Header.Height,
+				BlockHash:          obj.Header.Hash,
+			}
+
+			if receipt, ok := receipts[outcome.Id]; ok {
+				receiptOutcome.PredecessorId = receipt.PredecessorId
+				receiptOutcome.ReceiverId = receipt.ReceiverId
+				if receipt.Receipt.Action != nil {
+					receiptOutcome.SignerId = receipt.Receipt.Action.SignerId
+					receiptActions, err := parseActions(receipt.Receipt.Action.Actions)
+					if err != nil {
+						return nil, fmt.Errorf("failed to parse actions of receipt %s: %v", outcome.Id, err)
+					}
+					receiptOutcome.Actions = receiptActions
+				}
+			} else {
+				receiptOutcome.ReceiverId = outcome.Outcome.ExecutorId
+			}
+
+			tx.Receipts = append(tx.Receipts, receiptOutcome)
+		}
+
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	return block, nil
+}
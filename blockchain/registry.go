@@ -0,0 +1,111 @@
+package blockchain
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"plugin"
+	"sort"
+	"strings"
+	"sync"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+)
+
+// ClientFactory creates a client for a blockchain from node URL and timeout in seconds.
+type ClientFactory func(url string, timeout int) (BlockchainClient, error)
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]ClientFactory{}
+
+	capabilitiesMu sync.RWMutex
+	capabilities   = map[string]*seer_common.NodeCapabilities{}
+)
+
+// Register makes a blockchain client available to crawler, synchronizer and other
+// seer commands under the given chain name. It is intended to be called from init
+// functions of packages which implement BlockchainClient, including Go plugins
+// loaded with LoadPlugins.
+func Register(chain string, factory ClientFactory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if factory == nil {
+		log.Fatalf("Blockchain client factory for %s is nil", chain)
+	}
+	if _, exists := registry[chain]; exists {
+		log.Fatalf("Blockchain client for %s is already registered", chain)
+	}
+	registry[chain] = factory
+}
+
+// RegisteredChains returns sorted list of chain names with registered clients.
+func RegisteredChains() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	chains := make([]string, 0, len(registry))
+	for chain := range registry {
+		chains = append(chains, chain)
+	}
+	sort.Strings(chains)
+
+	return chains
+}
+
+func getFactory(chain string) (ClientFactory, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	factory, ok := registry[chain]
+	return factory, ok
+}
+
+// SetCapabilities stores capability profile of node of the chain, probed on first connection.
+func SetCapabilities(chain string, nodeCapabilities *seer_common.NodeCapabilities) {
+	capabilitiesMu.Lock()
+	defer capabilitiesMu.Unlock()
+
+	capabilities[chain] = nodeCapabilities
+}
+
+// Capabilities returns capability profile of node of the chain, false if it was not probed.
+func Capabilities(chain string) (*seer_common.NodeCapabilities, bool) {
+	capabilitiesMu.RLock()
+	defer capabilitiesMu.RUnlock()
+
+	nodeCapabilities, ok := capabilities[chain]
+	return nodeCapabilities, ok
+}
+
+// LoadPlugins opens Go plugins (.so files built with -buildmode=plugin). Plugins register
+// their clients with Register in init functions, which run when plugin is opened.
+func LoadPlugins(paths []string) error {
+	for _, path := range paths {
+		if path == "" {
+			continue
+		}
+		if _, err := plugin.Open(path); err != nil {
+			return fmt.Errorf("failed to load blockchain plugin %s: %w", path, err)
+		}
+		log.Printf("Loaded blockchain plugin %s", path)
+	}
+
+	return nil
+}
+
+// LoadPluginsFromEnv loads plugins listed in comma separated SEER_BLOCKCHAIN_PLUGINS environment variable.
+func LoadPluginsFromEnv() error {
+	SEER_BLOCKCHAIN_PLUGINS := os.Getenv("SEER_BLOCKCHAIN_PLUGINS")
+	if SEER_BLOCKCHAIN_PLUGINS == "" {
+		return nil
+	}
+
+	var paths []string
+	for _, path := range strings.Split(SEER_BLOCKCHAIN_PLUGINS, ",") {
+		paths = append(paths, strings.TrimSpace(path))
+	}
+
+	return LoadPlugins(paths)
+}
@@ -0,0 +1,905 @@
+package substrate
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// SCALE codec decoding with portable type registry of runtime metadata V14 and V15,
+// docs: https://docs.substrate.io/reference/scale-codec/
+
+var errUnexpectedEnd = errors.New("unexpected end of SCALE data")
+
+type scaleDecoder struct {
+	data []byte
+	pos  int
+}
+
+func newScaleDecoder(data []byte) *scaleDecoder {
+	return &scaleDecoder{data: data}
+}
+
+func (d *scaleDecoder) remaining() int {
+	return len(d.data) - d.pos
+}
+
+func (d *scaleDecoder) readBytes(n int) ([]byte, error) {
+	if n < 0 || d.remaining() < n {
+		return nil, errUnexpectedEnd
+	}
+	result := d.data[d.pos : d.pos+n]
+	d.pos += n
+	return result, nil
+}
+
+func (d *scaleDecoder) readByte() (byte, error) {
+	b, err := d.readBytes(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (d *scaleDecoder) readUint(size int) (uint64, error) {
+	b, err := d.readBytes(size)
+	if err != nil {
+		return 0, err
+	}
+	var buf [8]byte
+	copy(buf[:], b)
+	return binary.LittleEndian.Uint64(buf[:]), nil
+}
+
+// readBigUint reads little endian unsigned integer of any size, e.g. u128 and u256.
+func (d *scaleDecoder) readBigUint(size int) (*big.Int, error) {
+	b, err := d.readBytes(size)
+	if err != nil {
+		return nil, err
+	}
+	bigEndian := make([]byte, size)
+	for i := range b {
+		bigEndian[size-1-i] = b[i]
+	}
+	return new(big.Int).SetBytes(bigEndian), nil
+}
+
+func (d *scaleDecoder) readBigInt(size int) (*big.Int, error) {
+	value, err := d.readBigUint(size)
+	if err != nil {
+		return nil, err
+	}
+	if value.Bit(size*8-1) == 1 {
+		value.Sub(value, new(big.Int).Lsh(big.NewInt(1), uint(size*8)))
+	}
+	return value, nil
+}
+
+func (d *scaleDecoder) readCompact() (*big.Int, error) {
+	first, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	switch first & 0x03 {
+	case 0:
+		return big.NewInt(int64(first >> 2)), nil
+	case 1:
+		second, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		return big.NewInt(int64(uint16(first)|uint16(second)<<8) >> 2), nil
+	case 2:
+		rest, err := d.readBytes(3)
+		if err != nil {
+			return nil, err
+		}
+		value := uint32(first) | uint32(rest[0])<<8 | uint32(rest[1])<<16 | uint32(rest[2])<<24
+		return big.NewInt(int64(value >> 2)), nil
+	default:
+		return d.readBigUint(int(first>>2) + 4)
+	}
+}
+
+func (d *scaleDecoder) readCompactUint() (uint64, error) {
+	value, err := d.readCompact()
+	if err != nil {
+		return 0, err
+	}
+	if !value.IsUint64() {
+		return 0, fmt.Errorf("compact value %s overflows uint64", value.String())
+	}
+	return value.Uint64(), nil
+}
+
+func (d *scaleDecoder) readString() (string, error) {
+	length, err := d.readCompactUint()
+	if err != nil {
+		return "", err
+	}
+	b, err := d.readBytes(int(length))
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func (d *scaleDecoder) readStrings() ([]string, error) {
+	length, err := d.readCompactUint()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]string, 0, length)
+	for i := uint64(0); i < length; i++ {
+		s, err := d.readString()
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, s)
+	}
+	return result, nil
+}
+
+func (d *scaleDecoder) readOption() (bool, error) {
+	flag, err := d.readByte()
+	if err != nil {
+		return false, err
+	}
+	switch flag {
+	case 0:
+		return false, nil
+	case 1:
+		return true, nil
+	default:
+		return false, fmt.Errorf("invalid option flag %d", flag)
+	}
+}
+
+func (d *scaleDecoder) readOptionalString() (string, error) {
+	some, err := d.readOption()
+	if err != nil || !some {
+		return "", err
+	}
+	return d.readString()
+}
+
+func (d *scaleDecoder) readOptionalCompact() (*uint32, error) {
+	some, err := d.readOption()
+	if err != nil || !some {
+		return nil, err
+	}
+	value, err := d.readCompactUint()
+	if err != nil {
+		return nil, err
+	}
+	result := uint32(value)
+	return &result, nil
+}
+
+// Portable type registry
+
+const (
+	typeDefComposite = iota
+	typeDefVariant
+	typeDefSequence
+	typeDefArray
+	typeDefTuple
+	typeDefPrimitive
+	typeDefCompact
+	typeDefBitSequence
+)
+
+const (
+	primitiveBool = iota
+	primitiveChar
+	primitiveStr
+	primitiveU8
+	primitiveU16
+	primitiveU32
+	primitiveU64
+	primitiveU128
+	primitiveU256
+	primitiveI8
+	primitiveI16
+	primitiveI32
+	primitiveI64
+	primitiveI128
+	primitiveI256
+)
+
+type TypeField struct {
+	Name     string
+	Type     uint32
+	TypeName string
+}
+
+type TypeVariant struct {
+	Name   string
+	Fields []TypeField
+	Index  uint8
+}
+
+type TypeParam struct {
+	Name string
+	Type *uint32
+}
+
+type TypeDef struct {
+	Kind      int
+	Fields    []TypeField   // Composite
+	Variants  []TypeVariant // Variant
+	Elem      uint32        // Sequence, Array, Compact, BitSequence store type
+	Len       uint32        // Array
+	Tuple     []uint32      // Tuple
+	Primitive uint8         // Primitive
+}
+
+type PortableType struct {
+	Path   []string
+	Params []TypeParam
+	Def    TypeDef
+}
+
+type Pallet struct {
+	Name      string
+	Index     uint8
+	CallType  *uint32
+	EventType *uint32
+}
+
+type SignedExtension struct {
+	Identifier       string
+	Type             uint32
+	AdditionalSigned uint32
+}
+
+type ExtrinsicMetadata struct {
+	Version          uint8
+	AddressType      uint32
+	CallType         uint32
+	SignatureType    uint32
+	SignedExtensions []SignedExtension
+}
+
+// Metadata is part of runtime metadata required to decode extrinsics and events.
+type Metadata struct {
+	Version   uint8
+	Types     map[uint32]*PortableType
+	Pallets   map[uint8]*Pallet
+	Extrinsic ExtrinsicMetadata
+}
+
+// metadataMagic is "meta" prefix of encoded runtime metadata
+var metadataMagic = []byte{0x6d, 0x65, 0x74, 0x61}
+
+// DecodeMetadata decodes runtime metadata returned by state_getMetadata, V14 and V15 are supported.
+func DecodeMetadata(data []byte) (*Metadata, error) {
+	d := newScaleDecoder(data)
+
+	magic, err := d.readBytes(4)
+	if err != nil {
+		return nil, err
+	}
+	if string(magic) != string(metadataMagic) {
+		return nil, fmt.Errorf("invalid metadata magic %x", magic)
+	}
+
+	version, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	if version != 14 && version != 15 {
+		return nil, fmt.Errorf("unsupported metadata version %d", version)
+	}
+
+	metadata := &Metadata{
+		Version: version,
+		Types:   make(map[uint32]*PortableType),
+		Pallets: make(map[uint8]*Pallet),
+	}
+
+	if err := metadata.decodeTypes(d); err != nil {
+		return nil, fmt.Errorf("failed to decode types: %v", err)
+	}
+	if err := metadata.decodePallets(d); err != nil {
+		return nil, fmt.Errorf("failed to decode pallets: %v", err)
+	}
+	if err := metadata.decodeExtrinsic(d); err != nil {
+		return nil, fmt.Errorf("failed to decode extrinsic metadata: %v", err)
+	}
+
+	return metadata, nil
+}
+
+func decodeFields(d *scaleDecoder) ([]TypeField, error) {
+	count, err := d.readCompactUint()
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]TypeField, 0, count)
+	for i := uint64(0); i < count; i++ {
+		var field TypeField
+		if field.Name, err = d.readOptionalString(); err != nil {
+			return nil, err
+		}
+		fieldType, err := d.readCompactUint()
+		if err != nil {
+			return nil, err
+		}
+		field.Type = uint32(fieldType)
+		if field.TypeName, err = d.readOptionalString(); err != nil {
+			return nil, err
+		}
+		if _, err = d.readStrings(); err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+
+	return fields, nil
+}
+
+func decodeTypeDef(d *scaleDecoder) (TypeDef, error) {
+	kind, err := d.readByte()
+	if err != nil {
+		return TypeDef{}, err
+	}
+
+	def := TypeDef{Kind: int(kind)}
+	switch def.Kind {
+	case typeDefComposite:
+		def.Fields, err = decodeFields(d)
+	case typeDefVariant:
+		var count uint64
+		if count, err = d.readCompactUint(); err != nil {
+			return def, err
+		}
+		for i := uint64(0); i < count; i++ {
+			var variant TypeVariant
+			if variant.Name, err = d.readString(); err != nil {
+				return def, err
+			}
+			if variant.Fields, err = decodeFields(d); err != nil {
+				return def, err
+			}
+			if variant.Index, err = d.readByte(); err != nil {
+				return def, err
+			}
+			if _, err = d.readStrings(); err != nil {
+				return def, err
+			}
+			def.Variants = append(def.Variants, variant)
+		}
+	case typeDefSequence, typeDefCompact:
+		var elem uint64
+		elem, err = d.readCompactUint()
+		def.Elem = uint32(elem)
+	case typeDefArray:
+		var length, elem uint64
+		if length, err = d.readUint(4); err != nil {
+			return def, err
+		}
+		elem, err = d.readCompactUint()
+		def.Len = uint32(length)
+		def.Elem = uint32(elem)
+	case typeDefTuple:
+		var count uint64
+		if count, err = d.readCompactUint(); err != nil {
+			return def, err
+		}
+		for i := uint64(0); i < count; i++ {
+			var elem uint64
+			if elem, err = d.readCompactUint(); err != nil {
+				return def, err
+			}
+			def.Tuple = append(def.Tuple, uint32(elem))
+		}
+	case typeDefPrimitive:
+		def.Primitive, err = d.readByte()
+	case typeDefBitSequence:
+		var store uint64
+		if store, err = d.readCompactUint(); err != nil {
+			return def, err
+		}
+		def.Elem = uint32(store)
+		_, err = d.readCompactUint()
+	default:
+		err = fmt.Errorf("unknown type definition %d", kind)
+	}
+
+	return def, err
+}
+
+func (m *Metadata) decodeTypes(d *scaleDecoder) error {
+	count, err := d.readCompactUint()
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < count; i++ {
+		id, err := d.readCompactUint()
+		if err != nil {
+			return err
+		}
+
+		portableType := &PortableType{}
+		if portableType.Path, err = d.readStrings(); err != nil {
+			return err
+		}
+
+		paramsCount, err := d.readCompactUint()
+		if err != nil {
+			return err
+		}
+		for p := uint64(0); p < paramsCount; p++ {
+			var param TypeParam
+			if param.Name, err = d.readString(); err != nil {
+				return err
+			}
+			if param.Type, err = d.readOptionalCompact(); err != nil {
+				return err
+			}
+			portableType.Params = append(portableType.Params, param)
+		}
+
+		if portableType.Def, err = decodeTypeDef(d); err != nil {
+			return fmt.Errorf("type %d: %v", id, err)
+		}
+		if _, err = d.readStrings(); err != nil {
+			return err
+		}
+
+		m.Types[uint32(id)] = portableType
+	}
+
+	return nil
+}
+
+func (m *Metadata) decodePallets(d *scaleDecoder) error {
+	count, err := d.readCompactUint()
+	if err != nil {
+		return err
+	}
+
+	for i := uint64(0); i < count; i++ {
+		pallet := &Pallet{}
+		if pallet.Name, err = d.readString(); err != nil {
+			return err
+		}
+
+		// Storage entries are skipped, only events storage key is used and it is well known
+		hasStorage, err := d.readOption()
+		if err != nil {
+			return err
+		}
+		if hasStorage {
+			if err := skipStorage(d); err != nil {
+				return fmt.Errorf("pallet %s storage: %v", pallet.Name, err)
+			}
+		}
+
+		if pallet.CallType, err = d.readOptionalCompact(); err != nil {
+			return err
+		}
+		if pallet.EventType, err = d.readOptionalCompact(); err != nil {
+			return err
+		}
+
+		constantsCount, err := d.readCompactUint()
+		if err != nil {
+			return err
+		}
+		for c := uint64(0); c < constantsCount; c++ {
+			if _, err = d.readString(); err != nil {
+				return err
+			}
+			if _, err = d.readCompactUint(); err != nil {
+				return err
+			}
+			valueLength, err := d.readCompactUint()
+			if err != nil {
+				return err
+			}
+			if _, err = d.readBytes(int(valueLength)); err != nil {
+				return err
+			}
+			if _, err = d.readStrings(); err != nil {
+				return err
+			}
+		}
+
+		if _, err = d.readOptionalCompact(); err != nil {
+			return err
+		}
+		if pallet.Index, err = d.readByte(); err != nil {
+			return err
+		}
+		if m.Version >= 15 {
+			if _, err = d.readStrings(); err != nil {
+				return err
+			}
+		}
+
+		m.Pallets[pallet.Index] = pallet
+	}
+
+	return nil
+}
+
+func skipStorage(d *scaleDecoder) error {
+	if _, err := d.readString(); err != nil {
+		return err
+	}
+
+	count, err := d.readCompactUint()
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < count; i++ {
+		if _, err := d.readString(); err != nil {
+			return err
+		}
+		if _, err := d.readByte(); err != nil {
+			return err
+		}
+
+		entryType, err := d.readByte()
+		if err != nil {
+			return err
+		}
+		switch entryType {
+		case 0:
+			if _, err := d.readCompactUint(); err != nil {
+				return err
+			}
+		case 1:
+			hashersCount, err := d.readCompactUint()
+			if err != nil {
+				return err
+			}
+			if _, err := d.readBytes(int(hashersCount)); err != nil {
+				return err
+			}
+			if _, err := d.readCompactUint(); err != nil {
+				return err
+			}
+			if _, err := d.readCompactUint(); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unknown storage entry type %d", entryType)
+		}
+
+		defaultLength, err := d.readCompactUint()
+		if err != nil {
+			return err
+		}
+		if _, err := d.readBytes(int(defaultLength)); err != nil {
+			return err
+		}
+		if _, err := d.readStrings(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Metadata) decodeExtrinsic(d *scaleDecoder) error {
+	var extrinsicType uint64
+	var err error
+
+	if m.Version == 14 {
+		if extrinsicType, err = d.readCompactUint(); err != nil {
+			return err
+		}
+	}
+
+	if m.Extrinsic.Version, err = d.readByte(); err != nil {
+		return err
+	}
+
+	if m.Version >= 15 {
+		types := make([]uint64, 4)
+		for i := range types {
+			if types[i], err = d.readCompactUint(); err != nil {
+				return err
+			}
+		}
+		m.Extrinsic.AddressType = uint32(types[0])
+		m.Extrinsic.CallType = uint32(types[1])
+		m.Extrinsic.SignatureType = uint32(types[2])
+	}
+
+	count, err := d.readCompactUint()
+	if err != nil {
+		return err
+	}
+	for i := uint64(0); i < count; i++ {
+		var extension SignedExtension
+		if extension.Identifier, err = d.readString(); err != nil {
+			return err
+		}
+		extensionType, err := d.readCompactUint()
+		if err != nil {
+			return err
+		}
+		additionalSigned, err := d.readCompactUint()
+		if err != nil {
+			return err
+		}
+		extension.Type = uint32(extensionType)
+		extension.AdditionalSigned = uint32(additionalSigned)
+		m.Extrinsic.SignedExtensions = append(m.Extrinsic.SignedExtensions, extension)
+	}
+
+	// In V14 address, call and signature types are parameters of UncheckedExtrinsic type
+	if m.Version == 14 {
+		uncheckedExtrinsic, ok := m.Types[uint32(extrinsicType)]
+		if !ok {
+			return fmt.Errorf("extrinsic type %d not found", extrinsicType)
+		}
+		for _, param := range uncheckedExtrinsic.Params {
+			if param.Type == nil {
+				continue
+			}
+			switch param.Name {
+			case "Address":
+				m.Extrinsic.AddressType = *param.Type
+			case "Call":
+				m.Extrinsic.CallType = *param.Type
+			case "Signature":
+				m.Extrinsic.SignatureType = *param.Type
+			}
+		}
+	}
+
+	return nil
+}
+
+// Values decoding
+
+func (m *Metadata) isU8(typeId uint32) bool {
+	t, ok := m.Types[typeId]
+	return ok && t.Def.Kind == typeDefPrimitive && t.Def.Primitive == primitiveU8
+}
+
+// primitiveSize returns size in bytes of unsigned primitive type, used for bit sequences store.
+func (m *Metadata) primitiveSize(typeId uint32) int {
+	t, ok := m.Types[typeId]
+	if !ok || t.Def.Kind != typeDefPrimitive {
+		return 1
+	}
+	switch t.Def.Primitive {
+	case primitiveU16:
+		return 2
+	case primitiveU32:
+		return 4
+	case primitiveU64:
+		return 8
+	default:
+		return 1
+	}
+}
+
+// decodeFieldsValue decodes fields into map for named fields, value for single unnamed field
+// and list for multiple unnamed fields.
+func (m *Metadata) decodeFieldsValue(d *scaleDecoder, fields []TypeField) (interface{}, error) {
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	if fields[0].Name == "" {
+		if len(fields) == 1 {
+			return m.decodeValue(d, fields[0].Type)
+		}
+		values := make([]interface{}, 0, len(fields))
+		for _, field := range fields {
+			value, err := m.decodeValue(d, field.Type)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+	}
+
+	values := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		value, err := m.decodeValue(d, field.Type)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %v", field.Name, err)
+		}
+		values[field.Name] = value
+	}
+	return values, nil
+}
+
+func (m *Metadata) readVariant(d *scaleDecoder, typeId uint32) (*TypeVariant, error) {
+	t, ok := m.Types[typeId]
+	if !ok {
+		return nil, fmt.Errorf("type %d not found", typeId)
+	}
+	if t.Def.Kind != typeDefVariant {
+		return nil, fmt.Errorf("type %d is not variant", typeId)
+	}
+
+	index, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	for i := range t.Def.Variants {
+		if t.Def.Variants[i].Index == index {
+			return &t.Def.Variants[i], nil
+		}
+	}
+	return nil, fmt.Errorf("variant %d of type %d not found", index, typeId)
+}
+
+func bigToValue(value *big.Int) interface{} {
+	if value.IsUint64() {
+		return value.Uint64()
+	}
+	return value.String()
+}
+
+// decodeValue decodes value of type into JSON compatible structure. Byte sequences and arrays
+// are returned as 0x-prefixed hex, integers wider than 64 bits as decimal strings.
+func (m *Metadata) decodeValue(d *scaleDecoder, typeId uint32) (interface{}, error) {
+	t, ok := m.Types[typeId]
+	if !ok {
+		return nil, fmt.Errorf("type %d not found", typeId)
+	}
+
+	switch t.Def.Kind {
+	case typeDefComposite:
+		return m.decodeFieldsValue(d, t.Def.Fields)
+
+	case typeDefVariant:
+		variant, err := m.readVariant(d, typeId)
+		if err != nil {
+			return nil, err
+		}
+		if len(t.Path) == 1 && t.Path[0] == "Option" {
+			if variant.Name == "None" {
+				return nil, nil
+			}
+			return m.decodeFieldsValue(d, variant.Fields)
+		}
+		if len(variant.Fields) == 0 {
+			return variant.Name, nil
+		}
+		value, err := m.decodeFieldsValue(d, variant.Fields)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{variant.Name: value}, nil
+
+	case typeDefSequence:
+		length, err := d.readCompactUint()
+		if err != nil {
+			return nil, err
+		}
+		return m.decodeList(d, t.Def.Elem, length)
+
+	case typeDefArray:
+		return m.decodeList(d, t.Def.Elem, uint64(t.Def.Len))
+
+	case typeDefTuple:
+		if len(t.Def.Tuple) == 0 {
+			return nil, nil
+		}
+		values := make([]interface{}, 0, len(t.Def.Tuple))
+		for _, elem := range t.Def.Tuple {
+			value, err := m.decodeValue(d, elem)
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return values, nil
+
+	case typeDefPrimitive:
+		return decodePrimitive(d, t.Def.Primitive)
+
+	case typeDefCompact:
+		value, err := d.readCompact()
+		if err != nil {
+			return nil, err
+		}
+		return bigToValue(value), nil
+
+	case typeDefBitSequence:
+		bits, err := d.readCompactUint()
+		if err != nil {
+			return nil, err
+		}
+		storeSize := m.primitiveSize(t.Def.Elem)
+		storeBits := uint64(storeSize * 8)
+		b, err := d.readBytes(int((bits+storeBits-1)/storeBits) * storeSize)
+		if err != nil {
+			return nil, err
+		}
+		return "0x" + hex.EncodeToString(b), nil
+	}
+
+	return nil, fmt.Errorf("unknown type definition %d of type %d", t.Def.Kind, typeId)
+}
+
+func (m *Metadata) decodeList(d *scaleDecoder, elem uint32, length uint64) (interface{}, error) {
+	if m.isU8(elem) {
+		b, err := d.readBytes(int(length))
+		if err != nil {
+			return nil, err
+		}
+		return "0x" + hex.EncodeToString(b), nil
+	}
+
+	if length > uint64(d.remaining()) {
+		return nil, errUnexpectedEnd
+	}
+
+	values := make([]interface{}, 0, length)
+	for i := uint64(0); i < length; i++ {
+		value, err := m.decodeValue(d, elem)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func decodePrimitive(d *scaleDecoder, primitive uint8) (interface{}, error) {
+	switch primitive {
+	case primitiveBool:
+		b, err := d.readByte()
+		return b == 1, err
+	case primitiveChar:
+		value, err := d.readUint(4)
+		return string(rune(value)), err
+	case primitiveStr:
+		return d.readString()
+	case primitiveU8:
+		return d.readUint(1)
+	case primitiveU16:
+		return d.readUint(2)
+	case primitiveU32:
+		return d.readUint(4)
+	case primitiveU64:
+		return d.readUint(8)
+	case primitiveU128, primitiveU256:
+		size := 16
+		if primitive == primitiveU256 {
+			size = 32
+		}
+		value, err := d.readBigUint(size)
+		if err != nil {
+			return nil, err
+		}
+		return value.String(), nil
+	case primitiveI8, primitiveI16, primitiveI32, primitiveI64:
+		size := 1 << (primitive - primitiveI8)
+		value, err := d.readBigInt(size)
+		if err != nil {
+			return nil, err
+		}
+		return value.Int64(), nil
+	case primitiveI128, primitiveI256:
+		size := 16
+		if primitive == primitiveI256 {
+			size = 32
+		}
+		value, err := d.readBigInt(size)
+		if err != nil {
+			return nil, err
+		}
+		return value.String(), nil
+	}
+
+	return nil, fmt.Errorf("unknown primitive %d", primitive)
+}
@@ -0,0 +1,918 @@
+package substrate
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/blake2b"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/version"
+)
+
+// systemEventsStorageKey is twox128("System") ++ twox128("Events")
+const systemEventsStorageKey = "0x26aa394eea5630e07c48ae0c9558cef780d41e5e16056765bc8461851072c9d7"
+
+// Chains lists Substrate based chains supported out of the box, other chains could be
+// registered with NewClient from third-party package.
+var Chains = []string{"polkadot", "kusama"}
+
+func init() {
+	for _, chain := range Chains {
+		seer_common.RegisterHasher(chain, seer_common.SubstrateHasher{})
+	}
+}
+
+func NewClient(chain, url string, timeout int) (*Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	rpcClient, err := seer_common.DialRPC(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		chain:     chain,
+		rpcClient: rpcClient,
+		hasher:    seer_common.GetHasher(chain),
+		metadata:  make(map[uint32]*Metadata),
+	}, nil
+}
+
+// Client is a wrapper around the Substrate JSON-RPC client. Extrinsics and events are decoded
+// with runtime metadata of block's spec version, metadata is cached per spec version.
+type Client struct {
+	chain     string
+	rpcClient *seer_common.RPCClient
+	hasher    seer_common.Hasher
+
+	metadataMu sync.Mutex
+	metadata   map[uint32]*Metadata
+}
+
+// Client common
+
+// ChainType returns the chain type.
+func (c *Client) ChainType() string {
+	return c.chain
+}
+
+// Close closes the underlying RPC client.
+func (c *Client) Close() {
+	c.rpcClient.Close()
+}
+
+// GetLatestBlockNumber returns the latest block number.
+func (c *Client) GetLatestBlockNumber(ctx context.Context) (*big.Int, error) {
+	var header HeaderJson
+	if err := c.rpcClient.CallContext(ctx, &header, "chain_getHeader"); err != nil {
+		return nil, err
+	}
+
+	return parseHexNumber(header.Number)
+}
+
+// GetMetadata returns runtime metadata of spec version active at block.
+func (c *Client) GetMetadata(ctx context.Context, blockHash string) (*Metadata, uint32, error) {
+	var runtimeVersion RuntimeVersionJson
+	if err := c.rpcClient.CallContext(ctx, &runtimeVersion, "state_getRuntimeVersion", blockHash); err != nil {
+		return nil, 0, err
+	}
+
+	c.metadataMu.Lock()
+	metadata, ok := c.metadata[runtimeVersion.SpecVersion]
+	c.metadataMu.Unlock()
+	if ok {
+		return metadata, runtimeVersion.SpecVersion, nil
+	}
+
+	var metadataHex string
+	if err := c.rpcClient.CallContext(ctx, &metadataHex, "state_getMetadata", blockHash); err != nil {
+		return nil, 0, err
+	}
+	metadataBytes, err := decodeHex(metadataHex)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	metadata, err = DecodeMetadata(metadataBytes)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode metadata of spec version %d: %v", runtimeVersion.SpecVersion, err)
+	}
+
+	c.metadataMu.Lock()
+	c.metadata[runtimeVersion.SpecVersion] = metadata
+	c.metadataMu.Unlock()
+
+	return metadata, runtimeVersion.SpecVersion, nil
+}
+
+// GetBlockByNumber returns block with decoded extrinsics and events.
+func (c *Client) GetBlockByNumber(ctx context.Context, number uint64) (*SubstrateBlock, error) {
+	var blockHash string
+	if err := c.rpcClient.CallContext(ctx, &blockHash, "chain_getBlockHash", number); err != nil {
+		return nil, err
+	}
+	if blockHash == "" {
+		return nil, fmt.Errorf("block %d not found", number)
+	}
+
+	var signedBlock SignedBlockJson
+	if err := c.rpcClient.CallContext(ctx, &signedBlock, "chain_getBlock", blockHash); err != nil {
+		return nil, err
+	}
+
+	metadata, specVersion, err := c.GetMetadata(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	var eventsHex *string
+	if err := c.rpcClient.CallContext(ctx, &eventsHex, "state_getStorage", systemEventsStorageKey, blockHash); err != nil {
+		return nil, err
+	}
+
+	var events []DecodedEvent
+	if eventsHex != nil {
+		eventsBytes, err := decodeHex(*eventsHex)
+		if err != nil {
+			return nil, err
+		}
+		events, err = metadata.DecodeEvents(eventsBytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode events of block %d: %v", number, err)
+		}
+	}
+
+	return ToProtoSingleBlock(&signedBlock.Block, blockHash, specVersion, metadata, events)
+}
+
+// FetchBlocksInRange fetches blocks within a specified range, with up to maxRequests concurrent requests.
+// Blocks are returned in ascending order.
+func (c *Client) FetchBlocksInRange(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]*SubstrateBlock, error) {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	fromNumber := from.Uint64()
+	toNumber := to.Uint64()
+	if toNumber < fromNumber {
+		return nil, nil
+	}
+
+	blocks := make([]*SubstrateBlock, toNumber-fromNumber+1)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	sem := make(chan struct{}, maxRequests)
+
+	for number := fromNumber; number <= toNumber; number++ {
+		wg.Add(1)
+		go func(number uint64) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			block, err := c.GetBlockByNumber(ctx, number)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			blocks[number-fromNumber] = block
+
+			if debug {
+				fmt.Printf("Fetched block number: %d\n", number)
+			}
+		}(number)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return blocks, nil
+}
+
+// FetchAsProtoBlocksWithEvents fetches blocks with extrinsics and events. Extrinsics are indexed
+// by "Pallet.call" and events by "Pallet.Event" names, pallet name is used as address.
+func (c *Client) FetchAsProtoBlocksWithEvents(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error) {
+	blocks, err := c.FetchBlocksInRange(ctx, from, to, debug, maxRequests)
+	if err != nil {
+		return nil, nil, nil, nil, 0, err
+	}
+
+	var blocksSize uint64
+	var blocksProto []proto.Message
+	var blocksIndex []indexer.BlockIndex
+	var txsIndex []indexer.TransactionIndex
+	var eventsIndex []indexer.LogIndex
+
+	for bI, block := range blocks {
+		for _, extrinsic := range block.Extrinsics {
+			var fromAddress, toAddress, selector string
+			if extrinsic.Signer != "" {
+				fromAddress = c.hasher.NormalizeAddress(extrinsic.Signer)
+			}
+			if extrinsic.Pallet != "" {
+				toAddress = c.hasher.NormalizeAddress(extrinsic.Pallet)
+				selector = c.hasher.MethodSelector(extrinsic.Pallet + "." + extrinsic.Call)
+			}
+
+			var extrinsicType uint64
+			if extrinsic.Signed {
+				extrinsicType = 1
+			}
+
+			txsIndex = append(txsIndex, indexer.TransactionIndex{
+				BlockNumber:      extrinsic.BlockNumber,
+				BlockHash:        extrinsic.BlockHash,
+				BlockTimestamp:   extrinsic.BlockTimestamp,
+				FromAddress:      fromAddress,
+				ToAddress:        toAddress,
+				RowID:            extrinsic.ExtrinsicIndex,
+				Selector:         selector, // Pallet.call instead of 4 bytes selector
+				TransactionHash:  extrinsic.Hash,
+				TransactionIndex: extrinsic.ExtrinsicIndex,
+				Type:             extrinsicType,
+				Path:             "",
+			})
+		}
+
+		for _, event := range blockEvents(block) {
+			transactionHash := event.ExtrinsicHash
+			if transactionHash == "" {
+				transactionHash = block.Hash
+			}
+
+			eventName := c.hasher.EventTopic(event.Pallet + "." + event.Name)
+			eventsIndex = append(eventsIndex, indexer.LogIndex{
+				Address:         c.hasher.NormalizeAddress(event.Pallet),
+				BlockNumber:     event.BlockNumber,
+				BlockHash:       event.BlockHash,
+				BlockTimestamp:  block.Timestamp,
+				TransactionHash: transactionHash,
+				Selector:        &eventName, // Pallet.Event instead of topic0
+				RowID:           event.EventIndex,
+				LogIndex:        event.EventIndex,
+				Path:            "",
+			})
+		}
+
+		blocksIndex = append(blocksIndex, indexer.NewBlockIndex(c.chain,
+			block.BlockNumber,
+			block.Hash,
+			block.Timestamp,
+			block.ParentHash,
+			uint64(bI),
+			"",
+			0,
+		))
+
+		blocksSize += uint64(proto.Size(block))
+		blocksProto = append(blocksProto, block)
+	}
+
+	return blocksProto, blocksIndex, txsIndex, eventsIndex, blocksSize, nil
+}
+
+// blockEvents returns all events of block ordered by index.
+func blockEvents(block *SubstrateBlock) []*SubstrateEvent {
+	events := append([]*SubstrateEvent{}, block.Events...)
+	for _, extrinsic := range block.Extrinsics {
+		events = append(events, extrinsic.Events...)
+	}
+
+	for i := 1; i < len(events); i++ {
+		for j := i; j > 0 && events[j].EventIndex < events[j-1].EventIndex; j-- {
+			events[j], events[j-1] = events[j-1], events[j]
+		}
+	}
+
+	return events
+}
+
+func (c *Client) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
+	var blocks []*SubstrateBlock
+	for _, msg := range msgs {
+		block, ok := msg.(*SubstrateBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *SubstrateBlock")
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &SubstrateBlocksBatch{
+		Blocks:      blocks,
+		SeerVersion: version.SeerVersion,
+	}, nil
+}
+
+func (c *Client) decodeBlocksBatch(rawData *bytes.Buffer) (*SubstrateBlocksBatch, error) {
+	var protoBlocksBatch SubstrateBlocksBatch
+
+	if err := proto.Unmarshal(rawData.Bytes(), &protoBlocksBatch); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	return &protoBlocksBatch, nil
+}
+
+// DecodeProtoEntireBlockToJson returns blocks, extrinsics and events in EVM oriented JSON structure,
+// "Pallet.Event" name is placed as the first topic.
+func (c *Client) DecodeProtoEntireBlockToJson(rawData *bytes.Buffer) (*seer_common.BlocksBatchJson, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksBatchJson := seer_common.BlocksBatchJson{
+		Blocks:      []seer_common.BlockJson{},
+		SeerVersion: protoBlocksBatch.SeerVersion,
+	}
+
+	for _, b := range protoBlocksBatch.Blocks {
+		var txs []seer_common.TransactionJson
+		for _, extrinsic := range b.Extrinsics {
+			var events []seer_common.EventJson
+			for _, e := range extrinsic.Events {
+				events = append(events, seer_common.EventJson{
+					Address:          e.Pallet,
+					Topics:           append([]string{e.Pallet + "." + e.Name}, e.Topics...),
+					Data:             e.Args,
+					BlockNumber:      fmt.Sprintf("%d", e.BlockNumber),
+					TransactionHash:  e.ExtrinsicHash,
+					BlockHash:        e.BlockHash,
+					LogIndex:         fmt.Sprintf("%d", e.EventIndex),
+					TransactionIndex: fmt.Sprintf("%d", e.ExtrinsicIndex),
+				})
+			}
+
+			txs = append(txs, seer_common.TransactionJson{
+				BlockHash:        extrinsic.BlockHash,
+				BlockNumber:      fmt.Sprintf("%d", extrinsic.BlockNumber),
+				FromAddress:      extrinsic.Signer,
+				Hash:             extrinsic.Hash,
+				Input:            extrinsic.Raw,
+				Nonce:            fmt.Sprintf("%d", extrinsic.Nonce),
+				ToAddress:        extrinsic.Pallet,
+				TransactionIndex: fmt.Sprintf("%d", extrinsic.ExtrinsicIndex),
+				IndexedAt:        fmt.Sprintf("%d", extrinsic.IndexedAt),
+				BlockTimestamp:   fmt.Sprintf("%d", extrinsic.BlockTimestamp),
+
+				Events: events,
+			})
+		}
+
+		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
+			Hash:         b.Hash,
+			BlockNumber:  fmt.Sprintf("%d", b.BlockNumber),
+			ParentHash:   b.ParentHash,
+			StateRoot:    b.StateRoot,
+			Timestamp:    fmt.Sprintf("%d", b.Timestamp),
+			IndexedAt:    fmt.Sprintf("%d", b.IndexedAt),
+			Transactions: txs,
+		})
+	}
+
+	return &blocksBatchJson, nil
+}
+
+// DecodeProtoEntireBlockToProtoJson returns batch with all fields, including signed extensions
+// and events of Initialization and Finalization phases.
+func (c *Client) DecodeProtoEntireBlockToProtoJson(rawData *bytes.Buffer) ([]byte, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	return protojson.Marshal(protoBlocksBatch)
+}
+
+// DecodeProtoEntireBlockToLabels converts extrinsics and events of pallets matched with ABI jobs
+// to labels. Arguments are already decoded with runtime metadata during crawling.
+func (c *Client) DecodeProtoEntireBlockToLabels(ctx context.Context, rawData *bytes.Buffer, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var labels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
+
+	for _, b := range protoBlocksBatch.Blocks {
+		for _, extrinsic := range b.Extrinsics {
+			txLabel, matched, labelErr := c.extrinsicToLabel(extrinsic, abiMap)
+			if labelErr != nil {
+				return nil, nil, labelErr
+			}
+			if matched {
+				txLabels = append(txLabels, txLabel)
+			}
+		}
+
+		signers := make(map[uint64]string)
+		for _, extrinsic := range b.Extrinsics {
+			signers[extrinsic.ExtrinsicIndex] = extrinsic.Signer
+		}
+
+		for _, e := range blockEvents(b) {
+			pallet := c.hasher.NormalizeAddress(e.Pallet)
+			eventName := c.hasher.EventTopic(e.Pallet + "." + e.Name)
+
+			if abiMap[pallet] == nil || abiMap[pallet][eventName] == nil {
+				continue
+			}
+
+			var args interface{}
+			label := indexer.SeerCrawlerLabel
+			if unmarshalErr := json.Unmarshal([]byte(e.Args), &args); unmarshalErr != nil {
+				args = e.Args
+				label = indexer.SeerCrawlerRawLabel
+			}
+
+			labelDataBytes, err := json.Marshal(map[string]interface{}{
+				"type":  "event",
+				"name":  e.Name,
+				"phase": e.Phase,
+				"args":  args,
+			})
+			if err != nil {
+				return nil, nil, err
+			}
+
+			transactionHash := e.ExtrinsicHash
+			var originAddress string
+			if transactionHash == "" {
+				transactionHash = b.Hash
+			} else {
+				originAddress = signers[e.ExtrinsicIndex]
+			}
+
+			labels = append(labels, indexer.EventLabel{
+				Label:           label,
+				LabelName:       abiMap[pallet][eventName]["abi_name"],
+				LabelType:       "event",
+				BlockNumber:     e.BlockNumber,
+				BlockHash:       e.BlockHash,
+				Address:         pallet,
+				OriginAddress:   originAddress,
+				TransactionHash: transactionHash,
+				LabelData:       string(labelDataBytes),
+				BlockTimestamp:  b.Timestamp,
+				LogIndex:        e.EventIndex,
+			})
+		}
+	}
+
+	return labels, txLabels, nil
+}
+
+func (c *Client) extrinsicToLabel(extrinsic *SubstrateExtrinsic, abiMap map[string]map[string]map[string]string) (indexer.TransactionLabel, bool, error) {
+	if extrinsic.Pallet == "" {
+		return indexer.TransactionLabel{}, false, nil
+	}
+
+	pallet := c.hasher.NormalizeAddress(extrinsic.Pallet)
+	selector := c.hasher.MethodSelector(extrinsic.Pallet + "." + extrinsic.Call)
+	if abiMap[pallet] == nil || abiMap[pallet][selector] == nil {
+		return indexer.TransactionLabel{}, false, nil
+	}
+
+	var args interface{}
+	label := indexer.SeerCrawlerLabel
+	if err := json.Unmarshal([]byte(extrinsic.Args), &args); err != nil {
+		args = extrinsic.Args
+		label = indexer.SeerCrawlerRawLabel
+	}
+
+	labelDataBytes, err := json.Marshal(map[string]interface{}{
+		"type":   "tx_call",
+		"name":   extrinsic.Call,
+		"status": extrinsic.Success,
+		"nonce":  extrinsic.Nonce,
+		"tip":    extrinsic.Tip,
+		"args":   args,
+		"signed": extrinsic.Signed,
+	})
+	if err != nil {
+		return indexer.TransactionLabel{}, false, err
+	}
+
+	return indexer.TransactionLabel{
+		Address:         pallet,
+		BlockNumber:     extrinsic.BlockNumber,
+		BlockHash:       extrinsic.BlockHash,
+		CallerAddress:   extrinsic.Signer,
+		LabelName:       abiMap[pallet][selector]["abi_name"],
+		LabelType:       "tx_call",
+		OriginAddress:   extrinsic.Signer,
+		Label:           label,
+		TransactionHash: extrinsic.Hash,
+		LabelData:       string(labelDataBytes),
+		BlockTimestamp:  extrinsic.BlockTimestamp,
+	}, true, nil
+}
+
+func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error) {
+	var labels []indexer.TransactionLabel
+
+	for _, data := range transactions {
+		var extrinsic SubstrateExtrinsic
+		if err := proto.Unmarshal([]byte(data), &extrinsic); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal extrinsic: %v", err)
+		}
+
+		txLabel, matched, err := c.extrinsicToLabel(&extrinsic, abiMap)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			labels = append(labels, txLabel)
+		}
+	}
+
+	return labels, nil
+}
+
+// Extrinsics and events decoding
+
+// DecodedEvent is event record from System.Events storage.
+type DecodedEvent struct {
+	Phase          string
+	ExtrinsicIndex uint64
+	Pallet         string
+	Name           string
+	Args           interface{}
+	Topics         []string
+}
+
+// DecodeEvents decodes Vec<EventRecord> from System.Events storage.
+func (m *Metadata) DecodeEvents(data []byte) ([]DecodedEvent, error) {
+	d := newScaleDecoder(data)
+
+	count, err := d.readCompactUint()
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]DecodedEvent, 0, count)
+	for i := uint64(0); i < count; i++ {
+		var event DecodedEvent
+
+		phase, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		switch phase {
+		case 0:
+			event.Phase = "ApplyExtrinsic"
+			if event.ExtrinsicIndex, err = d.readUint(4); err != nil {
+				return nil, err
+			}
+		case 1:
+			event.Phase = "Finalization"
+		case 2:
+			event.Phase = "Initialization"
+		default:
+			return nil, fmt.Errorf("unknown phase %d of event %d", phase, i)
+		}
+
+		palletIndex, err := d.readByte()
+		if err != nil {
+			return nil, err
+		}
+		pallet, ok := m.Pallets[palletIndex]
+		if !ok || pallet.EventType == nil {
+			return nil, fmt.Errorf("pallet %d with events not found for event %d", palletIndex, i)
+		}
+		event.Pallet = pallet.Name
+
+		variant, err := m.readVariant(d, *pallet.EventType)
+		if err != nil {
+			return nil, fmt.Errorf("event %d of pallet %s: %v", i, pallet.Name, err)
+		}
+		event.Name = variant.Name
+
+		if event.Args, err = m.decodeFieldsValue(d, variant.Fields); err != nil {
+			return nil, fmt.Errorf("event %s.%s: %v", pallet.Name, variant.Name, err)
+		}
+
+		topicsCount, err := d.readCompactUint()
+		if err != nil {
+			return nil, err
+		}
+		for t := uint64(0); t < topicsCount; t++ {
+			topic, err := d.readBytes(32)
+			if err != nil {
+				return nil, err
+			}
+			event.Topics = append(event.Topics, "0x"+hex.EncodeToString(topic))
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// DecodedExtrinsic is extrinsic decoded with runtime metadata.
+type DecodedExtrinsic struct {
+	Signed           bool
+	Signer           string
+	Signature        interface{}
+	SignedExtensions map[string]interface{}
+	Nonce            uint64
+	Tip              string
+	Pallet           string
+	Call             string
+	Args             interface{}
+}
+
+// DecodeExtrinsic decodes length prefixed extrinsic of version 4.
+func (m *Metadata) DecodeExtrinsic(data []byte) (*DecodedExtrinsic, error) {
+	d := newScaleDecoder(data)
+
+	if _, err := d.readCompactUint(); err != nil {
+		return nil, err
+	}
+
+	versionByte, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	if versionByte&0x7f != 4 {
+		return nil, fmt.Errorf("unsupported extrinsic version %d", versionByte&0x7f)
+	}
+
+	extrinsic := &DecodedExtrinsic{Signed: versionByte&0x80 != 0}
+
+	if extrinsic.Signed {
+		address, err := m.decodeValue(d, m.Extrinsic.AddressType)
+		if err != nil {
+			return nil, fmt.Errorf("address: %v", err)
+		}
+		extrinsic.Signer = signerFromAddress(address)
+
+		if extrinsic.Signature, err = m.decodeValue(d, m.Extrinsic.SignatureType); err != nil {
+			return nil, fmt.Errorf("signature: %v", err)
+		}
+
+		extrinsic.SignedExtensions = make(map[string]interface{})
+		for _, extension := range m.Extrinsic.SignedExtensions {
+			value, err := m.decodeValue(d, extension.Type)
+			if err != nil {
+				return nil, fmt.Errorf("signed extension %s: %v", extension.Identifier, err)
+			}
+			if value != nil {
+				extrinsic.SignedExtensions[extension.Identifier] = value
+			}
+		}
+
+		if nonce, ok := extrinsic.SignedExtensions["CheckNonce"].(uint64); ok {
+			extrinsic.Nonce = nonce
+		}
+		extrinsic.Tip = tipFromExtensions(extrinsic.SignedExtensions)
+	}
+
+	palletIndex, err := d.readByte()
+	if err != nil {
+		return nil, err
+	}
+	pallet, ok := m.Pallets[palletIndex]
+	if !ok || pallet.CallType == nil {
+		return nil, fmt.Errorf("pallet %d with calls not found", palletIndex)
+	}
+	extrinsic.Pallet = pallet.Name
+
+	variant, err := m.readVariant(d, *pallet.CallType)
+	if err != nil {
+		return nil, fmt.Errorf("call of pallet %s: %v", pallet.Name, err)
+	}
+	extrinsic.Call = variant.Name
+
+	if extrinsic.Args, err = m.decodeFieldsValue(d, variant.Fields); err != nil {
+		return nil, fmt.Errorf("call %s.%s: %v", pallet.Name, variant.Name, err)
+	}
+	if extrinsic.Args == nil {
+		extrinsic.Args = map[string]interface{}{}
+	}
+
+	return extrinsic, nil
+}
+
+// signerFromAddress returns account id from MultiAddress or AccountId32 address.
+func signerFromAddress(address interface{}) string {
+	switch value := address.(type) {
+	case string:
+		return value
+	case map[string]interface{}:
+		for _, key := range []string{"Id", "Address32", "Address20"} {
+			if accountId, ok := value[key].(string); ok {
+				return accountId
+			}
+		}
+	}
+	return ""
+}
+
+func tipFromExtensions(extensions map[string]interface{}) string {
+	for _, identifier := range []string{"ChargeTransactionPayment", "ChargeAssetTxPayment"} {
+		switch value := extensions[identifier].(type) {
+		case nil:
+			continue
+		case map[string]interface{}:
+			if tip, ok := value["tip"]; ok {
+				return fmt.Sprint(tip)
+			}
+		default:
+			return fmt.Sprint(value)
+		}
+	}
+	return "0"
+}
+
+// Substrate JSON-RPC structures
+
+type DigestJson struct {
+	Logs []string `json:"logs"`
+}
+
+type HeaderJson struct {
+	ParentHash     string     `json:"parentHash"`
+	Number         string     `json:"number"`
+	StateRoot      string     `json:"stateRoot"`
+	ExtrinsicsRoot string     `json:"extrinsicsRoot"`
+	Digest         DigestJson `json:"digest"`
+}
+
+type BlockJson struct {
+	Header     HeaderJson `json:"header"`
+	Extrinsics []string   `json:"extrinsics"`
+}
+
+type SignedBlockJson struct {
+	Block BlockJson `json:"block"`
+}
+
+type RuntimeVersionJson struct {
+	SpecName    string `json:"specName"`
+	SpecVersion uint32 `json:"specVersion"`
+}
+
+func decodeHex(value string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(value, "0x"))
+}
+
+func parseHexNumber(value string) (*big.Int, error) {
+	number, ok := new(big.Int).SetString(strings.TrimPrefix(value, "0x"), 16)
+	if !ok {
+		return nil, fmt.Errorf("invalid hex number %q", value)
+	}
+	return number, nil
+}
+
+func toJsonString(value interface{}) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ToProtoSingleBlock converts block with its events to proto, extrinsics which could not be decoded
+// are kept in raw form with decode error.
+func ToProtoSingleBlock(obj *BlockJson, blockHash string, specVersion uint32, metadata *Metadata, events []DecodedEvent) (*SubstrateBlock, error) {
+	indexedAt := uint64(time.Now().Unix())
+
+	blockNumber, err := parseHexNumber(obj.Header.Number)
+	if err != nil {
+		return nil, err
+	}
+
+	digest, err := toJsonString(obj.Header.Digest.Logs)
+	if err != nil {
+		return nil, err
+	}
+
+	block := &SubstrateBlock{
+		BlockNumber:    blockNumber.Uint64(),
+		Hash:           blockHash,
+		ParentHash:     obj.Header.ParentHash,
+		StateRoot:      obj.Header.StateRoot,
+		ExtrinsicsRoot: obj.Header.ExtrinsicsRoot,
+		SpecVersion:    specVersion,
+		Digest:         digest,
+		IndexedAt:      indexedAt,
+	}
+
+	for eI, extrinsicHex := range obj.Extrinsics {
+		raw, err := decodeHex(extrinsicHex)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode extrinsic %d hex: %v", eI, err)
+		}
+		hash := blake2b.Sum256(raw)
+
+		extrinsic := &SubstrateExtrinsic{
+			Hash:           "0x" + hex.EncodeToString(hash[:]),
+			ExtrinsicIndex: uint64(eI),
+			Raw:            extrinsicHex,
+			BlockNumber:    block.BlockNumber,
+			BlockHash:      blockHash,
+			IndexedAt:      indexedAt,
+		}
+
+		decoded, decodeErr := metadata.DecodeExtrinsic(raw)
+		if decodeErr != nil {
+			extrinsic.DecodeError = decodeErr.Error()
+		} else {
+			extrinsic.Signed = decoded.Signed
+			extrinsic.Signer = decoded.Signer
+			extrinsic.Nonce = decoded.Nonce
+			extrinsic.Tip = decoded.Tip
+			extrinsic.Pallet = decoded.Pallet
+			extrinsic.Call = decoded.Call
+
+			if extrinsic.Signature, err = toJsonString(decoded.Signature); err != nil {
+				return nil, err
+			}
+			if extrinsic.SignedExtensions, err = toJsonString(decoded.SignedExtensions); err != nil {
+				return nil, err
+			}
+			if extrinsic.Args, err = toJsonString(decoded.Args); err != nil {
+				return nil, err
+			}
+
+			if decoded.Pallet == "Timestamp" && decoded.Call == "set" {
+				if args, ok := decoded.Args.(map[string]interface{}); ok {
+					if now, ok := args["now"].(uint64); ok {
+						block.Timestamp = now / 1000
+					}
+				}
+			}
+		}
+
+		block.Extrinsics = append(block.Extrinsics, extrinsic)
+	}
+
+	for eI, event := range events {
+		args, err := toJsonString(event.Args)
+		if err != nil {
+			return nil, err
+		}
+
+		protoEvent := &SubstrateEvent{
+			EventIndex:     uint64(eI),
+			Phase:          event.Phase,
+			ExtrinsicIndex: event.ExtrinsicIndex,
+			Pallet:         event.Pallet,
+			Name:           event.Name,
+			Args:           args,
+			Topics:         event.Topics,
+			BlockNumber:    block.BlockNumber,
+			BlockHash:      blockHash,
+		}
+
+		if event.Phase == "ApplyExtrinsic" && event.ExtrinsicIndex < uint64(len(block.Extrinsics)) {
+			extrinsic := block.Extrinsics[event.ExtrinsicIndex]
+			protoEvent.ExtrinsicHash = extrinsic.Hash
+			if event.Pallet == "System" && event.Name == "ExtrinsicSuccess" {
+				extrinsic.Success = true
+			}
+			extrinsic.Events = append(extrinsic.Events, protoEvent)
+			continue
+		}
+
+		block.Events = append(block.Events, protoEvent)
+	}
+
+	for _, extrinsic := range block.Extrinsics {
+		extrinsic.BlockTimestamp = block.Timestamp
+	}
+
+	return block, nil
+}
@@ -0,0 +1,719 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.6.1
+// source: blockchain/substrate/substrate_index_types.proto
+
+package substrate
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a single pallet event from System.Events storage
+type SubstrateEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EventIndex     uint64   `protobuf:"varint,1,opt,name=event_index,json=eventIndex,proto3" json:"event_index,omitempty"`             // The index of the event in the block
+	Phase          string   `protobuf:"bytes,2,opt,name=phase,proto3" json:"phase,omitempty"`                                          // ApplyExtrinsic, Finalization or Initialization
+	ExtrinsicIndex uint64   `protobuf:"varint,3,opt,name=extrinsic_index,json=extrinsicIndex,proto3" json:"extrinsic_index,omitempty"` // For ApplyExtrinsic phase
+	Pallet         string   `protobuf:"bytes,4,opt,name=pallet,proto3" json:"pallet,omitempty"`
+	Name           string   `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	Args           string   `protobuf:"bytes,6,opt,name=args,proto3" json:"args,omitempty"` // JSON encoded event fields decoded with runtime metadata
+	Topics         []string `protobuf:"bytes,7,rep,name=topics,proto3" json:"topics,omitempty"`
+	ExtrinsicHash  string   `protobuf:"bytes,8,opt,name=extrinsic_hash,json=extrinsicHash,proto3" json:"extrinsic_hash,omitempty"` // Empty for Finalization and Initialization phases
+	BlockNumber    uint64   `protobuf:"varint,9,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash      string   `protobuf:"bytes,10,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+}
+
+func (x *SubstrateEvent) Reset() {
+	*x = SubstrateEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_substrate_substrate_index_types_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubstrateEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubstrateEvent) ProtoMessage() {}
+
+func (x *SubstrateEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_substrate_substrate_index_types_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubstrateEvent.ProtoReflect.Descriptor instead.
+func (*SubstrateEvent) Descriptor() ([]byte, []int) {
+	return file_blockchain_substrate_substrate_index_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SubstrateEvent) GetEventIndex() uint64 {
+	if x != nil {
+		return x.EventIndex
+	}
+	return 0
+}
+
+func (x *SubstrateEvent) GetPhase() string {
+	if x != nil {
+		return x.Phase
+	}
+	return ""
+}
+
+func (x *SubstrateEvent) GetExtrinsicIndex() uint64 {
+	if x != nil {
+		return x.ExtrinsicIndex
+	}
+	return 0
+}
+
+func (x *SubstrateEvent) GetPallet() string {
+	if x != nil {
+		return x.Pallet
+	}
+	return ""
+}
+
+func (x *SubstrateEvent) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SubstrateEvent) GetArgs() string {
+	if x != nil {
+		return x.Args
+	}
+	return ""
+}
+
+func (x *SubstrateEvent) GetTopics() []string {
+	if x != nil {
+		return x.Topics
+	}
+	return nil
+}
+
+func (x *SubstrateEvent) GetExtrinsicHash() string {
+	if x != nil {
+		return x.ExtrinsicHash
+	}
+	return ""
+}
+
+func (x *SubstrateEvent) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *SubstrateEvent) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+// Represents a single extrinsic within the block
+type SubstrateExtrinsic struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash             string            `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	ExtrinsicIndex   uint64            `protobuf:"varint,2,opt,name=extrinsic_index,json=extrinsicIndex,proto3" json:"extrinsic_index,omitempty"`
+	Signed           bool              `protobuf:"varint,3,opt,name=signed,proto3" json:"signed,omitempty"`
+	Signer           string            `protobuf:"bytes,4,opt,name=signer,proto3" json:"signer,omitempty"`       // Account id of signer, 0x-prefixed hex
+	Signature        string            `protobuf:"bytes,5,opt,name=signature,proto3" json:"signature,omitempty"` // JSON encoded signature
+	Nonce            uint64            `protobuf:"varint,6,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	Tip              string            `protobuf:"bytes,7,opt,name=tip,proto3" json:"tip,omitempty"`
+	SignedExtensions string            `protobuf:"bytes,8,opt,name=signed_extensions,json=signedExtensions,proto3" json:"signed_extensions,omitempty"` // JSON encoded signed extensions
+	Pallet           string            `protobuf:"bytes,9,opt,name=pallet,proto3" json:"pallet,omitempty"`
+	Call             string            `protobuf:"bytes,10,opt,name=call,proto3" json:"call,omitempty"`
+	Args             string            `protobuf:"bytes,11,opt,name=args,proto3" json:"args,omitempty"`                                  // JSON encoded call arguments decoded with runtime metadata
+	Success          bool              `protobuf:"varint,12,opt,name=success,proto3" json:"success,omitempty"`                           // System.ExtrinsicSuccess event was emitted
+	Raw              string            `protobuf:"bytes,13,opt,name=raw,proto3" json:"raw,omitempty"`                                    // 0x-prefixed hex of encoded extrinsic
+	DecodeError      string            `protobuf:"bytes,14,opt,name=decode_error,json=decodeError,proto3" json:"decode_error,omitempty"` // Set when extrinsic could not be decoded with runtime metadata
+	BlockNumber      uint64            `protobuf:"varint,15,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	BlockHash        string            `protobuf:"bytes,16,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`
+	BlockTimestamp   uint64            `protobuf:"varint,17,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	IndexedAt        uint64            `protobuf:"varint,18,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	Events           []*SubstrateEvent `protobuf:"bytes,19,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *SubstrateExtrinsic) Reset() {
+	*x = SubstrateExtrinsic{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_substrate_substrate_index_types_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubstrateExtrinsic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubstrateExtrinsic) ProtoMessage() {}
+
+func (x *SubstrateExtrinsic) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_substrate_substrate_index_types_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubstrateExtrinsic.ProtoReflect.Descriptor instead.
+func (*SubstrateExtrinsic) Descriptor() ([]byte, []int) {
+	return file_blockchain_substrate_substrate_index_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SubstrateExtrinsic) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetExtrinsicIndex() uint64 {
+	if x != nil {
+		return x.ExtrinsicIndex
+	}
+	return 0
+}
+
+func (x *SubstrateExtrinsic) GetSigned() bool {
+	if x != nil {
+		return x.Signed
+	}
+	return false
+}
+
+func (x *SubstrateExtrinsic) GetSigner() string {
+	if x != nil {
+		return x.Signer
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetNonce() uint64 {
+	if x != nil {
+		return x.Nonce
+	}
+	return 0
+}
+
+func (x *SubstrateExtrinsic) GetTip() string {
+	if x != nil {
+		return x.Tip
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetSignedExtensions() string {
+	if x != nil {
+		return x.SignedExtensions
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetPallet() string {
+	if x != nil {
+		return x.Pallet
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetCall() string {
+	if x != nil {
+		return x.Call
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetArgs() string {
+	if x != nil {
+		return x.Args
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *SubstrateExtrinsic) GetRaw() string {
+	if x != nil {
+		return x.Raw
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetDecodeError() string {
+	if x != nil {
+		return x.DecodeError
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *SubstrateExtrinsic) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *SubstrateExtrinsic) GetBlockTimestamp() uint64 {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return 0
+}
+
+func (x *SubstrateExtrinsic) GetIndexedAt() uint64 {
+	if x != nil {
+		return x.IndexedAt
+	}
+	return 0
+}
+
+func (x *SubstrateExtrinsic) GetEvents() []*SubstrateEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type SubstrateBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	BlockNumber    uint64                `protobuf:"varint,1,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"`
+	Hash           string                `protobuf:"bytes,2,opt,name=hash,proto3" json:"hash,omitempty"`
+	ParentHash     string                `protobuf:"bytes,3,opt,name=parent_hash,json=parentHash,proto3" json:"parent_hash,omitempty"`
+	StateRoot      string                `protobuf:"bytes,4,opt,name=state_root,json=stateRoot,proto3" json:"state_root,omitempty"`
+	ExtrinsicsRoot string                `protobuf:"bytes,5,opt,name=extrinsics_root,json=extrinsicsRoot,proto3" json:"extrinsics_root,omitempty"`
+	Timestamp      uint64                `protobuf:"varint,6,opt,name=timestamp,proto3" json:"timestamp,omitempty"`                        // Seconds, from Timestamp.set inherent
+	SpecVersion    uint32                `protobuf:"varint,7,opt,name=spec_version,json=specVersion,proto3" json:"spec_version,omitempty"` // Runtime version used to decode block
+	Digest         string                `protobuf:"bytes,8,opt,name=digest,proto3" json:"digest,omitempty"`                               // JSON encoded digest logs
+	IndexedAt      uint64                `protobuf:"varint,9,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	Extrinsics     []*SubstrateExtrinsic `protobuf:"bytes,10,rep,name=extrinsics,proto3" json:"extrinsics,omitempty"`
+	Events         []*SubstrateEvent     `protobuf:"bytes,11,rep,name=events,proto3" json:"events,omitempty"` // Events of Initialization and Finalization phases
+}
+
+func (x *SubstrateBlock) Reset() {
+	*x = SubstrateBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_substrate_substrate_index_types_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubstrateBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubstrateBlock) ProtoMessage() {}
+
+func (x *SubstrateBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_substrate_substrate_index_types_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubstrateBlock.ProtoReflect.Descriptor instead.
+func (*SubstrateBlock) Descriptor() ([]byte, []int) {
+	return file_blockchain_substrate_substrate_index_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SubstrateBlock) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *SubstrateBlock) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *SubstrateBlock) GetParentHash() string {
+	if x != nil {
+		return x.ParentHash
+	}
+	return ""
+}
+
+func (x *SubstrateBlock) GetStateRoot() string {
+	if x != nil {
+		return x.StateRoot
+	}
+	return ""
+}
+
+func (x *SubstrateBlock) GetExtrinsicsRoot() string {
+	if x != nil {
+		return x.ExtrinsicsRoot
+	}
+	return ""
+}
+
+func (x *SubstrateBlock) GetTimestamp() uint64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *SubstrateBlock) GetSpecVersion() uint32 {
+	if x != nil {
+		return x.SpecVersion
+	}
+	return 0
+}
+
+func (x *SubstrateBlock) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *SubstrateBlock) GetIndexedAt() uint64 {
+	if x != nil {
+		return x.IndexedAt
+	}
+	return 0
+}
+
+func (x *SubstrateBlock) GetExtrinsics() []*SubstrateExtrinsic {
+	if x != nil {
+		return x.Extrinsics
+	}
+	return nil
+}
+
+func (x *SubstrateBlock) GetEvents() []*SubstrateEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type SubstrateBlocksBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blocks      []*SubstrateBlock `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	SeerVersion string            `protobuf:"bytes,2,opt,name=seer_version,json=seerVersion,proto3" json:"seer_version,omitempty"`
+}
+
+func (x *SubstrateBlocksBatch) Reset() {
+	*x = SubstrateBlocksBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_substrate_substrate_index_types_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubstrateBlocksBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubstrateBlocksBatch) ProtoMessage() {}
+
+func (x *SubstrateBlocksBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_substrate_substrate_index_types_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubstrateBlocksBatch.ProtoReflect.Descriptor instead.
+func (*SubstrateBlocksBatch) Descriptor() ([]byte, []int) {
+	return file_blockchain_substrate_substrate_index_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SubstrateBlocksBatch) GetBlocks() []*SubstrateBlock {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *SubstrateBlocksBatch) GetSeerVersion() string {
+	if x != nil {
+		return x.SeerVersion
+	}
+	return ""
+}
+
+var File_blockchain_substrate_substrate_index_types_proto protoreflect.FileDescriptor
+
+var file_blockchain_substrate_substrate_index_types_proto_rawDesc = []byte{
+	0x0a, 0x30, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x73, 0x75, 0x62,
+	0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x22, 0xb1, 0x02, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65,
+	0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x65, 0x76, 0x65, 0x6e,
+	0x74, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x70, 0x68, 0x61, 0x73, 0x65, 0x12, 0x27, 0x0a, 0x0f,
+	0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x18,
+	0x07, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x74, 0x6f, 0x70, 0x69, 0x63, 0x73, 0x12, 0x25, 0x0a,
+	0x0e, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63,
+	0x48, 0x61, 0x73, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75,
+	0x6d, 0x62, 0x65, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x6f,
+	0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x22, 0xb6, 0x04, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x73, 0x74,
+	0x72, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x12, 0x12, 0x0a,
+	0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73,
+	0x68, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x5f, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x65, 0x78, 0x74, 0x72,
+	0x69, 0x6e, 0x73, 0x69, 0x63, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69,
+	0x67, 0x6e, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e,
+	0x65, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x72, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x6e, 0x6f, 0x6e, 0x63,
+	0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x12, 0x10,
+	0x0a, 0x03, 0x74, 0x69, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x74, 0x69, 0x70,
+	0x12, 0x2b, 0x0a, 0x11, 0x73, 0x69, 0x67, 0x6e, 0x65, 0x64, 0x5f, 0x65, 0x78, 0x74, 0x65, 0x6e,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x73, 0x69, 0x67,
+	0x6e, 0x65, 0x64, 0x45, 0x78, 0x74, 0x65, 0x6e, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x16, 0x0a,
+	0x06, 0x70, 0x61, 0x6c, 0x6c, 0x65, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70,
+	0x61, 0x6c, 0x6c, 0x65, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x61, 0x6c, 0x6c, 0x18, 0x0a, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x63, 0x61, 0x6c, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x61, 0x72, 0x67,
+	0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x61, 0x72, 0x67, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x72, 0x61, 0x77, 0x18, 0x0d,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x72, 0x61, 0x77, 0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x63,
+	0x6f, 0x64, 0x65, 0x5f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x64, 0x65, 0x63, 0x6f, 0x64, 0x65, 0x45, 0x72, 0x72, 0x6f, 0x72, 0x12, 0x21, 0x0a, 0x0c,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12,
+	0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x10, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x27,
+	0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d,
+	0x70, 0x18, 0x11, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x65, 0x64, 0x41, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x18, 0x13, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22,
+	0x86, 0x03, 0x0a, 0x0e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f,
+	0x63, 0x6b, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d, 0x62,
+	0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x4e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x61, 0x72,
+	0x65, 0x6e, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x70, 0x61, 0x72, 0x65, 0x6e, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1d, 0x0a, 0x0a, 0x73, 0x74,
+	0x61, 0x74, 0x65, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
+	0x73, 0x74, 0x61, 0x74, 0x65, 0x52, 0x6f, 0x6f, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x65, 0x78, 0x74,
+	0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x5f, 0x72, 0x6f, 0x6f, 0x74, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x0e, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x52, 0x6f,
+	0x6f, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70,
+	0x12, 0x21, 0x0a, 0x0c, 0x73, 0x70, 0x65, 0x63, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0b, 0x73, 0x70, 0x65, 0x63, 0x56, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x41, 0x74, 0x12, 0x33, 0x0a, 0x0a, 0x65, 0x78,
+	0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13,
+	0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x45, 0x78, 0x74, 0x72, 0x69, 0x6e,
+	0x73, 0x69, 0x63, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x72, 0x69, 0x6e, 0x73, 0x69, 0x63, 0x73, 0x12,
+	0x27, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x62, 0x0a, 0x14, 0x53, 0x75, 0x62, 0x73,
+	0x74, 0x72, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68,
+	0x12, 0x27, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0f, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61, 0x74, 0x65, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x65,
+	0x72, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0b, 0x73, 0x65, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x34, 0x5a, 0x32,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x6f, 0x6f, 0x6e, 0x73,
+	0x74, 0x72, 0x65, 0x61, 0x6d, 0x2d, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x65, 0x72, 0x2f, 0x62, 0x6c,
+	0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x73, 0x75, 0x62, 0x73, 0x74, 0x72, 0x61,
+	0x74, 0x65, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_blockchain_substrate_substrate_index_types_proto_rawDescOnce sync.Once
+	file_blockchain_substrate_substrate_index_types_proto_rawDescData = file_blockchain_substrate_substrate_index_types_proto_rawDesc
+)
+
+func file_blockchain_substrate_substrate_index_types_proto_rawDescGZIP() []byte {
+	file_blockchain_substrate_substrate_index_types_proto_rawDescOnce.Do(func() {
+		file_blockchain_substrate_substrate_index_types_proto_rawDescData = protoimpl.X.CompressGZIP(file_blockchain_substrate_substrate_index_types_proto_rawDescData)
+	})
+	return file_blockchain_substrate_substrate_index_types_proto_rawDescData
+}
+
+var file_blockchain_substrate_substrate_index_types_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_blockchain_substrate_substrate_index_types_proto_goTypes = []any{
+	(*SubstrateEvent)(nil),       // 0: SubstrateEvent
+	(*SubstrateExtrinsic)(nil),   // 1: SubstrateExtrinsic
+	(*SubstrateBlock)(nil),       // 2: SubstrateBlock
+	(*SubstrateBlocksBatch)(nil), // 3: SubstrateBlocksBatch
+}
+var file_blockchain_substrate_substrate_index_types_proto_depIdxs = []int32{
+	0, // 0: SubstrateExtrinsic.events:type_name -> SubstrateEvent
+	1, // 1: SubstrateBlock.extrinsics:type_name -> SubstrateExtrinsic
+	0, // 2: SubstrateBlock.events:type_name -> SubstrateEvent
+	2, // 3: SubstrateBlocksBatch.blocks:type_name -> SubstrateBlock
+	4, // [4:4] is the sub-list for method output_type
+	4, // [4:4] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_blockchain_substrate_substrate_index_types_proto_init() }
+func file_blockchain_substrate_substrate_index_types_proto_init() {
+	if File_blockchain_substrate_substrate_index_types_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_blockchain_substrate_substrate_index_types_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SubstrateEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_substrate_substrate_index_types_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*SubstrateExtrinsic); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_substrate_substrate_index_types_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*SubstrateBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_substrate_substrate_index_types_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*SubstrateBlocksBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_blockchain_substrate_substrate_index_types_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_blockchain_substrate_substrate_index_types_proto_goTypes,
+		DependencyIndexes: file_blockchain_substrate_substrate_index_types_proto_depIdxs,
+		MessageInfos:      file_blockchain_substrate_substrate_index_types_proto_msgTypes,
+	}.Build()
+	File_blockchain_substrate_substrate_index_types_proto = out.File
+	file_blockchain_substrate_substrate_index_types_proto_rawDesc = nil
+	file_blockchain_substrate_substrate_index_types_proto_goTypes = nil
+	file_blockchain_substrate_substrate_index_types_proto_depIdxs = nil
+}
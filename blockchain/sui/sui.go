@@ -0,0 +1,796 @@
+package sui
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/version"
+)
+
+const (
+	// ObjectChangesIndexKind is kind of custom index with object changes, stored in sui_object_changes table
+	ObjectChangesIndexKind = "object_changes"
+
+	// multiGetTransactionBlocksLimit is maximum amount of digests in sui_multiGetTransactionBlocks request
+	multiGetTransactionBlocksLimit = 50
+)
+
+func init() {
+	seer_common.RegisterHasher("sui", seer_common.MoveHasher{})
+
+	indexer.RegisterCustomIndexTable("sui", indexer.CustomIndexTable{
+		Kind: ObjectChangesIndexKind,
+		Columns: []indexer.CustomIndexColumn{
+			{Name: "transaction_hash", Type: "TEXT"},
+			{Name: "block_number", Type: "BIGINT"},
+			{Name: "block_hash", Type: "TEXT"},
+			{Name: "change_index", Type: "BIGINT"},
+			{Name: "change_type", Type: "TEXT"},
+			{Name: "object_id", Type: "TEXT"},
+			{Name: "object_type", Type: "TEXT"},
+			{Name: "owner", Type: "TEXT"},
+			{Name: "version", Type: "BIGINT"},
+		},
+		ConflictClause: "ON CONFLICT (transaction_hash, change_index) DO NOTHING",
+	})
+}
+
+func NewClient(url string, timeout int) (*Client, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeout)*time.Second)
+	defer cancel()
+
+	rpcClient, err := seer_common.DialRPC(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{rpcClient: rpcClient, hasher: seer_common.GetHasher("sui")}, nil
+}
+
+// Client is a wrapper around the Sui fullnode JSON-RPC client. Checkpoints are used as blocks,
+// block number is checkpoint sequence number and block hash is checkpoint digest.
+type Client struct {
+	rpcClient *seer_common.RPCClient
+	hasher    seer_common.Hasher
+}
+
+// Client common
+
+// ChainType returns the chain type.
+func (c *Client) ChainType() string {
+	return "sui"
+}
+
+// Close closes the underlying RPC client.
+func (c *Client) Close() {
+	c.rpcClient.Close()
+}
+
+// GetLatestBlockNumber returns the latest checkpoint sequence number.
+func (c *Client) GetLatestBlockNumber(ctx context.Context) (*big.Int, error) {
+	var result string
+	if err := c.rpcClient.CallContext(ctx, &result, "sui_getLatestCheckpointSequenceNumber"); err != nil {
+		return nil, err
+	}
+
+	sequenceNumber, err := strconv.ParseUint(result, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse checkpoint sequence number %q: %v", result, err)
+	}
+
+	return new(big.Int).SetUint64(sequenceNumber), nil
+}
+
+// GetCheckpoint returns checkpoint with transaction blocks, including events and object changes.
+func (c *Client) GetCheckpoint(ctx context.Context, sequenceNumber uint64) (*CheckpointJson, error) {
+	var checkpoint CheckpointJson
+	if err := c.rpcClient.CallContext(ctx, &checkpoint, "sui_getCheckpoint", strconv.FormatUint(sequenceNumber, 10)); err != nil {
+		return nil, err
+	}
+
+	options := TransactionBlockResponseOptions{
+		ShowInput:          true,
+		ShowEffects:        true,
+		ShowEvents:         true,
+		ShowObjectChanges:  true,
+		ShowBalanceChanges: true,
+	}
+
+	for start := 0; start < len(checkpoint.Transactions); start += multiGetTransactionBlocksLimit {
+		end := start + multiGetTransactionBlocksLimit
+		if end > len(checkpoint.Transactions) {
+			end = len(checkpoint.Transactions)
+		}
+
+		var transactionBlocks []TransactionBlockJson
+		if err := c.rpcClient.CallContext(ctx, &transactionBlocks, "sui_multiGetTransactionBlocks", checkpoint.Transactions[start:end], options); err != nil {
+			return nil, err
+		}
+		if len(transactionBlocks) != end-start {
+			return nil, fmt.Errorf("expected %d transaction blocks for checkpoint %d, got %d", end-start, sequenceNumber, len(transactionBlocks))
+		}
+
+		checkpoint.TransactionBlocks = append(checkpoint.TransactionBlocks, transactionBlocks...)
+	}
+
+	return &checkpoint, nil
+}
+
+// FetchBlocksInRange fetches checkpoints within a specified range, with up to maxRequests concurrent requests.
+// Checkpoints are returned in ascending order.
+func (c *Client) FetchBlocksInRange(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]*CheckpointJson, error) {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	fromNumber := from.Uint64()
+	toNumber := to.Uint64()
+	if toNumber < fromNumber {
+		return nil, nil
+	}
+
+	checkpoints := make([]*CheckpointJson, toNumber-fromNumber+1)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	sem := make(chan struct{}, maxRequests)
+
+	for number := fromNumber; number <= toNumber; number++ {
+		wg.Add(1)
+		go func(number uint64) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			checkpoint, err := c.GetCheckpoint(ctx, number)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			checkpoints[number-fromNumber] = checkpoint
+
+			if debug {
+				fmt.Printf("Fetched checkpoint: %d\n", number)
+			}
+		}(number)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return checkpoints, nil
+}
+
+// FetchAsProtoBlocksWithEvents fetches checkpoints with transaction blocks and events. Transaction
+// blocks are indexed by first Move call and events by Move type tag, addresses are in 32 bytes form.
+func (c *Client) FetchAsProtoBlocksWithEvents(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error) {
+	checkpointsJson, err := c.FetchBlocksInRange(ctx, from, to, debug, maxRequests)
+	if err != nil {
+		return nil, nil, nil, nil, 0, err
+	}
+
+	var blocksSize uint64
+	var blocksProto []proto.Message
+	var blocksIndex []indexer.BlockIndex
+	var txsIndex []indexer.TransactionIndex
+	var eventsIndex []indexer.LogIndex
+
+	for bI, checkpointJson := range checkpointsJson {
+		checkpoint, convErr := ToProtoSingleBlock(checkpointJson)
+		if convErr != nil {
+			return nil, nil, nil, nil, 0, convErr
+		}
+
+		timestamp := checkpoint.TimestampMs / 1000
+
+		for _, tx := range checkpoint.Transactions {
+			var packageAddress, selector string
+			if len(tx.MoveCalls) > 0 {
+				packageAddress = c.hasher.NormalizeAddress(tx.MoveCalls[0].Package)
+				selector = c.hasher.MethodSelector(MoveCallFunctionId(tx.MoveCalls[0]))
+			}
+
+			var sender string
+			if tx.Sender != "" {
+				sender = c.hasher.NormalizeAddress(tx.Sender)
+			}
+
+			txsIndex = append(txsIndex, indexer.TransactionIndex{
+				BlockNumber:      tx.Checkpoint,
+				BlockHash:        tx.CheckpointDigest,
+				BlockTimestamp:   timestamp,
+				FromAddress:      sender,
+				ToAddress:        packageAddress,
+				RowID:            tx.TransactionIndex,
+				Selector:         selector, // Move function id of the first Move call
+				TransactionHash:  tx.Digest,
+				TransactionIndex: tx.TransactionIndex,
+				Type:             TransactionKindCode(tx.Kind),
+				Path:             "",
+			})
+
+			for _, event := range tx.Events {
+				eventType := c.hasher.EventTopic(event.Type)
+				eventsIndex = append(eventsIndex, indexer.LogIndex{
+					Address:         c.hasher.NormalizeAddress(event.TypeAddress),
+					BlockNumber:     event.Checkpoint,
+					BlockHash:       event.CheckpointDigest,
+					BlockTimestamp:  timestamp,
+					TransactionHash: event.TransactionDigest,
+					Selector:        &eventType, // Move type tag instead of topic0
+					RowID:           event.EventSeq,
+					LogIndex:        event.EventSeq,
+					Path:            "",
+				})
+			}
+		}
+
+		blocksIndex = append(blocksIndex, indexer.NewBlockIndex("sui",
+			checkpoint.SequenceNumber,
+			checkpoint.Digest,
+			timestamp,
+			checkpoint.PreviousDigest,
+			uint64(bI),
+			"",
+			0,
+		))
+
+		blocksSize += uint64(proto.Size(checkpoint))
+		blocksProto = append(blocksProto, checkpoint)
+	}
+
+	return blocksProto, blocksIndex, txsIndex, eventsIndex, blocksSize, nil
+}
+
+// CustomIndexesFromProtoBlocks returns object changes of transaction blocks.
+func (c *Client) CustomIndexesFromProtoBlocks(msgs []proto.Message) ([]indexer.CustomIndex, error) {
+	var customIndexes []indexer.CustomIndex
+	for _, msg := range msgs {
+		checkpoint, ok := msg.(*SuiCheckpoint)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *SuiCheckpoint")
+		}
+
+		for _, tx := range checkpoint.Transactions {
+			for _, change := range tx.ObjectChanges {
+				customIndexes = append(customIndexes, indexer.CustomIndex{
+					Kind: ObjectChangesIndexKind,
+					Values: map[string]interface{}{
+						"transaction_hash": tx.Digest,
+						"block_number":     tx.Checkpoint,
+						"block_hash":       tx.CheckpointDigest,
+						"change_index":     change.ChangeIndex,
+						"change_type":      change.Type,
+						"object_id":        change.ObjectId,
+						"object_type":      change.ObjectType,
+						"owner":            change.Owner,
+						"version":          change.Version,
+					},
+				})
+			}
+		}
+	}
+
+	return customIndexes, nil
+}
+
+func (c *Client) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
+	var checkpoints []*SuiCheckpoint
+	for _, msg := range msgs {
+		checkpoint, ok := msg.(*SuiCheckpoint)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *SuiCheckpoint")
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	return &SuiBlocksBatch{
+		Blocks:      checkpoints,
+		SeerVersion: version.SeerVersion,
+	}, nil
+}
+
+func (c *Client) decodeBlocksBatch(rawData *bytes.Buffer) (*SuiBlocksBatch, error) {
+	var protoBlocksBatch SuiBlocksBatch
+
+	if err := proto.Unmarshal(rawData.Bytes(), &protoBlocksBatch); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	return &protoBlocksBatch, nil
+}
+
+// DecodeProtoEntireBlockToJson returns checkpoints, transaction blocks and events in EVM oriented
+// JSON structure, event type tag is placed as the first topic.
+func (c *Client) DecodeProtoEntireBlockToJson(rawData *bytes.Buffer) (*seer_common.BlocksBatchJson, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksBatchJson := seer_common.BlocksBatchJson{
+		Blocks:      []seer_common.BlockJson{},
+		SeerVersion: protoBlocksBatch.SeerVersion,
+	}
+
+	for _, b := range protoBlocksBatch.Blocks {
+		var txs []seer_common.TransactionJson
+		for _, tx := range b.Transactions {
+			var events []seer_common.EventJson
+			for _, e := range tx.Events {
+				events = append(events, seer_common.EventJson{
+					Address:          e.TypeAddress,
+					Topics:           []string{e.Type},
+					Data:             e.ParsedJson,
+					BlockNumber:      fmt.Sprintf("%d", e.Checkpoint),
+					TransactionHash:  e.TransactionDigest,
+					BlockHash:        e.CheckpointDigest,
+					LogIndex:         fmt.Sprintf("%d", e.EventSeq),
+					TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+				})
+			}
+
+			var toAddress string
+			if len(tx.MoveCalls) > 0 {
+				toAddress = MoveCallFunctionId(tx.MoveCalls[0])
+			}
+
+			txs = append(txs, seer_common.TransactionJson{
+				BlockHash:        tx.CheckpointDigest,
+				BlockNumber:      fmt.Sprintf("%d", tx.Checkpoint),
+				FromAddress:      tx.Sender,
+				Gas:              fmt.Sprintf("%d", tx.GasBudget),
+				GasPrice:         fmt.Sprintf("%d", tx.GasPrice),
+				Hash:             tx.Digest,
+				Input:            tx.Commands,
+				ToAddress:        toAddress,
+				TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+				TransactionType:  fmt.Sprintf("%d", TransactionKindCode(tx.Kind)),
+				IndexedAt:        fmt.Sprintf("%d", tx.IndexedAt),
+				BlockTimestamp:   fmt.Sprintf("%d", tx.TimestampMs/1000),
+
+				Events: events,
+			})
+		}
+
+		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
+			Hash:         b.Digest,
+			BlockNumber:  fmt.Sprintf("%d", b.SequenceNumber),
+			ParentHash:   b.PreviousDigest,
+			Timestamp:    fmt.Sprintf("%d", b.TimestampMs/1000),
+			IndexedAt:    fmt.Sprintf("%d", b.IndexedAt),
+			Transactions: txs,
+		})
+	}
+
+	return &blocksBatchJson, nil
+}
+
+// DecodeProtoEntireBlockToProtoJson returns batch with all fields, including object changes.
+func (c *Client) DecodeProtoEntireBlockToProtoJson(rawData *bytes.Buffer) ([]byte, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	return protojson.Marshal(protoBlocksBatch)
+}
+
+// DecodeProtoEntireBlockToLabels converts Move calls and events matched with ABI jobs to labels.
+// Fullnode returns event data already decoded to JSON, so ABI is only used to match package
+// with function id or event type tag.
+func (c *Client) DecodeProtoEntireBlockToLabels(ctx context.Context, rawData *bytes.Buffer, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var labels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
+
+	for _, b := range protoBlocksBatch.Blocks {
+		for _, tx := range b.Transactions {
+			moveCallLabels, labelsErr := c.moveCallsToLabels(tx, abiMap)
+			if labelsErr != nil {
+				return nil, nil, labelsErr
+			}
+			txLabels = append(txLabels, moveCallLabels...)
+
+			for _, e := range tx.Events {
+				typeAddress := c.hasher.NormalizeAddress(e.TypeAddress)
+				eventType := c.hasher.EventTopic(e.Type)
+
+				if abiMap[typeAddress] == nil || abiMap[typeAddress][eventType] == nil {
+					continue
+				}
+
+				var eventData interface{}
+				label := indexer.SeerCrawlerLabel
+				if unmarshalErr := json.Unmarshal([]byte(e.ParsedJson), &eventData); unmarshalErr != nil {
+					eventData = e.ParsedJson
+					label = indexer.SeerCrawlerRawLabel
+				}
+
+				labelDataBytes, err := json.Marshal(map[string]interface{}{
+					"type": "event",
+					"name": e.TypeName,
+					"args": eventData,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+
+				labels = append(labels, indexer.EventLabel{
+					Label:           label,
+					LabelName:       abiMap[typeAddress][eventType]["abi_name"],
+					LabelType:       "event",
+					BlockNumber:     e.Checkpoint,
+					BlockHash:       e.CheckpointDigest,
+					Address:         typeAddress,
+					OriginAddress:   tx.Sender,
+					TransactionHash: e.TransactionDigest,
+					LabelData:       string(labelDataBytes),
+					BlockTimestamp:  e.TimestampMs / 1000,
+					LogIndex:        e.EventSeq,
+				})
+			}
+		}
+	}
+
+	return labels, txLabels, nil
+}
+
+func (c *Client) moveCallsToLabels(tx *SuiTransaction, abiMap map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error) {
+	var labels []indexer.TransactionLabel
+
+	for _, moveCall := range tx.MoveCalls {
+		packageAddress := c.hasher.NormalizeAddress(moveCall.Package)
+		selector := c.hasher.MethodSelector(MoveCallFunctionId(moveCall))
+
+		if abiMap[packageAddress] == nil || abiMap[packageAddress][selector] == nil {
+			continue
+		}
+
+		label := indexer.SeerCrawlerLabel
+
+		var arguments interface{}
+		if err := json.Unmarshal([]byte(moveCall.Arguments), &arguments); err != nil {
+			arguments = moveCall.Arguments
+			label = indexer.SeerCrawlerRawLabel
+		}
+
+		labelDataBytes, err := json.Marshal(map[string]interface{}{
+			"type":          "tx_call",
+			"name":          moveCall.Function,
+			"command_index": moveCall.CommandIndex,
+			"status":        tx.Status,
+			"args": map[string]interface{}{
+				"type_arguments": moveCall.TypeArguments,
+				"arguments":      arguments,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, indexer.TransactionLabel{
+			Address:         packageAddress,
+			BlockNumber:     tx.Checkpoint,
+			BlockHash:       tx.CheckpointDigest,
+			CallerAddress:   tx.Sender,
+			LabelName:       abiMap[packageAddress][selector]["abi_name"],
+			LabelType:       "tx_call",
+			OriginAddress:   tx.Sender,
+			Label:           label,
+			TransactionHash: tx.Digest,
+			LabelData:       string(labelDataBytes),
+			BlockTimestamp:  tx.TimestampMs / 1000,
+		})
+	}
+
+	return labels, nil
+}
+
+func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error) {
+	var labels []indexer.TransactionLabel
+
+	for _, data := range transactions {
+		var tx SuiTransaction
+		if err := proto.Unmarshal([]byte(data), &tx); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal transaction: %v", err)
+		}
+
+		moveCallLabels, err := c.moveCallsToLabels(&tx, abiMap)
+		if err != nil {
+			return nil, err
+		}
+		labels = append(labels, moveCallLabels...)
+	}
+
+	return labels, nil
+}
+
+// MoveCallFunctionId returns fully qualified function id of Move call, e.g. 0x2::coin::join.
+func MoveCallFunctionId(moveCall *SuiMoveCall) string {
+	return moveCall.Package + "::" + moveCall.Module + "::" + moveCall.Function
+}
+
+// TransactionKindCode maps transaction kind to number stored in transactions index.
+func TransactionKindCode(kind string) uint64 {
+	switch kind {
+	case "ProgrammableTransaction":
+		return 0
+	case "ConsensusCommitPrologue", "ConsensusCommitPrologueV2", "ConsensusCommitPrologueV3":
+		return 1
+	case "ChangeEpoch", "EndOfEpochTransaction":
+		return 2
+	case "Genesis":
+		return 3
+	case "AuthenticatorStateUpdate":
+		return 4
+	case "RandomnessStateUpdate":
+		return 5
+	default:
+		return 255
+	}
+}
+
+// Fullnode JSON-RPC structures, u64 values are returned as strings
+
+type TransactionBlockResponseOptions struct {
+	ShowInput          bool `json:"showInput"`
+	ShowEffects        bool `json:"showEffects"`
+	ShowEvents         bool `json:"showEvents"`
+	ShowObjectChanges  bool `json:"showObjectChanges"`
+	ShowBalanceChanges bool `json:"showBalanceChanges"`
+}
+
+type CheckpointJson struct {
+	Epoch                    uint64   `json:"epoch,string"`
+	SequenceNumber           uint64   `json:"sequenceNumber,string"`
+	Digest                   string   `json:"digest"`
+	NetworkTotalTransactions uint64   `json:"networkTotalTransactions,string"`
+	PreviousDigest           string   `json:"previousDigest"`
+	TimestampMs              uint64   `json:"timestampMs,string"`
+	ValidatorSignature       string   `json:"validatorSignature"`
+	Transactions             []string `json:"transactions"`
+
+	TransactionBlocks []TransactionBlockJson `json:"-"`
+}
+
+type EventIdJson struct {
+	TxDigest string `json:"txDigest"`
+	EventSeq uint64 `json:"eventSeq,string"`
+}
+
+type EventJson struct {
+	Id                EventIdJson     `json:"id"`
+	PackageId         string          `json:"packageId"`
+	TransactionModule string          `json:"transactionModule"`
+	Sender            string          `json:"sender"`
+	Type              string          `json:"type"`
+	ParsedJson        json.RawMessage `json:"parsedJson"`
+	Bcs               string          `json:"bcs"`
+}
+
+type ObjectChangeJson struct {
+	Type            string          `json:"type"`
+	Sender          string          `json:"sender"`
+	Owner           json.RawMessage `json:"owner"`
+	ObjectType      string          `json:"objectType"`
+	ObjectId        string          `json:"objectId"`
+	PackageId       string          `json:"packageId"`
+	Modules         []string        `json:"modules"`
+	Version         uint64          `json:"version,string"`
+	PreviousVersion uint64          `json:"previousVersion,string"`
+	Digest          string          `json:"digest"`
+}
+
+type MoveCallJson struct {
+	Package       string          `json:"package"`
+	Module        string          `json:"module"`
+	Function      string          `json:"function"`
+	TypeArguments []string        `json:"type_arguments"`
+	Arguments     json.RawMessage `json:"arguments"`
+}
+
+type TransactionKindJson struct {
+	Kind         string                       `json:"kind"`
+	Inputs       json.RawMessage              `json:"inputs"`
+	Transactions []map[string]json.RawMessage `json:"transactions"`
+}
+
+type GasDataJson struct {
+	Owner  string `json:"owner"`
+	Price  uint64 `json:"price,string"`
+	Budget uint64 `json:"budget,string"`
+}
+
+type TransactionDataJson struct {
+	Sender      string              `json:"sender"`
+	GasData     GasDataJson         `json:"gasData"`
+	Transaction TransactionKindJson `json:"transaction"`
+}
+
+type SenderSignedDataJson struct {
+	Data         TransactionDataJson `json:"data"`
+	TxSignatures []string            `json:"txSignatures"`
+}
+
+type GasCostSummaryJson struct {
+	ComputationCost         uint64 `json:"computationCost,string"`
+	StorageCost             uint64 `json:"storageCost,string"`
+	StorageRebate           uint64 `json:"storageRebate,string"`
+	NonRefundableStorageFee uint64 `json:"nonRefundableStorageFee,string"`
+}
+
+type ExecutionStatusJson struct {
+	Status string `json:"status"`
+	Error  string `json:"error"`
+}
+
+type TransactionEffectsJson struct {
+	Status  ExecutionStatusJson `json:"status"`
+	GasUsed GasCostSummaryJson  `json:"gasUsed"`
+}
+
+type TransactionBlockJson struct {
+	Digest         string                  `json:"digest"`
+	Transaction    *SenderSignedDataJson   `json:"transaction"`
+	Effects        *TransactionEffectsJson `json:"effects"`
+	Events         []EventJson             `json:"events"`
+	ObjectChanges  []ObjectChangeJson      `json:"objectChanges"`
+	BalanceChanges json.RawMessage         `json:"balanceChanges"`
+	TimestampMs    uint64                  `json:"timestampMs,string"`
+	Checkpoint     uint64                  `json:"checkpoint,string"`
+}
+
+func rawJsonToString(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	return string(raw)
+}
+
+func ToProtoSingleBlock(obj *CheckpointJson) (*SuiCheckpoint, error) {
+	indexedAt := uint64(time.Now().Unix())
+
+	checkpoint := &SuiCheckpoint{
+		SequenceNumber:           obj.SequenceNumber,
+		Digest:                   obj.Digest,
+		PreviousDigest:           obj.PreviousDigest,
+		Epoch:                    obj.Epoch,
+		TimestampMs:              obj.TimestampMs,
+		NetworkTotalTransactions: obj.NetworkTotalTransactions,
+		ValidatorSignature:       obj.ValidatorSignature,
+		IndexedAt:                indexedAt,
+	}
+
+	for txI, txJson := range obj.TransactionBlocks {
+		tx := &SuiTransaction{
+			Digest:           txJson.Digest,
+			BalanceChanges:   rawJsonToString(txJson.BalanceChanges),
+			Checkpoint:       obj.SequenceNumber,
+			CheckpointDigest: obj.Digest,
+			TimestampMs:      obj.TimestampMs,
+			TransactionIndex: uint64(txI),
+			IndexedAt:        indexedAt,
+		}
+
+		if txJson.Transaction != nil {
+			data := txJson.Transaction.Data
+			tx.Kind = data.Transaction.Kind
+			tx.Sender = data.Sender
+			tx.GasPrice = data.GasData.Price
+			tx.GasBudget = data.GasData.Budget
+			tx.GasOwner = data.GasData.Owner
+			tx.Inputs = rawJsonToString(data.Transaction.Inputs)
+			tx.Signatures = txJson.Transaction.TxSignatures
+
+			if len(data.Transaction.Transactions) > 0 {
+				commands, err := json.Marshal(data.Transaction.Transactions)
+				if err != nil {
+					return nil, err
+				}
+				tx.Commands = string(commands)
+			}
+
+			for commandIndex, command := range data.Transaction.Transactions {
+				rawMoveCall, ok := command["MoveCall"]
+				if !ok {
+					continue
+				}
+
+				var moveCall MoveCallJson
+				if err := json.Unmarshal(rawMoveCall, &moveCall); err != nil {
+					return nil, fmt.Errorf("failed to decode Move call of transaction %s: %v", txJson.Digest, err)
+				}
+
+				tx.MoveCalls = append(tx.MoveCalls, &SuiMoveCall{
+					Package:       moveCall.Package,
+					Module:        moveCall.Module,
+					Function:      moveCall.Function,
+					TypeArguments: moveCall.TypeArguments,
+					Arguments:     rawJsonToString(moveCall.Arguments),
+					CommandIndex:  uint64(commandIndex),
+				})
+			}
+		}
+
+		if txJson.Effects != nil {
+			tx.Status = txJson.Effects.Status.Status
+			tx.Error = txJson.Effects.Status.Error
+			tx.ComputationCost = txJson.Effects.GasUsed.ComputationCost
+			tx.StorageCost = txJson.Effects.GasUsed.StorageCost
+			tx.StorageRebate = txJson.Effects.GasUsed.StorageRebate
+			tx.NonRefundableStorageFee = txJson.Effects.GasUsed.NonRefundableStorageFee
+		}
+
+		for _, eventJson := range txJson.Events {
+			typeAddress, typeModule, typeName := seer_common.ParseMoveTypeTag(eventJson.Type)
+			tx.Events = append(tx.Events, &SuiEvent{
+				Type:              eventJson.Type,
+				TypeAddress:       typeAddress,
+				TypeModule:        typeModule,
+				TypeName:          typeName,
+				PackageId:         eventJson.PackageId,
+				TransactionModule: eventJson.TransactionModule,
+				Sender:            eventJson.Sender,
+				ParsedJson:        rawJsonToString(eventJson.ParsedJson),
+				Bcs:               eventJson.Bcs,
+				EventSeq:          eventJson.Id.EventSeq,
+				TransactionDigest: txJson.Digest,
+				Checkpoint:        obj.SequenceNumber,
+				CheckpointDigest:  obj.Digest,
+				TimestampMs:       obj.TimestampMs,
+			})
+		}
+
+		for changeI, changeJson := range txJson.ObjectChanges {
+			objectId := changeJson.ObjectId
+			if changeJson.Type == "published" {
+				objectId = changeJson.PackageId
+			}
+
+			tx.ObjectChanges = append(tx.ObjectChanges, &SuiObjectChange{
+				Type:            changeJson.Type,
+				ObjectId:        objectId,
+				ObjectType:      changeJson.ObjectType,
+				Sender:          changeJson.Sender,
+				Owner:           rawJsonToString(changeJson.Owner),
+				Version:         changeJson.Version,
+				PreviousVersion: changeJson.PreviousVersion,
+				Digest:          changeJson.Digest,
+				Modules:         changeJson.Modules,
+				ChangeIndex:     uint64(changeI),
+			})
+		}
+
+		checkpoint.Transactions = append(checkpoint.Transactions, tx)
+	}
+
+	return checkpoint, nil
+}
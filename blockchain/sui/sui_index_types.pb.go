@@ -0,0 +1,1068 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.6.1
+// source: blockchain/sui/sui_index_types.proto
+
+package sui
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a single Move event emitted by transaction block
+type SuiEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type              string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                                                    // Move type tag of event, e.g. 0x2::coin::CoinMetadata
+	TypeAddress       string `protobuf:"bytes,2,opt,name=type_address,json=typeAddress,proto3" json:"type_address,omitempty"`                   // Package which defines event struct
+	TypeModule        string `protobuf:"bytes,3,opt,name=type_module,json=typeModule,proto3" json:"type_module,omitempty"`                      // Module which defines event struct
+	TypeName          string `protobuf:"bytes,4,opt,name=type_name,json=typeName,proto3" json:"type_name,omitempty"`                            // Event struct name without generic type parameters
+	PackageId         string `protobuf:"bytes,5,opt,name=package_id,json=packageId,proto3" json:"package_id,omitempty"`                         // Package which emitted event
+	TransactionModule string `protobuf:"bytes,6,opt,name=transaction_module,json=transactionModule,proto3" json:"transaction_module,omitempty"` // Module which emitted event
+	Sender            string `protobuf:"bytes,7,opt,name=sender,proto3" json:"sender,omitempty"`
+	ParsedJson        string `protobuf:"bytes,8,opt,name=parsed_json,json=parsedJson,proto3" json:"parsed_json,omitempty"` // JSON encoded event data
+	Bcs               string `protobuf:"bytes,9,opt,name=bcs,proto3" json:"bcs,omitempty"`                                 // BCS encoded event data
+	EventSeq          uint64 `protobuf:"varint,10,opt,name=event_seq,json=eventSeq,proto3" json:"event_seq,omitempty"`     // The index of the event in the transaction block
+	TransactionDigest string `protobuf:"bytes,11,opt,name=transaction_digest,json=transactionDigest,proto3" json:"transaction_digest,omitempty"`
+	Checkpoint        uint64 `protobuf:"varint,12,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	CheckpointDigest  string `protobuf:"bytes,13,opt,name=checkpoint_digest,json=checkpointDigest,proto3" json:"checkpoint_digest,omitempty"`
+	TimestampMs       uint64 `protobuf:"varint,14,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+}
+
+func (x *SuiEvent) Reset() {
+	*x = SuiEvent{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuiEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuiEvent) ProtoMessage() {}
+
+func (x *SuiEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuiEvent.ProtoReflect.Descriptor instead.
+func (*SuiEvent) Descriptor() ([]byte, []int) {
+	return file_blockchain_sui_sui_index_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SuiEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetTypeAddress() string {
+	if x != nil {
+		return x.TypeAddress
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetTypeModule() string {
+	if x != nil {
+		return x.TypeModule
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetTypeName() string {
+	if x != nil {
+		return x.TypeName
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetPackageId() string {
+	if x != nil {
+		return x.PackageId
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetTransactionModule() string {
+	if x != nil {
+		return x.TransactionModule
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetParsedJson() string {
+	if x != nil {
+		return x.ParsedJson
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetBcs() string {
+	if x != nil {
+		return x.Bcs
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetEventSeq() uint64 {
+	if x != nil {
+		return x.EventSeq
+	}
+	return 0
+}
+
+func (x *SuiEvent) GetTransactionDigest() string {
+	if x != nil {
+		return x.TransactionDigest
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetCheckpoint() uint64 {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return 0
+}
+
+func (x *SuiEvent) GetCheckpointDigest() string {
+	if x != nil {
+		return x.CheckpointDigest
+	}
+	return ""
+}
+
+func (x *SuiEvent) GetTimestampMs() uint64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+// Represents a single object change made by transaction block
+type SuiObjectChange struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type            string   `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`                         // created, mutated, deleted, wrapped, transferred, published
+	ObjectId        string   `protobuf:"bytes,2,opt,name=object_id,json=objectId,proto3" json:"object_id,omitempty"` // Package id for published packages
+	ObjectType      string   `protobuf:"bytes,3,opt,name=object_type,json=objectType,proto3" json:"object_type,omitempty"`
+	Sender          string   `protobuf:"bytes,4,opt,name=sender,proto3" json:"sender,omitempty"`
+	Owner           string   `protobuf:"bytes,5,opt,name=owner,proto3" json:"owner,omitempty"` // JSON encoded owner
+	Version         uint64   `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	PreviousVersion uint64   `protobuf:"varint,7,opt,name=previous_version,json=previousVersion,proto3" json:"previous_version,omitempty"`
+	Digest          string   `protobuf:"bytes,8,opt,name=digest,proto3" json:"digest,omitempty"`
+	Modules         []string `protobuf:"bytes,9,rep,name=modules,proto3" json:"modules,omitempty"`                              // Modules of published package
+	ChangeIndex     uint64   `protobuf:"varint,10,opt,name=change_index,json=changeIndex,proto3" json:"change_index,omitempty"` // The index of the change in the transaction block
+}
+
+func (x *SuiObjectChange) Reset() {
+	*x = SuiObjectChange{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuiObjectChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuiObjectChange) ProtoMessage() {}
+
+func (x *SuiObjectChange) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuiObjectChange.ProtoReflect.Descriptor instead.
+func (*SuiObjectChange) Descriptor() ([]byte, []int) {
+	return file_blockchain_sui_sui_index_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SuiObjectChange) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *SuiObjectChange) GetObjectId() string {
+	if x != nil {
+		return x.ObjectId
+	}
+	return ""
+}
+
+func (x *SuiObjectChange) GetObjectType() string {
+	if x != nil {
+		return x.ObjectType
+	}
+	return ""
+}
+
+func (x *SuiObjectChange) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *SuiObjectChange) GetOwner() string {
+	if x != nil {
+		return x.Owner
+	}
+	return ""
+}
+
+func (x *SuiObjectChange) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *SuiObjectChange) GetPreviousVersion() uint64 {
+	if x != nil {
+		return x.PreviousVersion
+	}
+	return 0
+}
+
+func (x *SuiObjectChange) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *SuiObjectChange) GetModules() []string {
+	if x != nil {
+		return x.Modules
+	}
+	return nil
+}
+
+func (x *SuiObjectChange) GetChangeIndex() uint64 {
+	if x != nil {
+		return x.ChangeIndex
+	}
+	return 0
+}
+
+// Represents a single Move call command of programmable transaction
+type SuiMoveCall struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Package       string   `protobuf:"bytes,1,opt,name=package,proto3" json:"package,omitempty"`
+	Module        string   `protobuf:"bytes,2,opt,name=module,proto3" json:"module,omitempty"`
+	Function      string   `protobuf:"bytes,3,opt,name=function,proto3" json:"function,omitempty"`
+	TypeArguments []string `protobuf:"bytes,4,rep,name=type_arguments,json=typeArguments,proto3" json:"type_arguments,omitempty"`
+	Arguments     string   `protobuf:"bytes,5,opt,name=arguments,proto3" json:"arguments,omitempty"` // JSON encoded command arguments
+	CommandIndex  uint64   `protobuf:"varint,6,opt,name=command_index,json=commandIndex,proto3" json:"command_index,omitempty"`
+}
+
+func (x *SuiMoveCall) Reset() {
+	*x = SuiMoveCall{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuiMoveCall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuiMoveCall) ProtoMessage() {}
+
+func (x *SuiMoveCall) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuiMoveCall.ProtoReflect.Descriptor instead.
+func (*SuiMoveCall) Descriptor() ([]byte, []int) {
+	return file_blockchain_sui_sui_index_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SuiMoveCall) GetPackage() string {
+	if x != nil {
+		return x.Package
+	}
+	return ""
+}
+
+func (x *SuiMoveCall) GetModule() string {
+	if x != nil {
+		return x.Module
+	}
+	return ""
+}
+
+func (x *SuiMoveCall) GetFunction() string {
+	if x != nil {
+		return x.Function
+	}
+	return ""
+}
+
+func (x *SuiMoveCall) GetTypeArguments() []string {
+	if x != nil {
+		return x.TypeArguments
+	}
+	return nil
+}
+
+func (x *SuiMoveCall) GetArguments() string {
+	if x != nil {
+		return x.Arguments
+	}
+	return ""
+}
+
+func (x *SuiMoveCall) GetCommandIndex() uint64 {
+	if x != nil {
+		return x.CommandIndex
+	}
+	return 0
+}
+
+// Represents a single transaction block within a checkpoint
+type SuiTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Digest                  string             `protobuf:"bytes,1,opt,name=digest,proto3" json:"digest,omitempty"`
+	Kind                    string             `protobuf:"bytes,2,opt,name=kind,proto3" json:"kind,omitempty"` // ProgrammableTransaction, ConsensusCommitPrologue, ...
+	Sender                  string             `protobuf:"bytes,3,opt,name=sender,proto3" json:"sender,omitempty"`
+	GasPrice                uint64             `protobuf:"varint,4,opt,name=gas_price,json=gasPrice,proto3" json:"gas_price,omitempty"`
+	GasBudget               uint64             `protobuf:"varint,5,opt,name=gas_budget,json=gasBudget,proto3" json:"gas_budget,omitempty"`
+	GasOwner                string             `protobuf:"bytes,6,opt,name=gas_owner,json=gasOwner,proto3" json:"gas_owner,omitempty"`
+	Status                  string             `protobuf:"bytes,7,opt,name=status,proto3" json:"status,omitempty"` // success or failure
+	Error                   string             `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	ComputationCost         uint64             `protobuf:"varint,9,opt,name=computation_cost,json=computationCost,proto3" json:"computation_cost,omitempty"`
+	StorageCost             uint64             `protobuf:"varint,10,opt,name=storage_cost,json=storageCost,proto3" json:"storage_cost,omitempty"`
+	StorageRebate           uint64             `protobuf:"varint,11,opt,name=storage_rebate,json=storageRebate,proto3" json:"storage_rebate,omitempty"`
+	NonRefundableStorageFee uint64             `protobuf:"varint,12,opt,name=non_refundable_storage_fee,json=nonRefundableStorageFee,proto3" json:"non_refundable_storage_fee,omitempty"`
+	Inputs                  string             `protobuf:"bytes,13,opt,name=inputs,proto3" json:"inputs,omitempty"`                                       // JSON encoded programmable transaction inputs
+	Commands                string             `protobuf:"bytes,14,opt,name=commands,proto3" json:"commands,omitempty"`                                   // JSON encoded programmable transaction commands
+	BalanceChanges          string             `protobuf:"bytes,15,opt,name=balance_changes,json=balanceChanges,proto3" json:"balance_changes,omitempty"` // JSON encoded balance changes
+	Signatures              []string           `protobuf:"bytes,16,rep,name=signatures,proto3" json:"signatures,omitempty"`
+	Checkpoint              uint64             `protobuf:"varint,17,opt,name=checkpoint,proto3" json:"checkpoint,omitempty"`
+	CheckpointDigest        string             `protobuf:"bytes,18,opt,name=checkpoint_digest,json=checkpointDigest,proto3" json:"checkpoint_digest,omitempty"`
+	TimestampMs             uint64             `protobuf:"varint,19,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+	TransactionIndex        uint64             `protobuf:"varint,20,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"` // The index of the transaction block in the checkpoint
+	IndexedAt               uint64             `protobuf:"varint,21,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	MoveCalls               []*SuiMoveCall     `protobuf:"bytes,22,rep,name=move_calls,json=moveCalls,proto3" json:"move_calls,omitempty"`
+	Events                  []*SuiEvent        `protobuf:"bytes,23,rep,name=events,proto3" json:"events,omitempty"`
+	ObjectChanges           []*SuiObjectChange `protobuf:"bytes,24,rep,name=object_changes,json=objectChanges,proto3" json:"object_changes,omitempty"`
+}
+
+func (x *SuiTransaction) Reset() {
+	*x = SuiTransaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuiTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuiTransaction) ProtoMessage() {}
+
+func (x *SuiTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuiTransaction.ProtoReflect.Descriptor instead.
+func (*SuiTransaction) Descriptor() ([]byte, []int) {
+	return file_blockchain_sui_sui_index_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *SuiTransaction) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *SuiTransaction) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SuiTransaction) GetSender() string {
+	if x != nil {
+		return x.Sender
+	}
+	return ""
+}
+
+func (x *SuiTransaction) GetGasPrice() uint64 {
+	if x != nil {
+		return x.GasPrice
+	}
+	return 0
+}
+
+func (x *SuiTransaction) GetGasBudget() uint64 {
+	if x != nil {
+		return x.GasBudget
+	}
+	return 0
+}
+
+func (x *SuiTransaction) GetGasOwner() string {
+	if x != nil {
+		return x.GasOwner
+	}
+	return ""
+}
+
+func (x *SuiTransaction) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *SuiTransaction) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *SuiTransaction) GetComputationCost() uint64 {
+	if x != nil {
+		return x.ComputationCost
+	}
+	return 0
+}
+
+func (x *SuiTransaction) GetStorageCost() uint64 {
+	if x != nil {
+		return x.StorageCost
+	}
+	return 0
+}
+
+func (x *SuiTransaction) GetStorageRebate() uint64 {
+	if x != nil {
+		return x.StorageRebate
+	}
+	return 0
+}
+
+func (x *SuiTransaction) GetNonRefundableStorageFee() uint64 {
+	if x != nil {
+		return x.NonRefundableStorageFee
+	}
+	return 0
+}
+
+func (x *SuiTransaction) GetInputs() string {
+	if x != nil {
+		return x.Inputs
+	}
+	return ""
+}
+
+func (x *SuiTransaction) GetCommands() string {
+	if x != nil {
+		return x.Commands
+	}
+	return ""
+}
+
+func (x *SuiTransaction) GetBalanceChanges() string {
+	if x != nil {
+		return x.BalanceChanges
+	}
+	return ""
+}
+
+func (x *SuiTransaction) GetSignatures() []string {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+func (x *SuiTransaction) GetCheckpoint() uint64 {
+	if x != nil {
+		return x.Checkpoint
+	}
+	return 0
+}
+
+func (x *SuiTransaction) GetCheckpointDigest() string {
+	if x != nil {
+		return x.CheckpointDigest
+	}
+	return ""
+}
+
+func (x *SuiTransaction) GetTimestampMs() uint64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *SuiTransaction) GetTransactionIndex() uint64 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *SuiTransaction) GetIndexedAt() uint64 {
+	if x != nil {
+		return x.IndexedAt
+	}
+	return 0
+}
+
+func (x *SuiTransaction) GetMoveCalls() []*SuiMoveCall {
+	if x != nil {
+		return x.MoveCalls
+	}
+	return nil
+}
+
+func (x *SuiTransaction) GetEvents() []*SuiEvent {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *SuiTransaction) GetObjectChanges() []*SuiObjectChange {
+	if x != nil {
+		return x.ObjectChanges
+	}
+	return nil
+}
+
+// Represents a checkpoint, the crawl unit of Sui
+type SuiCheckpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SequenceNumber           uint64            `protobuf:"varint,1,opt,name=sequence_number,json=sequenceNumber,proto3" json:"sequence_number,omitempty"`
+	Digest                   string            `protobuf:"bytes,2,opt,name=digest,proto3" json:"digest,omitempty"`
+	PreviousDigest           string            `protobuf:"bytes,3,opt,name=previous_digest,json=previousDigest,proto3" json:"previous_digest,omitempty"`
+	Epoch                    uint64            `protobuf:"varint,4,opt,name=epoch,proto3" json:"epoch,omitempty"`
+	TimestampMs              uint64            `protobuf:"varint,5,opt,name=timestamp_ms,json=timestampMs,proto3" json:"timestamp_ms,omitempty"`
+	NetworkTotalTransactions uint64            `protobuf:"varint,6,opt,name=network_total_transactions,json=networkTotalTransactions,proto3" json:"network_total_transactions,omitempty"`
+	ValidatorSignature       string            `protobuf:"bytes,7,opt,name=validator_signature,json=validatorSignature,proto3" json:"validator_signature,omitempty"`
+	IndexedAt                uint64            `protobuf:"varint,8,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	Transactions             []*SuiTransaction `protobuf:"bytes,9,rep,name=transactions,proto3" json:"transactions,omitempty"`
+}
+
+func (x *SuiCheckpoint) Reset() {
+	*x = SuiCheckpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuiCheckpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuiCheckpoint) ProtoMessage() {}
+
+func (x *SuiCheckpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuiCheckpoint.ProtoReflect.Descriptor instead.
+func (*SuiCheckpoint) Descriptor() ([]byte, []int) {
+	return file_blockchain_sui_sui_index_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SuiCheckpoint) GetSequenceNumber() uint64 {
+	if x != nil {
+		return x.SequenceNumber
+	}
+	return 0
+}
+
+func (x *SuiCheckpoint) GetDigest() string {
+	if x != nil {
+		return x.Digest
+	}
+	return ""
+}
+
+func (x *SuiCheckpoint) GetPreviousDigest() string {
+	if x != nil {
+		return x.PreviousDigest
+	}
+	return ""
+}
+
+func (x *SuiCheckpoint) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+func (x *SuiCheckpoint) GetTimestampMs() uint64 {
+	if x != nil {
+		return x.TimestampMs
+	}
+	return 0
+}
+
+func (x *SuiCheckpoint) GetNetworkTotalTransactions() uint64 {
+	if x != nil {
+		return x.NetworkTotalTransactions
+	}
+	return 0
+}
+
+func (x *SuiCheckpoint) GetValidatorSignature() string {
+	if x != nil {
+		return x.ValidatorSignature
+	}
+	return ""
+}
+
+func (x *SuiCheckpoint) GetIndexedAt() uint64 {
+	if x != nil {
+		return x.IndexedAt
+	}
+	return 0
+}
+
+func (x *SuiCheckpoint) GetTransactions() []*SuiTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type SuiBlocksBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blocks      []*SuiCheckpoint `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	SeerVersion string           `protobuf:"bytes,2,opt,name=seer_version,json=seerVersion,proto3" json:"seer_version,omitempty"`
+}
+
+func (x *SuiBlocksBatch) Reset() {
+	*x = SuiBlocksBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SuiBlocksBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SuiBlocksBatch) ProtoMessage() {}
+
+func (x *SuiBlocksBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_sui_sui_index_types_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SuiBlocksBatch.ProtoReflect.Descriptor instead.
+func (*SuiBlocksBatch) Descriptor() ([]byte, []int) {
+	return file_blockchain_sui_sui_index_types_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SuiBlocksBatch) GetBlocks() []*SuiCheckpoint {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *SuiBlocksBatch) GetSeerVersion() string {
+	if x != nil {
+		return x.SeerVersion
+	}
+	return ""
+}
+
+var File_blockchain_sui_sui_index_types_proto protoreflect.FileDescriptor
+
+var file_blockchain_sui_sui_index_types_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x73, 0x75, 0x69,
+	0x2f, 0x73, 0x75, 0x69, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xd4, 0x03, 0x0a, 0x08, 0x53, 0x75, 0x69, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x79, 0x70, 0x65, 0x5f,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x74,
+	0x79, 0x70, 0x65, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x79,
+	0x70, 0x65, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0a, 0x74, 0x79, 0x70, 0x65, 0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x74,
+	0x79, 0x70, 0x65, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08,
+	0x74, 0x79, 0x70, 0x65, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x61, 0x63, 0x6b,
+	0x61, 0x67, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18, 0x06, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1f,
+	0x0a, 0x0b, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x5f, 0x6a, 0x73, 0x6f, 0x6e, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x61, 0x72, 0x73, 0x65, 0x64, 0x4a, 0x73, 0x6f, 0x6e, 0x12,
+	0x10, 0x0a, 0x03, 0x62, 0x63, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x62, 0x63,
+	0x73, 0x12, 0x1b, 0x0a, 0x09, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x73, 0x65, 0x71, 0x18, 0x0a,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x53, 0x65, 0x71, 0x12, 0x2d,
+	0x0a, 0x12, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x64, 0x69,
+	0x67, 0x65, 0x73, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x1e, 0x0a,
+	0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x2b, 0x0a,
+	0x11, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x64, 0x69, 0x67, 0x65,
+	0x73, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70,
+	0x6f, 0x69, 0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6d, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x73, 0x22, 0xab, 0x02,
+	0x0a, 0x0f, 0x53, 0x75, 0x69, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x68, 0x61, 0x6e, 0x67,
+	0x65, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x74, 0x79, 0x70, 0x65, 0x12, 0x1b, 0x0a, 0x09, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6f,
+	0x77, 0x6e, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6f, 0x77, 0x6e, 0x65,
+	0x72, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x70,
+	0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x5f, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x56,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x68, 0x61, 0x6e,
+	0x67, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b,
+	0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x22, 0xc5, 0x01, 0x0a, 0x0b,
+	0x53, 0x75, 0x69, 0x4d, 0x6f, 0x76, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x12, 0x18, 0x0a, 0x07, 0x70,
+	0x61, 0x63, 0x6b, 0x61, 0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x70, 0x61,
+	0x63, 0x6b, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x6f, 0x64, 0x75, 0x6c, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x08, 0x66, 0x75, 0x6e, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x79, 0x70,
+	0x65, 0x5f, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x0d, 0x74, 0x79, 0x70, 0x65, 0x41, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73,
+	0x12, 0x1c, 0x0a, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x61, 0x72, 0x67, 0x75, 0x6d, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x23,
+	0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x22, 0xcf, 0x06, 0x0a, 0x0e, 0x53, 0x75, 0x69, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x12,
+	0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69,
+	0x6e, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x73, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61,
+	0x73, 0x5f, 0x70, 0x72, 0x69, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52, 0x08, 0x67,
+	0x61, 0x73, 0x50, 0x72, 0x69, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x67, 0x61, 0x73, 0x5f, 0x62,
+	0x75, 0x64, 0x67, 0x65, 0x74, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x67, 0x61, 0x73,
+	0x42, 0x75, 0x64, 0x67, 0x65, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x61, 0x73, 0x5f, 0x6f, 0x77,
+	0x6e, 0x65, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x67, 0x61, 0x73, 0x4f, 0x77,
+	0x6e, 0x65, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x07, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65,
+	0x72, 0x72, 0x6f, 0x72, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f,
+	0x72, 0x12, 0x29, 0x0a, 0x10, 0x63, 0x6f, 0x6d, 0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0f, 0x63, 0x6f, 0x6d,
+	0x70, 0x75, 0x74, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x0c,
+	0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x0a, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0b, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x43, 0x6f, 0x73, 0x74, 0x12,
+	0x25, 0x0a, 0x0e, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65, 0x5f, 0x72, 0x65, 0x62, 0x61, 0x74,
+	0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0d, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x52, 0x65, 0x62, 0x61, 0x74, 0x65, 0x12, 0x3b, 0x0a, 0x1a, 0x6e, 0x6f, 0x6e, 0x5f, 0x72, 0x65,
+	0x66, 0x75, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x73, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x5f, 0x66, 0x65, 0x65, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x17, 0x6e, 0x6f, 0x6e, 0x52,
+	0x65, 0x66, 0x75, 0x6e, 0x64, 0x61, 0x62, 0x6c, 0x65, 0x53, 0x74, 0x6f, 0x72, 0x61, 0x67, 0x65,
+	0x46, 0x65, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x0d, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63,
+	0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x61, 0x6c, 0x61, 0x6e,
+	0x63, 0x65, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x10,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x73,
+	0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x18, 0x11,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74,
+	0x12, 0x2b, 0x0a, 0x11, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x5f, 0x64,
+	0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x12, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x63, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x44, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a,
+	0x0c, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6d, 0x73, 0x18, 0x13, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x73,
+	0x12, 0x2b, 0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x14, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a,
+	0x0a, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x15, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x41, 0x74, 0x12, 0x2b, 0x0a, 0x0a,
+	0x6d, 0x6f, 0x76, 0x65, 0x5f, 0x63, 0x61, 0x6c, 0x6c, 0x73, 0x18, 0x16, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0c, 0x2e, 0x53, 0x75, 0x69, 0x4d, 0x6f, 0x76, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x52, 0x09,
+	0x6d, 0x6f, 0x76, 0x65, 0x43, 0x61, 0x6c, 0x6c, 0x73, 0x12, 0x21, 0x0a, 0x06, 0x65, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x18, 0x17, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x53, 0x75, 0x69, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x37, 0x0a, 0x0e,
+	0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x73, 0x18, 0x18,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x53, 0x75, 0x69, 0x4f, 0x62, 0x6a, 0x65, 0x63, 0x74,
+	0x43, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x52, 0x0d, 0x6f, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x43, 0x68,
+	0x61, 0x6e, 0x67, 0x65, 0x73, 0x22, 0xf5, 0x02, 0x0a, 0x0d, 0x53, 0x75, 0x69, 0x43, 0x68, 0x65,
+	0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x73, 0x65, 0x71, 0x75, 0x65,
+	0x6e, 0x63, 0x65, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0e, 0x73, 0x65, 0x71, 0x75, 0x65, 0x6e, 0x63, 0x65, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x12, 0x16, 0x0a, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x0f, 0x70, 0x72, 0x65, 0x76,
+	0x69, 0x6f, 0x75, 0x73, 0x5f, 0x64, 0x69, 0x67, 0x65, 0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x44, 0x69, 0x67, 0x65, 0x73,
+	0x74, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x65, 0x70, 0x6f, 0x63, 0x68, 0x12, 0x21, 0x0a, 0x0c, 0x74, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x5f, 0x6d, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x4d, 0x73, 0x12, 0x3c, 0x0a, 0x1a, 0x6e, 0x65,
+	0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x18,
+	0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x54, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x2f, 0x0a, 0x13, 0x76, 0x61, 0x6c, 0x69,
+	0x64, 0x61, 0x74, 0x6f, 0x72, 0x5f, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x12, 0x76, 0x61, 0x6c, 0x69, 0x64, 0x61, 0x74, 0x6f, 0x72,
+	0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e, 0x64,
+	0x65, 0x78, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x41, 0x74, 0x12, 0x33, 0x0a, 0x0c, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f,
+	0x2e, 0x53, 0x75, 0x69, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x5b, 0x0a,
+	0x0e, 0x53, 0x75, 0x69, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x42, 0x61, 0x74, 0x63, 0x68, 0x12,
+	0x26, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x53, 0x75, 0x69, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x65, 0x72, 0x5f,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73,
+	0x65, 0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x6f, 0x6f, 0x6e, 0x73, 0x74, 0x72,
+	0x65, 0x61, 0x6d, 0x2d, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x65, 0x72, 0x2f, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x73, 0x75, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x33,
+}
+
+var (
+	file_blockchain_sui_sui_index_types_proto_rawDescOnce sync.Once
+	file_blockchain_sui_sui_index_types_proto_rawDescData = file_blockchain_sui_sui_index_types_proto_rawDesc
+)
+
+func file_blockchain_sui_sui_index_types_proto_rawDescGZIP() []byte {
+	file_blockchain_sui_sui_index_types_proto_rawDescOnce.Do(func() {
+		file_blockchain_sui_sui_index_types_proto_rawDescData = protoimpl.X.CompressGZIP(file_blockchain_sui_sui_index_types_proto_rawDescData)
+	})
+	return file_blockchain_sui_sui_index_types_proto_rawDescData
+}
+
+var file_blockchain_sui_sui_index_types_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_blockchain_sui_sui_index_types_proto_goTypes = []any{
+	(*SuiEvent)(nil),        // 0: SuiEvent
+	(*SuiObjectChange)(nil), // 1: SuiObjectChange
+	(*SuiMoveCall)(nil),     // 2: SuiMoveCall
+	(*SuiTransaction)(nil),  // 3: SuiTransaction
+	(*SuiCheckpoint)(nil),   // 4: SuiCheckpoint
+	(*SuiBlocksBatch)(nil),  // 5: SuiBlocksBatch
+}
+var file_blockchain_sui_sui_index_types_proto_depIdxs = []int32{
+	2, // 0: SuiTransaction.move_calls:type_name -> SuiMoveCall
+	0, // 1: SuiTransaction.events:type_name -> SuiEvent
+	1, // 2: SuiTransaction.object_changes:type_name -> SuiObjectChange
+	3, // 3: SuiCheckpoint.transactions:type_name -> SuiTransaction
+	4, // 4: SuiBlocksBatch.blocks:type_name -> SuiCheckpoint
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_blockchain_sui_sui_index_types_proto_init() }
+func file_blockchain_sui_sui_index_types_proto_init() {
+	if File_blockchain_sui_sui_index_types_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_blockchain_sui_sui_index_types_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SuiEvent); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_sui_sui_index_types_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*SuiObjectChange); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_sui_sui_index_types_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*SuiMoveCall); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_sui_sui_index_types_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*SuiTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_sui_sui_index_types_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*SuiCheckpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_sui_sui_index_types_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*SuiBlocksBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_blockchain_sui_sui_index_types_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_blockchain_sui_sui_index_types_proto_goTypes,
+		DependencyIndexes: file_blockchain_sui_sui_index_types_proto_depIdxs,
+		MessageInfos:      file_blockchain_sui_sui_index_types_proto_msgTypes,
+	}.Build()
+	File_blockchain_sui_sui_index_types_proto = out.File
+	file_blockchain_sui_sui_index_types_proto_rawDesc = nil
+	file_blockchain_sui_sui_index_types_proto_goTypes = nil
+	file_blockchain_sui_sui_index_types_proto_depIdxs = nil
+}
@@ -0,0 +1,861 @@
+package ton
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/version"
+)
+
+const (
+	// TransactionShardsIndexKind is kind of custom index with workchain and shard of transactions,
+	// stored in ton_transaction_shards table
+	TransactionShardsIndexKind = "transaction_shards"
+
+	// MessagesIndexKind is kind of custom index with in and out messages, stored in ton_messages table
+	MessagesIndexKind = "messages"
+
+	// masterchainWorkchain is workchain id of masterchain
+	masterchainWorkchain = -1
+
+	// transactionsPageLimit is page size for transactions of masterchain block
+	transactionsPageLimit = 256
+)
+
+func init() {
+	seer_common.RegisterHasher("ton", seer_common.TonHasher{})
+
+	indexer.RegisterCustomIndexTable("ton", indexer.CustomIndexTable{
+		Kind: TransactionShardsIndexKind,
+		Columns: []indexer.CustomIndexColumn{
+			{Name: "transaction_hash", Type: "TEXT"},
+			{Name: "block_number", Type: "BIGINT"},
+			{Name: "block_hash", Type: "TEXT"},
+			{Name: "account", Type: "TEXT"},
+			{Name: "lt", Type: "BIGINT"},
+			{Name: "workchain", Type: "INTEGER"},
+			{Name: "shard", Type: "TEXT"},
+			{Name: "shard_seqno", Type: "BIGINT"},
+		},
+		ConflictClause: "ON CONFLICT (transaction_hash) DO NOTHING",
+	})
+	indexer.RegisterCustomIndexTable("ton", indexer.CustomIndexTable{
+		Kind: MessagesIndexKind,
+		Columns: []indexer.CustomIndexColumn{
+			{Name: "transaction_hash", Type: "TEXT"},
+			{Name: "block_number", Type: "BIGINT"},
+			{Name: "block_hash", Type: "TEXT"},
+			{Name: "message_hash", Type: "TEXT"},
+			{Name: "direction", Type: "TEXT"},
+			{Name: "message_index", Type: "BIGINT"},
+			{Name: "source", Type: "TEXT"},
+			{Name: "destination", Type: "TEXT"},
+			{Name: "opcode", Type: "TEXT"},
+			{Name: "value", Type: "NUMERIC"},
+		},
+		ConflictClause: "ON CONFLICT (transaction_hash, direction, message_index) DO NOTHING",
+	})
+}
+
+func NewClient(rawURL string, timeout int) (*Client, error) {
+	baseURL, err := url.Parse(strings.TrimSuffix(rawURL, "/"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{
+		baseURL:    baseURL,
+		httpClient: seer_common.NewHTTPClient(rawURL, time.Duration(timeout)*time.Second),
+		hasher:     seer_common.GetHasher("ton"),
+	}, nil
+}
+
+// Client is a wrapper around the toncenter v3 indexer API, url is expected with version prefix,
+// e.g. https://toncenter.com/api/v3?api_key=<key>. Masterchain blocks are used as blocks,
+// transactions of all shard blocks committed in masterchain block belong to it.
+type Client struct {
+	baseURL    *url.URL
+	httpClient *http.Client
+	hasher     seer_common.Hasher
+}
+
+// Client common
+
+// ChainType returns the chain type.
+func (c *Client) ChainType() string {
+	return "ton"
+}
+
+// Close closes idle connections of the underlying HTTP client.
+func (c *Client) Close() {
+	c.httpClient.CloseIdleConnections()
+}
+
+func (c *Client) get(ctx context.Context, path string, params url.Values, result interface{}) error {
+	requestURL := *c.baseURL
+	requestURL.Path += path
+
+	query := requestURL.Query()
+	for key, values := range params {
+		query[key] = values
+	}
+	requestURL.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", requestURL.String(), nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request %s failed with status %d: %s", path, resp.StatusCode, string(body))
+	}
+
+	return json.Unmarshal(body, result)
+}
+
+// GetLatestBlockNumber returns the latest masterchain block seqno.
+func (c *Client) GetLatestBlockNumber(ctx context.Context) (*big.Int, error) {
+	var masterchainInfo MasterchainInfoJson
+	if err := c.get(ctx, "/masterchainInfo", nil, &masterchainInfo); err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetUint64(masterchainInfo.Last.Seqno), nil
+}
+
+// GetBlockByNumber returns masterchain block with committed shard blocks and all their transactions.
+func (c *Client) GetBlockByNumber(ctx context.Context, seqno uint64) (*BlockJson, error) {
+	var shards BlocksJson
+	if err := c.get(ctx, "/masterchainBlockShards", url.Values{"seqno": {strconv.FormatUint(seqno, 10)}}, &shards); err != nil {
+		return nil, err
+	}
+
+	block := BlockJson{}
+	for _, shardBlock := range shards.Blocks {
+		if shardBlock.Workchain == masterchainWorkchain && shardBlock.Seqno == seqno {
+			masterBlock := shardBlock
+			block.Master = &masterBlock
+			continue
+		}
+		block.Shards = append(block.Shards, shardBlock)
+	}
+	if block.Master == nil {
+		return nil, fmt.Errorf("masterchain block %d not found", seqno)
+	}
+
+	for offset := 0; ; offset += transactionsPageLimit {
+		var transactions TransactionsJson
+		params := url.Values{
+			"seqno":  {strconv.FormatUint(seqno, 10)},
+			"limit":  {strconv.Itoa(transactionsPageLimit)},
+			"offset": {strconv.Itoa(offset)},
+			"sort":   {"asc"},
+		}
+		if err := c.get(ctx, "/transactionsByMasterchainBlock", params, &transactions); err != nil {
+			return nil, err
+		}
+
+		block.Transactions = append(block.Transactions, transactions.Transactions...)
+		if len(transactions.Transactions) < transactionsPageLimit {
+			break
+		}
+	}
+
+	return &block, nil
+}
+
+// FetchBlocksInRange fetches masterchain blocks within a specified range, with up to maxRequests
+// concurrent requests. Blocks are returned in ascending order.
+func (c *Client) FetchBlocksInRange(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]*BlockJson, error) {
+	if maxRequests < 1 {
+		maxRequests = 1
+	}
+
+	fromNumber := from.Uint64()
+	toNumber := to.Uint64()
+	if toNumber < fromNumber {
+		return nil, nil
+	}
+
+	blocks := make([]*BlockJson, toNumber-fromNumber+1)
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	sem := make(chan struct{}, maxRequests)
+
+	for number := fromNumber; number <= toNumber; number++ {
+		wg.Add(1)
+		go func(number uint64) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			block, err := c.GetBlockByNumber(ctx, number)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			blocks[number-fromNumber] = block
+
+			if debug {
+				fmt.Printf("Fetched masterchain block: %d\n", number)
+			}
+		}(number)
+	}
+
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+
+	return blocks, nil
+}
+
+// FetchAsProtoBlocksWithEvents fetches masterchain blocks with transactions. Transactions are indexed
+// by operation code of in message and external out messages are indexed as account events.
+func (c *Client) FetchAsProtoBlocksWithEvents(ctx context.Context, from, to *big.Int, debug bool, maxRequests int) ([]proto.Message, []indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, uint64, error) {
+	blocksJson, err := c.FetchBlocksInRange(ctx, from, to, debug, maxRequests)
+	if err != nil {
+		return nil, nil, nil, nil, 0, err
+	}
+
+	var blocksSize uint64
+	var blocksProto []proto.Message
+	var blocksIndex []indexer.BlockIndex
+	var txsIndex []indexer.TransactionIndex
+	var eventsIndex []indexer.LogIndex
+
+	for bI, blockJson := range blocksJson {
+		block, convErr := ToProtoSingleBlock(blockJson)
+		if convErr != nil {
+			return nil, nil, nil, nil, 0, convErr
+		}
+
+		for _, tx := range block.Transactions {
+			account := c.hasher.NormalizeAddress(tx.Account)
+
+			var fromAddress, selector string
+			if tx.InMsg != nil {
+				if tx.InMsg.Source != "" {
+					fromAddress = c.hasher.NormalizeAddress(tx.InMsg.Source)
+				}
+				selector = tx.InMsg.Opcode
+			}
+
+			txsIndex = append(txsIndex, indexer.TransactionIndex{
+				BlockNumber:      tx.BlockNumber,
+				BlockHash:        tx.BlockHash,
+				BlockTimestamp:   tx.BlockTimestamp,
+				FromAddress:      fromAddress,
+				ToAddress:        account,
+				RowID:            tx.TransactionIndex,
+				Selector:         selector, // Operation code of in message
+				TransactionHash:  tx.Hash,
+				TransactionIndex: tx.TransactionIndex,
+				Type:             0,
+				Path:             "",
+			})
+
+			for _, msg := range tx.OutMsgs {
+				if msg.Destination != "" {
+					continue
+				}
+
+				var opcode *string
+				if msg.Opcode != "" {
+					msgOpcode := msg.Opcode
+					opcode = &msgOpcode
+				}
+
+				eventsIndex = append(eventsIndex, indexer.LogIndex{
+					Address:         account,
+					BlockNumber:     tx.BlockNumber,
+					BlockHash:       tx.BlockHash,
+					BlockTimestamp:  tx.BlockTimestamp,
+					TransactionHash: tx.Hash,
+					Selector:        opcode, // Operation code of external out message
+					RowID:           msg.MessageIndex,
+					LogIndex:        msg.MessageIndex,
+					Path:            "",
+				})
+			}
+		}
+
+		blocksIndex = append(blocksIndex, indexer.NewBlockIndex("ton",
+			block.Seqno,
+			block.RootHash,
+			block.GenUtime,
+			"",
+			uint64(bI),
+			"",
+			0,
+		))
+
+		blocksSize += uint64(proto.Size(block))
+		blocksProto = append(blocksProto, block)
+	}
+
+	return blocksProto, blocksIndex, txsIndex, eventsIndex, blocksSize, nil
+}
+
+// CustomIndexesFromProtoBlocks returns workchain and shard of transactions and their messages.
+func (c *Client) CustomIndexesFromProtoBlocks(msgs []proto.Message) ([]indexer.CustomIndex, error) {
+	var customIndexes []indexer.CustomIndex
+	for _, msg := range msgs {
+		block, ok := msg.(*TonBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *TonBlock")
+		}
+
+		for _, tx := range block.Transactions {
+			customIndexes = append(customIndexes, indexer.CustomIndex{
+				Kind: TransactionShardsIndexKind,
+				Values: map[string]interface{}{
+					"transaction_hash": tx.Hash,
+					"block_number":     tx.BlockNumber,
+					"block_hash":       tx.BlockHash,
+					"account":          tx.Account,
+					"lt":               tx.Lt,
+					"workchain":        tx.Workchain,
+					"shard":            tx.Shard,
+					"shard_seqno":      tx.ShardSeqno,
+				},
+			})
+
+			messages := tx.OutMsgs
+			if tx.InMsg != nil {
+				messages = append([]*TonMessage{tx.InMsg}, messages...)
+			}
+
+			for _, message := range messages {
+				customIndexes = append(customIndexes, indexer.CustomIndex{
+					Kind: MessagesIndexKind,
+					Values: map[string]interface{}{
+						"transaction_hash": tx.Hash,
+						"block_number":     tx.BlockNumber,
+						"block_hash":       tx.BlockHash,
+						"message_hash":     message.Hash,
+						"direction":        message.Direction,
+						"message_index":    message.MessageIndex,
+						"source":           message.Source,
+						"destination":      message.Destination,
+						"opcode":           message.Opcode,
+						"value":            strconv.FormatUint(message.Value, 10),
+					},
+				})
+			}
+		}
+	}
+
+	return customIndexes, nil
+}
+
+func (c *Client) ProcessBlocksToBatch(msgs []proto.Message) (proto.Message, error) {
+	var blocks []*TonBlock
+	for _, msg := range msgs {
+		block, ok := msg.(*TonBlock)
+		if !ok {
+			return nil, fmt.Errorf("failed to type assert proto.Message to *TonBlock")
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &TonBlocksBatch{
+		Blocks:      blocks,
+		SeerVersion: version.SeerVersion,
+	}, nil
+}
+
+func (c *Client) decodeBlocksBatch(rawData *bytes.Buffer) (*TonBlocksBatch, error) {
+	var protoBlocksBatch TonBlocksBatch
+
+	if err := proto.Unmarshal(rawData.Bytes(), &protoBlocksBatch); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal data: %v", err)
+	}
+
+	return &protoBlocksBatch, nil
+}
+
+// DecodeProtoEntireBlockToJson returns blocks, transactions and external out messages in EVM oriented
+// JSON structure, operation code is placed as the first topic.
+func (c *Client) DecodeProtoEntireBlockToJson(rawData *bytes.Buffer) (*seer_common.BlocksBatchJson, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	blocksBatchJson := seer_common.BlocksBatchJson{
+		Blocks:      []seer_common.BlockJson{},
+		SeerVersion: protoBlocksBatch.SeerVersion,
+	}
+
+	for _, b := range protoBlocksBatch.Blocks {
+		var txs []seer_common.TransactionJson
+		for _, tx := range b.Transactions {
+			var events []seer_common.EventJson
+			for _, msg := range tx.OutMsgs {
+				if msg.Destination != "" {
+					continue
+				}
+				events = append(events, seer_common.EventJson{
+					Address:          tx.Account,
+					Topics:           []string{msg.Opcode},
+					Data:             msg.Body,
+					BlockNumber:      fmt.Sprintf("%d", tx.BlockNumber),
+					TransactionHash:  tx.Hash,
+					BlockHash:        tx.BlockHash,
+					LogIndex:         fmt.Sprintf("%d", msg.MessageIndex),
+					TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+				})
+			}
+
+			txJson := seer_common.TransactionJson{
+				BlockHash:        tx.BlockHash,
+				BlockNumber:      fmt.Sprintf("%d", tx.BlockNumber),
+				Hash:             tx.Hash,
+				ToAddress:        tx.Account,
+				Nonce:            fmt.Sprintf("%d", tx.Lt),
+				TransactionIndex: fmt.Sprintf("%d", tx.TransactionIndex),
+				IndexedAt:        fmt.Sprintf("%d", tx.IndexedAt),
+				BlockTimestamp:   fmt.Sprintf("%d", tx.BlockTimestamp),
+
+				Events: events,
+			}
+			if tx.InMsg != nil {
+				txJson.FromAddress = tx.InMsg.Source
+				txJson.Input = tx.InMsg.Body
+				txJson.Value = fmt.Sprintf("%d", tx.InMsg.Value)
+			}
+
+			txs = append(txs, txJson)
+		}
+
+		blocksBatchJson.Blocks = append(blocksBatchJson.Blocks, seer_common.BlockJson{
+			Hash:         b.RootHash,
+			BlockNumber:  fmt.Sprintf("%d", b.Seqno),
+			Timestamp:    fmt.Sprintf("%d", b.GenUtime),
+			IndexedAt:    fmt.Sprintf("%d", b.IndexedAt),
+			Transactions: txs,
+		})
+	}
+
+	return &blocksBatchJson, nil
+}
+
+// DecodeProtoEntireBlockToProtoJson returns batch with all fields, including shard blocks and messages.
+func (c *Client) DecodeProtoEntireBlockToProtoJson(rawData *bytes.Buffer) ([]byte, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, err
+	}
+
+	return protojson.Marshal(protoBlocksBatch)
+}
+
+// DecodeProtoEntireBlockToLabels converts in messages and external out messages matched with ABI jobs
+// by operation code to labels. Message bodies are not parsed, decoded body is used when API returns
+// it, otherwise label contains base64 encoded body cell.
+func (c *Client) DecodeProtoEntireBlockToLabels(ctx context.Context, rawData *bytes.Buffer, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.EventLabel, []indexer.TransactionLabel, error) {
+	protoBlocksBatch, err := c.decodeBlocksBatch(rawData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var labels []indexer.EventLabel
+	var txLabels []indexer.TransactionLabel
+
+	for _, b := range protoBlocksBatch.Blocks {
+		for _, tx := range b.Transactions {
+			txLabel, matched, labelErr := c.transactionToLabel(tx, abiMap)
+			if labelErr != nil {
+				return nil, nil, labelErr
+			}
+			if matched {
+				txLabels = append(txLabels, txLabel)
+			}
+
+			account := c.hasher.NormalizeAddress(tx.Account)
+			if abiMap[account] == nil {
+				continue
+			}
+
+			for _, msg := range tx.OutMsgs {
+				if msg.Destination != "" || abiMap[account][msg.Opcode] == nil {
+					continue
+				}
+
+				label, args := messageArgs(msg)
+				labelDataBytes, err := json.Marshal(map[string]interface{}{
+					"type": "event",
+					"name": abiMap[account][msg.Opcode]["abi_name"],
+					"args": args,
+				})
+				if err != nil {
+					return nil, nil, err
+				}
+
+				labels = append(labels, indexer.EventLabel{
+					Label:           label,
+					LabelName:       abiMap[account][msg.Opcode]["abi_name"],
+					LabelType:       "event",
+					BlockNumber:     tx.BlockNumber,
+					BlockHash:       tx.BlockHash,
+					Address:         account,
+					TransactionHash: tx.Hash,
+					LabelData:       string(labelDataBytes),
+					BlockTimestamp:  tx.BlockTimestamp,
+					LogIndex:        msg.MessageIndex,
+				})
+			}
+		}
+	}
+
+	return labels, txLabels, nil
+}
+
+func (c *Client) transactionToLabel(tx *TonTransaction, abiMap map[string]map[string]map[string]string) (indexer.TransactionLabel, bool, error) {
+	if tx.InMsg == nil || tx.InMsg.Opcode == "" {
+		return indexer.TransactionLabel{}, false, nil
+	}
+
+	account := c.hasher.NormalizeAddress(tx.Account)
+	if abiMap[account] == nil || abiMap[account][tx.InMsg.Opcode] == nil {
+		return indexer.TransactionLabel{}, false, nil
+	}
+
+	label, args := messageArgs(tx.InMsg)
+	labelDataBytes, err := json.Marshal(map[string]interface{}{
+		"type":    "tx_call",
+		"name":    abiMap[account][tx.InMsg.Opcode]["abi_name"],
+		"value":   tx.InMsg.Value,
+		"aborted": tx.Aborted,
+		"args":    args,
+	})
+	if err != nil {
+		return indexer.TransactionLabel{}, false, err
+	}
+
+	return indexer.TransactionLabel{
+		Address:         account,
+		BlockNumber:     tx.BlockNumber,
+		BlockHash:       tx.BlockHash,
+		CallerAddress:   tx.InMsg.Source,
+		LabelName:       abiMap[account][tx.InMsg.Opcode]["abi_name"],
+		LabelType:       "tx_call",
+		OriginAddress:   tx.InMsg.Source,
+		Label:           label,
+		TransactionHash: tx.Hash,
+		LabelData:       string(labelDataBytes),
+		BlockTimestamp:  tx.BlockTimestamp,
+	}, true, nil
+}
+
+// messageArgs returns decoded message body if available, raw body cell otherwise.
+func messageArgs(msg *TonMessage) (string, interface{}) {
+	if msg.Decoded != "" {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(msg.Decoded), &decoded); err == nil {
+			return indexer.SeerCrawlerLabel, decoded
+		}
+	}
+
+	return indexer.SeerCrawlerRawLabel, map[string]interface{}{"body": msg.Body}
+}
+
+func (c *Client) DecodeProtoTransactionsToLabels(transactions []string, blocksCache map[uint64]uint64, abiMap map[string]map[string]map[string]string) ([]indexer.TransactionLabel, error) {
+	var labels []indexer.TransactionLabel
+
+	for _, data := range transactions {
+		var tx TonTransaction
+		if err := proto.Unmarshal([]byte(data), &tx); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal transaction: %v", err)
+		}
+
+		txLabel, matched, err := c.transactionToLabel(&tx, abiMap)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			labels = append(labels, txLabel)
+		}
+	}
+
+	return labels, nil
+}
+
+// toncenter v3 API structures, u64 values are returned as strings
+
+type ShardBlockJson struct {
+	Workchain int32  `json:"workchain"`
+	Shard     string `json:"shard"`
+	Seqno     uint64 `json:"seqno"`
+	RootHash  string `json:"root_hash"`
+	FileHash  string `json:"file_hash"`
+	GlobalId  int32  `json:"global_id"`
+	GenUtime  uint64 `json:"gen_utime,string"`
+	StartLt   uint64 `json:"start_lt,string"`
+	EndLt     uint64 `json:"end_lt,string"`
+	TxCount   uint64 `json:"tx_count"`
+}
+
+type MasterchainInfoJson struct {
+	Last  ShardBlockJson `json:"last"`
+	First ShardBlockJson `json:"first"`
+}
+
+type BlocksJson struct {
+	Blocks []ShardBlockJson `json:"blocks"`
+}
+
+type MessageContentJson struct {
+	Hash    string          `json:"hash"`
+	Body    string          `json:"body"`
+	Decoded json.RawMessage `json:"decoded"`
+}
+
+type MessageJson struct {
+	Hash           string              `json:"hash"`
+	Source         *string             `json:"source"`
+	Destination    *string             `json:"destination"`
+	Value          *string             `json:"value"`
+	FwdFee         *string             `json:"fwd_fee"`
+	IhrFee         *string             `json:"ihr_fee"`
+	CreatedLt      *string             `json:"created_lt"`
+	CreatedAt      *string             `json:"created_at"`
+	Opcode         *string             `json:"opcode"`
+	Bounce         *bool               `json:"bounce"`
+	Bounced        *bool               `json:"bounced"`
+	MessageContent *MessageContentJson `json:"message_content"`
+}
+
+type BlockRefJson struct {
+	Workchain int32  `json:"workchain"`
+	Shard     string `json:"shard"`
+	Seqno     uint64 `json:"seqno"`
+}
+
+type TransactionDescriptionJson struct {
+	Aborted bool `json:"aborted"`
+}
+
+type TransactionJson struct {
+	Account       string          `json:"account"`
+	Hash          string          `json:"hash"`
+	Lt            uint64          `json:"lt,string"`
+	Now           uint64          `json:"now"`
+	McBlockSeqno  uint64          `json:"mc_block_seqno"`
+	PrevTransHash string          `json:"prev_trans_hash"`
+	PrevTransLt   uint64          `json:"prev_trans_lt,string"`
+	OrigStatus    string          `json:"orig_status"`
+	EndStatus     string          `json:"end_status"`
+	TotalFees     uint64          `json:"total_fees,string"`
+	Description   json.RawMessage `json:"description"`
+	BlockRef      BlockRefJson    `json:"block_ref"`
+	InMsg         *MessageJson    `json:"in_msg"`
+	OutMsgs       []MessageJson   `json:"out_msgs"`
+}
+
+type TransactionsJson struct {
+	Transactions []TransactionJson `json:"transactions"`
+}
+
+// BlockJson is masterchain block with committed shard blocks and their transactions.
+type BlockJson struct {
+	Master       *ShardBlockJson
+	Shards       []ShardBlockJson
+	Transactions []TransactionJson
+}
+
+// base64ToHex converts base64 encoded hash returned by API to 0x-prefixed hex form.
+func base64ToHex(value string) string {
+	if value == "" {
+		return ""
+	}
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil {
+		decoded, err = base64.URLEncoding.DecodeString(value)
+		if err != nil {
+			return value
+		}
+	}
+	return "0x" + hex.EncodeToString(decoded)
+}
+
+func parseOptionalUint(value *string) (uint64, error) {
+	if value == nil || *value == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(*value, 10, 64)
+}
+
+func optionalString(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
+
+func optionalBool(value *bool) bool {
+	return value != nil && *value
+}
+
+func toProtoMessage(obj *MessageJson, direction string, messageIndex uint64) (*TonMessage, error) {
+	msg := &TonMessage{
+		Hash:         base64ToHex(obj.Hash),
+		Direction:    direction,
+		MessageIndex: messageIndex,
+		Source:       optionalString(obj.Source),
+		Destination:  optionalString(obj.Destination),
+		Opcode:       strings.ToLower(optionalString(obj.Opcode)),
+		Bounce:       optionalBool(obj.Bounce),
+		Bounced:      optionalBool(obj.Bounced),
+	}
+
+	var err error
+	if msg.Value, err = parseOptionalUint(obj.Value); err != nil {
+		return nil, fmt.Errorf("failed to parse value of message %s: %v", obj.Hash, err)
+	}
+	if msg.FwdFee, err = parseOptionalUint(obj.FwdFee); err != nil {
+		return nil, fmt.Errorf("failed to parse fwd_fee of message %s: %v", obj.Hash, err)
+	}
+	if msg.IhrFee, err = parseOptionalUint(obj.IhrFee); err != nil {
+		return nil, fmt.Errorf("failed to parse ihr_fee of message %s: %v", obj.Hash, err)
+	}
+	if msg.CreatedLt, err = parseOptionalUint(obj.CreatedLt); err != nil {
+		return nil, fmt.Errorf("failed to parse created_lt of message %s: %v", obj.Hash, err)
+	}
+	if msg.CreatedAt, err = parseOptionalUint(obj.CreatedAt); err != nil {
+		return nil, fmt.Errorf("failed to parse created_at of message %s: %v", obj.Hash, err)
+	}
+
+	if obj.MessageContent != nil {
+		msg.Body = obj.MessageContent.Body
+		if len(obj.MessageContent.Decoded) > 0 && string(obj.MessageContent.Decoded) != "null" {
+			msg.Decoded = string(obj.MessageContent.Decoded)
+		}
+	}
+
+	return msg, nil
+}
+
+func ToProtoSingleBlock(obj *BlockJson) (*TonBlock, error) {
+	indexedAt := uint64(time.Now().Unix())
+	blockHash := base64ToHex(obj.Master.RootHash)
+
+	block := &TonBlock{
+		Seqno:     obj.Master.Seqno,
+		RootHash:  blockHash,
+		FileHash:  base64ToHex(obj.Master.FileHash),
+		GlobalId:  obj.Master.GlobalId,
+		GenUtime:  obj.Master.GenUtime,
+		StartLt:   obj.Master.StartLt,
+		EndLt:     obj.Master.EndLt,
+		TxCount:   obj.Master.TxCount,
+		IndexedAt: indexedAt,
+	}
+
+	for _, shardBlock := range obj.Shards {
+		block.ShardBlocks = append(block.ShardBlocks, &TonShardBlock{
+			Workchain: shardBlock.Workchain,
+			Shard:     shardBlock.Shard,
+			Seqno:     shardBlock.Seqno,
+			RootHash:  base64ToHex(shardBlock.RootHash),
+			FileHash:  base64ToHex(shardBlock.FileHash),
+			GenUtime:  shardBlock.GenUtime,
+			StartLt:   shardBlock.StartLt,
+			EndLt:     shardBlock.EndLt,
+			TxCount:   shardBlock.TxCount,
+		})
+	}
+
+	for txI, txJson := range obj.Transactions {
+		var description TransactionDescriptionJson
+		if len(txJson.Description) > 0 {
+			if err := json.Unmarshal(txJson.Description, &description); err != nil {
+				return nil, fmt.Errorf("failed to decode description of transaction %s: %v", txJson.Hash, err)
+			}
+		}
+
+		tx := &TonTransaction{
+			Hash:             base64ToHex(txJson.Hash),
+			Account:          txJson.Account,
+			Lt:               txJson.Lt,
+			Now:              txJson.Now,
+			Workchain:        txJson.BlockRef.Workchain,
+			Shard:            txJson.BlockRef.Shard,
+			ShardSeqno:       txJson.BlockRef.Seqno,
+			OrigStatus:       txJson.OrigStatus,
+			EndStatus:        txJson.EndStatus,
+			TotalFees:        txJson.TotalFees,
+			PrevTransHash:    base64ToHex(txJson.PrevTransHash),
+			PrevTransLt:      txJson.PrevTransLt,
+			Aborted:          description.Aborted,
+			Description:      string(txJson.Description),
+			BlockNumber:      obj.Master.Seqno,
+			BlockHash:        blockHash,
+			BlockTimestamp:   obj.Master.GenUtime,
+			TransactionIndex: uint64(txI),
+			IndexedAt:        indexedAt,
+		}
+
+		if txJson.InMsg != nil {
+			inMsg, err := toProtoMessage(txJson.InMsg, "in", 0)
+			if err != nil {
+				return nil, err
+			}
+			tx.InMsg = inMsg
+		}
+
+		for msgI := range txJson.OutMsgs {
+			outMsg, err := toProtoMessage(&txJson.OutMsgs[msgI], "out", uint64(msgI))
+			if err != nil {
+				return nil, err
+			}
+			tx.OutMsgs = append(tx.OutMsgs, outMsg)
+		}
+
+		block.Transactions = append(block.Transactions, tx)
+	}
+
+	return block, nil
+}
@@ -0,0 +1,928 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v3.6.1
+// source: blockchain/ton/ton_index_types.proto
+
+package ton
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Represents a single internal or external message of transaction
+type TonMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash         string `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Direction    string `protobuf:"bytes,2,opt,name=direction,proto3" json:"direction,omitempty"`                            // in or out
+	MessageIndex uint64 `protobuf:"varint,3,opt,name=message_index,json=messageIndex,proto3" json:"message_index,omitempty"` // The index of the message in transaction out messages, 0 for in message
+	Source       string `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`                                  // Empty for external in messages
+	Destination  string `protobuf:"bytes,5,opt,name=destination,proto3" json:"destination,omitempty"`                        // Empty for external out messages (account events)
+	Value        uint64 `protobuf:"varint,6,opt,name=value,proto3" json:"value,omitempty"`                                   // Nanotons
+	FwdFee       uint64 `protobuf:"varint,7,opt,name=fwd_fee,json=fwdFee,proto3" json:"fwd_fee,omitempty"`
+	IhrFee       uint64 `protobuf:"varint,8,opt,name=ihr_fee,json=ihrFee,proto3" json:"ihr_fee,omitempty"`
+	CreatedLt    uint64 `protobuf:"varint,9,opt,name=created_lt,json=createdLt,proto3" json:"created_lt,omitempty"`
+	CreatedAt    uint64 `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Opcode       string `protobuf:"bytes,11,opt,name=opcode,proto3" json:"opcode,omitempty"` // 0x-prefixed 32 bit operation code of body
+	Bounce       bool   `protobuf:"varint,12,opt,name=bounce,proto3" json:"bounce,omitempty"`
+	Bounced      bool   `protobuf:"varint,13,opt,name=bounced,proto3" json:"bounced,omitempty"`
+	Body         string `protobuf:"bytes,14,opt,name=body,proto3" json:"body,omitempty"`       // Base64 encoded body cell
+	Decoded      string `protobuf:"bytes,15,opt,name=decoded,proto3" json:"decoded,omitempty"` // JSON encoded body decoded by API, if available
+}
+
+func (x *TonMessage) Reset() {
+	*x = TonMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_ton_ton_index_types_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TonMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TonMessage) ProtoMessage() {}
+
+func (x *TonMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_ton_ton_index_types_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TonMessage.ProtoReflect.Descriptor instead.
+func (*TonMessage) Descriptor() ([]byte, []int) {
+	return file_blockchain_ton_ton_index_types_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TonMessage) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *TonMessage) GetDirection() string {
+	if x != nil {
+		return x.Direction
+	}
+	return ""
+}
+
+func (x *TonMessage) GetMessageIndex() uint64 {
+	if x != nil {
+		return x.MessageIndex
+	}
+	return 0
+}
+
+func (x *TonMessage) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *TonMessage) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+func (x *TonMessage) GetValue() uint64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+func (x *TonMessage) GetFwdFee() uint64 {
+	if x != nil {
+		return x.FwdFee
+	}
+	return 0
+}
+
+func (x *TonMessage) GetIhrFee() uint64 {
+	if x != nil {
+		return x.IhrFee
+	}
+	return 0
+}
+
+func (x *TonMessage) GetCreatedLt() uint64 {
+	if x != nil {
+		return x.CreatedLt
+	}
+	return 0
+}
+
+func (x *TonMessage) GetCreatedAt() uint64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *TonMessage) GetOpcode() string {
+	if x != nil {
+		return x.Opcode
+	}
+	return ""
+}
+
+func (x *TonMessage) GetBounce() bool {
+	if x != nil {
+		return x.Bounce
+	}
+	return false
+}
+
+func (x *TonMessage) GetBounced() bool {
+	if x != nil {
+		return x.Bounced
+	}
+	return false
+}
+
+func (x *TonMessage) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *TonMessage) GetDecoded() string {
+	if x != nil {
+		return x.Decoded
+	}
+	return ""
+}
+
+// Represents a single transaction of account within a shard block
+type TonTransaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Hash             string        `protobuf:"bytes,1,opt,name=hash,proto3" json:"hash,omitempty"`
+	Account          string        `protobuf:"bytes,2,opt,name=account,proto3" json:"account,omitempty"`
+	Lt               uint64        `protobuf:"varint,3,opt,name=lt,proto3" json:"lt,omitempty"`
+	Now              uint64        `protobuf:"varint,4,opt,name=now,proto3" json:"now,omitempty"`
+	Workchain        int32         `protobuf:"varint,5,opt,name=workchain,proto3" json:"workchain,omitempty"`
+	Shard            string        `protobuf:"bytes,6,opt,name=shard,proto3" json:"shard,omitempty"`
+	ShardSeqno       uint64        `protobuf:"varint,7,opt,name=shard_seqno,json=shardSeqno,proto3" json:"shard_seqno,omitempty"`
+	OrigStatus       string        `protobuf:"bytes,8,opt,name=orig_status,json=origStatus,proto3" json:"orig_status,omitempty"`
+	EndStatus        string        `protobuf:"bytes,9,opt,name=end_status,json=endStatus,proto3" json:"end_status,omitempty"`
+	TotalFees        uint64        `protobuf:"varint,10,opt,name=total_fees,json=totalFees,proto3" json:"total_fees,omitempty"`
+	PrevTransHash    string        `protobuf:"bytes,11,opt,name=prev_trans_hash,json=prevTransHash,proto3" json:"prev_trans_hash,omitempty"`
+	PrevTransLt      uint64        `protobuf:"varint,12,opt,name=prev_trans_lt,json=prevTransLt,proto3" json:"prev_trans_lt,omitempty"`
+	Aborted          bool          `protobuf:"varint,13,opt,name=aborted,proto3" json:"aborted,omitempty"`
+	Description      string        `protobuf:"bytes,14,opt,name=description,proto3" json:"description,omitempty"`                     // JSON encoded transaction description with compute and action phases
+	BlockNumber      uint64        `protobuf:"varint,15,opt,name=block_number,json=blockNumber,proto3" json:"block_number,omitempty"` // Masterchain block seqno
+	BlockHash        string        `protobuf:"bytes,16,opt,name=block_hash,json=blockHash,proto3" json:"block_hash,omitempty"`        // Masterchain block root hash
+	BlockTimestamp   uint64        `protobuf:"varint,17,opt,name=block_timestamp,json=blockTimestamp,proto3" json:"block_timestamp,omitempty"`
+	TransactionIndex uint64        `protobuf:"varint,18,opt,name=transaction_index,json=transactionIndex,proto3" json:"transaction_index,omitempty"` // The index of the transaction in the masterchain block
+	IndexedAt        uint64        `protobuf:"varint,19,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	InMsg            *TonMessage   `protobuf:"bytes,20,opt,name=in_msg,json=inMsg,proto3" json:"in_msg,omitempty"`
+	OutMsgs          []*TonMessage `protobuf:"bytes,21,rep,name=out_msgs,json=outMsgs,proto3" json:"out_msgs,omitempty"`
+}
+
+func (x *TonTransaction) Reset() {
+	*x = TonTransaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_ton_ton_index_types_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TonTransaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TonTransaction) ProtoMessage() {}
+
+func (x *TonTransaction) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_ton_ton_index_types_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TonTransaction.ProtoReflect.Descriptor instead.
+func (*TonTransaction) Descriptor() ([]byte, []int) {
+	return file_blockchain_ton_ton_index_types_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TonTransaction) GetHash() string {
+	if x != nil {
+		return x.Hash
+	}
+	return ""
+}
+
+func (x *TonTransaction) GetAccount() string {
+	if x != nil {
+		return x.Account
+	}
+	return ""
+}
+
+func (x *TonTransaction) GetLt() uint64 {
+	if x != nil {
+		return x.Lt
+	}
+	return 0
+}
+
+func (x *TonTransaction) GetNow() uint64 {
+	if x != nil {
+		return x.Now
+	}
+	return 0
+}
+
+func (x *TonTransaction) GetWorkchain() int32 {
+	if x != nil {
+		return x.Workchain
+	}
+	return 0
+}
+
+func (x *TonTransaction) GetShard() string {
+	if x != nil {
+		return x.Shard
+	}
+	return ""
+}
+
+func (x *TonTransaction) GetShardSeqno() uint64 {
+	if x != nil {
+		return x.ShardSeqno
+	}
+	return 0
+}
+
+func (x *TonTransaction) GetOrigStatus() string {
+	if x != nil {
+		return x.OrigStatus
+	}
+	return ""
+}
+
+func (x *TonTransaction) GetEndStatus() string {
+	if x != nil {
+		return x.EndStatus
+	}
+	return ""
+}
+
+func (x *TonTransaction) GetTotalFees() uint64 {
+	if x != nil {
+		return x.TotalFees
+	}
+	return 0
+}
+
+func (x *TonTransaction) GetPrevTransHash() string {
+	if x != nil {
+		return x.PrevTransHash
+	}
+	return ""
+}
+
+func (x *TonTransaction) GetPrevTransLt() uint64 {
+	if x != nil {
+		return x.PrevTransLt
+	}
+	return 0
+}
+
+func (x *TonTransaction) GetAborted() bool {
+	if x != nil {
+		return x.Aborted
+	}
+	return false
+}
+
+func (x *TonTransaction) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *TonTransaction) GetBlockNumber() uint64 {
+	if x != nil {
+		return x.BlockNumber
+	}
+	return 0
+}
+
+func (x *TonTransaction) GetBlockHash() string {
+	if x != nil {
+		return x.BlockHash
+	}
+	return ""
+}
+
+func (x *TonTransaction) GetBlockTimestamp() uint64 {
+	if x != nil {
+		return x.BlockTimestamp
+	}
+	return 0
+}
+
+func (x *TonTransaction) GetTransactionIndex() uint64 {
+	if x != nil {
+		return x.TransactionIndex
+	}
+	return 0
+}
+
+func (x *TonTransaction) GetIndexedAt() uint64 {
+	if x != nil {
+		return x.IndexedAt
+	}
+	return 0
+}
+
+func (x *TonTransaction) GetInMsg() *TonMessage {
+	if x != nil {
+		return x.InMsg
+	}
+	return nil
+}
+
+func (x *TonTransaction) GetOutMsgs() []*TonMessage {
+	if x != nil {
+		return x.OutMsgs
+	}
+	return nil
+}
+
+// Represents a shard block committed in masterchain block
+type TonShardBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Workchain int32  `protobuf:"varint,1,opt,name=workchain,proto3" json:"workchain,omitempty"`
+	Shard     string `protobuf:"bytes,2,opt,name=shard,proto3" json:"shard,omitempty"`
+	Seqno     uint64 `protobuf:"varint,3,opt,name=seqno,proto3" json:"seqno,omitempty"`
+	RootHash  string `protobuf:"bytes,4,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	FileHash  string `protobuf:"bytes,5,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
+	GenUtime  uint64 `protobuf:"varint,6,opt,name=gen_utime,json=genUtime,proto3" json:"gen_utime,omitempty"`
+	StartLt   uint64 `protobuf:"varint,7,opt,name=start_lt,json=startLt,proto3" json:"start_lt,omitempty"`
+	EndLt     uint64 `protobuf:"varint,8,opt,name=end_lt,json=endLt,proto3" json:"end_lt,omitempty"`
+	TxCount   uint64 `protobuf:"varint,9,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+}
+
+func (x *TonShardBlock) Reset() {
+	*x = TonShardBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_ton_ton_index_types_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TonShardBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TonShardBlock) ProtoMessage() {}
+
+func (x *TonShardBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_ton_ton_index_types_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TonShardBlock.ProtoReflect.Descriptor instead.
+func (*TonShardBlock) Descriptor() ([]byte, []int) {
+	return file_blockchain_ton_ton_index_types_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TonShardBlock) GetWorkchain() int32 {
+	if x != nil {
+		return x.Workchain
+	}
+	return 0
+}
+
+func (x *TonShardBlock) GetShard() string {
+	if x != nil {
+		return x.Shard
+	}
+	return ""
+}
+
+func (x *TonShardBlock) GetSeqno() uint64 {
+	if x != nil {
+		return x.Seqno
+	}
+	return 0
+}
+
+func (x *TonShardBlock) GetRootHash() string {
+	if x != nil {
+		return x.RootHash
+	}
+	return ""
+}
+
+func (x *TonShardBlock) GetFileHash() string {
+	if x != nil {
+		return x.FileHash
+	}
+	return ""
+}
+
+func (x *TonShardBlock) GetGenUtime() uint64 {
+	if x != nil {
+		return x.GenUtime
+	}
+	return 0
+}
+
+func (x *TonShardBlock) GetStartLt() uint64 {
+	if x != nil {
+		return x.StartLt
+	}
+	return 0
+}
+
+func (x *TonShardBlock) GetEndLt() uint64 {
+	if x != nil {
+		return x.EndLt
+	}
+	return 0
+}
+
+func (x *TonShardBlock) GetTxCount() uint64 {
+	if x != nil {
+		return x.TxCount
+	}
+	return 0
+}
+
+// Represents masterchain block, the crawl unit of TON
+type TonBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Seqno        uint64            `protobuf:"varint,1,opt,name=seqno,proto3" json:"seqno,omitempty"`
+	RootHash     string            `protobuf:"bytes,2,opt,name=root_hash,json=rootHash,proto3" json:"root_hash,omitempty"`
+	FileHash     string            `protobuf:"bytes,3,opt,name=file_hash,json=fileHash,proto3" json:"file_hash,omitempty"`
+	GlobalId     int32             `protobuf:"varint,4,opt,name=global_id,json=globalId,proto3" json:"global_id,omitempty"`
+	GenUtime     uint64            `protobuf:"varint,5,opt,name=gen_utime,json=genUtime,proto3" json:"gen_utime,omitempty"`
+	StartLt      uint64            `protobuf:"varint,6,opt,name=start_lt,json=startLt,proto3" json:"start_lt,omitempty"`
+	EndLt        uint64            `protobuf:"varint,7,opt,name=end_lt,json=endLt,proto3" json:"end_lt,omitempty"`
+	TxCount      uint64            `protobuf:"varint,8,opt,name=tx_count,json=txCount,proto3" json:"tx_count,omitempty"`
+	IndexedAt    uint64            `protobuf:"varint,9,opt,name=indexed_at,json=indexedAt,proto3" json:"indexed_at,omitempty"`
+	ShardBlocks  []*TonShardBlock  `protobuf:"bytes,10,rep,name=shard_blocks,json=shardBlocks,proto3" json:"shard_blocks,omitempty"`
+	Transactions []*TonTransaction `protobuf:"bytes,11,rep,name=transactions,proto3" json:"transactions,omitempty"`
+}
+
+func (x *TonBlock) Reset() {
+	*x = TonBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_ton_ton_index_types_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TonBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TonBlock) ProtoMessage() {}
+
+func (x *TonBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_ton_ton_index_types_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TonBlock.ProtoReflect.Descriptor instead.
+func (*TonBlock) Descriptor() ([]byte, []int) {
+	return file_blockchain_ton_ton_index_types_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TonBlock) GetSeqno() uint64 {
+	if x != nil {
+		return x.Seqno
+	}
+	return 0
+}
+
+func (x *TonBlock) GetRootHash() string {
+	if x != nil {
+		return x.RootHash
+	}
+	return ""
+}
+
+func (x *TonBlock) GetFileHash() string {
+	if x != nil {
+		return x.FileHash
+	}
+	return ""
+}
+
+func (x *TonBlock) GetGlobalId() int32 {
+	if x != nil {
+		return x.GlobalId
+	}
+	return 0
+}
+
+func (x *TonBlock) GetGenUtime() uint64 {
+	if x != nil {
+		return x.GenUtime
+	}
+	return 0
+}
+
+func (x *TonBlock) GetStartLt() uint64 {
+	if x != nil {
+		return x.StartLt
+	}
+	return 0
+}
+
+func (x *TonBlock) GetEndLt() uint64 {
+	if x != nil {
+		return x.EndLt
+	}
+	return 0
+}
+
+func (x *TonBlock) GetTxCount() uint64 {
+	if x != nil {
+		return x.TxCount
+	}
+	return 0
+}
+
+func (x *TonBlock) GetIndexedAt() uint64 {
+	if x != nil {
+		return x.IndexedAt
+	}
+	return 0
+}
+
+func (x *TonBlock) GetShardBlocks() []*TonShardBlock {
+	if x != nil {
+		return x.ShardBlocks
+	}
+	return nil
+}
+
+func (x *TonBlock) GetTransactions() []*TonTransaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+type TonBlocksBatch struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Blocks      []*TonBlock `protobuf:"bytes,1,rep,name=blocks,proto3" json:"blocks,omitempty"`
+	SeerVersion string      `protobuf:"bytes,2,opt,name=seer_version,json=seerVersion,proto3" json:"seer_version,omitempty"`
+}
+
+func (x *TonBlocksBatch) Reset() {
+	*x = TonBlocksBatch{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_blockchain_ton_ton_index_types_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TonBlocksBatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TonBlocksBatch) ProtoMessage() {}
+
+func (x *TonBlocksBatch) ProtoReflect() protoreflect.Message {
+	mi := &file_blockchain_ton_ton_index_types_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TonBlocksBatch.ProtoReflect.Descriptor instead.
+func (*TonBlocksBatch) Descriptor() ([]byte, []int) {
+	return file_blockchain_ton_ton_index_types_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *TonBlocksBatch) GetBlocks() []*TonBlock {
+	if x != nil {
+		return x.Blocks
+	}
+	return nil
+}
+
+func (x *TonBlocksBatch) GetSeerVersion() string {
+	if x != nil {
+		return x.SeerVersion
+	}
+	return ""
+}
+
+var File_blockchain_ton_ton_index_types_proto protoreflect.FileDescriptor
+
+var file_blockchain_ton_ton_index_types_proto_rawDesc = []byte{
+	0x0a, 0x24, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x74, 0x6f, 0x6e,
+	0x2f, 0x74, 0x6f, 0x6e, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x5f, 0x74, 0x79, 0x70, 0x65, 0x73,
+	0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x9b, 0x03, 0x0a, 0x0a, 0x54, 0x6f, 0x6e, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x64, 0x69, 0x72,
+	0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x64, 0x69,
+	0x72, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x23, 0x0a, 0x0d, 0x6d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0c,
+	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x17, 0x0a, 0x07,
+	0x66, 0x77, 0x64, 0x5f, 0x66, 0x65, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x66,
+	0x77, 0x64, 0x46, 0x65, 0x65, 0x12, 0x17, 0x0a, 0x07, 0x69, 0x68, 0x72, 0x5f, 0x66, 0x65, 0x65,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x69, 0x68, 0x72, 0x46, 0x65, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x6c, 0x74, 0x18, 0x09, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x4c, 0x74, 0x12, 0x1d, 0x0a,
+	0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x12, 0x16, 0x0a, 0x06,
+	0x6f, 0x70, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6f, 0x70,
+	0x63, 0x6f, 0x64, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x18, 0x0c,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x62, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x12, 0x18, 0x0a, 0x07,
+	0x62, 0x6f, 0x75, 0x6e, 0x63, 0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x62,
+	0x6f, 0x75, 0x6e, 0x63, 0x65, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x0e,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x63, 0x6f, 0x64, 0x65, 0x64, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x64, 0x65, 0x63,
+	0x6f, 0x64, 0x65, 0x64, 0x22, 0x9f, 0x05, 0x0a, 0x0e, 0x54, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x68, 0x61, 0x73, 0x68, 0x12, 0x18, 0x0a, 0x07, 0x61,
+	0x63, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x63,
+	0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x6c, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x02, 0x6c, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6e, 0x6f, 0x77, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x03, 0x6e, 0x6f, 0x77, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b, 0x63,
+	0x68, 0x61, 0x69, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x6f, 0x72, 0x6b,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x64, 0x18, 0x06,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x73,
+	0x68, 0x61, 0x72, 0x64, 0x5f, 0x73, 0x65, 0x71, 0x6e, 0x6f, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x0a, 0x73, 0x68, 0x61, 0x72, 0x64, 0x53, 0x65, 0x71, 0x6e, 0x6f, 0x12, 0x1f, 0x0a, 0x0b,
+	0x6f, 0x72, 0x69, 0x67, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0a, 0x6f, 0x72, 0x69, 0x67, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x0a,
+	0x0a, 0x65, 0x6e, 0x64, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x09, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x09, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x1d, 0x0a, 0x0a,
+	0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x66, 0x65, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x46, 0x65, 0x65, 0x73, 0x12, 0x26, 0x0a, 0x0f, 0x70,
+	0x72, 0x65, 0x76, 0x5f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x0b,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x22, 0x0a, 0x0d, 0x70, 0x72, 0x65, 0x76, 0x5f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x5f, 0x6c, 0x74, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x70, 0x72, 0x65, 0x76,
+	0x54, 0x72, 0x61, 0x6e, 0x73, 0x4c, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x62, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x61, 0x62, 0x6f, 0x72, 0x74, 0x65,
+	0x64, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x18, 0x0e, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x6e, 0x75, 0x6d,
+	0x62, 0x65, 0x72, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0b, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x12, 0x1d, 0x0a, 0x0a, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f,
+	0x68, 0x61, 0x73, 0x68, 0x18, 0x10, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x62, 0x6c, 0x6f, 0x63,
+	0x6b, 0x48, 0x61, 0x73, 0x68, 0x12, 0x27, 0x0a, 0x0f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x5f, 0x74,
+	0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x11, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0e,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x2b,
+	0x0a, 0x11, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x18, 0x12, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x1d, 0x0a, 0x0a, 0x69,
+	0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x13, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x41, 0x74, 0x12, 0x22, 0x0a, 0x06, 0x69, 0x6e,
+	0x5f, 0x6d, 0x73, 0x67, 0x18, 0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x54, 0x6f, 0x6e,
+	0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x05, 0x69, 0x6e, 0x4d, 0x73, 0x67, 0x12, 0x26,
+	0x0a, 0x08, 0x6f, 0x75, 0x74, 0x5f, 0x6d, 0x73, 0x67, 0x73, 0x18, 0x15, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x0b, 0x2e, 0x54, 0x6f, 0x6e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6f,
+	0x75, 0x74, 0x4d, 0x73, 0x67, 0x73, 0x22, 0xfd, 0x01, 0x0a, 0x0d, 0x54, 0x6f, 0x6e, 0x53, 0x68,
+	0x61, 0x72, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x77, 0x6f, 0x72, 0x6b,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x77, 0x6f, 0x72,
+	0x6b, 0x63, 0x68, 0x61, 0x69, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x68, 0x61, 0x72, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x68, 0x61, 0x72, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x65, 0x71, 0x6e, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71,
+	0x6e, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x6f, 0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x6f, 0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12,
+	0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09,
+	0x67, 0x65, 0x6e, 0x5f, 0x75, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x08, 0x67, 0x65, 0x6e, 0x55, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x5f, 0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x4c, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x5f, 0x6c, 0x74, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x6e, 0x64, 0x4c, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x74,
+	0x78, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x07, 0x74,
+	0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xe8, 0x02, 0x0a, 0x08, 0x54, 0x6f, 0x6e, 0x42, 0x6c,
+	0x6f, 0x63, 0x6b, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x65, 0x71, 0x6e, 0x6f, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x05, 0x73, 0x65, 0x71, 0x6e, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x72, 0x6f, 0x6f,
+	0x74, 0x5f, 0x68, 0x61, 0x73, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x72, 0x6f,
+	0x6f, 0x74, 0x48, 0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x69, 0x6c, 0x65, 0x5f, 0x68,
+	0x61, 0x73, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x48,
+	0x61, 0x73, 0x68, 0x12, 0x1b, 0x0a, 0x09, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x5f, 0x69, 0x64,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x67, 0x6c, 0x6f, 0x62, 0x61, 0x6c, 0x49, 0x64,
+	0x12, 0x1b, 0x0a, 0x09, 0x67, 0x65, 0x6e, 0x5f, 0x75, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x08, 0x67, 0x65, 0x6e, 0x55, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x19, 0x0a,
+	0x08, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x6c, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x07, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4c, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x65, 0x6e, 0x64, 0x5f,
+	0x6c, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x65, 0x6e, 0x64, 0x4c, 0x74, 0x12,
+	0x19, 0x0a, 0x08, 0x74, 0x78, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x07, 0x74, 0x78, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x6e,
+	0x64, 0x65, 0x78, 0x65, 0x64, 0x5f, 0x61, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x04, 0x52, 0x09,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x65, 0x64, 0x41, 0x74, 0x12, 0x31, 0x0a, 0x0c, 0x73, 0x68, 0x61,
+	0x72, 0x64, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x54, 0x6f, 0x6e, 0x53, 0x68, 0x61, 0x72, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52,
+	0x0b, 0x73, 0x68, 0x61, 0x72, 0x64, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x33, 0x0a, 0x0c,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x0b, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x54, 0x6f, 0x6e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x52, 0x0c, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x22, 0x56, 0x0a, 0x0e, 0x54, 0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x42, 0x61,
+	0x74, 0x63, 0x68, 0x12, 0x21, 0x0a, 0x06, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x09, 0x2e, 0x54, 0x6f, 0x6e, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x52, 0x06,
+	0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x12, 0x21, 0x0a, 0x0c, 0x73, 0x65, 0x65, 0x72, 0x5f, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x73, 0x65,
+	0x65, 0x72, 0x56, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x42, 0x2e, 0x5a, 0x2c, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x6d, 0x6f, 0x6f, 0x6e, 0x73, 0x74, 0x72, 0x65,
+	0x61, 0x6d, 0x2d, 0x74, 0x6f, 0x2f, 0x73, 0x65, 0x65, 0x72, 0x2f, 0x62, 0x6c, 0x6f, 0x63, 0x6b,
+	0x63, 0x68, 0x61, 0x69, 0x6e, 0x2f, 0x74, 0x6f, 0x6e, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
+}
+
+var (
+	file_blockchain_ton_ton_index_types_proto_rawDescOnce sync.Once
+	file_blockchain_ton_ton_index_types_proto_rawDescData = file_blockchain_ton_ton_index_types_proto_rawDesc
+)
+
+func file_blockchain_ton_ton_index_types_proto_rawDescGZIP() []byte {
+	file_blockchain_ton_ton_index_types_proto_rawDescOnce.Do(func() {
+		file_blockchain_ton_ton_index_types_proto_rawDescData = protoimpl.X.CompressGZIP(file_blockchain_ton_ton_index_types_proto_rawDescData)
+	})
+	return file_blockchain_ton_ton_index_types_proto_rawDescData
+}
+
+var file_blockchain_ton_ton_index_types_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_blockchain_ton_ton_index_types_proto_goTypes = []any{
+	(*TonMessage)(nil),     // 0: TonMessage
+	(*TonTransaction)(nil), // 1: TonTransaction
+	(*TonShardBlock)(nil),  // 2: TonShardBlock
+	(*TonBlock)(nil),       // 3: TonBlock
+	(*TonBlocksBatch)(nil), // 4: TonBlocksBatch
+}
+var file_blockchain_ton_ton_index_types_proto_depIdxs = []int32{
+	0, // 0: TonTransaction.in_msg:type_name -> TonMessage
+	0, // 1: TonTransaction.out_msgs:type_name -> TonMessage
+	2, // 2: TonBlock.shard_blocks:type_name -> TonShardBlock
+	1, // 3: TonBlock.transactions:type_name -> TonTransaction
+	3, // 4: TonBlocksBatch.blocks:type_name -> TonBlock
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
+}
+
+func init() { file_blockchain_ton_ton_index_types_proto_init() }
+func file_blockchain_ton_ton_index_types_proto_init() {
+	if File_blockchain_ton_ton_index_types_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_blockchain_ton_ton_index_types_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*TonMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_ton_ton_index_types_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*TonTransaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_ton_ton_index_types_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*TonShardBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_ton_ton_index_types_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*TonBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_blockchain_ton_ton_index_types_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*TonBlocksBatch); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_blockchain_ton_ton_index_types_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   5,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_blockchain_ton_ton_index_types_proto_goTypes,
+		DependencyIndexes: file_blockchain_ton_ton_index_types_proto_depIdxs,
+		MessageInfos:      file_blockchain_ton_ton_index_types_proto_msgTypes,
+	}.Build()
+	File_blockchain_ton_ton_index_types_proto = out.File
+	file_blockchain_ton_ton_index_types_proto_rawDesc = nil
+	file_blockchain_ton_ton_index_types_proto_goTypes = nil
+	file_blockchain_ton_ton_index_types_proto_depIdxs = nil
+}
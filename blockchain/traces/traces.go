@@ -0,0 +1,391 @@
+package traces
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/proto"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/version"
+)
+
+// CallTracesIndexKind is kind of custom index with call frames, stored in <chain>_call_traces table
+const CallTracesIndexKind = "call_traces"
+
+// CallTracesIndexTable describes index table of call frames, registered by crawler for chains
+// crawled with debug traces. Frame position in call tree is stored as trace_address, comma
+// separated indexes of calls from top level call, empty for top level call.
+var CallTracesIndexTable = indexer.CustomIndexTable{
+	Kind: CallTracesIndexKind,
+	Columns: []indexer.CustomIndexColumn{
+		{Name: "block_number", Type: "BIGINT"},
+		{Name: "block_hash", Type: "TEXT"},
+		{Name: "block_timestamp", Type: "BIGINT"},
+		{Name: "transaction_hash", Type: "TEXT"},
+		{Name: "trace_address", Type: "TEXT"},
+		{Name: "depth", Type: "BIGINT"},
+		{Name: "call_type", Type: "TEXT"},
+		{Name: "from_address", Type: "TEXT"},
+		{Name: "to_address", Type: "TEXT"},
+		{Name: "selector", Type: "TEXT"},
+		{Name: "failed", Type: "BOOLEAN"},
+	},
+	ConflictClause: "ON CONFLICT (transaction_hash, trace_address) DO NOTHING",
+}
+
+// FlatTracesIndexKind is kind of custom index with flat traces, stored in <chain>_flat_traces table
+const FlatTracesIndexKind = "flat_traces"
+
+// FlatTracesIndexTable describes index table of flat traces, registered by crawler for chains
+// crawled with Parity traces. Rewards are not indexed as they do not belong to transactions.
+var FlatTracesIndexTable = indexer.CustomIndexTable{
+	Kind: FlatTracesIndexKind,
+	Columns: []indexer.CustomIndexColumn{
+		{Name: "block_number", Type: "BIGINT"},
+		{Name: "block_hash", Type: "TEXT"},
+		{Name: "block_timestamp", Type: "BIGINT"},
+		{Name: "transaction_hash", Type: "TEXT"},
+		{Name: "trace_address", Type: "TEXT"},
+		{Name: "depth", Type: "BIGINT"},
+		{Name: "trace_type", Type: "TEXT"},
+		{Name: "call_type", Type: "TEXT"},
+		{Name: "from_address", Type: "TEXT"},
+		{Name: "to_address", Type: "TEXT"},
+		{Name: "value", Type: "NUMERIC"},
+		{Name: "selector", Type: "TEXT"},
+		{Name: "failed", Type: "BOOLEAN"},
+	},
+	ConflictClause: "ON CONFLICT (transaction_hash, trace_address) DO NOTHING",
+}
+
+// Block identifies block to trace, transaction hashes are used to match traces of nodes which
+// do not return txHash with results.
+type Block struct {
+	Number            uint64
+	Hash              string
+	Timestamp         uint64
+	TransactionHashes []string
+}
+
+// TraceBlockCalls traces all transactions of block with debug_traceBlockByNumber and callTracer.
+func TraceBlockCalls(ctx context.Context, rpcClient *seer_common.RPCClient, block Block) ([]*TransactionCallTrace, error) {
+	if len(block.TransactionHashes) == 0 {
+		return nil, nil
+	}
+
+	var results []CallTraceResultJson
+	err := rpcClient.CallContext(ctx, &results, "debug_traceBlockByNumber", fmt.Sprintf("0x%x", block.Number), map[string]interface{}{"tracer": "callTracer"})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) != len(block.TransactionHashes) {
+		return nil, fmt.Errorf("got %d traces for %d transactions of block %d", len(results), len(block.TransactionHashes), block.Number)
+	}
+
+	indexedAt := uint64(time.Now().Unix())
+
+	var traces []*TransactionCallTrace
+	for i, result := range results {
+		if result.Error != "" {
+			return nil, fmt.Errorf("failed to trace transaction %s: %s", block.TransactionHashes[i], result.Error)
+		}
+		if result.TxHash != "" && !strings.EqualFold(result.TxHash, block.TransactionHashes[i]) {
+			return nil, fmt.Errorf("traces of block %d do not match transactions order", block.Number)
+		}
+		if result.Result == nil {
+			continue
+		}
+
+		traces = append(traces, &TransactionCallTrace{
+			BlockNumber:      block.Number,
+			BlockHash:        block.Hash,
+			BlockTimestamp:   block.Timestamp,
+			TransactionHash:  block.TransactionHashes[i],
+			TransactionIndex: uint64(i),
+			Call:             ToProtoCallFrame(result.Result),
+			IndexedAt:        indexedAt,
+		})
+	}
+
+	return traces, nil
+}
+
+// TraceBlockFlat returns flat traces of block from trace_block of Parity trace module, available
+// on Erigon, Nethermind, Reth and OpenEthereum nodes.
+func TraceBlockFlat(ctx context.Context, rpcClient *seer_common.RPCClient, block Block) ([]*FlatTrace, error) {
+	var results []FlatTraceJson
+	err := rpcClient.CallContext(ctx, &results, "trace_block", fmt.Sprintf("0x%x", block.Number))
+	if err != nil {
+		return nil, err
+	}
+
+	indexedAt := uint64(time.Now().Unix())
+
+	var traces []*FlatTrace
+	for i := range results {
+		if !strings.EqualFold(results[i].BlockHash, block.Hash) {
+			return nil, fmt.Errorf("trace of block %d has hash %s, expected %s", block.Number, results[i].BlockHash, block.Hash)
+		}
+
+		trace := ToProtoFlatTrace(&results[i])
+		trace.BlockTimestamp = block.Timestamp
+		trace.IndexedAt = indexedAt
+		traces = append(traces, trace)
+	}
+
+	return traces, nil
+}
+
+func ToProtoFlatTrace(obj *FlatTraceJson) *FlatTrace {
+	trace := &FlatTrace{
+		BlockNumber:     obj.BlockNumber,
+		BlockHash:       obj.BlockHash,
+		TransactionHash: obj.TransactionHash,
+		Type:            obj.Type,
+		TraceAddress:    obj.TraceAddress,
+		Subtraces:       obj.Subtraces,
+		Gas:             obj.Action.Gas,
+		Value:           obj.Action.Value,
+		Error:           obj.Error,
+	}
+	if obj.TransactionPosition != nil {
+		trace.TransactionIndex = *obj.TransactionPosition
+	}
+	if obj.Result != nil {
+		trace.GasUsed = obj.Result.GasUsed
+	}
+
+	switch obj.Type {
+	case "call":
+		trace.CallType = obj.Action.CallType
+		trace.FromAddress = obj.Action.From
+		trace.ToAddress = obj.Action.To
+		trace.Input = obj.Action.Input
+		if obj.Result != nil {
+			trace.Output = obj.Result.Output
+		}
+	case "create":
+		trace.FromAddress = obj.Action.From
+		trace.Input = obj.Action.Init
+		if obj.Result != nil {
+			trace.ToAddress = obj.Result.Address
+			trace.Output = obj.Result.Code
+		}
+	case "suicide":
+		trace.FromAddress = obj.Action.Address
+		trace.ToAddress = obj.Action.RefundAddress
+		trace.Value = obj.Action.Balance
+	case "reward":
+		trace.FromAddress = obj.Action.Author
+		trace.RewardType = obj.Action.RewardType
+	}
+
+	return trace
+}
+
+func ToProtoCallFrame(obj *CallFrameJson) *CallFrame {
+	var calls []*CallFrame
+	for i := range obj.Calls {
+		calls = append(calls, ToProtoCallFrame(&obj.Calls[i]))
+	}
+
+	return &CallFrame{
+		Type:         obj.Type,
+		FromAddress:  obj.From,
+		ToAddress:    obj.To,
+		Value:        obj.Value,
+		Gas:          obj.Gas,
+		GasUsed:      obj.GasUsed,
+		Input:        obj.Input,
+		Output:       obj.Output,
+		Error:        obj.Error,
+		RevertReason: obj.RevertReason,
+		Calls:        calls,
+	}
+}
+
+// ProcessCallTracesToBatch returns batch of call traces stored next to blocks batch.
+func ProcessCallTracesToBatch(traces []*TransactionCallTrace) proto.Message {
+	return &CallTracesBatch{
+		Traces:      traces,
+		SeerVersion: version.SeerVersion,
+	}
+}
+
+// ProcessFlatTracesToBatch returns batch of flat traces stored next to blocks batch.
+func ProcessFlatTracesToBatch(traces []*FlatTrace) proto.Message {
+	return &FlatTracesBatch{
+		Traces:      traces,
+		SeerVersion: version.SeerVersion,
+	}
+}
+
+// FlatTracesToCustomIndexes returns rows of flat traces index table, rewards are skipped.
+func FlatTracesToCustomIndexes(traces []*FlatTrace) []indexer.CustomIndex {
+	var indexes []indexer.CustomIndex
+	for _, trace := range traces {
+		if trace.TransactionHash == "" {
+			continue
+		}
+
+		traceAddress := make([]string, len(trace.TraceAddress))
+		for i, index := range trace.TraceAddress {
+			traceAddress[i] = strconv.FormatUint(index, 10)
+		}
+
+		value := "0"
+		if trace.Value != "" {
+			if v, ok := new(big.Int).SetString(trace.Value, 0); ok {
+				value = v.String()
+			}
+		}
+
+		var selector string
+		if trace.Type == "call" {
+			selector = Selector(trace.Input)
+		}
+
+		indexes = append(indexes, indexer.CustomIndex{
+			Kind: FlatTracesIndexKind,
+			Values: map[string]interface{}{
+				"block_number":     trace.BlockNumber,
+				"block_hash":       trace.BlockHash,
+				"block_timestamp":  trace.BlockTimestamp,
+				"transaction_hash": trace.TransactionHash,
+				"trace_address":    strings.Join(traceAddress, ","),
+				"depth":            len(traceAddress),
+				"trace_type":       trace.Type,
+				"call_type":        trace.CallType,
+				"from_address":     trace.FromAddress,
+				"to_address":       trace.ToAddress,
+				"value":            value,
+				"selector":         selector,
+				"failed":           trace.Error != "",
+			},
+		})
+	}
+
+	return indexes
+}
+
+// CallTracesToCustomIndexes returns rows of call traces index table, one row per call frame.
+func CallTracesToCustomIndexes(traces []*TransactionCallTrace) []indexer.CustomIndex {
+	var indexes []indexer.CustomIndex
+	for _, trace := range traces {
+		if trace.Call == nil {
+			continue
+		}
+		indexes = appendCallFrameIndexes(indexes, trace, trace.Call, nil)
+	}
+
+	return indexes
+}
+
+func appendCallFrameIndexes(indexes []indexer.CustomIndex, trace *TransactionCallTrace, frame *CallFrame, traceAddress []string) []indexer.CustomIndex {
+	indexes = append(indexes, indexer.CustomIndex{
+		Kind: CallTracesIndexKind,
+		Values: map[string]interface{}{
+			"block_number":     trace.BlockNumber,
+			"block_hash":       trace.BlockHash,
+			"block_timestamp":  trace.BlockTimestamp,
+			"transaction_hash": trace.TransactionHash,
+			"trace_address":    strings.Join(traceAddress, ","),
+			"depth":            len(traceAddress),
+			"call_type":        frame.Type,
+			"from_address":     frame.FromAddress,
+			"to_address":       frame.ToAddress,
+			"selector":         callSelector(frame),
+			"failed":           frame.Error != "",
+		},
+	})
+
+	for i, call := range frame.Calls {
+		callAddress := append(append([]string{}, traceAddress...), strconv.Itoa(i))
+		indexes = appendCallFrameIndexes(indexes, trace, call, callAddress)
+	}
+
+	return indexes
+}
+
+func callSelector(frame *CallFrame) string {
+	if strings.HasPrefix(frame.Type, "CREATE") {
+		return ""
+	}
+
+	return Selector(frame.Input)
+}
+
+// Selector returns 4 bytes method selector of call data, empty for calls without one.
+func Selector(input string) string {
+	if len(input) < 10 || !strings.HasPrefix(input, "0x") {
+		return ""
+	}
+
+	return strings.ToLower(input[:10])
+}
+
+// Node debug API structures
+
+type CallFrameJson struct {
+	Type         string          `json:"type"`
+	From         string          `json:"from"`
+	To           string          `json:"to,omitempty"`
+	Value        string          `json:"value,omitempty"`
+	Gas          string          `json:"gas"`
+	GasUsed      string          `json:"gasUsed"`
+	Input        string          `json:"input"`
+	Output       string          `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []CallFrameJson `json:"calls,omitempty"`
+}
+
+type CallTraceResultJson struct {
+	TxHash string         `json:"txHash,omitempty"`
+	Result *CallFrameJson `json:"result"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// Node trace API structures
+
+type FlatTraceActionJson struct {
+	CallType      string `json:"callType,omitempty"`
+	From          string `json:"from,omitempty"`
+	To            string `json:"to,omitempty"`
+	Gas           string `json:"gas,omitempty"`
+	Input         string `json:"input,omitempty"`
+	Init          string `json:"init,omitempty"`
+	Value         string `json:"value,omitempty"`
+	Address       string `json:"address,omitempty"`
+	RefundAddress string `json:"refundAddress,omitempty"`
+	Balance       string `json:"balance,omitempty"`
+	Author        string `json:"author,omitempty"`
+	RewardType    string `json:"rewardType,omitempty"`
+}
+
+type FlatTraceResultJson struct {
+	GasUsed string `json:"gasUsed,omitempty"`
+	Output  string `json:"output,omitempty"`
+	Address string `json:"address,omitempty"`
+	Code    string `json:"code,omitempty"`
+}
+
+type FlatTraceJson struct {
+	Action              FlatTraceActionJson  `json:"action"`
+	BlockHash           string               `json:"blockHash"`
+	BlockNumber         uint64               `json:"blockNumber"`
+	Result              *FlatTraceResultJson `json:"result"`
+	Subtraces           uint64               `json:"subtraces"`
+	TraceAddress        []uint64             `json:"traceAddress"`
+	TransactionHash     string               `json:"transactionHash,omitempty"`
+	TransactionPosition *uint64              `json:"transactionPosition,omitempty"`
+	Type                string               `json:"type"`
+	Error               string               `json:"error,omitempty"`
+}
@@ -0,0 +1,128 @@
+// Package bundle moves crawled datasets of chain between environments. Bundle is gzipped tar
+// archive with protos of packs, rows of index tables which point to them and manifest with
+// checksums of files and schemas of index tables, so it could be verified before it is imported.
+package bundle
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/moonstream-to/seer/blockchain/beacon"
+	"github.com/moonstream-to/seer/blockchain/traces"
+	"github.com/moonstream-to/seer/indexer"
+)
+
+// FormatVersion is version of bundle layout, bundles of other versions are not imported.
+const FormatVersion = 1
+
+const (
+	ManifestFile = "manifest.json"
+
+	// Protos are stored under data directory as <pack range>/<file>, rows of index tables as
+	// indexes/<kind>.jsonl with path relative to data directory.
+	dataDir    = "data"
+	indexesDir = "indexes"
+)
+
+// Manifest describes content of bundle.
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	SeerVersion   string    `json:"seer_version"`
+	Chain         string    `json:"chain"`
+	FromBlock     uint64    `json:"from_block"`
+	ToBlock       uint64    `json:"to_block"`
+	CreatedAt     time.Time `json:"created_at"`
+	Tables        []Table   `json:"tables"`
+	Files         []File    `json:"files"`
+}
+
+// Table is index table exported to bundle. Schema is version of table columns, table could be
+// imported only to table with the same columns.
+type Table struct {
+	Kind    string   `json:"kind"`
+	Schema  string   `json:"schema"`
+	Columns []string `json:"columns"`
+	Rows    int      `json:"rows"`
+}
+
+// File is entry of bundle archive with checksum.
+type File struct {
+	Path   string `json:"path"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// tableKinds returns kinds of index tables which could be exported for blockchain. Custom tables
+// registered by crawler options are included, tables missing in database are skipped by export.
+func tableKinds(blockchain string) []string {
+	kinds := []string{"blocks", "transactions", "logs"}
+	seen := make(map[string]bool)
+	for _, table := range append(indexer.GetCustomIndexTables(blockchain), beacon.BlobSidecarsIndexTable, traces.CallTracesIndexTable, traces.FlatTracesIndexTable) {
+		if !seen[table.Kind] {
+			seen[table.Kind] = true
+			kinds = append(kinds, table.Kind)
+		}
+	}
+	return kinds
+}
+
+// tableName returns name of index table of blockchain with kind.
+func tableName(blockchain, kind string) string {
+	switch kind {
+	case "blocks":
+		return indexer.BlocksTableName(blockchain)
+	case "transactions":
+		return indexer.TransactionsTableName(blockchain)
+	case "logs":
+		return indexer.LogsTableName(blockchain)
+	default:
+		return indexer.CustomIndexTableName(blockchain, kind)
+	}
+}
+
+// schemaVersion returns short checksum of table columns.
+func schemaVersion(columns []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(columns, "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// relativePath returns path of proto in bundle data directory, it is <pack range>/<file>.
+func relativePath(storagePath string) string {
+	return path.Join(filepath.Base(filepath.Dir(storagePath)), filepath.Base(storagePath))
+}
+
+// rewritePath replaces path of index row, numbers are kept as they are to not lose precision of
+// numeric columns.
+func rewritePath(row json.RawMessage, rewrite func(string) (string, error)) (json.RawMessage, error) {
+	decoder := json.NewDecoder(strings.NewReader(string(row)))
+	decoder.UseNumber()
+
+	var values map[string]interface{}
+	if err := decoder.Decode(&values); err != nil {
+		return nil, fmt.Errorf("failed to decode index row: %w", err)
+	}
+
+	rowPath, ok := values["path"].(string)
+	if !ok {
+		return nil, fmt.Errorf("index row has no path")
+	}
+
+	newPath, err := rewrite(rowPath)
+	if err != nil {
+		return nil, err
+	}
+	values["path"] = newPath
+
+	rewritten, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	return rewritten, nil
+}
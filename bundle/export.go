@@ -0,0 +1,252 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/progress"
+	"github.com/moonstream-to/seer/storage"
+	"github.com/moonstream-to/seer/version"
+)
+
+// Export writes bundle with packs of blockchain which have blocks in range. Packs are exported
+// whole, so range of bundle in manifest could be wider than requested one. Protos are read from
+// storage with paths of index rows.
+func Export(ctx context.Context, blockchain string, fromBlock, toBlock uint64, storer storage.Storer, out io.Writer) (*Manifest, error) {
+	packsPaths, err := indexer.DBConnection.ReadPacksPaths(ctx, blockchain, fromBlock, toBlock)
+	if err != nil {
+		return nil, err
+	}
+	if len(packsPaths) == 0 {
+		return nil, fmt.Errorf("there are no indexed blocks of %s in range %d-%d", blockchain, fromBlock, toBlock)
+	}
+
+	manifest := &Manifest{
+		FormatVersion: FormatVersion,
+		SeerVersion:   version.SeerVersion,
+		Chain:         blockchain,
+		CreatedAt:     time.Now().UTC(),
+	}
+
+	// Files of bundle by path in data directory with their paths in storage
+	dataFiles := make(map[string]string)
+
+	var packsDirs []string
+	var packsStart, packsEnd uint64
+	for i, packPath := range packsPaths {
+		packDir := filepath.Dir(packPath)
+		startBlock, endBlock, rangeErr := packRange(filepath.Base(packDir))
+		if rangeErr != nil {
+			return nil, fmt.Errorf("unexpected path of pack %s: %w", packPath, rangeErr)
+		}
+		if i == 0 || startBlock < packsStart {
+			packsStart = startBlock
+		}
+		if endBlock > packsEnd {
+			packsEnd = endBlock
+		}
+
+		packsDirs = append(packsDirs, packDir)
+		dataFiles[relativePath(packPath)] = packPath
+	}
+	manifest.FromBlock, manifest.ToBlock = packsStart, packsEnd
+
+	// Rows are exported first, they point to protos of custom indexes stored next to blocks
+	tempDir, err := os.MkdirTemp("", "seer-bundle-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	var indexFiles []string
+	for _, kind := range tableKinds(blockchain) {
+		table := tableName(blockchain, kind)
+		columns, columnsErr := indexer.DBConnection.IndexTableColumns(ctx, table)
+		if columnsErr != nil {
+			return nil, columnsErr
+		}
+		if len(columns) == 0 {
+			continue
+		}
+
+		rowsFile := filepath.Join(tempDir, kind+".jsonl")
+		rowsNum, exportErr := exportRows(ctx, blockchain, table, columns, packsDirs, packsStart, packsEnd, rowsFile, dataFiles)
+		if exportErr != nil {
+			return nil, exportErr
+		}
+		if rowsNum == 0 {
+			continue
+		}
+
+		manifest.Tables = append(manifest.Tables, Table{
+			Kind:    kind,
+			Schema:  schemaVersion(columns),
+			Columns: columns,
+			Rows:    rowsNum,
+		})
+		indexFiles = append(indexFiles, rowsFile)
+		log.Printf("Exported %d rows of %s table", rowsNum, table)
+	}
+
+	gzipWriter := gzip.NewWriter(out)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	var dataPaths []string
+	for dataPath := range dataFiles {
+		dataPaths = append(dataPaths, dataPath)
+	}
+	sort.Strings(dataPaths)
+
+	exportProgress := progress.NewTracker(fmt.Sprintf("export %s", blockchain), uint64(len(dataPaths)))
+	for _, dataPath := range dataPaths {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		data, readErr := storer.Read(dataFiles[dataPath])
+		if readErr != nil {
+			return nil, fmt.Errorf("failed to read %s from storage: %w", dataFiles[dataPath], readErr)
+		}
+
+		file, addErr := addEntry(tarWriter, path.Join(dataDir, dataPath), int64(data.Len()), &data)
+		if addErr != nil {
+			return nil, addErr
+		}
+		manifest.Files = append(manifest.Files, file)
+		exportProgress.Add(1)
+	}
+	exportProgress.Finish()
+
+	for _, rowsFile := range indexFiles {
+		file, addErr := addFileEntry(tarWriter, path.Join(indexesDir, filepath.Base(rowsFile)), rowsFile)
+		if addErr != nil {
+			return nil, addErr
+		}
+		manifest.Files = append(manifest.Files, file)
+	}
+
+	// Manifest is the last entry, it is written when checksums of all files are known
+	manifestBytes, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := addEntry(tarWriter, ManifestFile, int64(len(manifestBytes)), strings.NewReader(string(manifestBytes))); err != nil {
+		return nil, err
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return nil, err
+	}
+
+	return manifest, nil
+}
+
+// exportRows writes rows of index table which belong to packs to file as JSON lines with paths
+// relative to bundle data directory. Protos referenced by rows are added to data files.
+func exportRows(ctx context.Context, blockchain, table string, columns, packsDirs []string, startBlock, endBlock uint64, rowsFile string, dataFiles map[string]string) (int, error) {
+	file, err := os.Create(rowsFile)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	writer := bufio.NewWriter(file)
+	rowsNum, err := indexer.DBConnection.ExportPacksRows(ctx, blockchain, table, columns, packsDirs, startBlock, endBlock, func(row json.RawMessage) error {
+		rewritten, rewriteErr := rewritePath(row, func(storagePath string) (string, error) {
+			dataPath := relativePath(storagePath)
+			dataFiles[dataPath] = storagePath
+			return dataPath, nil
+		})
+		if rewriteErr != nil {
+			return rewriteErr
+		}
+
+		if _, err := writer.Write(rewritten); err != nil {
+			return err
+		}
+		return writer.WriteByte('\n')
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if err := writer.Flush(); err != nil {
+		return 0, err
+	}
+	return rowsNum, file.Close()
+}
+
+// addEntry writes entry to archive and returns it with checksum.
+func addEntry(tarWriter *tar.Writer, name string, size int64, r io.Reader) (File, error) {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    size,
+		ModTime: time.Now(),
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return File{}, fmt.Errorf("failed to write %s to bundle: %w", name, err)
+	}
+
+	hash := sha256.New()
+	written, err := io.Copy(tarWriter, io.TeeReader(r, hash))
+	if err != nil {
+		return File{}, fmt.Errorf("failed to write %s to bundle: %w", name, err)
+	}
+
+	return File{Path: name, Size: written, SHA256: hex.EncodeToString(hash.Sum(nil))}, nil
+}
+
+// addFileEntry writes local file to archive.
+func addFileEntry(tarWriter *tar.Writer, name, localPath string) (File, error) {
+	file, err := os.Open(localPath)
+	if err != nil {
+		return File{}, err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return File{}, err
+	}
+
+	return addEntry(tarWriter, name, info.Size(), file)
+}
+
+// packRange parses range of pack from name of its directory, <start block>-<end block>.
+func packRange(packDir string) (uint64, uint64, error) {
+	bounds := strings.SplitN(packDir, "-", 2)
+	if len(bounds) != 2 {
+		return 0, 0, fmt.Errorf("pack directory %s is not a block range", packDir)
+	}
+
+	startBlock, err := strconv.ParseUint(bounds[0], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	endBlock, err := strconv.ParseUint(bounds[1], 10, 64)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return startBlock, endBlock, nil
+}
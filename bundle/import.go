@@ -0,0 +1,260 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/progress"
+	"github.com/moonstream-to/seer/storage"
+)
+
+// importBatchSize is number of index rows inserted with one statement.
+const importBatchSize = 1000
+
+// Verify reads bundle and checks its format version and checksums of all files listed in
+// manifest. It returns manifest of verified bundle.
+func Verify(bundlePath string) (*Manifest, error) {
+	var manifest *Manifest
+	checksums := make(map[string]File)
+
+	err := walk(bundlePath, func(header *tar.Header, r io.Reader) error {
+		if header.Name == ManifestFile {
+			manifest = &Manifest{}
+			if err := json.NewDecoder(r).Decode(manifest); err != nil {
+				return fmt.Errorf("failed to decode manifest: %w", err)
+			}
+			return nil
+		}
+
+		hash := sha256.New()
+		size, err := io.Copy(hash, r)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		checksums[header.Name] = File{Path: header.Name, Size: size, SHA256: hex.EncodeToString(hash.Sum(nil))}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if manifest == nil {
+		return nil, fmt.Errorf("bundle has no %s", ManifestFile)
+	}
+	if manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("bundle format version %d is not supported, expected %d", manifest.FormatVersion, FormatVersion)
+	}
+
+	for _, file := range manifest.Files {
+		if !filepath.IsLocal(file.Path) {
+			return nil, fmt.Errorf("bundle file %s is outside of bundle", file.Path)
+		}
+		checksum, ok := checksums[file.Path]
+		if !ok {
+			return nil, fmt.Errorf("bundle file %s is missing", file.Path)
+		}
+		if checksum != file {
+			return nil, fmt.Errorf("bundle file %s is corrupted, sha256 %s of %d bytes does not match manifest", file.Path, checksum.SHA256, checksum.Size)
+		}
+	}
+
+	return manifest, nil
+}
+
+// Import writes protos of verified bundle to storage of chain with base path and rows to index
+// tables. Index tables must exist and have the same schema as exported ones. Protos which already
+// exist in storage with the same content are skipped and rows are inserted with conflicts skipped,
+// so interrupted import could be repeated. Checkpoint of chain is not changed.
+func Import(ctx context.Context, bundlePath string, manifest *Manifest, storer storage.Storer, basePath string) error {
+	knownKinds := make(map[string]bool)
+	for _, kind := range tableKinds(manifest.Chain) {
+		knownKinds[kind] = true
+	}
+
+	bundleKinds := make(map[string]bool)
+	for _, table := range manifest.Tables {
+		if !knownKinds[table.Kind] {
+			return fmt.Errorf("unknown index table %s of %s in bundle", table.Kind, manifest.Chain)
+		}
+		bundleKinds[table.Kind] = true
+
+		name := tableName(manifest.Chain, table.Kind)
+		columns, err := indexer.DBConnection.IndexTableColumns(ctx, name)
+		if err != nil {
+			return err
+		}
+		if len(columns) == 0 {
+			return fmt.Errorf("index table %s does not exist", name)
+		}
+		if schema := schemaVersion(columns); schema != table.Schema {
+			return fmt.Errorf("schema %s of index table %s does not match schema %s of bundle, columns %s expected", schema, name, table.Schema, strings.Join(table.Columns, ", "))
+		}
+	}
+
+	// Protos already imported are not written again, storage could append to existing files
+	listedFiles := make(map[string]bool)
+	existingFiles := make(map[string]bool)
+	for _, file := range manifest.Files {
+		listedFiles[file.Path] = true
+
+		dataPath, ok := strings.CutPrefix(file.Path, dataDir+"/")
+		if !ok {
+			continue
+		}
+
+		existing, readErr := storer.Read(filepath.Join(basePath, dataPath))
+		if readErr != nil {
+			continue
+		}
+		sum := sha256.Sum256(existing.Bytes())
+		if hex.EncodeToString(sum[:]) != file.SHA256 {
+			return fmt.Errorf("%s already exists in storage with different content", filepath.Join(basePath, dataPath))
+		}
+		existingFiles[file.Path] = true
+	}
+
+	importProgress := progress.NewTracker(fmt.Sprintf("import %s", manifest.Chain), uint64(len(manifest.Files)))
+	defer importProgress.Finish()
+
+	// Protos precede index rows in bundle, so rows never point to protos which are not imported yet
+	return walk(bundlePath, func(header *tar.Header, r io.Reader) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		// Only files verified against manifest are imported
+		if !listedFiles[header.Name] {
+			return nil
+		}
+
+		switch {
+		case strings.HasPrefix(header.Name, dataDir+"/"):
+			defer importProgress.Add(1)
+			if existingFiles[header.Name] {
+				return nil
+			}
+
+			var data bytes.Buffer
+			if _, err := io.Copy(&data, r); err != nil {
+				return err
+			}
+
+			dataPath := strings.TrimPrefix(header.Name, dataDir+"/")
+			if err := storer.Save(path.Dir(dataPath), path.Base(dataPath), data); err != nil {
+				return fmt.Errorf("failed to save %s: %w", dataPath, err)
+			}
+
+		case strings.HasPrefix(header.Name, indexesDir+"/"):
+			defer importProgress.Add(1)
+			kind := strings.TrimSuffix(path.Base(header.Name), ".jsonl")
+			if !bundleKinds[kind] {
+				return fmt.Errorf("index rows %s do not belong to tables of bundle", header.Name)
+			}
+			table := tableName(manifest.Chain, kind)
+
+			imported, err := importRows(ctx, table, basePath, r)
+			if err != nil {
+				return err
+			}
+			log.Printf("Imported %d new rows into %s table", imported, table)
+		}
+
+		return nil
+	})
+}
+
+// importRows inserts JSON lines of index table in batches with paths in storage with base path.
+func importRows(ctx context.Context, table, basePath string, r io.Reader) (int64, error) {
+	var imported int64
+	var batch []json.RawMessage
+
+	flush := func() error {
+		inserted, err := indexer.DBConnection.ImportRows(ctx, table, batch)
+		if err != nil {
+			return err
+		}
+		imported += inserted
+		batch = nil
+		return nil
+	}
+
+	reader := bufio.NewReader(r)
+	for {
+		line, readErr := reader.ReadBytes('\n')
+		if len(bytes.TrimSpace(line)) > 0 {
+			row, err := rewritePath(line, func(dataPath string) (string, error) {
+				if !filepath.IsLocal(dataPath) {
+					return "", fmt.Errorf("path %s of %s row is outside of bundle", dataPath, table)
+				}
+				return filepath.Join(basePath, dataPath), nil
+			})
+			if err != nil {
+				return imported, err
+			}
+
+			batch = append(batch, row)
+			if len(batch) >= importBatchSize {
+				if err := flush(); err != nil {
+					return imported, err
+				}
+			}
+		}
+
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return imported, readErr
+		}
+	}
+
+	return imported, flush()
+}
+
+// walk calls fn with every regular file of bundle archive.
+func walk(bundlePath string, fn func(header *tar.Header, r io.Reader) error) error {
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return fmt.Errorf("failed to read bundle %s: %w", bundlePath, err)
+	}
+	defer gzipReader.Close()
+
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read bundle %s: %w", bundlePath, err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if err := fn(header, tarReader); err != nil {
+			return err
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -9,21 +10,38 @@ import (
 	"io"
 	"log"
 	"os"
+	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
 	"text/template"
+	"time"
 
 	"github.com/spf13/cobra"
 
+	"github.com/moonstream-to/seer/aggregator"
 	seer_blockchain "github.com/moonstream-to/seer/blockchain"
+	"github.com/moonstream-to/seer/blockchain/beacon"
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/blockchain/traces"
+	"github.com/moonstream-to/seer/bundle"
 	"github.com/moonstream-to/seer/crawler"
+	"github.com/moonstream-to/seer/enricher"
 	"github.com/moonstream-to/seer/evm"
+	"github.com/moonstream-to/seer/filters"
 	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+	"github.com/moonstream-to/seer/migrations"
 	"github.com/moonstream-to/seer/starknet"
 	"github.com/moonstream-to/seer/storage"
 	"github.com/moonstream-to/seer/synchronizer"
 	"github.com/moonstream-to/seer/version"
+	"github.com/moonstream-to/seer/webhooks"
 )
 
 func CreateRootCommand() *cobra.Command {
@@ -31,6 +49,14 @@ func CreateRootCommand() *cobra.Command {
 	rootCmd := &cobra.Command{
 		Use:   "seer",
 		Short: "Seer: Generate interfaces and crawlers from various blockchains",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			pluginsErr := seer_blockchain.LoadPluginsFromEnv()
+			if pluginsErr != nil {
+				return pluginsErr
+			}
+
+			return seer_blockchain.LoadCustomChainsFromEnv()
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.Help()
 		},
@@ -44,7 +70,13 @@ func CreateRootCommand() *cobra.Command {
 	inspectorCmd := CreateInspectorCommand()
 	evmCmd := CreateEVMCommand()
 	synchronizerCmd := CreateSynchronizerCommand()
-	rootCmd.AddCommand(completionCmd, versionCmd, blockchainCmd, starknetCmd, evmCmd, crawlerCmd, inspectorCmd, synchronizerCmd)
+	backfillCmd := CreateBackfillCommand()
+	enricherCmd := CreateEnricherCommand()
+	aggregatorCmd := CreateAggregatorCommand()
+	utilsCmd := CreateUtilsCommand()
+	wormCmd := CreateWormCommand()
+	databaseCmd := CreateDatabaseCommand()
+	rootCmd.AddCommand(completionCmd, versionCmd, blockchainCmd, starknetCmd, evmCmd, crawlerCmd, inspectorCmd, synchronizerCmd, backfillCmd, enricherCmd, aggregatorCmd, utilsCmd, wormCmd, databaseCmd)
 
 	// By default, cobra Command objects write to stderr. We have to forcibly set them to output to
 	// stdout.
@@ -129,7 +161,8 @@ func CreateBlockchainCommand() *cobra.Command {
 	}
 
 	blockchainGenerateCmd := CreateBlockchainGenerateCommand()
-	blockchainCmd.AddCommand(blockchainGenerateCmd)
+	blockchainRemoveCmd := CreateBlockchainRemoveCommand()
+	blockchainCmd.AddCommand(blockchainGenerateCmd, blockchainRemoveCmd)
 
 	return blockchainCmd
 }
@@ -138,16 +171,44 @@ type BlockchainTemplateData struct {
 	BlockchainName      string
 	BlockchainNameLower string
 	IsSideChain         bool
+	IsZkSync            bool
+	IsOpStack           bool
+}
+
+// blockchainTemplatePath returns path of template from templates directory if it exists there,
+// otherwise path of built-in template.
+func blockchainTemplatePath(templatesDir, name string) string {
+	if templatesDir != "" {
+		overridePath := filepath.Join(templatesDir, name)
+		if _, statErr := os.Stat(overridePath); statErr == nil {
+			log.Printf("Using template %s", overridePath)
+			return overridePath
+		}
+	}
+
+	return filepath.Join("blockchain", name)
 }
 
 func CreateBlockchainGenerateCommand() *cobra.Command {
 	var blockchainNameLower string
-	var sideChain bool
+	var sideChain, zkSync, opStack bool
+	var protocPath, templatesDir string
+	var skipProtoc bool
 
 	blockchainGenerateCmd := &cobra.Command{
 		Use:   "generate",
 		Short: "Generate methods and types for different blockchains from template",
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if templatesDir != "" {
+				templatesDirInfo, statErr := os.Stat(templatesDir)
+				if statErr != nil {
+					return statErr
+				}
+				if !templatesDirInfo.IsDir() {
+					return fmt.Errorf("templates directory %s is not a directory", templatesDir)
+				}
+			}
+
 			dirPath := filepath.Join(".", "blockchain", blockchainNameLower)
 			blockchainNameFilePath := filepath.Join(dirPath, fmt.Sprintf("%s.go", blockchainNameLower))
 
@@ -158,7 +219,7 @@ func CreateBlockchainGenerateCommand() *cobra.Command {
 			}
 
 			// Read and parse the template file
-			tmpl, parseErr := template.ParseFiles("blockchain/blockchain.go.tmpl")
+			tmpl, parseErr := template.ParseFiles(blockchainTemplatePath(templatesDir, "blockchain.go.tmpl"))
 			if parseErr != nil {
 				return parseErr
 			}
@@ -182,6 +243,8 @@ func CreateBlockchainGenerateCommand() *cobra.Command {
 				BlockchainName:      blockchainName,
 				BlockchainNameLower: blockchainNameLower,
 				IsSideChain:         sideChain,
+				IsZkSync:            zkSync,
+				IsOpStack:           opStack,
 			}
 			execErr := tmpl.Execute(outputFile, data)
 			if execErr != nil {
@@ -190,16 +253,266 @@ func CreateBlockchainGenerateCommand() *cobra.Command {
 
 			log.Printf("Blockchain file generated successfully: %s", blockchainNameFilePath)
 
+			// Proto and index tables of existing chain are kept as is, field numbers of proto are
+			// part of stored data
+			protoFilePath := filepath.Join(dirPath, fmt.Sprintf("%s_index_types.proto", blockchainNameLower))
+			if _, statErr := os.Stat(protoFilePath); os.IsNotExist(statErr) {
+				protoTmpl, protoParseErr := template.ParseFiles(blockchainTemplatePath(templatesDir, "blockchain.proto.tmpl"))
+				if protoParseErr != nil {
+					return protoParseErr
+				}
+
+				protoFile, protoCreateErr := os.Create(protoFilePath)
+				if protoCreateErr != nil {
+					return protoCreateErr
+				}
+				defer protoFile.Close()
+
+				protoExecErr := protoTmpl.Execute(protoFile, data)
+				if protoExecErr != nil {
+					return protoExecErr
+				}
+
+				log.Printf("Proto file generated successfully: %s", protoFilePath)
+
+				// Generated clients register the same custom index tables as Ethereum client
+				migrationFilePath := filepath.Join(dirPath, fmt.Sprintf("%s_index_tables.sql", blockchainNameLower))
+				ddl, ddlErr := indexer.IndexTablesDDL(blockchainNameLower, sideChain || opStack, indexer.GetCustomIndexTables("ethereum"))
+				if ddlErr != nil {
+					return ddlErr
+				}
+				migrationErr := os.WriteFile(migrationFilePath, []byte(fmt.Sprintf("-- Index tables of %s blockchain\n\n%s", blockchainNameLower, ddl)), 0644)
+				if migrationErr != nil {
+					return migrationErr
+				}
+
+				log.Printf("Index tables migration generated successfully: %s", migrationFilePath)
+			} else {
+				log.Printf("Proto file already exists, keeping it and index tables of chain: %s", protoFilePath)
+			}
+
+			if skipProtoc {
+				return nil
+			}
+
+			protocCmd := exec.CommandContext(cmd.Context(), protocPath, "--go_out=.", "--go_opt=paths=source_relative", protoFilePath)
+			protocCmd.Stdout = os.Stdout
+			protocCmd.Stderr = os.Stderr
+			protocErr := protocCmd.Run()
+			if errors.Is(protocErr, exec.ErrNotFound) {
+				return fmt.Errorf("%s is not found, install protoc with protoc-gen-go or run with --skip-protoc: %w", protocPath, protocErr)
+			} else if protocErr != nil {
+				return fmt.Errorf("failed to compile %s: %w", protoFilePath, protocErr)
+			}
+
+			log.Printf("Proto interface generated successfully: %s", strings.TrimSuffix(protoFilePath, ".proto")+".pb.go")
+
 			return nil
 		},
 	}
 
 	blockchainGenerateCmd.Flags().StringVarP(&blockchainNameLower, "name", "n", "", "The name of the blockchain to generate lowercase (example: 'arbitrum_one')")
 	blockchainGenerateCmd.Flags().BoolVar(&sideChain, "side-chain", false, "Set this flag to extend Blocks and Transactions with additional fields for side chains (default: false)")
+	blockchainGenerateCmd.Flags().BoolVar(&zkSync, "zksync", false, "Set this flag to extend Blocks and Transactions with zkSync Era L1 batch and EIP-712 fields (default: false)")
+	blockchainGenerateCmd.Flags().BoolVar(&opStack, "op-stack", false, "Set this flag to extend Blocks and Transactions with OP-stack L1 attributes and deposit transaction fields (default: false)")
+	blockchainGenerateCmd.Flags().StringVar(&protocPath, "protoc", "protoc", "Path to protoc compiler used to generate proto interface, protoc-gen-go should be in PATH")
+	blockchainGenerateCmd.Flags().BoolVar(&skipProtoc, "skip-protoc", false, "Set this flag to skip compilation of proto file, for example when it is compiled separately (default: false)")
+	blockchainGenerateCmd.Flags().StringVar(&templatesDir, "templates-dir", "", "Directory with blockchain.go.tmpl and blockchain.proto.tmpl templates which override built-in ones, missing templates are taken from built-in")
 
 	return blockchainGenerateCmd
 }
 
+// chainCodePatterns returns patterns of code which registers chain in seer sources, generated
+// by blockchain generate or added by hand when chain was onboarded.
+func chainCodePatterns(chain string) map[string][]*regexp.Regexp {
+	name := regexp.QuoteMeta(chain)
+	envName := regexp.QuoteMeta(strings.ToUpper(chain))
+
+	return map[string][]*regexp.Regexp{
+		filepath.Join("blockchain", "handlers.go"): {
+			regexp.MustCompile(`(?m)^\t"github\.com/moonstream-to/seer/blockchain/` + name + `"\n`),
+			regexp.MustCompile(`(?s)\tRegister\("` + name + `", func\(.*?\n\t\}\)\n`),
+		},
+		filepath.Join("crawler", "settings.go"): {
+			regexp.MustCompile(`\tMOONSTREAM_NODE_` + envName + `_A_EXTERNAL_URI := os\.Getenv\([^\n]*\n\tif [^\n]*\n\t\treturn [^\n]*\n\t\}\n`),
+			regexp.MustCompile(`(?m)^\t\t"` + name + `": +MOONSTREAM_NODE_` + envName + `_A_EXTERNAL_URI,\n`),
+		},
+		filepath.Join("storage", "settings.go"): {
+			regexp.MustCompile(`(?m)^\t"` + name + `": +"[^"]*",\n`),
+		},
+		filepath.Join("indexer", "db.go"): {
+			regexp.MustCompile(`(?m)^\tcase "` + name + `":\n\t\treturn (?:true|false)\n`),
+		},
+		"sample.env": {
+			regexp.MustCompile(`(?m)^export MOONSTREAM_(?:NODE|WS|BEACON)_` + envName + `_[AB]_EXTERNAL_URI=[^\n]*\n`),
+		},
+	}
+}
+
+// removeChainCode deletes generated package of chain and its registration from seer sources,
+// returns list of changed files.
+func removeChainCode(chain string) ([]string, error) {
+	var changed []string
+
+	dirPath := filepath.Join("blockchain", chain)
+	if _, statErr := os.Stat(dirPath); statErr == nil {
+		removeErr := os.RemoveAll(dirPath)
+		if removeErr != nil {
+			return changed, removeErr
+		}
+		changed = append(changed, dirPath)
+	}
+
+	for filePath, patterns := range chainCodePatterns(chain) {
+		content, readErr := os.ReadFile(filePath)
+		if readErr != nil {
+			return changed, readErr
+		}
+
+		updated := content
+		for _, pattern := range patterns {
+			updated = pattern.ReplaceAll(updated, nil)
+		}
+		if bytes.Equal(updated, content) {
+			continue
+		}
+
+		// Alignment of maps and switches is changed after lines are removed
+		if filepath.Ext(filePath) == ".go" {
+			formatted, formatErr := format.Source(updated)
+			if formatErr != nil {
+				return changed, fmt.Errorf("failed to format %s: %w", filePath, formatErr)
+			}
+			updated = formatted
+		}
+
+		writeErr := os.WriteFile(filePath, updated, 0644)
+		if writeErr != nil {
+			return changed, writeErr
+		}
+		changed = append(changed, filePath)
+	}
+
+	sort.Strings(changed)
+
+	return changed, nil
+}
+
+func CreateBlockchainRemoveCommand() *cobra.Command {
+	var archiveSchema, archiveTablespace, archiveStorage, baseDir string
+	var keepCode bool
+
+	blockchainRemoveCmd := &cobra.Command{
+		Use:   "remove <chain>",
+		Short: "Decommission blockchain: archive its indexes and storage and remove generated code",
+		Args:  cobra.ExactArgs(1),
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			chain := args[0]
+			if chain == "ethereum" || chain == "common" {
+				return fmt.Errorf("%s could not be removed, other chains are built on it", chain)
+			}
+
+			if archiveSchema != "" {
+				indexerErr := indexer.CheckVariablesForIndexer()
+				if indexerErr != nil {
+					return indexerErr
+				}
+			}
+
+			if archiveStorage != "" {
+				crawlerErr := crawler.CheckVariablesForCrawler()
+				if crawlerErr != nil {
+					return crawlerErr
+				}
+
+				storageErr := storage.CheckVariablesForStorage()
+				if storageErr != nil {
+					return storageErr
+				}
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			chain := args[0]
+			ctx := cmd.Context()
+
+			if archiveSchema != "" {
+				indexer.InitDBConnection()
+				if indexer.DBConnection == nil {
+					return fmt.Errorf("failed to connect to indexes database")
+				}
+
+				tables := []string{
+					indexer.BlocksTableName(chain),
+					indexer.TransactionsTableName(chain),
+					indexer.LogsTableName(chain),
+				}
+				kinds := []string{beacon.BlobSidecarsIndexTable.Kind, traces.CallTracesIndexTable.Kind, traces.FlatTracesIndexTable.Kind}
+				for _, table := range indexer.GetCustomIndexTables(chain) {
+					kinds = append(kinds, table.Kind)
+				}
+				for _, kind := range kinds {
+					tables = append(tables, indexer.CustomIndexTableName(chain, kind))
+				}
+
+				archived, archiveErr := indexer.DBConnection.ArchiveIndexTables(ctx, tables, archiveSchema, archiveTablespace)
+				if archiveErr != nil {
+					return archiveErr
+				}
+
+				log.Printf("Moved %d index tables of %s to schema %s: %s", len(archived), chain, archiveSchema, strings.Join(archived, ", "))
+			}
+
+			if archiveStorage != "" {
+				basePath := filepath.Join(baseDir, crawler.SeerCrawlerStoragePrefix, "data", chain)
+				storageInstance, newStorageErr := storage.NewStorage(storage.SeerCrawlerStorageType, basePath)
+				if newStorageErr != nil {
+					return newStorageErr
+				}
+
+				archiver, ok := storageInstance.(storage.Archiver)
+				if !ok {
+					return fmt.Errorf("storage %s does not support archiving", storage.SeerCrawlerStorageType)
+				}
+
+				archivedNum, archiveErr := archiver.Archive(ctx, archiveStorage)
+				if archiveErr != nil {
+					return archiveErr
+				}
+
+				log.Printf("Archived %d objects of %s storage prefix %s to %s", archivedNum, chain, basePath, archiveStorage)
+			}
+
+			if keepCode {
+				return nil
+			}
+
+			changed, removeErr := removeChainCode(chain)
+			if removeErr != nil {
+				return removeErr
+			}
+			if len(changed) == 0 {
+				log.Printf("No code of %s is found, it is not built in seer", chain)
+				return nil
+			}
+
+			log.Printf("Removed code of %s from: %s", chain, strings.Join(changed, ", "))
+			log.Printf("Rebuild seer and check remaining references, e.g. with: grep -rnw %s --include='*.go' --include='*.sh' --include='*.md' .", chain)
+
+			return nil
+		},
+	}
+
+	blockchainRemoveCmd.Flags().StringVar(&archiveSchema, "archive-schema", "", "Move index tables of the chain with their partitions to this database schema (default: tables are kept)")
+	blockchainRemoveCmd.Flags().StringVar(&archiveTablespace, "archive-tablespace", "", "Tablespace on cold storage to move archived index tables to, used with --archive-schema")
+	blockchainRemoveCmd.Flags().StringVar(&archiveStorage, "archive-storage", "", "Cold tier to move storage prefix of the chain to: storage class for gcp-storage (COLDLINE, ARCHIVE) or target directory for filesystem (default: data is kept)")
+	blockchainRemoveCmd.Flags().StringVar(&baseDir, "base-dir", "", "The base directory of the crawled data (default: '')")
+	blockchainRemoveCmd.Flags().BoolVar(&keepCode, "keep-code", false, "Set this flag to keep generated code and registration of the chain (default: false)")
+
+	return blockchainRemoveCmd
+}
+
 func CreateStarknetCommand() *cobra.Command {
 	starknetCmd := &cobra.Command{
 		Use:   "starknet",
@@ -217,19 +530,41 @@ func CreateStarknetCommand() *cobra.Command {
 }
 
 func CreateCrawlerCommand() *cobra.Command {
-	var startBlock, endBlock, confirmations int64
-	var timeout, threads, protoTimeLimit int
+	var startBlock, endBlock, confirmations, maxReorgDepth, forceFrom, maxLag int64
+	var timeout, threads, protoTimeLimit, rpcRateBurst, rpcBatchSize, rpcMaxAttempts, pipelineDepth, fetchWorkers int
 	var protoSizeLimit uint64
-	var chain, baseDir string
-	var force bool
+	var rpcRateLimit float64
+	var chain, baseDir, traces, blockTag, metricsAddr, addresses, topics, allowlist, validators, workerID, configPath string
+	var force, receipts, verifyBlocks, crossValidate, backfill, logsFromReceipts, deadLetter, dryRun, leaderElection, indexGasUsed bool
+	var leaseDuration, leaderLease, backpressureThreshold, maxBackpressure, headBatchTime time.Duration
 
 	crawlerCmd := &cobra.Command{
 		Use:   "crawler",
 		Short: "Start crawlers for various blockchains",
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			indexerErr := indexer.CheckVariablesForIndexer()
-			if indexerErr != nil {
-				return indexerErr
+			if configPath != "" && (backfill || addresses != "" || topics != "" || allowlist != "" || validators != "") {
+				return fmt.Errorf("--config could not be used with --backfill, --addresses, --topics, --allowlist or --validators, validators of beacon chain are set in config file")
+			}
+
+			// Replicas take over from checkpoint, forced start block would be crawled again by every leader
+			if leaderElection && (configPath != "" || backfill || dryRun || force || cmd.Flags().Changed("force-from")) {
+				return fmt.Errorf("--leader-election could not be used with --config, --backfill, --dry-run, --force or --force-from")
+			}
+
+			if dryRun {
+				if backfill || deadLetter {
+					return fmt.Errorf("--dry-run could not be used with --backfill or --dead-letter, they write to indexes database")
+				}
+			} else {
+				indexerErr := indexer.CheckVariablesForIndexer()
+				if indexerErr != nil {
+					return indexerErr
+				}
+
+				// Units of backfill, failed ranges and leaders are coordinated in PostgreSQL
+				if indexer.IsSQLDatabaseURI(indexer.MOONSTREAM_DB_V3_INDEXES_URI) && (backfill || deadLetter || leaderElection) {
+					return fmt.Errorf("--backfill, --dead-letter and --leader-election require PostgreSQL indexes database")
+				}
 			}
 
 			storageErr := storage.CheckVariablesForStorage()
@@ -246,14 +581,253 @@ func CreateCrawlerCommand() *cobra.Command {
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 
-			indexer.InitDBConnection()
+			if !dryRun {
+				indexer.InitIndexesDBConnection()
+			}
+
+			// Gas used by transactions is known only from their receipts
+			if indexGasUsed {
+				receipts = true
+				indexer.SetIndexGasUsed(true)
+			}
+
+			if metricsAddr != "" {
+				metrics.Serve(metricsAddr)
+			}
+
+			seer_common.SetRPCRateLimit(rpcRateLimit, rpcRateBurst)
+			retryPolicy := seer_common.DefaultRPCRetryPolicy
+			retryPolicy.MaxAttempts = rpcMaxAttempts
+			seer_common.SetRPCRetryPolicy(retryPolicy)
+
+			// On the first SIGINT or SIGTERM crawler stops fetching new blocks, writes crawled
+			// batches with checkpoint and exits, the second signal terminates it immediately
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			go func() {
+				<-ctx.Done()
+				stop()
+			}()
+
+			// Chains of config file are crawled concurrently, failure of one chain does not stop
+			// other chains
+			if configPath != "" {
+				multiChainConfig, configErr := crawler.LoadMultiChainConfig(configPath)
+				if configErr != nil {
+					return configErr
+				}
+
+				for _, chainConfig := range multiChainConfig.Chains {
+					if chainConfig.RPC != "" {
+						crawler.BlockchainURLs[chainConfig.Chain] = chainConfig.RPC
+					}
+					if crawler.BlockchainURLs[chainConfig.Chain] == "" {
+						return fmt.Errorf("RPC of chain %s is not set in config file or environment variables", chainConfig.Chain)
+					}
+				}
+
+				newChainCrawler := func(chainConfig crawler.ChainConfig) (*crawler.Crawler, error) {
+					chainConfirmations := crawler.ChainConfirmations(chainConfig.Chain)
+					if chainConfig.Confirmations != nil {
+						chainConfirmations = *chainConfig.Confirmations
+					} else if cmd.Flags().Changed("confirmations") {
+						chainConfirmations = confirmations
+					}
+
+					chainBlockTag := blockTag
+					if chainConfig.BlockTag != "" {
+						chainBlockTag = chainConfig.BlockTag
+					}
+
+					chainCrawler, chainCrawlerErr := crawler.NewCrawler(ctx, chainConfig.Chain, chainConfig.StartBlock, chainConfig.EndBlock, chainConfirmations, timeout, baseDir, force, receipts, verifyBlocks, crossValidate, traces, chainBlockTag, protoSizeLimit, protoTimeLimit)
+					if chainCrawlerErr != nil {
+						return nil, chainCrawlerErr
+					}
+					chainCrawler.SetIsolated()
+
+					chainBatchSize := rpcBatchSize
+					if chainConfig.BatchSize > 0 {
+						chainBatchSize = chainConfig.BatchSize
+					}
+					if chainBatchSize > 1 {
+						batchSizer, ok := chainCrawler.Client.(seer_blockchain.BlocksBatchSizer)
+						if !ok {
+							return nil, fmt.Errorf("batch requests of blocks are not supported for blockchain: %s", chainConfig.Chain)
+						}
+						if capabilities, ok := seer_blockchain.Capabilities(chainConfig.Chain); ok && capabilities.MaxBatchSize > 0 && chainBatchSize > capabilities.MaxBatchSize {
+							log.Printf("Node of %s accepts batches of up to %d requests, batch size is reduced from %d", chainConfig.Chain, capabilities.MaxBatchSize, chainBatchSize)
+							chainBatchSize = capabilities.MaxBatchSize
+						}
+						batchSizer.SetBlocksBatchSize(chainBatchSize)
+					}
+
+					chainFetchWorkers := fetchWorkers
+					if chainConfig.FetchWorkers > 0 {
+						chainFetchWorkers = chainConfig.FetchWorkers
+					}
+
+					if dryRun {
+						chainCrawler.SetDryRun()
+					}
+					chainCrawler.SetPipelineDepth(pipelineDepth)
+					chainCrawler.SetBackpressure(backpressureThreshold, maxBackpressure)
+					chainCrawler.SetHeadBatchTime(headBatchTime)
+					chainCrawler.SetFetchWorkers(chainFetchWorkers)
+					chainCrawler.SetMaxReorgDepth(maxReorgDepth)
+					if metricsAddr != "" {
+						chainCrawler.RegisterHealthChecks(maxLag)
+					}
+					if logsFromReceipts {
+						if setErr := chainCrawler.SetLogsFromReceipts(); setErr != nil {
+							return nil, setErr
+						}
+					}
+					if deadLetter {
+						if setErr := chainCrawler.SetDeadLetter(ctx); setErr != nil {
+							return nil, setErr
+						}
+					}
+					if len(chainConfig.Validators) > 0 {
+						if setErr := chainCrawler.SetValidators(chainConfig.Validators); setErr != nil {
+							return nil, setErr
+						}
+					}
+
+					return chainCrawler, nil
+				}
+
+				var wg sync.WaitGroup
+				for _, chainConfig := range multiChainConfig.Chains {
+					wg.Add(1)
+					go func(chainConfig crawler.ChainConfig) {
+						defer wg.Done()
+
+						// Crawler of chain which could not be created, for example while its node
+						// is down, is created again after delay
+						for {
+							chainCrawler, chainCrawlerErr := newChainCrawler(chainConfig)
+							if chainCrawlerErr == nil {
+								chainThreads := threads
+								if chainConfig.Threads > 0 {
+									chainThreads = chainConfig.Threads
+								}
+								chainCrawler.RunIsolated(ctx, chainThreads)
+								return
+							}
+
+							log.Printf("Failed to start crawler of %s: %v. Retrying in %s...", chainConfig.Chain, chainCrawlerErr, crawler.DefaultRestartDelay)
+							select {
+							case <-ctx.Done():
+								return
+							case <-time.After(crawler.DefaultRestartDelay):
+							}
+						}
+					}(chainConfig)
+				}
+				wg.Wait()
+
+				return nil
+			}
 
-			newCrawler, crawlerError := crawler.NewCrawler(chain, startBlock, endBlock, confirmations, timeout, baseDir, force, protoSizeLimit, protoTimeLimit)
+			if !cmd.Flags().Changed("confirmations") {
+				confirmations = crawler.ChainConfirmations(chain)
+			}
+
+			newCrawler, crawlerError := crawler.NewCrawler(ctx, chain, startBlock, endBlock, confirmations, timeout, baseDir, force, receipts, verifyBlocks, crossValidate, traces, blockTag, protoSizeLimit, protoTimeLimit)
 			if crawlerError != nil {
 				return crawlerError
 			}
 
-			latestBlockNumber, latestErr := newCrawler.Client.GetLatestBlockNumber()
+			if rpcBatchSize > 1 {
+				batchSizer, ok := newCrawler.Client.(seer_blockchain.BlocksBatchSizer)
+				if !ok {
+					return fmt.Errorf("batch requests of blocks are not supported for blockchain: %s", chain)
+				}
+				if capabilities, ok := seer_blockchain.Capabilities(chain); ok && capabilities.MaxBatchSize > 0 && rpcBatchSize > capabilities.MaxBatchSize {
+					log.Printf("Node accepts batches of up to %d requests, batch size is reduced from %d", capabilities.MaxBatchSize, rpcBatchSize)
+					rpcBatchSize = capabilities.MaxBatchSize
+				}
+				batchSizer.SetBlocksBatchSize(rpcBatchSize)
+			}
+
+			if dryRun {
+				newCrawler.SetDryRun()
+			}
+			newCrawler.SetPipelineDepth(pipelineDepth)
+			newCrawler.SetBackpressure(backpressureThreshold, maxBackpressure)
+			newCrawler.SetHeadBatchTime(headBatchTime)
+			newCrawler.SetFetchWorkers(fetchWorkers)
+			newCrawler.SetMaxReorgDepth(maxReorgDepth)
+			if metricsAddr != "" {
+				newCrawler.RegisterHealthChecks(maxLag)
+			}
+			if cmd.Flags().Changed("force-from") {
+				newCrawler.SetForceFrom(forceFrom)
+			}
+
+			logsFilter, filterErr := seer_common.ParseLogsFilter(addresses, topics)
+			if filterErr != nil {
+				return filterErr
+			}
+
+			if logsFromReceipts {
+				if setErr := newCrawler.SetLogsFromReceipts(); setErr != nil {
+					return setErr
+				}
+			}
+
+			// Allowlist crawls events emitted by addresses and transactions sent from or to them
+			if allowlist != "" {
+				if strings.HasPrefix(allowlist, "@") {
+					allowlistData, readErr := os.ReadFile(strings.TrimPrefix(allowlist, "@"))
+					if readErr != nil {
+						return fmt.Errorf("failed to read allowlist: %w", readErr)
+					}
+					allowlist = strings.Join(strings.Fields(string(allowlistData)), ",")
+				}
+				allowlistFilter, allowlistErr := seer_common.ParseLogsFilter(allowlist, "")
+				if allowlistErr != nil {
+					return fmt.Errorf("invalid allowlist: %w", allowlistErr)
+				}
+				if len(allowlistFilter.Address) == 0 {
+					return fmt.Errorf("allowlist has no addresses")
+				}
+				logsFilter.Address = append(logsFilter.Address, allowlistFilter.Address...)
+				if setErr := newCrawler.SetTransactionsAddresses(allowlistFilter.Address); setErr != nil {
+					return setErr
+				}
+			}
+
+			if !logsFilter.IsEmpty() {
+				if setErr := newCrawler.SetLogsFilter(logsFilter); setErr != nil {
+					return setErr
+				}
+			}
+
+			// Balances of validators are snapshotted at the first slot of every epoch of beacon chain
+			if validators != "" {
+				if strings.HasPrefix(validators, "@") {
+					validatorsData, readErr := os.ReadFile(strings.TrimPrefix(validators, "@"))
+					if readErr != nil {
+						return fmt.Errorf("failed to read validators: %w", readErr)
+					}
+					validators = strings.Join(strings.Fields(string(validatorsData)), ",")
+				}
+				var validatorsList []string
+				for _, validator := range strings.Split(validators, ",") {
+					if validator = strings.TrimSpace(validator); validator != "" {
+						validatorsList = append(validatorsList, validator)
+					}
+				}
+				if len(validatorsList) == 0 {
+					return fmt.Errorf("validators list is empty")
+				}
+				if setErr := newCrawler.SetValidators(validatorsList); setErr != nil {
+					return setErr
+				}
+			}
+
+			latestBlockNumber, latestErr := newCrawler.Client.GetLatestBlockNumber(ctx)
 			if latestErr != nil {
 				return fmt.Errorf("Failed to get latest block number: %v", latestErr)
 			}
@@ -264,30 +838,80 @@ func CreateCrawlerCommand() *cobra.Command {
 
 			crawler.CurrentBlockchainState.SetLatestBlockNumber(latestBlockNumber)
 
-			newCrawler.Start(threads)
+			if deadLetter {
+				if setErr := newCrawler.SetDeadLetter(ctx); setErr != nil {
+					return setErr
+				}
+			}
 
-			return nil
+			if workerID == "" {
+				hostname, _ := os.Hostname()
+				workerID = fmt.Sprintf("%s-%d", hostname, os.Getpid())
+			}
+
+			if backfill {
+				return newCrawler.RunBackfillWorker(ctx, threads, workerID, leaseDuration)
+			}
+
+			if leaderElection {
+				return newCrawler.RunReplica(ctx, threads, workerID, leaderLease)
+			}
+
+			return newCrawler.Start(ctx, threads)
 		},
 	}
 
 	crawlerCmd.Flags().StringVar(&chain, "chain", "ethereum", "The blockchain to crawl (default: ethereum)")
+	crawlerCmd.Flags().StringVar(&configPath, "config", "", "YAML file with chains crawled concurrently by one process, --chain is ignored and settings missing in file are taken from flags (default: single chain)")
 	crawlerCmd.Flags().Int64Var(&startBlock, "start-block", 0, "The block number to start crawling from (default: fetch from database, if it is empty, run from latestBlockNumber minus shift)")
 	crawlerCmd.Flags().Int64Var(&endBlock, "end-block", 0, "The block number to end crawling at (default: endless)")
 	crawlerCmd.Flags().IntVar(&timeout, "timeout", 30, "The timeout for the crawler in seconds (default: 30)")
 	crawlerCmd.Flags().IntVar(&threads, "threads", 1, "Number of go-routines for concurrent crawling (default: 1)")
-	crawlerCmd.Flags().Int64Var(&confirmations, "confirmations", 10, "The number of confirmations to consider for block finality (default: 10)")
+	crawlerCmd.Flags().Int64Var(&confirmations, "confirmations", crawler.DefaultConfirmations, "The number of confirmations to consider for block finality, 0 crawls blocks as soon as they are mined (default: SEER_CRAWLER_CONFIRMATIONS_<CHAIN> or 10)")
 	crawlerCmd.Flags().StringVar(&baseDir, "base-dir", "", "The base directory to store the crawled data (default: '')")
 	crawlerCmd.Flags().BoolVar(&force, "force", false, "Set this flag to force the crawler start from the specified block, otherwise it checks database latest indexed block number (default: false)")
+	crawlerCmd.Flags().Int64Var(&forceFrom, "force-from", 0, "Block to start crawling from, overrides checkpoint and latest indexed block stored in database")
+	crawlerCmd.Flags().BoolVar(&receipts, "receipts", false, "Set this flag to fetch transaction receipts and store status, gas used and created contract address of transactions (default: false)")
+	crawlerCmd.Flags().BoolVar(&logsFromReceipts, "logs-from-receipts", false, "Set this flag to take events from receipts of crawled blocks instead of eth_getLogs, so stored events always belong to stored blocks, implies --receipts (default: false)")
+	crawlerCmd.Flags().BoolVar(&indexGasUsed, "index-gas-used", false, "Set this flag to write gas used by transactions to gas_used column of transactions index, which daily stats of aggregator are summed from, implies --receipts (default: false)")
+	crawlerCmd.Flags().BoolVar(&verifyBlocks, "verify-blocks", false, "Set this flag to check that every fetched block references hash of previous block as parent, batches which do not chain are fetched again (default: false)")
+	crawlerCmd.Flags().BoolVar(&crossValidate, "cross-validate", false, "Set this flag to fetch every block also from second provider set with MOONSTREAM_NODE_<CHAIN>_B_EXTERNAL_URI and compare hashes, transactions and logs counts before writing (default: false)")
+	crawlerCmd.Flags().StringVar(&traces, "traces", "", "Set to 'debug' to store call trees of transactions traced with debug_traceBlockByNumber or 'parity' to store flat traces from trace_block, 'auto' selects mode supported by node (default: disabled)")
+	crawlerCmd.Flags().StringVar(&blockTag, "block-tag", "", "Block crawler follows as head of chain: 'latest' block minus confirmations, 'safe' or 'finalized' block, falls back to latest if node does not support the tag (default: SEER_CRAWLER_BLOCK_TAG_<CHAIN> or latest)")
+	crawlerCmd.Flags().StringVar(&addresses, "addresses", "", "Comma separated addresses of contracts, only their events and transactions which emitted them are crawled (default: all)")
+	crawlerCmd.Flags().StringVar(&topics, "topics", "", "Topics of events to crawl, positions separated by semicolon and alternatives by comma, empty position matches any topic, e.g. '0xddf2...;;0x0000...' (default: all)")
+	crawlerCmd.Flags().StringVar(&allowlist, "allowlist", "", "Comma separated addresses or @file with address per line, only transactions sent from or to them and events they emitted are crawled (default: all)")
+	crawlerCmd.Flags().StringVar(&validators, "validators", "", "Comma separated indexes or public keys of validators or @file with validator per line, their balances are stored at every epoch of ethereum_beacon chain (default: none)")
+	crawlerCmd.Flags().Float64Var(&rpcRateLimit, "rpc-rate-limit", 0, "Maximum requests per second to every RPC endpoint, batch elements are counted as requests (default: unlimited)")
+	crawlerCmd.Flags().IntVar(&rpcRateBurst, "rpc-rate-burst", 1, "Number of requests which could be sent to RPC endpoint at once above rate limit (default: 1)")
+	crawlerCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Address to serve Prometheus metrics at /metrics and health checks at /healthz and /readyz, for example :9090 (default: disabled)")
+	crawlerCmd.Flags().IntVar(&rpcMaxAttempts, "rpc-max-attempts", 5, "Maximum attempts of RPC call failed with rate limit, server error, timeout or dropped connection, retried with exponential backoff (default: 5)")
+	crawlerCmd.Flags().IntVar(&rpcBatchSize, "rpc-batch-size", 1, "Number of blocks requested in single JSON-RPC batch call, each of threads sends own batches (default: 1, no batching)")
 	crawlerCmd.Flags().Uint64Var(&protoSizeLimit, "proto-size-limit", 25, "Proto file size limit in Mb (default: 25Mb)")
 	crawlerCmd.Flags().IntVar(&protoTimeLimit, "proto-time-limit", 300, "Proto time limit in seconds (default: 300sec)")
+	crawlerCmd.Flags().IntVar(&pipelineDepth, "pipeline-depth", crawler.DefaultPipelineDepth, "Number of crawled batches buffered while previous pack is written, bounds memory of crawler together with --proto-size-limit (default: 4)")
+	crawlerCmd.Flags().DurationVar(&backpressureThreshold, "backpressure-threshold", crawler.DefaultBackpressureThreshold, "Duration of pack write after which fetching is paused until queued batches are written, 0 disables pausing on slow writes (default: 1m)")
+	crawlerCmd.Flags().DurationVar(&maxBackpressure, "max-backpressure", crawler.DefaultMaxBackpressure, "Time failed write of pack is retried with fetching paused before crawler exits (default: 10m)")
+	crawlerCmd.Flags().DurationVar(&headBatchTime, "head-batch-time", crawler.DefaultHeadBatchTime, "Time of blocks crawled in one batch at head of chain, measured with observed block time of chain, 0 keeps batches of 10 blocks (default: 1m)")
+	crawlerCmd.Flags().IntVar(&fetchWorkers, "fetch-workers", 1, "Number of consecutive batches crawled concurrently, batches are committed in block order (default: 1)")
+	crawlerCmd.Flags().Int64Var(&maxLag, "max-lag", 0, "Number of blocks crawler could be behind latest block before /readyz reports it is not ready, 0 disables the check (default: 0)")
+	crawlerCmd.Flags().Int64Var(&maxReorgDepth, "max-reorg-depth", crawler.DefaultMaxReorgDepth, "Number of recent blocks checked for common ancestor when parent hash of new block does not match previous block, 0 disables reorganization detection (default: 64)")
+	crawlerCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Set this flag to fetch, convert and validate blocks without writing them to storage and indexes database, summary of crawled data is printed on exit (default: false)")
+	crawlerCmd.Flags().BoolVar(&deadLetter, "dead-letter", false, "Set this flag to record ranges of blocks which failed all attempts to seer_failed_ranges table and continue with next range instead of exiting (default: false)")
+	crawlerCmd.Flags().BoolVar(&backfill, "backfill", false, "Set this flag to crawl units of historical blocks planned with 'seer backfill plan' instead of following head of chain (default: false)")
+	crawlerCmd.Flags().StringVar(&workerID, "worker-id", "", "Name of crawler instance which leases backfill units or leadership of chain (default: hostname and process ID)")
+	crawlerCmd.Flags().DurationVar(&leaseDuration, "lease-duration", crawler.DefaultBackfillLeaseDuration, "Time backfill unit stays leased without heartbeat before it is reassigned to other instance (default: 5m)")
+	crawlerCmd.Flags().BoolVar(&leaderElection, "leader-election", false, "Set this flag to run crawler as one of replicas of chain, only leader recorded in seer_crawler_leaders table writes blocks and standby takes over from checkpoint when leader fails (default: false)")
+	crawlerCmd.Flags().DurationVar(&leaderLease, "leader-lease", crawler.DefaultLeaderLeaseDuration, "Time replica stays leader without heartbeat before standby replica takes over (default: 30s)")
 
 	return crawlerCmd
 }
 
 func CreateSynchronizerCommand() *cobra.Command {
-	var startBlock, endBlock, batchSize uint64
-	var timeout int
-	var chain, baseDir, customerDbUriFlag string
+	var startBlock, endBlock, batchSize, maxLag uint64
+	var timeout, decodeWorkers, signaturesTimeout, webhookMaxAttempts int
+	var chain, baseDir, customerDbUriFlag, metricsAddr, direction, trustedForwarders, customerPriorities string
+	var resolveSelectors, resolveProxies, expandMulticalls, revertReasons, tokenTransfers, webhooksEnabled bool
 
 	synchronizerCmd := &cobra.Command{
 		Use:   "synchronizer",
@@ -317,17 +941,81 @@ func CreateSynchronizerCommand() *cobra.Command {
 				return fmt.Errorf("blockchain is required via --chain")
 			}
 
+			if direction != indexer.SyncForward && (startBlock == 0 || endBlock == 0) {
+				return fmt.Errorf("%s direction requires range of blocks via --start-block and --end-block", direction)
+			}
+
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			indexer.InitDBConnection()
 
+			ctx := cmd.Context()
+
 			newSynchronizer, synchonizerErr := synchronizer.NewSynchronizer(chain, baseDir, startBlock, endBlock, batchSize, timeout)
 			if synchonizerErr != nil {
 				return synchonizerErr
 			}
+			newSynchronizer.SetDecodeWorkers(decodeWorkers)
+			newSynchronizer.SetTokenTransfers(tokenTransfers)
+
+			priorities, prioritiesErr := synchronizer.ParseCustomerPriorities(customerPriorities)
+			if prioritiesErr != nil {
+				return prioritiesErr
+			}
+			newSynchronizer.SetCustomerPriorities(priorities)
 
-			latestBlockNumber, latestErr := newSynchronizer.Client.GetLatestBlockNumber()
+			if resolveSelectors {
+				seer_common.SetSignatureResolver(seer_common.NewSignatureResolver(time.Duration(signaturesTimeout) * time.Second))
+				newSynchronizer.SetResolveUnknownSelectors(true)
+			}
+
+			if resolveProxies {
+				proxiesResolver, ok := newSynchronizer.Client.(seer_blockchain.ProxiesResolver)
+				if !ok {
+					return fmt.Errorf("client of %s does not support resolution of proxies", chain)
+				}
+				proxiesResolver.SetResolveProxies(true)
+			}
+
+			if expandMulticalls {
+				multicallsExpander, ok := newSynchronizer.Client.(seer_blockchain.MulticallsExpander)
+				if !ok {
+					return fmt.Errorf("client of %s does not support expansion of multicalls", chain)
+				}
+				multicallsExpander.SetExpandMulticalls(true)
+				newSynchronizer.SetExpandMulticalls(true)
+			}
+
+			if revertReasons {
+				revertReasonsResolver, ok := newSynchronizer.Client.(seer_blockchain.RevertReasonsResolver)
+				if !ok {
+					return fmt.Errorf("client of %s does not support revert reasons", chain)
+				}
+				revertReasonsResolver.SetRevertReasons(true)
+			}
+
+			for _, forwarder := range strings.Split(trustedForwarders, ",") {
+				if forwarder = strings.TrimSpace(forwarder); forwarder != "" {
+					seer_common.RegisterTrustedForwarder(chain, forwarder)
+				}
+			}
+
+			if webhooksEnabled {
+				tablesErr := indexer.DBConnection.EnsureWebhooksTables(ctx)
+				if tablesErr != nil {
+					return tablesErr
+				}
+				newSynchronizer.SetWebhooks(webhooks.NewNotifier(chain))
+				go webhooks.NewDispatcher(chain, webhookMaxAttempts).Run(ctx)
+			}
+
+			if metricsAddr != "" {
+				newSynchronizer.RegisterHealthChecks(maxLag)
+				metrics.Serve(metricsAddr)
+			}
+
+			latestBlockNumber, latestErr := newSynchronizer.Client.GetLatestBlockNumber(ctx)
 			if latestErr != nil {
 				return fmt.Errorf("Failed to get latest block number: %v", latestErr)
 			}
@@ -338,7 +1026,12 @@ func CreateSynchronizerCommand() *cobra.Command {
 
 			crawler.CurrentBlockchainState.SetLatestBlockNumber(latestBlockNumber)
 
-			newSynchronizer.Start(customerDbUriFlag)
+			// Synchronization of fixed range is resumed from its checkpoint
+			if startBlock != 0 && endBlock != 0 {
+				return newSynchronizer.SyncHistorical(ctx, customerDbUriFlag, direction)
+			}
+
+			newSynchronizer.Start(ctx, customerDbUriFlag)
 
 			return nil
 		},
@@ -350,49 +1043,1593 @@ func CreateSynchronizerCommand() *cobra.Command {
 	synchronizerCmd.Flags().StringVar(&baseDir, "base-dir", "", "The base directory to store the crawled data (default: '')")
 	synchronizerCmd.Flags().IntVar(&timeout, "timeout", 30, "The timeout for the crawler in seconds (default: 30)")
 	synchronizerCmd.Flags().Uint64Var(&batchSize, "batch-size", 100, "The number of blocks to crawl in each batch (default: 100)")
+	synchronizerCmd.Flags().StringVar(&direction, "direction", indexer.SyncForward, "Direction of synchronization of range set with --start-block and --end-block, 'forward' or 'backward' from end block to start block (default: forward)")
+	synchronizerCmd.Flags().IntVar(&decodeWorkers, "decode-workers", synchronizer.DefaultDecodeWorkers, "The number of proto batches of customer decoded concurrently, labels are written in order of blocks (default: 4)")
+	synchronizerCmd.Flags().BoolVar(&resolveSelectors, "resolve-selectors", false, "Set this flag to resolve selectors missing in customer ABIs with openchain and 4byte.directory signature databases and write partially decoded labels (default: false)")
+	synchronizerCmd.Flags().BoolVar(&resolveProxies, "resolve-proxies", false, "Set this flag to decode transactions and events of EIP-1967 proxies with ABI jobs of their implementations when selectors are missing in ABIs of proxies, implementation slot is read from node (default: false)")
+	synchronizerCmd.Flags().BoolVar(&expandMulticalls, "expand-multicalls", false, "Set this flag to decode calls batched in multicall(bytes[]) and Multicall3 aggregate transactions and calls of Safe execTransaction, including MultiSend batches, and ERC-4337 user operations into sub-call labels of contracts with ABI jobs (default: false)")
+	synchronizerCmd.Flags().BoolVar(&revertReasons, "revert-reasons", false, "Set this flag to label failed transactions as tx_call_failed with revert reasons read by debug_traceTransaction, or by eth_call on state of previous block if node does not support it, blocks must be crawled with --receipts (default: false)")
+	synchronizerCmd.Flags().BoolVar(&tokenTransfers, "token-transfers", false, "Set this flag to write ERC-20, ERC-721 and ERC-1155 transfers of decoded events to <chain>_erc20_transfers and <chain>_nft_transfers tables of customer databases (default: false)")
+	synchronizerCmd.Flags().BoolVar(&webhooksEnabled, "webhooks", false, "Set this flag to deliver written event labels which match webhooks registered with 'worm webhook add' (default: false)")
+	synchronizerCmd.Flags().IntVar(&webhookMaxAttempts, "webhook-max-attempts", webhooks.DefaultMaxAttempts, "The number of attempts of webhook delivery before it is failed (default: 10)")
+	synchronizerCmd.Flags().StringVar(&customerPriorities, "customer-priorities", "", "Comma separated priority classes of customers as <customer id>=<class>, class is high, normal or low, customers of higher classes are decoded first in each range (default: all customers are normal)")
+	synchronizerCmd.Flags().StringVar(&trustedForwarders, "trusted-forwarders", "", "Comma separated addresses of EIP-2771 trusted forwarders: forwarder contracts whose execute requests are decoded as calls of their signers and relayers which append address of the real sender to calldata (default: '')")
+	synchronizerCmd.Flags().IntVar(&signaturesTimeout, "signatures-timeout", 10, "The timeout for signature databases requests in seconds (default: 10)")
 	synchronizerCmd.Flags().StringVar(&customerDbUriFlag, "customer-db-uri", "", "Set customer database URI for development. This workflow bypass fetching customer IDs and its database URL connection strings from mdb-v3-controller API")
+	synchronizerCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Address to serve Prometheus metrics at /metrics and health checks at /healthz and /readyz, for example :9090 (default: disabled)")
+	synchronizerCmd.Flags().Uint64Var(&maxLag, "max-lag", 0, "Number of blocks synchronizer could be behind latest indexed block before /readyz reports it is not ready, 0 disables the check (default: 0)")
 
-	return synchronizerCmd
-}
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show position and completion of historical synchronizations",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			indexerErr := indexer.CheckVariablesForIndexer()
+			if indexerErr != nil {
+				return indexerErr
+			}
 
-type BlockInspectItem struct {
-	StartBlock int64
-	EndBlock   int64
-}
+			if chain == "" {
+				return fmt.Errorf("blockchain is required via --chain")
+			}
 
-func CreateInspectorCommand() *cobra.Command {
-	inspectorCmd := &cobra.Command{
-		Use:   "inspector",
-		Short: "Inspect storage and database consistency",
-	}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			indexer.InitDBConnection()
 
-	var chain, baseDir, delim, returnFunc, batch string
-	var timeout int
+			ctx := cmd.Context()
 
-	readCommand := &cobra.Command{
-		Use:   "read",
-		Short: "Read and decode indexed proto data from storage",
-		PreRunE: func(cmd *cobra.Command, args []string) error {
-			storageErr := storage.CheckVariablesForStorage()
-			if storageErr != nil {
-				return storageErr
+			tableErr := indexer.DBConnection.EnsureSyncCheckpointsTable(ctx)
+			if tableErr != nil {
+				return tableErr
 			}
 
-			crawlerErr := crawler.CheckVariablesForCrawler()
-			if crawlerErr != nil {
-				return crawlerErr
+			checkpoints, checkpointsErr := indexer.DBConnection.ReadSyncCheckpoints(ctx, chain)
+			if checkpointsErr != nil {
+				return checkpointsErr
 			}
 
-			if batch == "" {
-				return errors.New("batch is required via --batch")
+			if len(checkpoints) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No historical synchronizations of %s\n", chain)
+				return nil
+			}
+
+			for _, checkpoint := range checkpoints {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s blocks %d-%d: at block %d, %d of %d blocks synchronized (%.2f%%), updated at %s\n", checkpoint.Direction, checkpoint.StartBlock, checkpoint.EndBlock, checkpoint.BlockNumber, checkpoint.Done(), checkpoint.EndBlock-checkpoint.StartBlock+1, checkpoint.Percent(), checkpoint.UpdatedAt.Format(time.RFC3339))
 			}
 
 			return nil
 		},
-		RunE: func(cmd *cobra.Command, args []string) error {
-			basePath := filepath.Join(baseDir, crawler.SeerCrawlerStoragePrefix, "data", chain)
-			storageInstance, newStorageErr := storage.NewStorage(storage.SeerCrawlerStorageType, basePath)
-			if newStorageErr != nil {
+	}
+
+	statusCmd.Flags().StringVar(&chain, "chain", "ethereum", "The blockchain to show synchronizations of (default: ethereum)")
+
+	synchronizerCmd.AddCommand(statusCmd)
+
+	return synchronizerCmd
+}
+
+func CreateWormCommand() *cobra.Command {
+	wormCmd := &cobra.Command{
+		Use:   "worm",
+		Short: "Maintain crawled blocks and labels decoded with ABI jobs of customers",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	redecodeCmd := CreateWormRedecodeCommand()
+	webhookCmd := CreateWormWebhookCommand()
+	nftOwnersCmd := CreateWormNFTOwnersCommand()
+	retryFailedCmd := CreateWormRetryFailedCommand()
+	recrawlCmd := CreateWormRecrawlCommand()
+	wormCmd.AddCommand(redecodeCmd, webhookCmd, nftOwnersCmd, retryFailedCmd, recrawlCmd)
+
+	return wormCmd
+}
+
+func CreateWormRetryFailedCommand() *cobra.Command {
+	var chain, baseDir, traces string
+	var timeout, threads int
+	var protoSizeLimit uint64
+	var receipts, logsFromReceipts, verifyBlocks bool
+
+	retryFailedCmd := &cobra.Command{
+		Use:   "retry-failed",
+		Short: "Crawl again ranges of blocks recorded to dead-letter table by crawler",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			indexerErr := indexer.CheckVariablesForIndexer()
+			if indexerErr != nil {
+				return indexerErr
+			}
+
+			// Failed ranges are recorded only to PostgreSQL indexes database
+			if indexer.IsSQLDatabaseURI(indexer.MOONSTREAM_DB_V3_INDEXES_URI) {
+				return fmt.Errorf("retry of failed ranges requires PostgreSQL indexes database")
+			}
+
+			storageErr := storage.CheckVariablesForStorage()
+			if storageErr != nil {
+				return storageErr
+			}
+
+			crawlerErr := crawler.CheckVariablesForCrawler()
+			if crawlerErr != nil {
+				return crawlerErr
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			indexer.InitDBConnection()
+
+			ctx, stop := signal.NotifyContext(cmd.Context(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+
+			newCrawler, crawlerError := crawler.NewCrawler(ctx, chain, 0, 0, 0, timeout, baseDir, true, receipts, verifyBlocks, false, traces, "", protoSizeLimit, 0)
+			if crawlerError != nil {
+				return crawlerError
+			}
+
+			if logsFromReceipts {
+				if setErr := newCrawler.SetLogsFromReceipts(); setErr != nil {
+					return setErr
+				}
+			}
+
+			latestBlockNumber, latestErr := newCrawler.Client.GetLatestBlockNumber(ctx)
+			if latestErr != nil {
+				return fmt.Errorf("Failed to get latest block number: %v", latestErr)
+			}
+			crawler.CurrentBlockchainState.SetLatestBlockNumber(latestBlockNumber)
+
+			crawled, left, retryErr := newCrawler.RetryFailedRanges(ctx, threads)
+			if retryErr != nil {
+				return retryErr
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Crawled %d ranges of %s from dead-letter table, %d ranges are left\n", crawled, chain, left)
+			return nil
+		},
+	}
+
+	retryFailedCmd.Flags().StringVar(&chain, "chain", "ethereum", "The blockchain which failed ranges are crawled again (default: ethereum)")
+	retryFailedCmd.Flags().StringVar(&baseDir, "base-dir", "", "The base directory of crawled data, the same as of crawler (default: '')")
+	retryFailedCmd.Flags().IntVar(&timeout, "timeout", 30, "The timeout for the crawler in seconds (default: 30)")
+	retryFailedCmd.Flags().IntVar(&threads, "threads", 1, "Number of go-routines for concurrent crawling (default: 1)")
+	retryFailedCmd.Flags().BoolVar(&receipts, "receipts", false, "Set this flag to fetch transaction receipts, the same as of crawler (default: false)")
+	retryFailedCmd.Flags().BoolVar(&logsFromReceipts, "logs-from-receipts", false, "Set this flag to take events from receipts of blocks instead of eth_getLogs, implies --receipts (default: false)")
+	retryFailedCmd.Flags().BoolVar(&verifyBlocks, "verify-blocks", false, "Set this flag to check that fetched consecutive blocks are linked by parent hashes (default: false)")
+	retryFailedCmd.Flags().StringVar(&traces, "traces", "", "Set to 'debug', 'parity' or 'auto' to store traces of blocks, the same as of crawler (default: disabled)")
+	retryFailedCmd.Flags().Uint64Var(&protoSizeLimit, "proto-size-limit", 25, "Proto file size limit in Mb (default: 25Mb)")
+
+	return retryFailedCmd
+}
+
+func CreateWormRecrawlCommand() *cobra.Command {
+	var chain, baseDir, traces, blocks string
+	var fromBlock, toBlock int64
+	var timeout, threads int
+	var protoSizeLimit uint64
+	var receipts, logsFromReceipts, verifyBlocks bool
+
+	var blockNumbers []int64
+
+	recrawlCmd := &cobra.Command{
+		Use:   "recrawl",
+		Short: "Fetch blocks again, overwrite their protos in storage and replace their index rows",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			rangeSet := cmd.Flags().Changed("from") || cmd.Flags().Changed("to")
+			if blocks == "" && !rangeSet {
+				return fmt.Errorf("blocks are required via --blocks or --from and --to")
+			}
+			if blocks != "" && rangeSet {
+				return fmt.Errorf("--blocks could not be used with --from and --to")
+			}
+
+			if blocks != "" {
+				for _, block := range strings.Split(blocks, ",") {
+					block = strings.TrimSpace(block)
+					if block == "" {
+						continue
+					}
+					blockNumber, parseErr := strconv.ParseInt(block, 10, 64)
+					if parseErr != nil || blockNumber < 0 {
+						return fmt.Errorf("invalid block number: %s", block)
+					}
+					blockNumbers = append(blockNumbers, blockNumber)
+				}
+			} else {
+				if !cmd.Flags().Changed("from") || !cmd.Flags().Changed("to") {
+					return fmt.Errorf("both --from and --to are required")
+				}
+				if fromBlock < 0 || toBlock < fromBlock {
+					return fmt.Errorf("--to should be greater or equal to --from")
+				}
+				for blockNumber := fromBlock; blockNumber <= toBlock; blockNumber++ {
+					blockNumbers = append(blockNumbers, blockNumber)
+				}
+			}
+			if len(blockNumbers) == 0 {
+				return fmt.Errorf("no blocks to recrawl")
+			}
+
+			indexerErr := indexer.CheckVariablesForIndexer()
+			if indexerErr != nil {
+				return indexerErr
+			}
+
+			storageErr := storage.CheckVariablesForStorage()
+			if storageErr != nil {
+				return storageErr
+			}
+
+			crawlerErr := crawler.CheckVariablesForCrawler()
+			if crawlerErr != nil {
+				return crawlerErr
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			indexer.InitDBConnection()
+
+			ctx := cmd.Context()
+
+			newCrawler, crawlerError := crawler.NewCrawler(ctx, chain, 0, 0, 0, timeout, baseDir, true, receipts, verifyBlocks, false, traces, "", protoSizeLimit, 0)
+			if crawlerError != nil {
+				return crawlerError
+			}
+
+			if logsFromReceipts {
+				if setErr := newCrawler.SetLogsFromReceipts(); setErr != nil {
+					return setErr
+				}
+			}
+
+			latestBlockNumber, latestErr := newCrawler.Client.GetLatestBlockNumber(ctx)
+			if latestErr != nil {
+				return fmt.Errorf("Failed to get latest block number: %v", latestErr)
+			}
+			for _, blockNumber := range blockNumbers {
+				if blockNumber > latestBlockNumber.Int64() {
+					return fmt.Errorf("block %d is greater than latest block number %d", blockNumber, latestBlockNumber)
+				}
+			}
+
+			return newCrawler.RecrawlBlocks(ctx, blockNumbers, threads)
+		},
+	}
+
+	recrawlCmd.Flags().StringVar(&chain, "chain", "ethereum", "The blockchain to recrawl (default: ethereum)")
+	recrawlCmd.Flags().StringVar(&blocks, "blocks", "", "Comma separated numbers of blocks to recrawl")
+	recrawlCmd.Flags().Int64Var(&fromBlock, "from", 0, "The first block of range to recrawl")
+	recrawlCmd.Flags().Int64Var(&toBlock, "to", 0, "The last block of range to recrawl")
+	recrawlCmd.Flags().StringVar(&baseDir, "base-dir", "", "The base directory of crawled data, the same as of crawler (default: '')")
+	recrawlCmd.Flags().IntVar(&timeout, "timeout", 30, "The timeout for the crawler in seconds (default: 30)")
+	recrawlCmd.Flags().IntVar(&threads, "threads", 1, "Number of go-routines for concurrent crawling (default: 1)")
+	recrawlCmd.Flags().BoolVar(&receipts, "receipts", false, "Set this flag to fetch transaction receipts, the same as of crawler (default: false)")
+	recrawlCmd.Flags().BoolVar(&logsFromReceipts, "logs-from-receipts", false, "Set this flag to take events from receipts of blocks instead of eth_getLogs, implies --receipts (default: false)")
+	recrawlCmd.Flags().BoolVar(&verifyBlocks, "verify-blocks", false, "Set this flag to check that fetched consecutive blocks are linked by parent hashes (default: false)")
+	recrawlCmd.Flags().StringVar(&traces, "traces", "", "Set to 'debug', 'parity' or 'auto' to store traces of blocks, the same as of crawler (default: disabled)")
+	recrawlCmd.Flags().Uint64Var(&protoSizeLimit, "proto-size-limit", 25, "Proto file size limit in Mb (default: 25Mb)")
+
+	return recrawlCmd
+}
+
+func CreateWormNFTOwnersCommand() *cobra.Command {
+	var chain, customerID, customerDbUriFlag, token, tokenID, owner string
+	var limit int
+
+	// customerDB connects to database of customer which NFT transfers are synchronized to
+	customerDB := func() (*indexer.PostgreSQLpgx, error) {
+		connectionString := customerDbUriFlag
+		if connectionString == "" {
+			if customerID == "" {
+				return nil, fmt.Errorf("customer is required via --customer-id or --customer-db-uri")
+			}
+			var dbConnErr error
+			connectionString, dbConnErr = synchronizer.GetDBConnection(customerID)
+			if dbConnErr != nil {
+				return nil, dbConnErr
+			}
+		}
+		return indexer.NewPostgreSQLpgxWithCustomURI(connectionString)
+	}
+
+	nftOwnersCmd := &cobra.Command{
+		Use:   "nft-owners",
+		Short: "Show current owners of NFTs derived from NFT transfers of customer",
+		Long:  "Show current owners of ERC-721 tokens and balances of ERC-1155 tokens, they are kept in <chain>_nft_owners table of customer database by synchronizer started with --token-transfers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if token == "" && owner == "" {
+				return fmt.Errorf("--token or --owner is required")
+			}
+
+			pgx, pgxErr := customerDB()
+			if pgxErr != nil {
+				return pgxErr
+			}
+
+			owners, readErr := pgx.ReadNFTOwners(cmd.Context(), chain, token, tokenID, owner, limit)
+			if readErr != nil {
+				return readErr
+			}
+
+			for _, nftOwner := range owners {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s %s %s %s (block %d)\n", nftOwner.Token, nftOwner.TokenID, nftOwner.Owner, nftOwner.Standard, nftOwner.Amount, nftOwner.BlockNumber)
+			}
+
+			return nil
+		},
+	}
+
+	nftOwnersCmd.PersistentFlags().StringVar(&chain, "chain", "ethereum", "The blockchain of NFTs (default: ethereum)")
+	nftOwnersCmd.PersistentFlags().StringVar(&customerID, "customer-id", "", "The customer which database NFT transfers are synchronized to")
+	nftOwnersCmd.PersistentFlags().StringVar(&customerDbUriFlag, "customer-db-uri", "", "Set customer database URI for development. This workflow bypass fetching customer database URL connection string from mdb-v3-controller API")
+	nftOwnersCmd.Flags().StringVar(&token, "token", "", "The address of NFT contract to show owners of")
+	nftOwnersCmd.Flags().StringVar(&tokenID, "token-id", "", "The id of token to show owners of (default: all tokens of contract)")
+	nftOwnersCmd.Flags().StringVar(&owner, "owner", "", "The address to show NFTs of (default: all owners)")
+	nftOwnersCmd.Flags().IntVar(&limit, "limit", 1000, "The maximal number of shown owners (default: 1000)")
+
+	rebuildCmd := &cobra.Command{
+		Use:   "rebuild",
+		Short: "Derive owners of all NFTs from NFT transfers again",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pgx, pgxErr := customerDB()
+			if pgxErr != nil {
+				return pgxErr
+			}
+
+			ensureErr := pgx.EnsureTokenTransfersTables(cmd.Context(), chain)
+			if ensureErr != nil {
+				return ensureErr
+			}
+
+			owners, rebuildErr := pgx.RebuildNFTOwners(cmd.Context(), chain)
+			if rebuildErr != nil {
+				return rebuildErr
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Derived %d NFT owners of %s\n", owners, chain)
+			return nil
+		},
+	}
+
+	nftOwnersCmd.AddCommand(rebuildCmd)
+
+	return nftOwnersCmd
+}
+
+func CreateWormRedecodeCommand() *cobra.Command {
+	var chain, address, baseDir, customerDbUriFlag, trustedForwarders string
+	var fromBlock, toBlock, batchSize uint64
+	var timeout, signaturesTimeout int
+	var resolveSelectors, resolveProxies, expandMulticalls, revertReasons bool
+
+	redecodeCmd := &cobra.Command{
+		Use:   "redecode",
+		Short: "Decode range of blocks again with current ABI jobs of contract and replace its labels",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			indexerErr := indexer.CheckVariablesForIndexer()
+			if indexerErr != nil {
+				return indexerErr
+			}
+
+			storageErr := storage.CheckVariablesForStorage()
+			if storageErr != nil {
+				return storageErr
+			}
+
+			crawlerErr := crawler.CheckVariablesForCrawler()
+			if crawlerErr != nil {
+				return crawlerErr
+			}
+
+			syncErr := synchronizer.CheckVariablesForSynchronizer()
+			if syncErr != nil {
+				return syncErr
+			}
+
+			if chain == "" {
+				return fmt.Errorf("blockchain is required via --chain")
+			}
+
+			if address == "" {
+				return fmt.Errorf("contract address is required via --address")
+			}
+
+			if !cmd.Flags().Changed("from") || !cmd.Flags().Changed("to") {
+				return fmt.Errorf("both --from and --to are required")
+			}
+			if toBlock < fromBlock {
+				return fmt.Errorf("--to should be greater or equal to --from")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			indexer.InitDBConnection()
+
+			ctx := cmd.Context()
+
+			newSynchronizer, synchonizerErr := synchronizer.NewSynchronizer(chain, baseDir, fromBlock, toBlock, batchSize, timeout)
+			if synchonizerErr != nil {
+				return synchonizerErr
+			}
+
+			if resolveSelectors {
+				seer_common.SetSignatureResolver(seer_common.NewSignatureResolver(time.Duration(signaturesTimeout) * time.Second))
+				newSynchronizer.SetResolveUnknownSelectors(true)
+			}
+
+			if resolveProxies {
+				proxiesResolver, ok := newSynchronizer.Client.(seer_blockchain.ProxiesResolver)
+				if !ok {
+					return fmt.Errorf("client of %s does not support resolution of proxies", chain)
+				}
+				proxiesResolver.SetResolveProxies(true)
+			}
+
+			if expandMulticalls {
+				multicallsExpander, ok := newSynchronizer.Client.(seer_blockchain.MulticallsExpander)
+				if !ok {
+					return fmt.Errorf("client of %s does not support expansion of multicalls", chain)
+				}
+				multicallsExpander.SetExpandMulticalls(true)
+				newSynchronizer.SetExpandMulticalls(true)
+			}
+
+			if revertReasons {
+				revertReasonsResolver, ok := newSynchronizer.Client.(seer_blockchain.RevertReasonsResolver)
+				if !ok {
+					return fmt.Errorf("client of %s does not support revert reasons", chain)
+				}
+				revertReasonsResolver.SetRevertReasons(true)
+			}
+
+			for _, forwarder := range strings.Split(trustedForwarders, ",") {
+				if forwarder = strings.TrimSpace(forwarder); forwarder != "" {
+					seer_common.RegisterTrustedForwarder(chain, forwarder)
+				}
+			}
+
+			return newSynchronizer.Redecode(ctx, customerDbUriFlag, address, fromBlock, toBlock)
+		},
+	}
+
+	redecodeCmd.Flags().StringVar(&chain, "chain", "ethereum", "The blockchain to redecode (default: ethereum)")
+	redecodeCmd.Flags().StringVar(&address, "address", "", "The address of contract which labels are decoded again")
+	redecodeCmd.Flags().Uint64Var(&fromBlock, "from", 0, "The first block of range to redecode")
+	redecodeCmd.Flags().Uint64Var(&toBlock, "to", 0, "The last block of range to redecode")
+	redecodeCmd.Flags().StringVar(&baseDir, "base-dir", "", "The base directory of crawled data, the same as of synchronizer (default: '')")
+	redecodeCmd.Flags().IntVar(&timeout, "timeout", 30, "The timeout for the crawler in seconds (default: 30)")
+	redecodeCmd.Flags().Uint64Var(&batchSize, "batch-size", 100, "The number of blocks which labels are replaced in one database transaction (default: 100)")
+	redecodeCmd.Flags().BoolVar(&resolveSelectors, "resolve-selectors", false, "Set this flag to write partially decoded labels of selectors missing in ABIs, the same as of synchronizer (default: false)")
+	redecodeCmd.Flags().BoolVar(&resolveProxies, "resolve-proxies", false, "Set this flag to decode calls of EIP-1967 proxies with ABI jobs of implementations, the same as of synchronizer (default: false)")
+	redecodeCmd.Flags().BoolVar(&expandMulticalls, "expand-multicalls", false, "Set this flag to decode sub-calls of multicall, Safe and ERC-4337 transactions, the same as of synchronizer (default: false)")
+	redecodeCmd.Flags().BoolVar(&revertReasons, "revert-reasons", false, "Set this flag to label failed transactions with revert reasons, the same as of synchronizer (default: false)")
+	redecodeCmd.Flags().StringVar(&trustedForwarders, "trusted-forwarders", "", "Comma separated addresses of EIP-2771 trusted forwarders, the same as of synchronizer (default: '')")
+	redecodeCmd.Flags().IntVar(&signaturesTimeout, "signatures-timeout", 10, "The timeout for signature databases requests in seconds (default: 10)")
+	redecodeCmd.Flags().StringVar(&customerDbUriFlag, "customer-db-uri", "", "Set customer database URI for development. This workflow bypass fetching customer IDs and its database URL connection strings from mdb-v3-controller API")
+
+	return redecodeCmd
+}
+
+func CreateWormWebhookCommand() *cobra.Command {
+	webhookCmd := &cobra.Command{
+		Use:   "webhook",
+		Short: "Register webhooks which receive decoded events matching filters",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	// prepareWebhooksTables connects to indexes database and creates webhooks tables, it is set as
+	// PreRunE of subcommands, so root PersistentPreRunE still loads plugins and custom chains
+	prepareWebhooksTables := func(cmd *cobra.Command, args []string) error {
+		indexerErr := indexer.CheckVariablesForIndexer()
+		if indexerErr != nil {
+			return indexerErr
+		}
+
+		indexer.InitDBConnection()
+
+		return indexer.DBConnection.EnsureWebhooksTables(cmd.Context())
+	}
+
+	var chain, customerID, address, eventName, filter, url, secret string
+	var where []string
+
+	addCmd := &cobra.Command{
+		Use:     "add",
+		Short:   "Register webhook for event of contract",
+		PreRunE: prepareWebhooksTables,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if customerID == "" || address == "" || eventName == "" || url == "" || secret == "" {
+				return fmt.Errorf("--customer-id, --address, --event, --url and --secret are required")
+			}
+
+			var predicates []indexer.WebhookPredicate
+			for _, spec := range where {
+				predicate, predicateErr := webhooks.ParsePredicate(spec)
+				if predicateErr != nil {
+					return predicateErr
+				}
+				predicates = append(predicates, predicate)
+			}
+
+			if filter != "" {
+				_, parseErr := filters.Parse(filter)
+				if parseErr != nil {
+					return fmt.Errorf("invalid filter: %v", parseErr)
+				}
+			}
+
+			id, createErr := indexer.DBConnection.CreateWebhook(cmd.Context(), indexer.Webhook{
+				CustomerID: customerID,
+				Blockchain: chain,
+				Address:    strings.ToLower(address),
+				EventName:  eventName,
+				Predicates: predicates,
+				Expression: filter,
+				URL:        url,
+				Secret:     secret,
+			})
+			if createErr != nil {
+				return createErr
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Registered webhook %d\n", id)
+			return nil
+		},
+	}
+
+	addCmd.Flags().StringVar(&chain, "chain", "ethereum", "The blockchain of contract (default: ethereum)")
+	addCmd.Flags().StringVar(&customerID, "customer-id", "", "The customer which ABI jobs decode events of contract")
+	addCmd.Flags().StringVar(&address, "address", "", "The address of contract which emits event")
+	addCmd.Flags().StringVar(&eventName, "event", "", "The name of event as in ABI, for example Transfer")
+	addCmd.Flags().StringArrayVar(&where, "where", nil, "Predicate on argument of event as <arg><op><value>, op is =, !=, >, >=, < or <=, could be repeated and all predicates must match (default: every event)")
+	addCmd.Flags().StringVar(&filter, "filter", "", "Filter expression on fields of event label, for example 'args.value > 1e18 && args.to in [0x..., 0x...]' (default: every event)")
+	addCmd.Flags().StringVar(&url, "url", "", "The URL deliveries are posted to")
+	addCmd.Flags().StringVar(&secret, "secret", "", "The secret deliveries are signed with in X-Seer-Signature header")
+
+	var listChain string
+
+	listCmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Show webhooks of chain with numbers of their deliveries",
+		PreRunE: prepareWebhooksTables,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			registered, readErr := indexer.DBConnection.ReadWebhooks(cmd.Context(), listChain)
+			if readErr != nil {
+				return readErr
+			}
+
+			stats, statsErr := indexer.DBConnection.ReadWebhookDeliveriesStats(cmd.Context(), listChain)
+			if statsErr != nil {
+				return statsErr
+			}
+
+			if len(registered) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "No webhooks of %s\n", listChain)
+				return nil
+			}
+
+			for _, webhook := range registered {
+				var predicates []string
+				for _, predicate := range webhook.Predicates {
+					predicates = append(predicates, predicate.Arg+predicate.Op+predicate.Value)
+				}
+				if webhook.Expression != "" {
+					predicates = append(predicates, webhook.Expression)
+				}
+				webhookStats := stats[webhook.ID]
+				fmt.Fprintf(cmd.OutOrStdout(), "%d customer %s: %s %s [%s] -> %s, %d pending, %d delivered, %d failed\n", webhook.ID, webhook.CustomerID, webhook.Address, webhook.EventName, strings.Join(predicates, ", "), webhook.URL, webhookStats.Pending, webhookStats.Delivered, webhookStats.Failed)
+			}
+
+			return nil
+		},
+	}
+
+	listCmd.Flags().StringVar(&listChain, "chain", "ethereum", "The blockchain to show webhooks of (default: ethereum)")
+
+	var removeID int64
+
+	removeCmd := &cobra.Command{
+		Use:     "remove",
+		Short:   "Remove webhook with its deliveries",
+		PreRunE: prepareWebhooksTables,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			removed, removeErr := indexer.DBConnection.DeleteWebhook(cmd.Context(), removeID)
+			if removeErr != nil {
+				return removeErr
+			}
+			if !removed {
+				return fmt.Errorf("webhook %d not found", removeID)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed webhook %d\n", removeID)
+			return nil
+		},
+	}
+
+	removeCmd.Flags().Int64Var(&removeID, "id", 0, "The id of webhook to remove")
+
+	webhookCmd.AddCommand(addCmd, listCmd, removeCmd)
+
+	return webhookCmd
+}
+
+func CreateEnricherCommand() *cobra.Command {
+	var chain, customerID, userID, metricsAddr string
+	var chainID, window, minActivity uint64
+	var limit, timeout int
+	var interval time.Duration
+	var once bool
+
+	enricherCmd := &cobra.Command{
+		Use:   "enricher",
+		Short: "Register ABI jobs of active contracts with ABIs of verified contracts from Sourcify and explorer API",
+		Long:  "Register ABI jobs of contracts which are active in index but have no ABI jobs, ABIs are fetched from Sourcify and from Etherscan API v2 when SEER_ETHERSCAN_API_KEY environment variable is set",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			indexerErr := indexer.CheckVariablesForIndexer()
+			if indexerErr != nil {
+				return indexerErr
+			}
+
+			crawlerErr := crawler.CheckVariablesForCrawler()
+			if crawlerErr != nil {
+				return crawlerErr
+			}
+
+			if chain == "" {
+				return fmt.Errorf("blockchain is required via --chain")
+			}
+
+			if customerID == "" || userID == "" {
+				return fmt.Errorf("customer and user of registered ABI jobs are required via --customer-id and --user-id")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			indexer.InitDBConnection()
+
+			ctx := cmd.Context()
+
+			if chainID == 0 {
+				client, clientErr := seer_blockchain.NewClient(chain, crawler.BlockchainURLs[chain], timeout)
+				if clientErr != nil {
+					return clientErr
+				}
+
+				chainIDReader, ok := client.(seer_blockchain.ChainIDReader)
+				if !ok {
+					return fmt.Errorf("chain ID of %s is not known, set it via --chain-id", chain)
+				}
+
+				nodeChainID, chainIDErr := chainIDReader.GetChainID(ctx)
+				if chainIDErr != nil {
+					return fmt.Errorf("failed to get chain ID of %s: %v", chain, chainIDErr)
+				}
+				chainID = nodeChainID.Uint64()
+			}
+
+			fetcher := seer_common.NewVerifiedABIFetcher(os.Getenv("SEER_ETHERSCAN_API_KEY"), time.Duration(timeout)*time.Second)
+
+			newEnricher := enricher.NewEnricher(chain, chainID, customerID, userID, fetcher)
+			newEnricher.SetSelection(window, minActivity, limit)
+			newEnricher.SetInterval(interval)
+
+			if once {
+				registered, roundErr := newEnricher.Round(ctx)
+				if roundErr != nil {
+					return roundErr
+				}
+				log.Printf("Registered ABI jobs of %d contracts", registered)
+				return nil
+			}
+
+			if metricsAddr != "" {
+				metrics.Serve(metricsAddr)
+			}
+
+			return newEnricher.Run(ctx)
+		},
+	}
+
+	enricherCmd.Flags().StringVar(&chain, "chain", "ethereum", "The blockchain to look up active contracts of (default: ethereum)")
+	enricherCmd.Flags().Uint64Var(&chainID, "chain-id", 0, "Chain ID contracts are looked up with in Sourcify and explorer API (default: reported by node)")
+	enricherCmd.Flags().StringVar(&customerID, "customer-id", "", "Customer registered ABI jobs belong to")
+	enricherCmd.Flags().StringVar(&userID, "user-id", "", "User registered ABI jobs belong to")
+	enricherCmd.Flags().Uint64Var(&window, "window", enricher.DefaultWindow, "Number of latest indexed blocks activity of addresses is counted in (default: 100000)")
+	enricherCmd.Flags().Uint64Var(&minActivity, "min-activity", enricher.DefaultMinActivity, "Number of transactions and events in window after which address is looked up (default: 100)")
+	enricherCmd.Flags().IntVar(&limit, "limit", enricher.DefaultAddressesPerRound, "Number of the most active addresses looked up in one round (default: 50)")
+	enricherCmd.Flags().DurationVar(&interval, "interval", enricher.DefaultInterval, "Time between rounds (default: 1h)")
+	enricherCmd.Flags().BoolVar(&once, "once", false, "Set this flag to run one round and exit (default: false)")
+	enricherCmd.Flags().IntVar(&timeout, "timeout", 30, "The timeout for node and ABI sources requests in seconds (default: 30)")
+	enricherCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Address to serve Prometheus metrics at /metrics, for example :9090 (default: disabled)")
+
+	enricherCmd.AddCommand(CreateEnricherNamesCommand())
+
+	return enricherCmd
+}
+
+func CreateEnricherNamesCommand() *cobra.Command {
+	var chain, registry, reverseSuffix, serviceName, metricsAddr string
+	var window, minActivity uint64
+	var limit, timeout int
+	var interval, refreshAfter time.Duration
+	var once bool
+
+	initNamesTable := func(cmd *cobra.Command) error {
+		indexerErr := indexer.CheckVariablesForIndexer()
+		if indexerErr != nil {
+			return indexerErr
+		}
+
+		indexer.InitDBConnection()
+
+		return indexer.DBConnection.EnsureAddressNamesTable(cmd.Context())
+	}
+
+	namesCmd := &cobra.Command{
+		Use:   "names",
+		Short: "Resolve active addresses to names of ENS compatible name service",
+		Long:  "Resolve senders and recipients of transactions to primary names from reverse records of name service, names which resolve back to address are kept in seer_address_names table of index database",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			crawlerErr := crawler.CheckVariablesForCrawler()
+			if crawlerErr != nil {
+				return crawlerErr
+			}
+
+			if chain == "" {
+				return fmt.Errorf("blockchain is required via --chain")
+			}
+
+			if _, ok := seer_common.GetNameService(chain); !ok && registry == "" {
+				return fmt.Errorf("name service of %s is not known, set its registry via --registry", chain)
+			}
+
+			return initNamesTable(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			service, _ := seer_common.GetNameService(chain)
+			if registry != "" {
+				service = seer_common.NameService{Name: serviceName, Registry: registry, ReverseSuffix: reverseSuffix}
+			}
+
+			client, clientErr := seer_blockchain.NewClient(chain, crawler.BlockchainURLs[chain], timeout)
+			if clientErr != nil {
+				return clientErr
+			}
+
+			provider, ok := client.(seer_blockchain.NameResolverProvider)
+			if !ok {
+				return fmt.Errorf("names of addresses of %s could not be resolved", chain)
+			}
+
+			nameEnricher := enricher.NewNameEnricher(chain, provider.NameResolver(service))
+			nameEnricher.SetSelection(window, minActivity, limit)
+			nameEnricher.SetInterval(interval, refreshAfter)
+
+			if once {
+				named, roundErr := nameEnricher.Round(ctx)
+				if roundErr != nil {
+					return roundErr
+				}
+				log.Printf("Resolved names of %d addresses", named)
+				return nil
+			}
+
+			if metricsAddr != "" {
+				metrics.Serve(metricsAddr)
+			}
+
+			return nameEnricher.Run(ctx)
+		},
+	}
+
+	namesCmd.PersistentFlags().StringVar(&chain, "chain", "ethereum", "The blockchain to resolve names of addresses of (default: ethereum)")
+	namesCmd.Flags().StringVar(&registry, "registry", "", "Address of ENS compatible registry of name service, required for chains without known name service (default: ENS registry on ethereum and sepolia)")
+	namesCmd.Flags().StringVar(&reverseSuffix, "reverse-suffix", "addr.reverse", "Name reverse records of addresses are kept under in registry set via --registry (default: addr.reverse)")
+	namesCmd.Flags().StringVar(&serviceName, "service", "ens", "Name of name service set via --registry written with names (default: ens)")
+	namesCmd.Flags().Uint64Var(&window, "window", enricher.DefaultWindow, "Number of latest indexed blocks activity of addresses is counted in (default: 100000)")
+	namesCmd.Flags().Uint64Var(&minActivity, "min-activity", enricher.DefaultNamesMinActivity, "Number of transactions in window after which address is resolved (default: 10)")
+	namesCmd.Flags().IntVar(&limit, "limit", enricher.DefaultAddressesToNamePerRound, "Number of the most active addresses resolved in one round (default: 500)")
+	namesCmd.Flags().DurationVar(&interval, "interval", enricher.DefaultInterval, "Time between rounds (default: 1h)")
+	namesCmd.Flags().DurationVar(&refreshAfter, "refresh-after", enricher.DefaultNamesRefreshAfter, "Time after which names of addresses are resolved again (default: 168h)")
+	namesCmd.Flags().BoolVar(&once, "once", false, "Set this flag to run one round and exit (default: false)")
+	namesCmd.Flags().IntVar(&timeout, "timeout", 30, "The timeout for node requests in seconds (default: 30)")
+	namesCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Address to serve Prometheus metrics at /metrics, for example :9090 (default: disabled)")
+
+	var addresses string
+
+	lookupCmd := &cobra.Command{
+		Use:   "lookup",
+		Short: "Show resolved names of addresses",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return initNamesTable(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var lookupAddresses []string
+			for _, address := range strings.Split(addresses, ",") {
+				if address = strings.TrimSpace(address); address != "" {
+					lookupAddresses = append(lookupAddresses, strings.ToLower(address))
+				}
+			}
+			if len(lookupAddresses) == 0 {
+				return fmt.Errorf("addresses are required via --addresses")
+			}
+
+			names, readErr := indexer.DBConnection.ReadAddressNames(cmd.Context(), chain, lookupAddresses)
+			if readErr != nil {
+				return readErr
+			}
+
+			for _, address := range lookupAddresses {
+				name, ok := names[address]
+				if !ok {
+					name = "-"
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%s %s\n", address, name)
+			}
+
+			return nil
+		},
+	}
+
+	lookupCmd.Flags().StringVar(&addresses, "addresses", "", "Comma separated addresses to show names of")
+
+	namesCmd.AddCommand(lookupCmd)
+
+	return namesCmd
+}
+
+func CreateAggregatorCommand() *cobra.Command {
+	var chain, fromDate, metricsAddr string
+	var interval time.Duration
+	var once bool
+
+	initStatsTables := func(cmd *cobra.Command) error {
+		indexerErr := indexer.CheckVariablesForIndexer()
+		if indexerErr != nil {
+			return indexerErr
+		}
+
+		if chain == "" {
+			return fmt.Errorf("blockchain is required via --chain")
+		}
+
+		indexer.InitDBConnection()
+
+		return indexer.DBConnection.EnsureAddressDailyStatsTables(cmd.Context(), chain)
+	}
+
+	aggregatorCmd := &cobra.Command{
+		Use:   "aggregator",
+		Short: "Aggregate transactions and logs indexes into daily stats of addresses",
+		Long:  "Aggregate sent and received transactions, unique counterparties, gas used and emitted events of every address per UTC day into <chain>_address_daily_stats table of index database, gas used is summed only for transactions crawled with --index-gas-used",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return initStatsTables(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if fromDate != "" {
+				from, parseErr := time.Parse(time.DateOnly, fromDate)
+				if parseErr != nil {
+					return fmt.Errorf("invalid --from-date %s: %v", fromDate, parseErr)
+				}
+
+				resetErr := indexer.DBConnection.ResetCompletedDay(ctx, chain, indexer.AddressDailyStatsAggregation, from.AddDate(0, 0, -1))
+				if resetErr != nil {
+					return resetErr
+				}
+			}
+
+			newAggregator := aggregator.NewAggregator(chain)
+			newAggregator.SetInterval(interval)
+
+			if once {
+				days, roundErr := newAggregator.Round(ctx)
+				if roundErr != nil {
+					return roundErr
+				}
+				log.Printf("Aggregated %d days", days)
+				return nil
+			}
+
+			if metricsAddr != "" {
+				metrics.Serve(metricsAddr)
+			}
+
+			return newAggregator.Run(ctx)
+		},
+	}
+
+	aggregatorCmd.PersistentFlags().StringVar(&chain, "chain", "ethereum", "The blockchain to aggregate indexes of (default: ethereum)")
+	aggregatorCmd.Flags().StringVar(&fromDate, "from-date", "", "UTC day in YYYY-MM-DD format to aggregate again from, days after it are replaced (default: continue after the last complete day)")
+	aggregatorCmd.Flags().DurationVar(&interval, "interval", aggregator.DefaultInterval, "Time between rounds (default: 10m)")
+	aggregatorCmd.Flags().BoolVar(&once, "once", false, "Set this flag to run one round and exit (default: false)")
+	aggregatorCmd.Flags().StringVar(&metricsAddr, "metrics-addr", "", "Address to serve Prometheus metrics at /metrics, for example :9090 (default: disabled)")
+
+	var address, from, to string
+
+	statsCmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show daily stats of address",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if address == "" {
+				return fmt.Errorf("address is required via --address")
+			}
+
+			return initStatsTables(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			toDay := time.Now().UTC()
+			if to != "" {
+				parsed, parseErr := time.Parse(time.DateOnly, to)
+				if parseErr != nil {
+					return fmt.Errorf("invalid --to %s: %v", to, parseErr)
+				}
+				toDay = parsed
+			}
+
+			fromDay := toDay.AddDate(0, 0, -30)
+			if from != "" {
+				parsed, parseErr := time.Parse(time.DateOnly, from)
+				if parseErr != nil {
+					return fmt.Errorf("invalid --from %s: %v", from, parseErr)
+				}
+				fromDay = parsed
+			}
+
+			stats, readErr := indexer.DBConnection.ReadAddressDailyStats(cmd.Context(), chain, address, fromDay, toDay)
+			if readErr != nil {
+				return readErr
+			}
+
+			for _, dayStats := range stats {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s sent=%d received=%d counterparties=%d gas_used=%s events=%d\n", dayStats.Day.Format(time.DateOnly), dayStats.TransactionsSent, dayStats.TransactionsReceived, dayStats.Counterparties, dayStats.GasUsed, dayStats.EventsEmitted)
+			}
+
+			return nil
+		},
+	}
+
+	statsCmd.Flags().StringVar(&address, "address", "", "Address to show daily stats of")
+	statsCmd.Flags().StringVar(&from, "from", "", "The first UTC day in YYYY-MM-DD format (default: 30 days before --to)")
+	statsCmd.Flags().StringVar(&to, "to", "", "The last UTC day in YYYY-MM-DD format (default: today)")
+
+	aggregatorCmd.AddCommand(statsCmd)
+
+	return aggregatorCmd
+}
+
+func CreateDatabaseCommand() *cobra.Command {
+	databaseCmd := &cobra.Command{
+		Use:   "database",
+		Short: "Manage schema of indexes and customer databases",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	migrateCmd := CreateDatabaseMigrateCommand()
+	databaseCmd.AddCommand(migrateCmd)
+
+	return databaseCmd
+}
+
+func CreateDatabaseMigrateCommand() *cobra.Command {
+	var target, chain, customerID, customerDbUriFlag string
+
+	// migrator connects to indexes database or to database of customer by target of migrations
+	migrator := func(cmd *cobra.Command) (*migrations.Migrator, error) {
+		if chain == "" {
+			return nil, fmt.Errorf("blockchain is required via --chain")
+		}
+
+		var connectionString string
+		switch target {
+		case migrations.IndexesTarget:
+			indexerErr := indexer.CheckVariablesForIndexer()
+			if indexerErr != nil {
+				return nil, indexerErr
+			}
+			connectionString = indexer.MOONSTREAM_DB_V3_INDEXES_URI
+		case migrations.LabelsTarget:
+			connectionString = customerDbUriFlag
+			if connectionString == "" {
+				if customerID == "" {
+					return nil, fmt.Errorf("customer is required via --customer-id or --customer-db-uri")
+				}
+
+				syncErr := synchronizer.CheckVariablesForSynchronizer()
+				if syncErr != nil {
+					return nil, syncErr
+				}
+
+				var dbConnErr error
+				connectionString, dbConnErr = synchronizer.GetDBConnection(customerID)
+				if dbConnErr != nil {
+					return nil, dbConnErr
+				}
+			}
+		default:
+			return nil, fmt.Errorf("unknown --target %s, choose one of: %s", target, strings.Join(migrations.Targets(), ", "))
+		}
+
+		if indexer.IsSQLDatabaseURI(connectionString) {
+			return nil, fmt.Errorf("migrations are applied only to PostgreSQL databases, SQLite and MySQL databases create tables when they are used first")
+		}
+
+		pgx, pgxErr := indexer.NewPostgreSQLpgxWithCustomURI(connectionString)
+		if pgxErr != nil {
+			return nil, pgxErr
+		}
+
+		return migrations.NewMigrator(pgx, target, chain, indexer.IsBlockchainWithL1Chain(chain))
+	}
+
+	migrateCmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply or revert embedded migrations of index tables and labels tables",
+		Long:  "Apply or revert versioned migrations embedded into seer. Migrations of indexes target create index tables of chain in indexes database from MOONSTREAM_DB_V3_INDEXES_URI, migrations of labels target create labels table of chain in customer database. Applied migrations are recorded in seer_schema_migrations table of database",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	migrateCmd.PersistentFlags().StringVar(&target, "target", migrations.IndexesTarget, "Migrations to apply: indexes or labels (default: indexes)")
+	migrateCmd.PersistentFlags().StringVar(&chain, "chain", "ethereum", "The blockchain to migrate tables of (default: ethereum)")
+	migrateCmd.PersistentFlags().StringVar(&customerID, "customer-id", "", "The customer which database labels migrations are applied to")
+	migrateCmd.PersistentFlags().StringVar(&customerDbUriFlag, "customer-db-uri", "", "Set customer database URI for development. This workflow bypass fetching customer database URL connection string from mdb-v3-controller API")
+
+	var upTo int
+
+	upCmd := &cobra.Command{
+		Use:   "up",
+		Short: "Apply pending migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, migratorErr := migrator(cmd)
+			if migratorErr != nil {
+				return migratorErr
+			}
+
+			applied, upErr := m.Up(cmd.Context(), upTo)
+			if upErr != nil {
+				return upErr
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Applied %d migrations of %s for %s\n", applied, target, chain)
+			return nil
+		},
+	}
+
+	upCmd.Flags().IntVar(&upTo, "to", 0, "The last version to apply (default: all pending migrations)")
+
+	var downTo int
+
+	downCmd := &cobra.Command{
+		Use:   "down",
+		Short: "Revert applied migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("to") && downTo < 0 {
+				return fmt.Errorf("--to should be 0 or greater")
+			}
+
+			m, migratorErr := migrator(cmd)
+			if migratorErr != nil {
+				return migratorErr
+			}
+
+			reverted, downErr := m.Down(cmd.Context(), downTo)
+			if downErr != nil {
+				return downErr
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Reverted %d migrations of %s for %s\n", reverted, target, chain)
+			return nil
+		},
+	}
+
+	downCmd.Flags().IntVar(&downTo, "to", -1, "Revert migrations after this version, 0 reverts all migrations (default: the latest applied migration)")
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show applied and pending migrations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			m, migratorErr := migrator(cmd)
+			if migratorErr != nil {
+				return migratorErr
+			}
+
+			statuses, statusErr := m.Status(cmd.Context())
+			if statusErr != nil {
+				return statusErr
+			}
+
+			for _, status := range statuses {
+				state := "pending"
+				if status.Applied {
+					state = fmt.Sprintf("applied at %s", status.AppliedAt.UTC().Format(time.RFC3339))
+				}
+				fmt.Fprintf(cmd.OutOrStdout(), "%04d %s: %s\n", status.Version, status.Name, state)
+			}
+
+			return nil
+		},
+	}
+
+	migrateCmd.AddCommand(upCmd, downCmd, statusCmd)
+
+	return migrateCmd
+}
+
+func CreateBackfillCommand() *cobra.Command {
+	var chain string
+	var startBlock, endBlock, unitSize uint64
+
+	backfillCmd := &cobra.Command{
+		Use:   "backfill",
+		Short: "Plan and track backfill of historical blocks by many crawler instances",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	backfillCmd.PersistentFlags().StringVar(&chain, "chain", "ethereum", "The blockchain to backfill (default: ethereum)")
+
+	checkBackfillVariables := func() error {
+		indexerErr := indexer.CheckVariablesForIndexer()
+		if indexerErr != nil {
+			return indexerErr
+		}
+
+		if chain == "" {
+			return fmt.Errorf("blockchain is required via --chain")
+		}
+
+		return nil
+	}
+
+	planCmd := &cobra.Command{
+		Use:   "plan",
+		Short: "Split range of blocks into units which crawler instances started with --backfill lease",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			variablesErr := checkBackfillVariables()
+			if variablesErr != nil {
+				return variablesErr
+			}
+
+			if endBlock < startBlock {
+				return fmt.Errorf("end block should be greater or equal to start block")
+			}
+			if unitSize == 0 {
+				return fmt.Errorf("unit size should be greater than 0")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			indexer.InitDBConnection()
+
+			ctx := cmd.Context()
+
+			tableErr := indexer.DBConnection.EnsureBackfillUnitsTable(ctx)
+			if tableErr != nil {
+				return tableErr
+			}
+
+			created, createErr := indexer.DBConnection.CreateBackfillUnits(ctx, chain, startBlock, endBlock, unitSize)
+			if createErr != nil {
+				return createErr
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Planned %d new units of %s in blocks %d-%d\n", created, chain, startBlock, endBlock)
+
+			return nil
+		},
+	}
+
+	planCmd.Flags().Uint64Var(&startBlock, "start-block", 0, "The first block of backfill")
+	planCmd.Flags().Uint64Var(&endBlock, "end-block", 0, "The last block of backfill")
+	planCmd.Flags().Uint64Var(&unitSize, "unit-size", 100000, "Number of blocks in single unit (default: 100000)")
+	planCmd.MarkFlagRequired("end-block")
+
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show completion of backfill",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return checkBackfillVariables()
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			indexer.InitDBConnection()
+
+			ctx := cmd.Context()
+
+			tableErr := indexer.DBConnection.EnsureBackfillUnitsTable(ctx)
+			if tableErr != nil {
+				return tableErr
+			}
+
+			status, statusErr := indexer.DBConnection.ReadBackfillStatus(ctx, chain)
+			if statusErr != nil {
+				return statusErr
+			}
+
+			var completion float64
+			if status.TotalBlocks > 0 {
+				completion = float64(status.DoneBlocks) / float64(status.TotalBlocks) * 100
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Units: %d total, %d pending, %d leased, %d expired leases, %d done\n", status.Total, status.Pending, status.Leased, status.Expired, status.Done)
+			fmt.Fprintf(cmd.OutOrStdout(), "Blocks: %d of %d crawled (%.2f%%)\n", status.DoneBlocks, status.TotalBlocks, completion)
+
+			return nil
+		},
+	}
+
+	backfillCmd.AddCommand(planCmd, statusCmd)
+
+	return backfillCmd
+}
+
+func CreateUtilsCommand() *cobra.Command {
+	utilsCmd := &cobra.Command{
+		Use:   "utils",
+		Short: "Utilities for planning crawls and moving crawled datasets",
+		Run: func(cmd *cobra.Command, args []string) {
+			cmd.Help()
+		},
+	}
+
+	blockAtCmd := CreateBlockAtCommand()
+	exportCmd := CreateExportCommand()
+	importCmd := CreateImportCommand()
+	utilsCmd.AddCommand(blockAtCmd, exportCmd, importCmd)
+
+	return utilsCmd
+}
+
+func CreateBlockAtCommand() *cobra.Command {
+	var chain string
+	var times []string
+	var timeout int
+	var rpcOnly, useIndex bool
+
+	var timestamps []time.Time
+
+	blockAtCmd := &cobra.Command{
+		Use:   "block-at",
+		Short: "Resolve times to the last blocks mined at or before them",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if len(times) == 0 {
+				return fmt.Errorf("time is required via --time")
+			}
+
+			// Times are RFC 3339 timestamps, dates or unix timestamps in seconds
+			for _, rawTime := range times {
+				if parsedTime, parseErr := time.Parse(time.RFC3339, rawTime); parseErr == nil {
+					timestamps = append(timestamps, parsedTime)
+				} else if parsedDate, dateErr := time.Parse(time.DateOnly, rawTime); dateErr == nil {
+					timestamps = append(timestamps, parsedDate)
+				} else if unixTime, unixErr := strconv.ParseInt(rawTime, 10, 64); unixErr == nil {
+					timestamps = append(timestamps, time.Unix(unixTime, 0).UTC())
+				} else {
+					return fmt.Errorf("invalid time %q, expected RFC 3339 time, date or unix timestamp", rawTime)
+				}
+			}
+
+			crawlerErr := crawler.CheckVariablesForCrawler()
+			if crawlerErr != nil {
+				return crawlerErr
+			}
+
+			// Without indexes database blocks are searched over RPC
+			if !rpcOnly {
+				useIndex = indexer.CheckVariablesForIndexer() == nil
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			if useIndex {
+				indexer.InitDBConnection()
+				useIndex = indexer.DBConnection != nil
+			}
+
+			client, clientErr := seer_blockchain.NewClient(chain, crawler.BlockchainURLs[chain], timeout)
+			if clientErr != nil {
+				return clientErr
+			}
+
+			latestBlockNumber, latestErr := client.GetLatestBlockNumber(ctx)
+			if latestErr != nil {
+				return fmt.Errorf("failed to get latest block number: %w", latestErr)
+			}
+			latestBlock := latestBlockNumber.Uint64()
+
+			latestTimestamp, latestTimestampErr := seer_blockchain.BlockTimestamp(ctx, client, latestBlock)
+			if latestTimestampErr != nil {
+				return fmt.Errorf("failed to get timestamp of latest block %d: %w", latestBlock, latestTimestampErr)
+			}
+
+			for _, targetTime := range timestamps {
+				target := uint64(targetTime.Unix())
+				if targetTime.Unix() < 0 {
+					target = 0
+				}
+
+				var blockNumber, blockTimestamp uint64
+				var source string
+
+				lowBlock, highBlock := uint64(0), latestBlock
+				var lowKnown bool
+
+				if target >= latestTimestamp {
+					blockNumber, blockTimestamp, source = latestBlock, latestTimestamp, "latest"
+				} else if useIndex {
+					before, after, readErr := indexer.DBConnection.ReadBlocksAroundTimestamp(ctx, chain, target)
+					if readErr != nil {
+						return fmt.Errorf("failed to read blocks index: %w", readErr)
+					}
+
+					if before != nil && after != nil && after.BlockNumber == before.BlockNumber+1 {
+						blockNumber, blockTimestamp, source = before.BlockNumber, before.BlockTimestamp, "index"
+					}
+
+					// Indexed blocks around time narrow RPC search when index has gap there
+					if before != nil && before.BlockNumber <= highBlock {
+						lowBlock, lowKnown = before.BlockNumber, true
+					}
+					if after != nil && after.BlockNumber > lowBlock && after.BlockNumber-1 < highBlock {
+						highBlock = after.BlockNumber - 1
+					}
+				}
+
+				if source == "" {
+					if !lowKnown {
+						lowTimestamp, lowErr := seer_blockchain.BlockTimestamp(ctx, client, lowBlock)
+						if lowErr != nil {
+							return fmt.Errorf("failed to get timestamp of block %d: %w", lowBlock, lowErr)
+						}
+						if lowTimestamp > target {
+							return fmt.Errorf("time %s is before block %d mined at %s", targetTime.Format(time.RFC3339), lowBlock, time.Unix(int64(lowTimestamp), 0).UTC().Format(time.RFC3339))
+						}
+					}
+
+					var searchErr error
+					blockNumber, searchErr = seer_blockchain.BlockAtTimestamp(ctx, client, target, lowBlock, highBlock)
+					if searchErr != nil {
+						return fmt.Errorf("failed to search block at %s: %w", targetTime.Format(time.RFC3339), searchErr)
+					}
+
+					var timestampErr error
+					blockTimestamp, timestampErr = seer_blockchain.BlockTimestamp(ctx, client, blockNumber)
+					if timestampErr != nil {
+						return fmt.Errorf("failed to get timestamp of block %d: %w", blockNumber, timestampErr)
+					}
+					source = "rpc"
+				}
+
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\t%d\t%s\t%s\n", targetTime.UTC().Format(time.RFC3339), blockNumber, time.Unix(int64(blockTimestamp), 0).UTC().Format(time.RFC3339), source)
+			}
+
+			return nil
+		},
+	}
+
+	blockAtCmd.Flags().StringVar(&chain, "chain", "ethereum", "The blockchain to resolve times at (default: ethereum)")
+	blockAtCmd.Flags().StringSliceVar(&times, "time", []string{}, "Time to resolve as RFC 3339 time, date or unix timestamp, could be set several times, e.g. --time 2024-06-01T00:00:00Z --time 2024-07-01")
+	blockAtCmd.Flags().IntVar(&timeout, "timeout", 30, "The timeout for RPC requests in seconds (default: 30)")
+	blockAtCmd.Flags().BoolVar(&rpcOnly, "rpc-only", false, "Set this flag to search blocks over RPC without indexes database (default: false, index is used when MOONSTREAM_DB_V3_INDEXES_URI is set)")
+
+	return blockAtCmd
+}
+
+func CreateExportCommand() *cobra.Command {
+	var chain, baseDir, output string
+	var fromBlock, toBlock uint64
+
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export protos and index rows of blocks range to portable bundle",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if fromBlock > toBlock {
+				return fmt.Errorf("--from %d is greater than --to %d", fromBlock, toBlock)
+			}
+
+			crawlerErr := crawler.CheckVariablesForCrawler()
+			if crawlerErr != nil {
+				return crawlerErr
+			}
+
+			if _, ok := crawler.BlockchainURLs[chain]; !ok {
+				return fmt.Errorf("blockchain %s is not supported", chain)
+			}
+
+			storageErr := storage.CheckVariablesForStorage()
+			if storageErr != nil {
+				return storageErr
+			}
+
+			indexerErr := indexer.CheckVariablesForIndexer()
+			if indexerErr != nil {
+				return indexerErr
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			indexer.InitDBConnection()
+
+			basePath := filepath.Join(baseDir, crawler.SeerCrawlerStoragePrefix, "data", chain)
+			storageInstance, newStorageErr := storage.NewStorage(storage.SeerCrawlerStorageType, basePath)
+			if newStorageErr != nil {
+				return newStorageErr
+			}
+
+			if output == "" {
+				output = fmt.Sprintf("%s-%d-%d.tar.gz", chain, fromBlock, toBlock)
+			}
+
+			outputFile, createErr := os.Create(output)
+			if createErr != nil {
+				return createErr
+			}
+			defer outputFile.Close()
+
+			manifest, exportErr := bundle.Export(cmd.Context(), chain, fromBlock, toBlock, storageInstance, outputFile)
+			if exportErr != nil {
+				outputFile.Close()
+				os.Remove(output)
+				return exportErr
+			}
+
+			closeErr := outputFile.Close()
+			if closeErr != nil {
+				return closeErr
+			}
+
+			log.Printf("Exported blocks %d-%d of %s with %d files to %s", manifest.FromBlock, manifest.ToBlock, chain, len(manifest.Files), output)
+			return nil
+		},
+	}
+
+	exportCmd.Flags().StringVar(&chain, "chain", "ethereum", "The blockchain to export (default: ethereum)")
+	exportCmd.Flags().Uint64Var(&fromBlock, "from", 0, "The first block of range, packs with blocks of range are exported whole (default: 0)")
+	exportCmd.Flags().Uint64Var(&toBlock, "to", 0, "The last block of range (default: 0)")
+	exportCmd.Flags().StringVar(&baseDir, "base-dir", "", "The base directory of the crawled data (default: '')")
+	exportCmd.Flags().StringVar(&output, "output", "", "Path of bundle file (default: <chain>-<from>-<to>.tar.gz)")
+
+	exportCmd.MarkFlagRequired("to")
+
+	return exportCmd
+}
+
+func CreateImportCommand() *cobra.Command {
+	var chain, baseDir, input string
+	var verifyOnly bool
+
+	importCmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import bundle exported with export command to storage and index tables",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if input == "" {
+				return fmt.Errorf("bundle is required via --input")
+			}
+
+			if verifyOnly {
+				return nil
+			}
+
+			crawlerErr := crawler.CheckVariablesForCrawler()
+			if crawlerErr != nil {
+				return crawlerErr
+			}
+
+			storageErr := storage.CheckVariablesForStorage()
+			if storageErr != nil {
+				return storageErr
+			}
+
+			indexerErr := indexer.CheckVariablesForIndexer()
+			if indexerErr != nil {
+				return indexerErr
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			manifest, verifyErr := bundle.Verify(input)
+			if verifyErr != nil {
+				return verifyErr
+			}
+			log.Printf("Verified bundle of %s blocks %d-%d exported by seer %s at %s", manifest.Chain, manifest.FromBlock, manifest.ToBlock, manifest.SeerVersion, manifest.CreatedAt.Format(time.RFC3339))
+
+			if chain != "" && chain != manifest.Chain {
+				return fmt.Errorf("bundle contains %s blocks, not %s", manifest.Chain, chain)
+			}
+			if verifyOnly {
+				return nil
+			}
+
+			if _, ok := crawler.BlockchainURLs[manifest.Chain]; !ok {
+				return fmt.Errorf("blockchain %s of bundle is not supported", manifest.Chain)
+			}
+
+			indexer.InitDBConnection()
+
+			basePath := filepath.Join(baseDir, crawler.SeerCrawlerStoragePrefix, "data", manifest.Chain)
+			storageInstance, newStorageErr := storage.NewStorage(storage.SeerCrawlerStorageType, basePath)
+			if newStorageErr != nil {
+				return newStorageErr
+			}
+
+			importErr := bundle.Import(cmd.Context(), input, manifest, storageInstance, basePath)
+			if importErr != nil {
+				return importErr
+			}
+
+			log.Printf("Imported blocks %d-%d of %s from %s", manifest.FromBlock, manifest.ToBlock, manifest.Chain, input)
+			return nil
+		},
+	}
+
+	importCmd.Flags().StringVar(&input, "input", "", "Path of bundle file")
+	importCmd.Flags().StringVar(&chain, "chain", "", "The blockchain bundle is expected to contain (default: blockchain of bundle)")
+	importCmd.Flags().StringVar(&baseDir, "base-dir", "", "The base directory of the crawled data (default: '')")
+	importCmd.Flags().BoolVar(&verifyOnly, "verify-only", false, "Set this flag to only verify checksums of bundle without importing it (default: false)")
+
+	return importCmd
+}
+
+type BlockInspectItem struct {
+	StartBlock int64
+	EndBlock   int64
+}
+
+func CreateInspectorCommand() *cobra.Command {
+	inspectorCmd := &cobra.Command{
+		Use:   "inspector",
+		Short: "Inspect storage and database consistency",
+	}
+
+	var chain, baseDir, delim, returnFunc, batch string
+	var timeout int
+
+	readCommand := &cobra.Command{
+		Use:   "read",
+		Short: "Read and decode indexed proto data from storage",
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			storageErr := storage.CheckVariablesForStorage()
+			if storageErr != nil {
+				return storageErr
+			}
+
+			crawlerErr := crawler.CheckVariablesForCrawler()
+			if crawlerErr != nil {
+				return crawlerErr
+			}
+
+			if batch == "" {
+				return errors.New("batch is required via --batch")
+			}
+
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			basePath := filepath.Join(baseDir, crawler.SeerCrawlerStoragePrefix, "data", chain)
+			storageInstance, newStorageErr := storage.NewStorage(storage.SeerCrawlerStorageType, basePath)
+			if newStorageErr != nil {
 				return newStorageErr
 			}
 
@@ -407,6 +2644,17 @@ func CreateInspectorCommand() *cobra.Command {
 				return cleintErr
 			}
 
+			if protoJsonDecoder, ok := client.(seer_blockchain.ProtoJsonDecoder); ok {
+				protoJsonOutput, protoJsonErr := protoJsonDecoder.DecodeProtoEntireBlockToProtoJson(&rawData)
+				if protoJsonErr != nil {
+					return protoJsonErr
+				}
+
+				fmt.Println(string(protoJsonOutput))
+
+				return nil
+			}
+
 			output, decErr := client.DecodeProtoEntireBlockToJson(&rawData)
 			if decErr != nil {
 				return decErr
@@ -455,14 +2703,17 @@ func CreateInspectorCommand() *cobra.Command {
 			return nil
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
-			indexer.InitDBConnection()
+			indexer.InitIndexesDBConnection()
+			if indexer.IndexesDB == nil {
+				return fmt.Errorf("failed to connect to indexes database")
+			}
 
 			ctx := context.Background()
-			firstBlock, firstErr := indexer.DBConnection.GetEdgeDBBlock(ctx, chain, "first")
+			firstBlock, firstErr := indexer.IndexesDB.GetEdgeDBBlock(ctx, chain, "first")
 			if firstErr != nil {
 				return firstErr
 			}
-			lastBlock, lastErr := indexer.DBConnection.GetEdgeDBBlock(ctx, chain, "last")
+			lastBlock, lastErr := indexer.IndexesDB.GetEdgeDBBlock(ctx, chain, "last")
 			if firstErr != nil {
 				return lastErr
 			}
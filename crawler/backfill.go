@@ -0,0 +1,106 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/progress"
+)
+
+// DefaultBackfillLeaseDuration is time backfill unit stays leased to crawler instance without
+// heartbeat, after it unit is leased to other instance.
+const DefaultBackfillLeaseDuration = 5 * time.Minute
+
+// CrawlRange crawls blocks from start to end block through fetch and store stages, it returns
+// true when all blocks of range are written. Checkpoint and canonical chain of crawler are not
+// used, so ranges could be crawled by many instances at once.
+func (c *Crawler) CrawlRange(ctx context.Context, threads int, startBlock, endBlock int64) (bool, error) {
+	c.startBlock = startBlock
+	c.endBlock = endBlock
+	c.committedBlock.Store(startBlock - 1)
+
+	rangeProgress := progress.NewTracker(fmt.Sprintf("crawler %s", c.blockchain), uint64(endBlock-startBlock+1))
+
+	err := c.runPipeline(ctx, threads, c.state.GetLatestBlockNumber(), rangeProgress, startBlock)
+
+	rangeProgress.Finish()
+
+	return c.startBlock > endBlock, err
+}
+
+// RunBackfillWorker leases backfill units of chain and crawls them until no units are left or
+// ctx is cancelled. Lease of unit is extended every third of lease duration, if it is lost unit
+// is stopped and left to instance which leased it.
+func (c *Crawler) RunBackfillWorker(ctx context.Context, threads int, worker string, leaseDuration time.Duration) error {
+	if err := indexer.DBConnection.EnsureBackfillUnitsTable(ctx); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", indexer.BackfillUnitsTableName, err)
+	}
+
+	for ctx.Err() == nil {
+		unit, leaseErr := indexer.DBConnection.LeaseBackfillUnit(ctx, c.blockchain, worker, leaseDuration)
+		if leaseErr != nil {
+			return fmt.Errorf("failed to lease backfill unit: %w", leaseErr)
+		}
+		if unit == nil {
+			log.Printf("No backfill units of %s are left to lease", c.blockchain)
+			return nil
+		}
+
+		log.Printf("Worker %s leased backfill unit %d-%d of %s, attempt %d", worker, unit.StartBlock, unit.EndBlock, c.blockchain, unit.Attempts)
+
+		unitCtx, cancelUnit := context.WithCancel(ctx)
+		heartbeatDone := make(chan struct{})
+		go func() {
+			defer close(heartbeatDone)
+
+			ticker := time.NewTicker(leaseDuration / 3)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-unitCtx.Done():
+					return
+				case <-ticker.C:
+					err := indexer.DBConnection.HeartbeatBackfillUnit(unitCtx, unit, worker, leaseDuration)
+					if errors.Is(err, indexer.ErrBackfillLeaseLost) {
+						log.Printf("Lease of backfill unit %d-%d of %s is lost, unit is stopped", unit.StartBlock, unit.EndBlock, c.blockchain)
+						cancelUnit()
+						return
+					}
+					if err != nil && unitCtx.Err() == nil {
+						log.Printf("Failed to extend lease of backfill unit %d-%d: %v", unit.StartBlock, unit.EndBlock, err)
+					}
+				}
+			}
+		}()
+
+		crawled, crawlErr := c.CrawlRange(unitCtx, threads, int64(unit.StartBlock), int64(unit.EndBlock))
+		cancelUnit()
+		<-heartbeatDone
+
+		// Unit stays leased until lease expires, then it is crawled again by any worker
+		if crawlErr != nil {
+			return fmt.Errorf("failed to crawl backfill unit %d-%d: %w", unit.StartBlock, unit.EndBlock, crawlErr)
+		}
+
+		if !crawled {
+			continue
+		}
+
+		// Unit is completed also after shutdown signal, its blocks are already written
+		if err := indexer.DBConnection.CompleteBackfillUnit(context.Background(), unit, worker); err != nil {
+			if errors.Is(err, indexer.ErrBackfillLeaseLost) {
+				log.Printf("Backfill unit %d-%d of %s is crawled, but it is leased by other worker", unit.StartBlock, unit.EndBlock, c.blockchain)
+				continue
+			}
+			return fmt.Errorf("failed to complete backfill unit %d-%d: %w", unit.StartBlock, unit.EndBlock, err)
+		}
+		log.Printf("Backfill unit %d-%d of %s is completed", unit.StartBlock, unit.EndBlock, c.blockchain)
+	}
+
+	return nil
+}
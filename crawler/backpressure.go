@@ -0,0 +1,155 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// Defaults of backpressure: pack written longer than threshold pauses fetching until queued
+// batches are written, failing writes pause it until write succeeds or max duration passes.
+const (
+	DefaultBackpressureThreshold = time.Minute
+	DefaultMaxBackpressure       = 10 * time.Minute
+)
+
+var (
+	backpressureActive = metrics.NewGaugeVec("seer_crawler_backpressure",
+		"1 while fetching of blocks is paused because writes of packs fail or are slower than threshold", "chain")
+	backpressureSecondsTotal = metrics.NewCounterVec("seer_crawler_backpressure_seconds_total",
+		"Time fetching of blocks was paused by backpressure", "chain")
+	packWriteDuration = metrics.NewHistogramVec("seer_crawler_pack_write_duration_seconds",
+		"Duration of successful writes of packs to storage and indexes database", metrics.DefaultDurationBuckets, "chain")
+	queuedBatches = metrics.NewGaugeVec("seer_crawler_queued_batches",
+		"Crawled batches waiting for store stage", "chain")
+)
+
+// backpressure pauses fetch stage while store stage could not keep up with it.
+type backpressure struct {
+	threshold   time.Duration
+	maxDuration time.Duration
+
+	mux       sync.Mutex
+	active    bool
+	failing   bool
+	startedAt time.Time
+}
+
+// SetBackpressure sets duration of pack write after which fetching is paused and time failed
+// write is retried with fetching paused before crawler exits.
+func (c *Crawler) SetBackpressure(threshold, maxDuration time.Duration) {
+	c.backpressure.threshold = threshold
+	c.backpressure.maxDuration = maxDuration
+}
+
+// setBackpressure switches backpressure state, failing is set when writes fail and cleared only
+// by successful write.
+func (c *Crawler) setBackpressure(active, failing bool, reason string) {
+	b := &c.backpressure
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	b.failing = failing
+	if b.active == active {
+		return
+	}
+	b.active = active
+
+	if active {
+		b.startedAt = time.Now()
+		backpressureActive.Set(1, c.blockchain)
+		log.Printf("Fetching of %s blocks is paused: %s", c.blockchain, reason)
+		return
+	}
+
+	paused := time.Since(b.startedAt)
+	backpressureActive.Set(0, c.blockchain)
+	backpressureSecondsTotal.Add(paused.Seconds(), c.blockchain)
+	log.Printf("Fetching of %s blocks is resumed after %s", c.blockchain, paused.Round(time.Second))
+}
+
+func (c *Crawler) backpressureState() (active, failing bool) {
+	c.backpressure.mux.Lock()
+	defer c.backpressure.mux.Unlock()
+	return c.backpressure.active, c.backpressure.failing
+}
+
+// waitBackpressure blocks fetch stage until backpressure is released or ctx is cancelled.
+func (c *Crawler) waitBackpressure(ctx context.Context) {
+	for {
+		if active, _ := c.backpressureState(); !active {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// releaseQueuedBackpressure resumes fetching paused by slow write after queued batches were
+// taken by store stage.
+func (c *Crawler) releaseQueuedBackpressure(queued int) {
+	queuedBatches.Set(float64(queued), c.blockchain)
+	if active, failing := c.backpressureState(); active && !failing && queued == 0 {
+		c.setBackpressure(false, false, "")
+	}
+}
+
+// writePack writes pack with fetching paused while writes fail, failed write is retried with
+// backoff until max duration of backpressure passes. It returns duration of successful write.
+func (c *Crawler) writePack(pack *dataPack) (time.Duration, error) {
+	retryDelay := time.Second
+	var failingSince time.Time
+
+	for {
+		startedAt := time.Now()
+		err := c.pushPack(pack)
+		if err == nil {
+			duration := time.Since(startedAt)
+			packWriteDuration.Observe(duration.Seconds(), c.blockchain)
+			return duration, nil
+		}
+
+		// Pack of replica which lost leadership is crawled again by new leader
+		if errors.Is(err, indexer.ErrLeadershipLost) {
+			return 0, err
+		}
+
+		if failingSince.IsZero() {
+			failingSince = startedAt
+		}
+		if time.Since(failingSince) >= c.backpressure.maxDuration {
+			return 0, err
+		}
+
+		c.setBackpressure(true, true, "write of pack failed")
+		log.Printf("Failed to write pack of blocks %d-%d: %v. Retrying in %s...", pack.startBlock, pack.endBlock, err, retryDelay)
+		time.Sleep(retryDelay)
+
+		retryDelay *= 2
+		if retryDelay > time.Minute {
+			retryDelay = time.Minute
+		}
+	}
+}
+
+// updateBackpressure pauses fetching after pack was written longer than threshold while batches
+// are queued for store stage, it is resumed when queued batches are taken.
+func (c *Crawler) updateBackpressure(writeDuration time.Duration, queued int) {
+	queuedBatches.Set(float64(queued), c.blockchain)
+
+	if c.backpressure.threshold > 0 && writeDuration > c.backpressure.threshold && queued > 0 {
+		c.setBackpressure(true, false, fmt.Sprintf("write of pack took %s, %d batches are queued", writeDuration.Round(time.Second), queued))
+		return
+	}
+
+	c.setBackpressure(false, false, "")
+}
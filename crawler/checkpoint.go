@@ -0,0 +1,102 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+
+	"github.com/moonstream-to/seer/indexer"
+)
+
+// SetForceFrom makes crawler start from block regardless of checkpoint and indexed blocks.
+func (c *Crawler) SetForceFrom(blockNumber int64) {
+	c.forceFrom = &blockNumber
+}
+
+// resolveStartBlock sets block crawler starts from: --force-from block, start block of forced
+// crawl, block after checkpoint of chain, block after the latest indexed block or default shift
+// from latest block, in this order.
+func (c *Crawler) resolveStartBlock(ctx context.Context, latestBlockNumber *big.Int) error {
+	// Dry run does not touch indexes database, it starts from forced or default block
+	if c.dryRun != nil {
+		switch {
+		case c.forceFrom != nil:
+			c.startBlock = *c.forceFrom
+		case c.startBlock == 0:
+			c.startBlock = SetDefaultStartBlock(c.confirmations, latestBlockNumber)
+		}
+		log.Printf("Dry run starts from block: %d", c.startBlock)
+		return nil
+	}
+
+	if indexer.IndexesDB != nil {
+		if err := indexer.IndexesDB.EnsureCheckpointsTable(ctx); err != nil {
+			// Writes of replica are fenced by lease of leadership together with checkpoint
+			if c.replica != "" {
+				return fmt.Errorf("failed to create %s table: %w", indexer.CheckpointsTableName, err)
+			}
+			log.Printf("Checkpoints are disabled, failed to create %s table: %v", indexer.CheckpointsTableName, err)
+		} else {
+			c.checkpoints = true
+		}
+	}
+
+	if c.forceFrom != nil {
+		c.startBlock = *c.forceFrom
+		log.Printf("Start block is forced to: %d", c.startBlock)
+		return nil
+	}
+
+	if c.force {
+		if c.startBlock == 0 {
+			c.startBlock = SetDefaultStartBlock(c.confirmations, latestBlockNumber)
+		}
+		return nil
+	}
+
+	if c.checkpoints {
+		checkpoint, err := indexer.IndexesDB.ReadCheckpoint(ctx, c.blockchain)
+		if err != nil {
+			return fmt.Errorf("failed to read checkpoint: %w", err)
+		}
+		if checkpoint != nil {
+			c.startBlock = int64(checkpoint.BlockNumber) + 1
+			log.Printf("Start block set from checkpoint of %s to: %d", checkpoint.UpdatedAt, c.startBlock)
+			return nil
+		}
+	}
+
+	latestIndexedBlock, err := indexer.IndexesDB.GetLatestDBBlockNumber(c.blockchain)
+	if err != nil {
+		// If there are no rows in result then set startBlock with SetDefaultStartBlock()
+		if err.Error() != "no rows in result set" {
+			return fmt.Errorf("failed to get latest indexed block: %w", err)
+		}
+		c.startBlock = SetDefaultStartBlock(c.confirmations, latestBlockNumber)
+	}
+
+	if latestIndexedBlock != 0 {
+		c.startBlock = int64(latestIndexedBlock) + 1
+		log.Printf("Start block fetched from indexes database and set to: %d\n", c.startBlock)
+	}
+
+	return nil
+}
+
+// packCheckpoint returns checkpoint at the last block of pack, it is written in transaction of
+// pack indexes only while replica holds leadership. Nil is returned when checkpoints are disabled.
+func (c *Crawler) packCheckpoint(blocksIndex []indexer.BlockIndex, packEndBlock int64) *indexer.Checkpoint {
+	if !c.checkpoints {
+		return nil
+	}
+
+	checkpoint := &indexer.Checkpoint{Blockchain: c.blockchain, BlockNumber: uint64(packEndBlock), Leader: c.replica}
+	for _, block := range blocksIndex {
+		if int64(block.BlockNumber) == packEndBlock {
+			checkpoint.BlockHash = block.BlockHash
+		}
+	}
+
+	return checkpoint
+}
@@ -2,15 +2,22 @@ package crawler
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"math/big"
 	"path/filepath"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	seer_blockchain "github.com/moonstream-to/seer/blockchain"
+	"github.com/moonstream-to/seer/blockchain/beacon"
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/blockchain/traces"
 	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/progress"
 	"github.com/moonstream-to/seer/storage"
 	"google.golang.org/protobuf/proto"
 )
@@ -36,11 +43,46 @@ func (bs *BlockchainState) GetLatestBlockNumber() *big.Int {
 	return blockNumber
 }
 
+// Traces modes of crawler, set with --traces flag
+const (
+	TracesModeDebug  = "debug"  // call trees from debug_traceBlockByNumber with callTracer
+	TracesModeParity = "parity" // flat traces from trace_block
+	TracesModeAuto   = "auto"   // debug or parity, whichever node supports
+)
+
+// Block tags crawler follows as head of chain, set with --block-tag or SEER_CRAWLER_BLOCK_TAG_<CHAIN>
+const (
+	BlockTagLatest    = "latest"    // latest block minus confirmations
+	BlockTagSafe      = "safe"      // block unlikely to be reorganized according to consensus
+	BlockTagFinalized = "finalized" // block finalized by consensus
+)
+
 // Crawler defines the crawler structure.
 type Crawler struct {
 	Client          seer_blockchain.BlockchainClient
 	StorageInstance storage.Storer
 
+	// beaconClient is set if beacon node is configured for blockchain, used to fetch blob sidecars
+	beaconClient *beacon.Client
+
+	// headTracker is set if WebSocket endpoint is configured for blockchain, used to react to new
+	// blocks immediately instead of polling
+	headTracker *HeadTracker
+
+	// tracesMode is set to trace blocks, one of TracesModeDebug or TracesModeParity
+	tracesMode string
+
+	// blockTag is head of chain crawler follows, confirmations are not applied to safe and
+	// finalized blocks
+	blockTag string
+
+	// verifyBlocks enables check of parent hash linkage of fetched blocks
+	verifyBlocks bool
+
+	// validationClient is connected to second RPC provider when cross-validation is enabled,
+	// every batch is fetched from both providers and compared before it is written
+	validationClient seer_blockchain.BlockchainClient
+
 	blockchain     string
 	startBlock     int64
 	endBlock       int64
@@ -50,22 +92,165 @@ type Crawler struct {
 	basePath       string
 	protoSizeLimit uint64
 	protoTimeLimit int
+	// pipelineDepth is number of crawled batches buffered between fetch and store stages
+	pipelineDepth int
+
+	// maxReorgDepth is number of recent blocks kept in canonical chain to detect reorganizations
+	maxReorgDepth int64
+	canonical     *canonicalChain
+
+	// forceFrom overrides checkpoint and indexed blocks as start block
+	forceFrom *int64
+	// checkpoints is set when checkpoints table is available
+	checkpoints bool
+
+	// fetchWorkers is number of consecutive batches crawled concurrently
+	fetchWorkers int
+	// deadLetter makes failed ranges to be recorded and skipped instead of stopping crawler
+	deadLetter bool
+	// dryRun is set in dry-run mode, packs are counted in it instead of being written
+	dryRun *dryRunSummary
+	// backpressure pauses fetching while packs could not be written in time
+	backpressure backpressure
+	// blockTime is observed block time of chain, it sets polling interval and batch size at head
+	blockTime     blockTimeEstimator
+	headBatchTime time.Duration
+
+	// committedBlock is the last block which indexes are written, it is read by health checks
+	committedBlock atomic.Int64
+
+	// state holds the latest block number of chain, shared CurrentBlockchainState by default
+	state *BlockchainState
+	// isolated is set when crawler runs next to crawlers of other chains in one process, its
+	// health checks are named after chain
+	isolated bool
+
+	// replica is name of replica when crawler runs as one of replicas of chain, only leader
+	// crawls and writes blocks
+	replica string
+	leading atomic.Bool
 }
 
 // NewCrawler creates a new crawler instance with the given blockchain handler.
-func NewCrawler(blockchain string, startBlock, endBlock, confirmations int64, timeout int, baseDir string, force bool, protoSizeLimit uint64, protoTimeLimit int) (*Crawler, error) {
+func NewCrawler(ctx context.Context, blockchain string, startBlock, endBlock, confirmations int64, timeout int, baseDir string, force, receipts, verifyBlocks, crossValidate bool, tracesMode, blockTag string, protoSizeLimit uint64, protoTimeLimit int) (*Crawler, error) {
 	var crawler Crawler
 
 	basePath := filepath.Join(baseDir, SeerCrawlerStoragePrefix, "data", blockchain)
 	storageInstance, err := storage.NewStorage(storage.SeerCrawlerStorageType, basePath)
 	if err != nil {
-		log.Fatalf("Failed to create storage instance: %v", err)
-		panic(err)
+		return nil, fmt.Errorf("failed to create storage instance: %w", err)
 	}
 
 	client, err := seer_blockchain.NewClient(blockchain, BlockchainURLs[blockchain], timeout)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("failed to create client of blockchain %s: %w", blockchain, err)
+	}
+
+	// Capability profile of node, nil for clients which do not probe node
+	var capabilities *seer_common.NodeCapabilities
+	if prober, ok := client.(seer_blockchain.CapabilitiesProber); ok {
+		capabilities, err = prober.ProbeCapabilities(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to probe node capabilities: %w", err)
+		}
+		seer_blockchain.SetCapabilities(blockchain, capabilities)
+		log.Printf("Node capabilities for blockchain %s: %s", blockchain, capabilities)
+	}
+
+	var validationClient seer_blockchain.BlockchainClient
+	if crossValidate {
+		validationURL := ValidationURLs[blockchain]
+		if validationURL == "" {
+			return nil, fmt.Errorf("validation provider is not set for blockchain %s, set MOONSTREAM_NODE_%s_B_EXTERNAL_URI", blockchain, strings.ToUpper(blockchain))
+		}
+		validationClient, err = seer_blockchain.NewClient(blockchain, validationURL, timeout)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to validation provider: %w", err)
+		}
+		log.Printf("Blocks will be cross-validated with second provider for blockchain: %s", blockchain)
+	}
+
+	var beaconClient *beacon.Client
+	if beaconURL := BeaconURLs[blockchain]; beaconURL != "" {
+		if _, ok := client.(seer_blockchain.BlobTransactionsProvider); ok {
+			beaconClient, err = beacon.NewClient(beaconURL, timeout)
+			if err != nil {
+				return nil, fmt.Errorf("failed to create beacon client of %s: %w", blockchain, err)
+			}
+			indexer.RegisterCustomIndexTable(blockchain, beacon.BlobSidecarsIndexTable)
+			log.Printf("Blob sidecars will be fetched from beacon node for blockchain: %s", blockchain)
+		}
+	}
+
+	if receipts {
+		receiptsFetcher, ok := client.(seer_blockchain.ReceiptsFetcher)
+		if !ok {
+			return nil, fmt.Errorf("fetching of transaction receipts is not supported for blockchain: %s", blockchain)
+		}
+		receiptsFetcher.SetFetchReceipts(true)
+		log.Printf("Transaction receipts will be fetched for blockchain: %s", blockchain)
+	}
+
+	var headTracker *HeadTracker
+	if websocketURL := WebsocketURLs[blockchain]; websocketURL != "" {
+		headTracker = NewHeadTracker(blockchain, websocketURL)
+		log.Printf("New heads will be tracked with WebSocket subscription for blockchain: %s", blockchain)
+	}
+
+	if tracesMode == TracesModeAuto {
+		switch {
+		case capabilities != nil && capabilities.DebugTrace:
+			tracesMode = TracesModeDebug
+		case capabilities != nil && capabilities.ParityTrace:
+			tracesMode = TracesModeParity
+		default:
+			return nil, fmt.Errorf("node does not support tracing of blocks for blockchain: %s", blockchain)
+		}
+	}
+
+	switch tracesMode {
+	case "":
+	case TracesModeDebug:
+		if _, ok := client.(seer_blockchain.CallTracer); !ok {
+			return nil, fmt.Errorf("tracing of blocks is not supported for blockchain: %s", blockchain)
+		}
+		if capabilities != nil && !capabilities.DebugTrace {
+			return nil, fmt.Errorf("node does not support debug_traceBlockByNumber for blockchain: %s", blockchain)
+		}
+		indexer.RegisterCustomIndexTable(blockchain, traces.CallTracesIndexTable)
+		log.Printf("Call traces will be fetched with debug_traceBlockByNumber for blockchain: %s", blockchain)
+	case TracesModeParity:
+		if _, ok := client.(seer_blockchain.FlatTracer); !ok {
+			return nil, fmt.Errorf("tracing of blocks is not supported for blockchain: %s", blockchain)
+		}
+		if capabilities != nil && !capabilities.ParityTrace {
+			return nil, fmt.Errorf("node does not support trace_block for blockchain: %s", blockchain)
+		}
+		indexer.RegisterCustomIndexTable(blockchain, traces.FlatTracesIndexTable)
+		log.Printf("Flat traces will be fetched with trace_block for blockchain: %s", blockchain)
+	default:
+		return nil, fmt.Errorf("unknown traces mode: %s", tracesMode)
+	}
+
+	if blockTag == "" {
+		blockTag = BlockTags[blockchain]
+	}
+	switch blockTag {
+	case "", BlockTagLatest:
+		blockTag = BlockTagLatest
+	case BlockTagSafe, BlockTagFinalized:
+		if _, ok := client.(seer_blockchain.BlockTagProvider); !ok || capabilities == nil {
+			return nil, fmt.Errorf("block tags are not supported for blockchain: %s", blockchain)
+		}
+		if capabilities.SupportsBlockTag(blockTag) {
+			log.Printf("Crawler will follow %s block for blockchain: %s", blockTag, blockchain)
+			confirmations = 0
+		} else {
+			log.Printf("Node does not support %s block tag, latest block minus %d confirmations will be crawled for blockchain: %s", blockTag, confirmations, blockchain)
+			blockTag = BlockTagLatest
+		}
+	default:
+		return nil, fmt.Errorf("unknown block tag: %s", blockTag)
 	}
 
 	log.Printf("Initialized new crawler at blockchain: %s, startBlock: %d, endBlock: %d, force: %t", blockchain, startBlock, endBlock, force)
@@ -73,6 +258,16 @@ func NewCrawler(blockchain string, startBlock, endBlock, confirmations int64, ti
 		Client:          client,
 		StorageInstance: storageInstance,
 
+		beaconClient: beaconClient,
+		headTracker:  headTracker,
+		tracesMode:   tracesMode,
+		blockTag:     blockTag,
+		verifyBlocks: verifyBlocks,
+
+		validationClient: validationClient,
+
+		state: &CurrentBlockchainState,
+
 		blockchain:     blockchain,
 		startBlock:     startBlock,
 		endBlock:       endBlock,
@@ -82,20 +277,99 @@ func NewCrawler(blockchain string, startBlock, endBlock, confirmations int64, ti
 		basePath:       basePath,
 		protoSizeLimit: protoSizeLimit,
 		protoTimeLimit: protoTimeLimit,
+		pipelineDepth:  DefaultPipelineDepth,
+		maxReorgDepth:  DefaultMaxReorgDepth,
+		fetchWorkers:   1,
+		headBatchTime:  DefaultHeadBatchTime,
+		backpressure: backpressure{
+			threshold:   DefaultBackpressureThreshold,
+			maxDuration: DefaultMaxBackpressure,
+		},
 	}
 
 	return &crawler, nil
 }
 
+// SetLogsFilter narrows crawl to events matching filter and transactions which emitted them,
+// validation provider is filtered the same way to keep batches comparable.
+func (c *Crawler) SetLogsFilter(filter seer_common.LogsFilter) error {
+	for _, client := range []seer_blockchain.BlockchainClient{c.Client, c.validationClient} {
+		if client == nil {
+			continue
+		}
+		eventsFilterer, ok := client.(seer_blockchain.EventsFilterer)
+		if !ok {
+			return fmt.Errorf("filtering of events is not supported for blockchain: %s", c.blockchain)
+		}
+		eventsFilterer.SetLogsFilter(filter)
+	}
+
+	log.Printf("Only events of addresses %v with topics %v and their transactions will be crawled for blockchain: %s", filter.Address, filter.Topics, c.blockchain)
+	return nil
+}
+
+// SetTransactionsAddresses narrows crawl to transactions sent from or to addresses in addition to
+// transactions which emitted filtered events.
+func (c *Crawler) SetTransactionsAddresses(addresses []string) error {
+	for _, client := range []seer_blockchain.BlockchainClient{c.Client, c.validationClient} {
+		if client == nil {
+			continue
+		}
+		transactionsFilterer, ok := client.(seer_blockchain.TransactionsFilterer)
+		if !ok {
+			return fmt.Errorf("filtering of transactions is not supported for blockchain: %s", c.blockchain)
+		}
+		transactionsFilterer.SetTransactionsAddresses(addresses)
+	}
+
+	log.Printf("Transactions from or to addresses %v will be crawled for blockchain: %s", addresses, c.blockchain)
+	return nil
+}
+
+// SetLogsFromReceipts makes crawler take events from receipts of crawled blocks, so stored events
+// always match stored blocks and transactions.
+func (c *Crawler) SetLogsFromReceipts() error {
+	for _, client := range []seer_blockchain.BlockchainClient{c.Client, c.validationClient} {
+		if client == nil {
+			continue
+		}
+		receiptLogsReader, ok := client.(seer_blockchain.ReceiptLogsReader)
+		if !ok {
+			return fmt.Errorf("events from receipts are not supported for blockchain: %s", c.blockchain)
+		}
+		receiptLogsReader.SetLogsFromReceipts(true)
+	}
+
+	log.Printf("Events will be taken from receipts of blocks for blockchain: %s", c.blockchain)
+	return nil
+}
+
+// SetValidators makes crawler of beacon chain store balances of validators at every epoch,
+// validators are set with indexes or public keys.
+func (c *Crawler) SetValidators(validators []string) error {
+	validatorsTracker, ok := c.Client.(seer_blockchain.ValidatorsTracker)
+	if !ok {
+		return fmt.Errorf("balances of validators are not supported for blockchain: %s", c.blockchain)
+	}
+	validatorsTracker.SetValidators(validators)
+
+	log.Printf("Balances of %d validators will be crawled for blockchain: %s", len(validators), c.blockchain)
+	return nil
+}
+
 // Utility function to handle retries
-func retryOperation(attempts int, sleep time.Duration, fn func() error) error {
+func retryOperation(ctx context.Context, attempts int, sleep time.Duration, fn func() error) error {
 	for i := 0; i < attempts; i++ {
 		if err := fn(); err != nil {
-			if i == attempts-1 {
+			if i == attempts-1 || ctx.Err() != nil {
 				return err
 			}
 			log.Printf("Attempt %d/%d failed: %v. Retrying in %s...", i+1, attempts, err, sleep)
-			time.Sleep(sleep)
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(sleep):
+			}
 			continue
 		}
 		return nil
@@ -116,12 +390,30 @@ type BlocksBufferBatch struct {
 	Buffer bytes.Buffer
 }
 
-func (c *Crawler) PushPackOfData(blocksBufferPack *bytes.Buffer, blocksIndexPack []indexer.BlockIndex, txsIndexPack []indexer.TransactionIndex, eventsIndexPack []indexer.LogIndex, packStartBlock, packEndBlock int64) error {
+func (c *Crawler) PushPackOfData(blocksBufferPack *bytes.Buffer, blocksIndexPack []indexer.BlockIndex, txsIndexPack []indexer.TransactionIndex, eventsIndexPack []indexer.LogIndex, customIndexPack []indexer.CustomIndex, blobSidecarsPack []*beacon.BlobSidecar, callTracesPack []*traces.TransactionCallTrace, flatTracesPack []*traces.FlatTrace, packStartBlock, packEndBlock int64) error {
+	interfaceBlocksIndexPack, interfaceTxsIndexPack, interfaceEventsIndexPack, interfaceCustomIndexPack, err := c.savePackData(blocksBufferPack, blocksIndexPack, txsIndexPack, eventsIndexPack, customIndexPack, blobSidecarsPack, callTracesPack, flatTracesPack, packStartBlock, packEndBlock)
+	if err != nil {
+		return err
+	}
+
+	// Write indexes to database
+	err = indexer.WriteIndicesToDatabase(c.blockchain, interfaceBlocksIndexPack, interfaceTxsIndexPack, interfaceEventsIndexPack, interfaceCustomIndexPack, c.packCheckpoint(blocksIndexPack, packEndBlock))
+
+	if err != nil {
+		return fmt.Errorf("failed to write indices to database: %w", err)
+	}
+	c.committedBlock.Store(packEndBlock)
+
+	return nil
+}
+
+// savePackData saves protos of pack to storage and returns its indexes with path of protos.
+func (c *Crawler) savePackData(blocksBufferPack *bytes.Buffer, blocksIndexPack []indexer.BlockIndex, txsIndexPack []indexer.TransactionIndex, eventsIndexPack []indexer.LogIndex, customIndexPack []indexer.CustomIndex, blobSidecarsPack []*beacon.BlobSidecar, callTracesPack []*traces.TransactionCallTrace, flatTracesPack []*traces.FlatTrace, packStartBlock, packEndBlock int64) ([]indexer.BlockIndex, []indexer.TransactionIndex, []indexer.LogIndex, []indexer.CustomIndex, error) {
 	packRange := fmt.Sprintf("%d-%d", packStartBlock, packEndBlock)
 
 	// Save proto data
 	if err := c.StorageInstance.Save(packRange, "data.proto", *blocksBufferPack); err != nil {
-		return fmt.Errorf("failed to save data.proto: %w", err)
+		return nil, nil, nil, nil, fmt.Errorf("failed to save data.proto: %w", err)
 	}
 	log.Printf("Saved .proto blocks with transactions and events to %s", packRange)
 
@@ -144,56 +436,198 @@ func (c *Crawler) PushPackOfData(blocksBufferPack *bytes.Buffer, blocksIndexPack
 		interfaceEventsIndexPack = append(interfaceEventsIndexPack, v)
 	}
 
-	// Write indexes to database
-	err := indexer.WriteIndicesToDatabase(c.blockchain, interfaceBlocksIndexPack, interfaceTxsIndexPack, interfaceEventsIndexPack)
+	var interfaceCustomIndexPack []indexer.CustomIndex
+	for _, v := range customIndexPack {
+		v.Path = filepath.Join(c.basePath, packRange, "data.proto")
+		interfaceCustomIndexPack = append(interfaceCustomIndexPack, v)
+	}
+
+	// Save blob sidecars next to blocks with own index
+	if len(blobSidecarsPack) > 0 {
+		sidecarsBytes, err := proto.Marshal(beacon.ProcessSidecarsToBatch(blobSidecarsPack))
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to marshal blob sidecars: %w", err)
+		}
+		if err := c.StorageInstance.Save(packRange, "blobs.proto", *bytes.NewBuffer(sidecarsBytes)); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to save blobs.proto: %w", err)
+		}
+		log.Printf("Saved .proto blob sidecars to %s", packRange)
 
+		for _, v := range beacon.SidecarsToCustomIndexes(blobSidecarsPack) {
+			v.Path = filepath.Join(c.basePath, packRange, "blobs.proto")
+			interfaceCustomIndexPack = append(interfaceCustomIndexPack, v)
+		}
+	}
+
+	// Save call traces next to blocks with own index
+	if len(callTracesPack) > 0 {
+		tracesBytes, err := proto.Marshal(traces.ProcessCallTracesToBatch(callTracesPack))
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to marshal call traces: %w", err)
+		}
+		if err := c.StorageInstance.Save(packRange, "traces.proto", *bytes.NewBuffer(tracesBytes)); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to save traces.proto: %w", err)
+		}
+		log.Printf("Saved .proto call traces to %s", packRange)
+
+		for _, v := range traces.CallTracesToCustomIndexes(callTracesPack) {
+			v.Path = filepath.Join(c.basePath, packRange, "traces.proto")
+			interfaceCustomIndexPack = append(interfaceCustomIndexPack, v)
+		}
+	}
+
+	// Save flat traces next to blocks with own index
+	if len(flatTracesPack) > 0 {
+		tracesBytes, err := proto.Marshal(traces.ProcessFlatTracesToBatch(flatTracesPack))
+		if err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to marshal flat traces: %w", err)
+		}
+		if err := c.StorageInstance.Save(packRange, "flat_traces.proto", *bytes.NewBuffer(tracesBytes)); err != nil {
+			return nil, nil, nil, nil, fmt.Errorf("failed to save flat_traces.proto: %w", err)
+		}
+		log.Printf("Saved .proto flat traces to %s", packRange)
+
+		for _, v := range traces.FlatTracesToCustomIndexes(flatTracesPack) {
+			v.Path = filepath.Join(c.basePath, packRange, "flat_traces.proto")
+			interfaceCustomIndexPack = append(interfaceCustomIndexPack, v)
+		}
+	}
+
+	return interfaceBlocksIndexPack, interfaceTxsIndexPack, interfaceEventsIndexPack, interfaceCustomIndexPack, nil
+}
+
+// headBlockNumber returns number of block crawler follows: block with safe or finalized tag,
+// head received by WebSocket subscription or latest block from eth_blockNumber.
+func (c *Crawler) headBlockNumber(ctx context.Context) (*big.Int, error) {
+	if c.blockTag != BlockTagLatest {
+		return c.Client.(seer_blockchain.BlockTagProvider).GetBlockNumberByTag(ctx, c.blockTag)
+	}
+
+	if headBlockNumber := c.latestHeadBlockNumber(); headBlockNumber != nil {
+		return headBlockNumber, nil
+	}
+	return c.Client.GetLatestBlockNumber(ctx)
+}
+
+// latestHeadBlockNumber returns the latest block number received by WebSocket subscription, nil
+// if subscription is not configured or disconnected.
+func (c *Crawler) latestHeadBlockNumber() *big.Int {
+	if c.headTracker == nil {
+		return nil
+	}
+
+	blockNumber := c.headTracker.LatestBlockNumber()
+	if blockNumber != nil {
+		c.state.SetLatestBlockNumber(blockNumber)
+	}
+	return blockNumber
+}
+
+// CrawlBlobSidecars fetches blob sidecars of EIP-4844 transactions of blocks, if beacon node
+// is configured for blockchain.
+func (c *Crawler) CrawlBlobSidecars(ctx context.Context, blocks []proto.Message) ([]*beacon.BlobSidecar, error) {
+	if c.beaconClient == nil {
+		return nil, nil
+	}
+
+	provider, ok := c.Client.(seer_blockchain.BlobTransactionsProvider)
+	if !ok {
+		return nil, nil
+	}
+
+	blobTxs, err := provider.BlobTransactionsFromProtoBlocks(blocks)
 	if err != nil {
-		return fmt.Errorf("failed to write indices to database: %w", err)
+		return nil, err
+	}
+	if len(blobTxs) == 0 {
+		return nil, nil
 	}
 
-	return nil
+	return c.beaconClient.FetchBlobSidecars(ctx, blobTxs)
 }
 
-// Start initiates the crawling process for the configured blockchain.
-func (c *Crawler) Start(threads int) {
-	protoBufferSizeLimit := c.protoSizeLimit * 1024 * 1024 // In Mb
-	protoDurationTimeLimit := time.Duration(c.protoTimeLimit) * time.Second
+// CrawlCallTraces fetches call trees of transactions of blocks, if crawler is started with traces.
+func (c *Crawler) CrawlCallTraces(ctx context.Context, blocks []proto.Message) ([]*traces.TransactionCallTrace, error) {
+	if c.tracesMode != TracesModeDebug {
+		return nil, nil
+	}
 
-	batchSize := int64(10)
+	tracer, ok := c.Client.(seer_blockchain.CallTracer)
+	if !ok {
+		return nil, nil
+	}
 
-	latestBlockNumber := CurrentBlockchainState.GetLatestBlockNumber()
-	if c.force {
-		if c.startBlock == 0 {
-			c.startBlock = SetDefaultStartBlock(c.confirmations, latestBlockNumber)
-		}
-	} else {
-		latestIndexedBlock, err := indexer.DBConnection.GetLatestDBBlockNumber(c.blockchain)
+	return tracer.CallTracesFromProtoBlocks(ctx, blocks)
+}
 
-		// If there are no rows in result then set startBlock with SetDefaultStartBlock()
+// CrawlFlatTraces fetches flat traces of blocks, if crawler is started with Parity traces.
+func (c *Crawler) CrawlFlatTraces(ctx context.Context, blocks []proto.Message) ([]*traces.FlatTrace, error) {
+	if c.tracesMode != TracesModeParity {
+		return nil, nil
+	}
 
-		if err != nil {
-			if err.Error() == "no rows in result set" {
-				c.startBlock = SetDefaultStartBlock(c.confirmations, latestBlockNumber)
-			} else {
-				log.Fatalf("Failed to get latest indexed block: %v", err)
-			}
+	tracer, ok := c.Client.(seer_blockchain.FlatTracer)
+	if !ok {
+		return nil, nil
+	}
 
-		}
+	return tracer.FlatTracesFromProtoBlocks(ctx, blocks)
+}
 
-		if latestIndexedBlock != 0 {
-			c.startBlock = int64(latestIndexedBlock) + 1
-			log.Printf("Start block fetched from indexes database and set to: %d\n", c.startBlock)
-		}
+// Start initiates the crawling process for the configured blockchain, crawling stops when ctx
+// is cancelled and blocks crawled so far are written. It returns error of fetch or store stage
+// which stopped crawler.
+func (c *Crawler) Start(ctx context.Context, threads int) error {
+	if c.headTracker != nil {
+		go c.headTracker.Run(ctx)
+	}
+
+	latestBlockNumber := c.state.GetLatestBlockNumber()
+	if err := c.resolveStartBlock(ctx, latestBlockNumber); err != nil {
+		return fmt.Errorf("failed to set start block: %w", err)
+	}
+	c.committedBlock.Store(c.startBlock - 1)
+
+	// Report progress for backfills with known end block
+	var backfillProgress *progress.Tracker
+	backfillStartBlock := c.startBlock
+	if c.endBlock != 0 && c.endBlock >= c.startBlock {
+		backfillProgress = progress.NewTracker(fmt.Sprintf("crawler %s", c.blockchain), uint64(c.endBlock-c.startBlock+1))
+	}
+
+	c.loadCanonicalChain(ctx)
+
+	// Blocks are fetched and stored in separate stages, bounded channel between them keeps memory
+	// flat while slow writes hold back fetching
+	err := c.runPipeline(ctx, threads, latestBlockNumber, backfillProgress, backfillStartBlock)
+
+	if backfillProgress != nil {
+		backfillProgress.Finish()
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if ctx.Err() != nil {
+		log.Printf("Crawler of %s is shut down, crawled batches are written", c.blockchain)
+	}
+
+	if c.dryRun != nil {
+		log.Print(c.DryRunSummary())
 	}
 
-	// Variables to accumulate packs before write
-	var blocksPack []proto.Message
-	var blocksPackSize uint64
-	packCrawlStartTs := time.Now()
-	var blocksIndexPack []indexer.BlockIndex
-	var txsIndexPack []indexer.TransactionIndex
-	var eventsIndexPack []indexer.LogIndex
-	packStartBlock := c.startBlock
+	return nil
+}
+
+// fetchBatches is fetch stage of crawler pipeline, it crawls batches of blocks from start block
+// and sends them to store stage until end block is reached or context is cancelled. It returns
+// error which stopped crawling.
+func (c *Crawler) fetchBatches(ctx context.Context, threads int, latestBlockNumber *big.Int, batches chan<- *crawledBatch) error {
+	defer close(batches)
+
+	defaultBatchSize := int64(10)
+	batchSize := defaultBatchSize
 
 	tempEndBlock := c.startBlock + batchSize
 	var safeBlock int64
@@ -203,27 +637,46 @@ func (c *Crawler) Start(threads int) {
 	maxWaitForBlocksTime := 12 * retryWaitTime
 	retryAttempts := 3
 
+	// Last block of previous batch, blocks of next batch are verified to be linked with it
+	var previousBlock *indexer.BlockIndex
+	var linkageFailures int
+
 	var err error
 	var isEnd bool
+
+	// Latest block number was set on start, block with safe or finalized tag is behind it
+	if c.blockTag != BlockTagLatest {
+		latestBlockNumber, err = c.headBlockNumber(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get %s block number: %w", c.blockTag, err)
+		}
+	}
+
 	for {
+		// Batches are not fetched while store stage could not write them
+		c.waitBackpressure(ctx)
+
+		if ctx.Err() != nil {
+			log.Printf("Crawling of %s is stopped at block %d", c.blockchain, c.startBlock-1)
+			break
+		}
+
 		// Using CurrentBlockchainState (in future via mutex for async) to not fetch too often if there is a big difference
 		if tempEndBlock+c.confirmations >= latestBlockNumber.Int64() {
-			latestBlockNumber, err = c.Client.GetLatestBlockNumber()
+			latestBlockNumber, err = c.headBlockNumber(ctx)
 			if err != nil {
-				log.Fatalf("Failed to get latest block number: %v", err)
-				// Retry the operation
-				time.Sleep(retryWaitTime)
-				retryAttempts--
-				if retryAttempts == 0 {
-					log.Fatalf("Failed to get latest block number after %d attempts", retryAttempts)
+				if ctx.Err() != nil {
+					continue
 				}
-				continue
+				return fmt.Errorf("failed to get latest block number: %w", err)
 			}
 		}
 
 		safeBlock = latestBlockNumber.Int64() - c.confirmations
 
+		batchSize = c.batchSizeAt(safeBlock-c.startBlock, defaultBatchSize)
 		tempEndBlock = c.startBlock + batchSize
+		isEnd = false
 		if c.endBlock != 0 {
 			if c.endBlock <= tempEndBlock {
 				tempEndBlock = c.endBlock
@@ -232,36 +685,26 @@ func (c *Crawler) Start(threads int) {
 			}
 		}
 
-		if tempEndBlock > safeBlock {
-			// Before wait check if there is something to push
-			if packCrawlStartTs.Add(protoDurationTimeLimit).Before(time.Now()) && len(blocksPack) > 0 {
-				blocksBatch, batchErr := c.Client.ProcessBlocksToBatch(blocksPack)
-				if batchErr != nil {
-					log.Printf("Unable to process blocks to batch, err: %v", batchErr)
-				}
-
-				dataBytes, err := proto.Marshal(blocksBatch)
-				if err != nil {
-					log.Fatalf("Failed to marshal blocks: %v", err)
-				}
+		// Without confirmations crawler keeps up with head of chain, so batch is cut at latest
+		// block instead of waiting until it is filled, reorganizations are handled on next batch
+		if c.confirmations == 0 && c.blockTag == BlockTagLatest && tempEndBlock > safeBlock && c.startBlock <= safeBlock {
+			tempEndBlock = safeBlock
+			isEnd = c.endBlock != 0 && tempEndBlock >= c.endBlock
+		}
 
-				if pushEr := c.PushPackOfData(bytes.NewBuffer(dataBytes), blocksIndexPack, txsIndexPack, eventsIndexPack, packStartBlock, tempEndBlock); err != nil {
-					log.Printf("Unable to push data correctly, err: %v", pushEr)
+		if tempEndBlock > safeBlock {
+			// Wait is time missing blocks are expected to be mined in, until block time of chain
+			// is observed it is doubled on every wait
+			pollInterval := c.pollInterval(tempEndBlock-safeBlock, waitForBlocksTime)
+			log.Printf("Waiting %s for new blocks to be mined. Current latestBlockNumber: %d, safeBlock: %d", pollInterval, latestBlockNumber, safeBlock)
+			if c.headTracker != nil {
+				c.headTracker.WaitForNewHead(ctx, pollInterval)
+			} else {
+				select {
+				case <-ctx.Done():
+				case <-time.After(pollInterval):
 				}
-
-				blocksPackSize = 0
-				blocksPack = []proto.Message{}
-				blocksIndexPack = []indexer.BlockIndex{}
-				txsIndexPack = []indexer.TransactionIndex{}
-				eventsIndexPack = []indexer.LogIndex{}
-
-				packStartBlock = tempEndBlock + 1
-				packCrawlStartTs = time.Now()
 			}
-
-			// Auto adjust time
-			log.Printf("Waiting for new blocks to be mined. Current latestBlockNumber: %d, safeBlock: %d", latestBlockNumber, safeBlock)
-			time.Sleep(waitForBlocksTime)
 			if waitForBlocksTime < maxWaitForBlocksTime {
 				waitForBlocksTime = waitForBlocksTime * 2
 			}
@@ -269,85 +712,116 @@ func (c *Crawler) Start(threads int) {
 		}
 		waitForBlocksTime = retryWaitTime
 
-		// Retry the operation in case of failure with cumulative attempts
-		err = retryOperation(retryAttempts, retryWaitTime, func() error {
-			log.Printf("Operates with batch of blocks: %d-%d", c.startBlock, tempEndBlock)
-
-			// Fetch blocks with transactions
-			blocks, blocksIndex, txsIndex, eventsIndex, blocksSize, crawlErr := seer_blockchain.CrawlEntireBlocks(c.Client, big.NewInt(c.startBlock), big.NewInt(tempEndBlock), SEER_CRAWLER_DEBUG, threads)
-			if crawlErr != nil {
-				return fmt.Errorf("failed to crawl blocks, txs and events: %w", err)
+		// Next batches below safe block are crawled by workers concurrently, results are sent
+		// to store stage in block order
+		ranges := []blocksRange{{start: c.startBlock, end: tempEndBlock}}
+		waveEnd := isEnd
+		for len(ranges) < c.fetchWorkers && !waveEnd {
+			nextRange := blocksRange{start: ranges[len(ranges)-1].end + 1}
+			nextRange.end = nextRange.start + batchSize
+			nextIsEnd := false
+			if c.endBlock != 0 && c.endBlock <= nextRange.end {
+				nextRange.end = c.endBlock
+				nextIsEnd = true
 			}
+			if nextRange.start > nextRange.end || nextRange.end > safeBlock {
+				break
+			}
+			ranges = append(ranges, nextRange)
+			waveEnd = nextIsEnd
+		}
 
-			blocksPackSize += blocksSize
-			blocksPack = append(blocksPack, blocks...)
-
-			blocksIndexPack = append(blocksIndexPack, blocksIndex...)
-			txsIndexPack = append(txsIndexPack, txsIndex...)
-			eventsIndexPack = append(eventsIndexPack, eventsIndex...)
+		results := c.crawlBatches(ctx, ranges, threads, retryAttempts, retryWaitTime)
 
-			if packCrawlStartTs.Add(protoDurationTimeLimit).Before(time.Now()) || blocksPackSize >= protoBufferSizeLimit {
-				blocksBatch, batchErr := c.Client.ProcessBlocksToBatch(blocksPack)
-				if batchErr != nil {
-					return fmt.Errorf("unable to process blocks to batch: %w", batchErr)
+		var isFinished bool
+		for i, result := range results {
+			if result.err != nil {
+				if ctx.Err() != nil {
+					// Batch is not crawled, crawling stops at previous batch
+					break
 				}
-
-				dataBytes, err := proto.Marshal(blocksBatch)
-				if err != nil {
-					log.Fatalf("Failed to marshal blocks: %v", err)
+				if !c.deadLetter {
+					return fmt.Errorf("operation failed: %w", result.err)
 				}
 
-				if pushEr := c.PushPackOfData(bytes.NewBuffer(dataBytes), blocksIndexPack, txsIndexPack, eventsIndexPack, packStartBlock, tempEndBlock); err != nil {
-					return fmt.Errorf("unable to push data correctly: %w", pushEr)
+				// Failed range is skipped, so blocks after it are not linked with previous batch
+				if recordErr := c.recordFailedRange(ranges[i], result.err); recordErr != nil {
+					return recordErr
 				}
+				previousBlock = nil
+				c.startBlock = ranges[i].end + 1
+				if waveEnd && i == len(results)-1 {
+					isFinished = true
+				}
+				continue
+			}
+			batch := result.batch
+
+			if c.verifyBlocks {
+				if verifyErr := VerifyBlocksLinkage(batch.blocksIndex, previousBlock); verifyErr != nil {
+					linkageFailures++
+					if linkageFailures >= retryAttempts && c.deadLetter {
+						linkageFailures = 0
+						if recordErr := c.recordFailedRange(ranges[i], fmt.Errorf("fetched blocks are not linked: %w", verifyErr)); recordErr != nil {
+							return recordErr
+						}
+						previousBlock = nil
+						c.startBlock = ranges[i].end + 1
+						if waveEnd && i == len(results)-1 {
+							isFinished = true
+						}
+						continue
+					}
+					if linkageFailures >= retryAttempts {
+						return fmt.Errorf("operation failed: fetched blocks are not linked: %w", verifyErr)
+					}
+					// Rest of wave is discarded, batch is crawled again on next iteration
+					log.Printf("Attempt %d/%d failed: fetched blocks are not linked: %v. Retrying in %s...", linkageFailures, retryAttempts, verifyErr, retryWaitTime)
+					select {
+					case <-ctx.Done():
+					case <-time.After(retryWaitTime):
+					}
+					break
+				}
+				linkageFailures = 0
+			}
 
-				blocksPackSize = 0
-				blocksPack = []proto.Message{}
-				blocksIndexPack = []indexer.BlockIndex{}
-				txsIndexPack = []indexer.TransactionIndex{}
-				eventsIndexPack = []indexer.LogIndex{}
+			ancestor, orphaned, reorgErr := c.detectReorg(ctx, batch.blocksIndex)
+			if reorgErr != nil {
+				if ctx.Err() != nil {
+					break
+				}
+				return fmt.Errorf("failed to handle reorganization of chain: %w", reorgErr)
+			}
+			if ancestor != nil {
+				// Orphaned rows are removed by store stage after batches crawled before are written,
+				// then canonical chain is crawled again from common ancestor
+				batches <- &crawledBatch{orphanedBlocks: orphaned}
+				c.startBlock = int64(*ancestor) + 1
+				previousBlock = nil
+				break
+			}
 
-				packStartBlock = tempEndBlock + 1
-				packCrawlStartTs = time.Now()
+			if c.verifyBlocks {
+				previousBlock = lastBlock(batch.blocksIndex)
 			}
 
-			return nil
-		})
-		if err != nil {
-			log.Fatalf("Operation failed: %v", err)
+			// Store stage reads batches until channel is closed, so crawled batch is never dropped
+			batches <- batch
+			c.observeBlockTime(batch.blocksIndex)
+
+			c.startBlock = batch.endBlock + 1
+			if waveEnd && i == len(results)-1 {
+				isFinished = true
+			}
 		}
 
-		if isEnd {
+		if isFinished {
 			break
 		}
-
-		c.startBlock = tempEndBlock + 1
 	}
 
-	if len(blocksPack) > 0 {
-		blocksBatch, batchErr := c.Client.ProcessBlocksToBatch(blocksPack)
-		if batchErr != nil {
-			log.Printf("Unable to process blocks to batch, err: %v", batchErr)
-		}
-
-		dataBytes, err := proto.Marshal(blocksBatch)
-		if err != nil {
-			log.Fatalf("Failed to marshal blocks: %v", err)
-		}
-
-		if pushEr := c.PushPackOfData(bytes.NewBuffer(dataBytes), blocksIndexPack, txsIndexPack, eventsIndexPack, packStartBlock, tempEndBlock); err != nil {
-			log.Printf("Unable to push last pack of data correctly, err: %v", pushEr)
-		}
-
-		blocksPackSize = 0
-		blocksPack = []proto.Message{}
-		blocksIndexPack = []indexer.BlockIndex{}
-		txsIndexPack = []indexer.TransactionIndex{}
-		eventsIndexPack = []indexer.LogIndex{}
-
-		packStartBlock = tempEndBlock + 1
-		packCrawlStartTs = time.Now()
-	}
+	return nil
 }
 
 // TODO: methods here for additional functionalities
@@ -0,0 +1,91 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+var failedRangesTotal = metrics.NewCounterVec("seer_crawler_failed_ranges_total",
+	"Ranges of blocks recorded to dead-letter table after all attempts to crawl them failed", "chain")
+
+// SetDeadLetter makes crawler record ranges which failed all attempts to dead-letter table and
+// continue with next range instead of exiting.
+func (c *Crawler) SetDeadLetter(ctx context.Context) error {
+	if err := indexer.DBConnection.EnsureFailedRangesTable(ctx); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", indexer.FailedRangesTableName, err)
+	}
+	c.deadLetter = true
+	return nil
+}
+
+// recordFailedRange stores range in dead-letter table, error is returned if it could not be
+// stored, so range is never skipped silently.
+func (c *Crawler) recordFailedRange(blocks blocksRange, cause error) error {
+	err := retryOperation(context.Background(), 3, 10*time.Second, func() error {
+		return indexer.DBConnection.RecordFailedRange(context.Background(), c.blockchain, uint64(blocks.start), uint64(blocks.end), cause.Error())
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record blocks %d-%d to dead-letter table: %w, crawling failed with: %v", blocks.start, blocks.end, err, cause)
+	}
+
+	failedRangesTotal.Inc(c.blockchain)
+	log.Printf("Blocks %d-%d of %s are recorded to dead-letter table and skipped: %v", blocks.start, blocks.end, c.blockchain, cause)
+	return nil
+}
+
+// RetryFailedRanges crawls again ranges of dead-letter table. Crawled ranges are written and
+// removed from table, attempts of ranges which failed again are increased. It returns number of
+// crawled ranges and ranges which are left.
+func (c *Crawler) RetryFailedRanges(ctx context.Context, threads int) (int, int, error) {
+	if err := indexer.DBConnection.EnsureFailedRangesTable(ctx); err != nil {
+		return 0, 0, fmt.Errorf("failed to create %s table: %w", indexer.FailedRangesTableName, err)
+	}
+
+	failedRanges, err := indexer.DBConnection.ReadFailedRanges(ctx, c.blockchain)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to read dead-letter table: %w", err)
+	}
+
+	var crawled, left int
+	for _, failedRange := range failedRanges {
+		if ctx.Err() != nil {
+			left += len(failedRanges) - crawled - left
+			break
+		}
+
+		log.Printf("Retrying blocks %d-%d of %s failed %d times: %s", failedRange.StartBlock, failedRange.EndBlock, c.blockchain, failedRange.Attempts, failedRange.Error)
+
+		var batch *crawledBatch
+		crawlErr := retryOperation(ctx, 3, 10*time.Second, func() error {
+			var batchErr error
+			batch, batchErr = c.crawlBatch(ctx, int64(failedRange.StartBlock), int64(failedRange.EndBlock), threads)
+			return batchErr
+		})
+		if crawlErr == nil {
+			pack := newDataPack()
+			pack.add(batch)
+			crawlErr = c.pushPack(pack)
+		}
+		if crawlErr != nil {
+			left++
+			log.Printf("Blocks %d-%d of %s failed again: %v", failedRange.StartBlock, failedRange.EndBlock, c.blockchain, crawlErr)
+			if recordErr := indexer.DBConnection.RecordFailedRange(context.Background(), c.blockchain, failedRange.StartBlock, failedRange.EndBlock, crawlErr.Error()); recordErr != nil {
+				return crawled, left, fmt.Errorf("failed to update dead-letter table: %w", recordErr)
+			}
+			continue
+		}
+
+		if deleteErr := indexer.DBConnection.DeleteFailedRange(context.Background(), c.blockchain, failedRange.StartBlock, failedRange.EndBlock); deleteErr != nil {
+			return crawled, left, fmt.Errorf("failed to remove blocks %d-%d from dead-letter table: %w", failedRange.StartBlock, failedRange.EndBlock, deleteErr)
+		}
+		crawled++
+		log.Printf("Blocks %d-%d of %s are crawled and removed from dead-letter table", failedRange.StartBlock, failedRange.EndBlock, c.blockchain)
+	}
+
+	return crawled, left, nil
+}
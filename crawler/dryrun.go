@@ -0,0 +1,77 @@
+package crawler
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// dryRunSummary counts data crawler would write in dry-run mode.
+type dryRunSummary struct {
+	startedAt  time.Time
+	startBlock int64
+	endBlock   int64
+
+	packs        int
+	blocks       int
+	transactions int
+	events       int
+	customIndex  int
+	blobSidecars int
+	callTraces   int
+	flatTraces   int
+	protoBytes   int
+
+	mux sync.Mutex
+}
+
+// SetDryRun makes crawler fetch, convert and validate blocks without writing them to storage and
+// indexes database. Crawled data is summarized with DryRunSummary.
+func (c *Crawler) SetDryRun() {
+	c.dryRun = &dryRunSummary{startedAt: time.Now(), startBlock: -1}
+}
+
+// addDryRunPack counts pack instead of writing it.
+func (c *Crawler) addDryRunPack(pack *dataPack, protoBytes int) {
+	summary := c.dryRun
+	summary.mux.Lock()
+	if summary.startBlock < 0 {
+		summary.startBlock = pack.startBlock
+	}
+	summary.endBlock = pack.endBlock
+	summary.packs++
+	summary.blocks += len(pack.blocksIndex)
+	summary.transactions += len(pack.txsIndex)
+	summary.events += len(pack.eventsIndex)
+	summary.customIndex += len(pack.customIndex)
+	summary.blobSidecars += len(pack.blobSidecars)
+	summary.callTraces += len(pack.callTraces)
+	summary.flatTraces += len(pack.flatTraces)
+	summary.protoBytes += protoBytes
+	summary.mux.Unlock()
+
+	c.committedBlock.Store(pack.endBlock)
+	log.Printf("Dry run, skipped writing of blocks %d-%d: %d blocks, %d transactions, %d events, %d bytes of proto", pack.startBlock, pack.endBlock, len(pack.blocksIndex), len(pack.txsIndex), len(pack.eventsIndex), protoBytes)
+}
+
+// DryRunSummary returns summary of data crawled in dry-run mode, empty string if crawler is not
+// in dry-run mode.
+func (c *Crawler) DryRunSummary() string {
+	if c.dryRun == nil {
+		return ""
+	}
+
+	summary := c.dryRun
+	summary.mux.Lock()
+	defer summary.mux.Unlock()
+
+	if summary.packs == 0 {
+		return fmt.Sprintf("Dry run of %s crawled no blocks in %s", c.blockchain, time.Since(summary.startedAt).Round(time.Second))
+	}
+
+	return fmt.Sprintf("Dry run of %s crawled blocks %d-%d in %s: %d packs, %d blocks, %d transactions, %d events, %d custom index rows, %d blob sidecars, %d call traces, %d flat traces, %d bytes of proto, nothing is written",
+		c.blockchain, summary.startBlock, summary.endBlock, time.Since(summary.startedAt).Round(time.Second),
+		summary.packs, summary.blocks, summary.transactions, summary.events, summary.customIndex,
+		summary.blobSidecars, summary.callTraces, summary.flatTraces, summary.protoBytes)
+}
@@ -0,0 +1,143 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+)
+
+const (
+	headTrackerMinReconnectWait = time.Second
+	headTrackerMaxReconnectWait = time.Minute
+)
+
+// HeadTracker follows new blocks with eth_subscribe newHeads over WebSocket, it reconnects
+// automatically and crawler falls back to polling eth_blockNumber while it is disconnected.
+type HeadTracker struct {
+	url        string
+	blockchain string
+
+	mux               sync.RWMutex
+	connected         bool
+	latestBlockNumber *big.Int
+
+	newHeads chan struct{}
+}
+
+func NewHeadTracker(blockchain, url string) *HeadTracker {
+	return &HeadTracker{
+		url:        url,
+		blockchain: blockchain,
+		newHeads:   make(chan struct{}, 1),
+	}
+}
+
+// Run keeps subscription to new heads until context is cancelled.
+func (h *HeadTracker) Run(ctx context.Context) {
+	reconnectWait := headTrackerMinReconnectWait
+	for {
+		startedAt := time.Now()
+		err := h.subscribe(ctx)
+		h.setConnected(false)
+
+		if ctx.Err() != nil {
+			return
+		}
+		if time.Since(startedAt) > headTrackerMaxReconnectWait {
+			reconnectWait = headTrackerMinReconnectWait
+		}
+
+		log.Printf("Subscription to new heads of %s failed, fallback to polling, reconnect in %s: %v", h.blockchain, reconnectWait, err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectWait):
+		}
+
+		if reconnectWait < headTrackerMaxReconnectWait {
+			reconnectWait *= 2
+		}
+	}
+}
+
+func (h *HeadTracker) subscribe(ctx context.Context) error {
+	subscriber, err := seer_common.NewSubscriber(ctx, h.url)
+	if err != nil {
+		return err
+	}
+	defer subscriber.Close()
+
+	heads := make(chan *seer_common.BlockJson)
+	subscription, err := subscriber.SubscribeNewHeads(ctx, heads)
+	if err != nil {
+		return err
+	}
+	defer subscription.Unsubscribe()
+
+	h.setConnected(true)
+	log.Printf("Subscribed to new heads of %s", h.blockchain)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-subscription.Err():
+			if err == nil {
+				return fmt.Errorf("subscription closed")
+			}
+			return err
+		case head := <-heads:
+			blockNumber, ok := new(big.Int).SetString(head.BlockNumber, 0)
+			if !ok {
+				log.Printf("Invalid block number %q in head of %s", head.BlockNumber, h.blockchain)
+				continue
+			}
+			h.setLatestBlockNumber(blockNumber)
+		}
+	}
+}
+
+func (h *HeadTracker) setConnected(connected bool) {
+	h.mux.Lock()
+	h.connected = connected
+	h.mux.Unlock()
+}
+
+func (h *HeadTracker) setLatestBlockNumber(blockNumber *big.Int) {
+	h.mux.Lock()
+	if h.latestBlockNumber == nil || blockNumber.Cmp(h.latestBlockNumber) > 0 {
+		h.latestBlockNumber = blockNumber
+	}
+	h.mux.Unlock()
+
+	select {
+	case h.newHeads <- struct{}{}:
+	default:
+	}
+}
+
+// LatestBlockNumber returns number of the latest head received by subscription, nil while
+// subscription is not connected or no heads were received.
+func (h *HeadTracker) LatestBlockNumber() *big.Int {
+	h.mux.RLock()
+	defer h.mux.RUnlock()
+
+	if !h.connected || h.latestBlockNumber == nil {
+		return nil
+	}
+	return new(big.Int).Set(h.latestBlockNumber)
+}
+
+// WaitForNewHead blocks until new head is received, timeout expires or context is cancelled.
+func (h *HeadTracker) WaitForNewHead(ctx context.Context, timeout time.Duration) {
+	select {
+	case <-ctx.Done():
+	case <-h.newHeads:
+	case <-time.After(timeout):
+	}
+}
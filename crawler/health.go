@@ -0,0 +1,62 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// healthCheckName returns name of check, checks of isolated crawler are suffixed with chain.
+func (c *Crawler) healthCheckName(name string) string {
+	if c.isolated {
+		return fmt.Sprintf("%s_%s", name, c.blockchain)
+	}
+	return name
+}
+
+// RegisterHealthChecks adds readiness checks of node, indexes database and crawl lag of crawler.
+// Crawl lag is number of blocks between latest block of node and the last committed block, with
+// positive maxLag crawler is not ready when lag exceeds it. Lag of standby replica is not checked.
+func (c *Crawler) RegisterHealthChecks(maxLag int64) {
+	metrics.RegisterHealthCheck(c.healthCheckName("rpc"), func(ctx context.Context) (string, error) {
+		latestBlockNumber, err := c.Client.GetLatestBlockNumber(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("latest block %d", latestBlockNumber), nil
+	})
+
+	metrics.RegisterHealthCheck(c.healthCheckName("database"), func(ctx context.Context) (string, error) {
+		if c.dryRun != nil {
+			return "not used in dry run", nil
+		}
+		if indexer.IndexesDB == nil {
+			return "", fmt.Errorf("indexes database is not connected")
+		}
+		return "", indexer.IndexesDB.Ping(ctx)
+	})
+
+	metrics.RegisterHealthCheck(c.healthCheckName("lag"), func(ctx context.Context) (string, error) {
+		if c.replica != "" && !c.leading.Load() {
+			return "standby replica", nil
+		}
+
+		committedBlock := c.committedBlock.Load()
+		if committedBlock == 0 {
+			return "crawling has not started", nil
+		}
+
+		latestBlockNumber, err := c.Client.GetLatestBlockNumber(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to get latest block number: %w", err)
+		}
+
+		lag := latestBlockNumber.Int64() - committedBlock
+		if maxLag > 0 && lag > maxLag {
+			return fmt.Sprintf("%d blocks", lag), fmt.Errorf("crawler is %d blocks behind, allowed lag is %d blocks", lag, maxLag)
+		}
+		return fmt.Sprintf("%d blocks", lag), nil
+	})
+}
@@ -0,0 +1,151 @@
+package crawler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// DefaultLeaderLeaseDuration is time replica stays leader of chain without heartbeat, after it
+// standby replica takes over.
+const DefaultLeaderLeaseDuration = 30 * time.Second
+
+var crawlerLeader = metrics.NewGaugeVec("seer_crawler_leader",
+	"1 when replica of crawler is leader of chain and writes blocks, 0 when it is standby", "chain")
+
+// RunReplica runs crawler as one of replicas of chain, only replica which holds leadership crawls
+// and writes blocks. Standby replicas try to acquire leadership every third of lease duration and
+// the one which gets it continues from checkpoint. It returns when end block is reached or ctx is
+// cancelled.
+func (c *Crawler) RunReplica(ctx context.Context, threads int, replica string, leaseDuration time.Duration) error {
+	if err := indexer.DBConnection.EnsureCrawlerLeadersTable(ctx); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", indexer.CrawlerLeadersTableName, err)
+	}
+
+	c.replica = replica
+	crawlerLeader.Set(0, c.blockchain)
+
+	var standbyLogged bool
+	for ctx.Err() == nil {
+		acquired, err := indexer.DBConnection.AcquireLeadership(ctx, c.blockchain, replica, leaseDuration)
+		if err != nil {
+			if ctx.Err() == nil {
+				log.Printf("Failed to acquire leadership of %s: %v", c.blockchain, err)
+			}
+		} else if acquired {
+			if c.lead(ctx, threads, replica, leaseDuration) {
+				return nil
+			}
+			standbyLogged = false
+		} else if !standbyLogged {
+			leader, readErr := indexer.DBConnection.ReadCrawlerLeader(ctx, c.blockchain)
+			if readErr == nil && leader != nil {
+				log.Printf("Replica %s is standby, %s is leader of %s until %s", replica, leader.Leader, c.blockchain, leader.LeasedUntil.Format(time.RFC3339))
+				standbyLogged = true
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(leaseDuration / 3):
+		}
+	}
+
+	return nil
+}
+
+// lead crawls chain while replica holds leadership, lease is extended every third of lease
+// duration. Replica steps down when leadership is taken by other replica or lease could not be
+// extended before it expires. It returns true when crawler is finished and leadership is released.
+func (c *Crawler) lead(ctx context.Context, threads int, replica string, leaseDuration time.Duration) bool {
+	termCtx, cancelTerm := context.WithCancel(ctx)
+	defer cancelTerm()
+
+	c.leading.Store(true)
+	crawlerLeader.Set(1, c.blockchain)
+	defer func() {
+		c.leading.Store(false)
+		crawlerLeader.Set(0, c.blockchain)
+	}()
+
+	log.Printf("Replica %s is leader of %s", replica, c.blockchain)
+
+	var lost atomic.Bool
+	heartbeatDone := make(chan struct{})
+	go func() {
+		defer close(heartbeatDone)
+
+		ticker := time.NewTicker(leaseDuration / 3)
+		defer ticker.Stop()
+
+		renewedAt := time.Now()
+		for {
+			select {
+			case <-termCtx.Done():
+				return
+			case <-ticker.C:
+				err := indexer.DBConnection.HeartbeatLeadership(termCtx, c.blockchain, replica, leaseDuration)
+				if err == nil {
+					renewedAt = time.Now()
+					continue
+				}
+				if termCtx.Err() != nil {
+					return
+				}
+				// Standby could take over after lease expires, so leader stops before it
+				if errors.Is(err, indexer.ErrLeadershipLost) || time.Since(renewedAt) >= leaseDuration*2/3 {
+					log.Printf("Replica %s steps down as leader of %s: %v", replica, c.blockchain, err)
+					lost.Store(true)
+					// Store stage drops crawled packs from now on
+					c.leading.Store(false)
+					cancelTerm()
+					return
+				}
+				log.Printf("Failed to extend leadership of %s: %v", c.blockchain, err)
+			}
+		}
+	}()
+
+	latestBlockNumber, err := c.Client.GetLatestBlockNumber(termCtx)
+	if err != nil {
+		log.Printf("Failed to get latest block number: %v", err)
+	} else {
+		c.state.SetLatestBlockNumber(latestBlockNumber)
+		err = c.Start(termCtx, threads)
+		if errors.Is(err, indexer.ErrLeadershipLost) {
+			log.Printf("Replica %s is not leader of %s anymore: %v", replica, c.blockchain, err)
+			lost.Store(true)
+		} else if err != nil {
+			log.Printf("Crawler of %s failed: %v", c.blockchain, err)
+		}
+	}
+
+	cancelTerm()
+	<-heartbeatDone
+
+	if lost.Load() {
+		return false
+	}
+
+	// Crawled batches are written, standby takes over without waiting for lease to expire
+	if releaseErr := indexer.DBConnection.ReleaseLeadership(context.Background(), c.blockchain, replica); releaseErr != nil {
+		log.Printf("Failed to release leadership of %s: %v", c.blockchain, releaseErr)
+	}
+
+	return err == nil
+}
+
+// checkLeading returns ErrLeadershipLost when crawler is replica which is not leader anymore, its
+// crawled blocks are dropped and crawled again by new leader from checkpoint.
+func (c *Crawler) checkLeading() error {
+	if c.replica != "" && !c.leading.Load() {
+		return indexer.ErrLeadershipLost
+	}
+	return nil
+}
@@ -0,0 +1,139 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/moonstream-to/seer/metrics"
+	"gopkg.in/yaml.v3"
+)
+
+// Delays before crawler of chain which failed in multi-chain process is started again, delay is
+// doubled after every failure which follows shortly after previous one.
+var (
+	DefaultRestartDelay = 30 * time.Second
+	MaxRestartDelay     = 10 * time.Minute
+)
+
+var chainFailuresTotal = metrics.NewCounterVec("seer_crawler_chain_failures_total",
+	"Failures of crawler of chain in multi-chain process, crawler is restarted after each of them", "chain")
+
+// ChainConfig is crawler settings of single chain in multi-chain config file, unset fields are
+// taken from crawler flags.
+type ChainConfig struct {
+	Chain string `yaml:"chain"`
+	// RPC overrides MOONSTREAM_NODE_<CHAIN>_A_EXTERNAL_URI
+	RPC string `yaml:"rpc"`
+	// BatchSize is number of blocks requested in single JSON-RPC batch call
+	BatchSize     int    `yaml:"batch_size"`
+	Confirmations *int64 `yaml:"confirmations"`
+	BlockTag      string `yaml:"block_tag"`
+	Threads       int    `yaml:"threads"`
+	FetchWorkers  int    `yaml:"fetch_workers"`
+	StartBlock    int64  `yaml:"start_block"`
+	EndBlock      int64  `yaml:"end_block"`
+	// Validators are indexes or public keys of validators which balances are crawled at every
+	// epoch, only for beacon chain
+	Validators []string `yaml:"validators"`
+}
+
+// MultiChainConfig is config file of crawler which runs several chains in one process.
+type MultiChainConfig struct {
+	Chains []ChainConfig `yaml:"chains"`
+}
+
+// LoadMultiChainConfig reads and validates multi-chain config from YAML file.
+func LoadMultiChainConfig(path string) (*MultiChainConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var config MultiChainConfig
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	if len(config.Chains) == 0 {
+		return nil, fmt.Errorf("no chains are set in %s", path)
+	}
+
+	chains := make(map[string]bool)
+	for i, chainConfig := range config.Chains {
+		if chainConfig.Chain == "" {
+			return nil, fmt.Errorf("chain is not set for entry %d of %s", i, path)
+		}
+		if chains[chainConfig.Chain] {
+			return nil, fmt.Errorf("chain %s is set more than once in %s", chainConfig.Chain, path)
+		}
+		chains[chainConfig.Chain] = true
+
+		if chainConfig.Confirmations != nil && *chainConfig.Confirmations < 0 {
+			return nil, fmt.Errorf("confirmations of chain %s should not be negative", chainConfig.Chain)
+		}
+		if chainConfig.BatchSize < 0 || chainConfig.Threads < 0 || chainConfig.FetchWorkers < 0 {
+			return nil, fmt.Errorf("batch size, threads and fetch workers of chain %s should not be negative", chainConfig.Chain)
+		}
+		if chainConfig.EndBlock != 0 && chainConfig.EndBlock < chainConfig.StartBlock {
+			return nil, fmt.Errorf("end block of chain %s is less than start block", chainConfig.Chain)
+		}
+	}
+
+	return &config, nil
+}
+
+// SetIsolated prepares crawler to run next to crawlers of other chains in one process: it gets
+// own latest block state and its health checks are named after chain.
+func (c *Crawler) SetIsolated() {
+	c.isolated = true
+	c.state = &BlockchainState{}
+}
+
+// runIsolated crawls chain until end block is reached or ctx is cancelled, it returns failure of
+// crawler.
+func (c *Crawler) runIsolated(ctx context.Context, threads int) error {
+	latestBlockNumber, err := c.Client.GetLatestBlockNumber(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get latest block number: %w", err)
+	}
+	c.state.SetLatestBlockNumber(latestBlockNumber)
+
+	return c.Start(ctx, threads)
+}
+
+// RunIsolated runs isolated crawler and starts it again after failures, so failure of one chain
+// does not stop crawlers of other chains in the process. It returns when end block is reached or
+// ctx is cancelled.
+func (c *Crawler) RunIsolated(ctx context.Context, threads int) {
+	restartDelay := DefaultRestartDelay
+	for {
+		startedAt := time.Now()
+
+		err := c.runIsolated(ctx, threads)
+		if ctx.Err() != nil || err == nil {
+			return
+		}
+
+		// Delay is reset for crawler which ran longer than the longest delay before failure
+		if time.Since(startedAt) > MaxRestartDelay {
+			restartDelay = DefaultRestartDelay
+		}
+
+		chainFailuresTotal.Inc(c.blockchain)
+		log.Printf("Crawler of %s failed: %v. Restarting in %s...", c.blockchain, err, restartDelay)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(restartDelay):
+		}
+
+		restartDelay *= 2
+		if restartDelay > MaxRestartDelay {
+			restartDelay = MaxRestartDelay
+		}
+	}
+}
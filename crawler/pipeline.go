@@ -0,0 +1,224 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/moonstream-to/seer/blockchain/beacon"
+	"github.com/moonstream-to/seer/blockchain/traces"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/progress"
+	"google.golang.org/protobuf/proto"
+)
+
+// DefaultPipelineDepth is number of crawled batches which wait for store stage before fetch stage
+// blocks, it bounds memory of crawler together with proto size limit of pack.
+const DefaultPipelineDepth = 4
+
+// crawledBatch is batch of blocks passed from fetch stage to store stage of crawler pipeline.
+type crawledBatch struct {
+	startBlock int64
+	endBlock   int64
+
+	blocks       []proto.Message
+	blocksSize   uint64
+	blocksIndex  []indexer.BlockIndex
+	txsIndex     []indexer.TransactionIndex
+	eventsIndex  []indexer.LogIndex
+	customIndex  []indexer.CustomIndex
+	blobSidecars []*beacon.BlobSidecar
+	callTraces   []*traces.TransactionCallTrace
+	flatTraces   []*traces.FlatTrace
+
+	// orphanedBlocks are set instead of blocks when chain is reorganized, their index rows are
+	// removed after previous batches are written
+	orphanedBlocks []indexer.BlockIndex
+}
+
+// dataPack accumulates batches which are written together as single data.proto.
+type dataPack struct {
+	crawledBatch
+	startedAt time.Time
+}
+
+func newDataPack() *dataPack {
+	return &dataPack{startedAt: time.Now()}
+}
+
+func (p *dataPack) add(batch *crawledBatch) {
+	if p.isEmpty() {
+		p.startBlock = batch.startBlock
+	}
+	p.endBlock = batch.endBlock
+
+	p.blocks = append(p.blocks, batch.blocks...)
+	p.blocksSize += batch.blocksSize
+	p.blocksIndex = append(p.blocksIndex, batch.blocksIndex...)
+	p.txsIndex = append(p.txsIndex, batch.txsIndex...)
+	p.eventsIndex = append(p.eventsIndex, batch.eventsIndex...)
+	p.customIndex = append(p.customIndex, batch.customIndex...)
+	p.blobSidecars = append(p.blobSidecars, batch.blobSidecars...)
+	p.callTraces = append(p.callTraces, batch.callTraces...)
+	p.flatTraces = append(p.flatTraces, batch.flatTraces...)
+}
+
+func (p *dataPack) isEmpty() bool {
+	return len(p.blocks) == 0
+}
+
+// SetPipelineDepth sets number of crawled batches buffered between fetch and store stages.
+func (c *Crawler) SetPipelineDepth(depth int) {
+	if depth < 1 {
+		depth = 1
+	}
+	c.pipelineDepth = depth
+}
+
+// pushPack converts blocks of pack to batch proto and writes it with indexes.
+func (c *Crawler) pushPack(pack *dataPack) error {
+	blocksBatch, batchErr := c.Client.ProcessBlocksToBatch(pack.blocks)
+	if batchErr != nil {
+		return fmt.Errorf("unable to process blocks to batch: %w", batchErr)
+	}
+
+	dataBytes, marshalErr := proto.Marshal(blocksBatch)
+	if marshalErr != nil {
+		return fmt.Errorf("failed to marshal blocks: %w", marshalErr)
+	}
+
+	if c.dryRun != nil {
+		protoBytes := len(dataBytes)
+		// Blob sidecars and traces are converted as well, so dry run fails on the same data
+		if len(pack.blobSidecars) > 0 {
+			sidecarsBytes, err := proto.Marshal(beacon.ProcessSidecarsToBatch(pack.blobSidecars))
+			if err != nil {
+				return fmt.Errorf("failed to marshal blob sidecars: %w", err)
+			}
+			protoBytes += len(sidecarsBytes)
+		}
+		if len(pack.callTraces) > 0 {
+			tracesBytes, err := proto.Marshal(traces.ProcessCallTracesToBatch(pack.callTraces))
+			if err != nil {
+				return fmt.Errorf("failed to marshal call traces: %w", err)
+			}
+			protoBytes += len(tracesBytes)
+		}
+		if len(pack.flatTraces) > 0 {
+			tracesBytes, err := proto.Marshal(traces.ProcessFlatTracesToBatch(pack.flatTraces))
+			if err != nil {
+				return fmt.Errorf("failed to marshal flat traces: %w", err)
+			}
+			protoBytes += len(tracesBytes)
+		}
+		c.addDryRunPack(pack, protoBytes)
+		return nil
+	}
+
+	return c.PushPackOfData(bytes.NewBuffer(dataBytes), pack.blocksIndex, pack.txsIndex, pack.eventsIndex, pack.customIndex, pack.blobSidecars, pack.callTraces, pack.flatTraces, pack.startBlock, pack.endBlock)
+}
+
+// runPipeline crawls blocks through fetch and store stages connected by bounded channel. When
+// store stage fails, fetch stage is stopped and batches it crawled meanwhile are dropped. It
+// returns error of the stage which failed first.
+func (c *Crawler) runPipeline(ctx context.Context, threads int, latestBlockNumber *big.Int, backfillProgress *progress.Tracker, backfillStartBlock int64) error {
+	fetchCtx, cancelFetch := context.WithCancel(ctx)
+	defer cancelFetch()
+
+	batches := make(chan *crawledBatch, c.pipelineDepth)
+	fetchErr := make(chan error, 1)
+	go func() {
+		fetchErr <- c.fetchBatches(fetchCtx, threads, latestBlockNumber, batches)
+	}()
+
+	storeErr := c.storeBatches(batches, backfillProgress, backfillStartBlock)
+	if storeErr != nil {
+		cancelFetch()
+		for range batches {
+		}
+		<-fetchErr
+		return storeErr
+	}
+
+	return <-fetchErr
+}
+
+// storeBatches is store stage of crawler pipeline, it accumulates batches received from fetch
+// stage into packs and writes pack when it reaches proto size or time limit. The last pack is
+// written when batches channel is closed. Fetching is paused while packs could not be written in
+// time, so crawled batches are neither buffered without limit nor dropped. It returns error of
+// pack which could not be written, batches left in channel are not read then.
+func (c *Crawler) storeBatches(batches <-chan *crawledBatch, backfillProgress *progress.Tracker, backfillStartBlock int64) error {
+	protoBufferSizeLimit := c.protoSizeLimit * 1024 * 1024 // In Mb
+	protoDurationTimeLimit := time.Duration(c.protoTimeLimit) * time.Second
+
+	pack := newDataPack()
+	flush := func() error {
+		if err := c.checkLeading(); err != nil {
+			return fmt.Errorf("pack of blocks %d-%d is dropped: %w", pack.startBlock, pack.endBlock, err)
+		}
+
+		// Pack is written also after crawler context is cancelled, failed write is retried with
+		// fetching paused
+		writeDuration, err := c.writePack(pack)
+		if err != nil {
+			return fmt.Errorf("unable to push pack of blocks %d-%d: %w", pack.startBlock, pack.endBlock, err)
+		}
+		c.updateBackpressure(writeDuration, len(batches))
+		pack = newDataPack()
+		return nil
+	}
+
+	for {
+		// Pack which is not filled in time limit is written while crawler waits for new blocks
+		var packTimeout <-chan time.Time
+		if !pack.isEmpty() {
+			packTimeout = time.After(time.Until(pack.startedAt.Add(protoDurationTimeLimit)))
+		}
+
+		select {
+		case batch, ok := <-batches:
+			if !ok {
+				if !pack.isEmpty() {
+					return flush()
+				}
+				return nil
+			}
+
+			if len(batch.orphanedBlocks) > 0 {
+				if !pack.isEmpty() {
+					if err := flush(); err != nil {
+						return err
+					}
+				}
+				if err := c.checkLeading(); err != nil {
+					return fmt.Errorf("removal of %d orphaned blocks is dropped: %w", len(batch.orphanedBlocks), err)
+				}
+				err := retryOperation(context.Background(), 3, 10*time.Second, func() error {
+					return c.removeOrphanedBlocks(context.Background(), batch.orphanedBlocks)
+				})
+				if err != nil {
+					return fmt.Errorf("unable to remove %d orphaned blocks: %w", len(batch.orphanedBlocks), err)
+				}
+				continue
+			}
+
+			pack.add(batch)
+			if pack.startedAt.Add(protoDurationTimeLimit).Before(time.Now()) || pack.blocksSize >= protoBufferSizeLimit {
+				if err := flush(); err != nil {
+					return err
+				}
+			}
+
+			if backfillProgress != nil {
+				backfillProgress.Set(uint64(batch.endBlock - backfillStartBlock + 1))
+			}
+		case <-packTimeout:
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package crawler
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// DefaultHeadBatchTime is time of blocks crawled in one batch while crawler follows head of chain.
+const DefaultHeadBatchTime = time.Minute
+
+// Bounds of wait for new blocks adjusted to block time of chain and of batch size at head of chain.
+const (
+	MinPollInterval  = 500 * time.Millisecond
+	MaxPollInterval  = 2 * time.Minute
+	MaxHeadBatchSize = 100
+)
+
+// blockTimeSmoothing is weight of the last batch in observed block time.
+const blockTimeSmoothing = 0.3
+
+var observedBlockTime = metrics.NewGaugeVec("seer_crawler_block_time_seconds",
+	"Block time of chain observed from timestamps of crawled blocks, it sets polling interval and batch size at head of chain", "chain")
+
+// blockTimeEstimator tracks average block time of chain from timestamps of crawled blocks.
+type blockTimeEstimator struct {
+	mux sync.Mutex
+
+	lastNumber    uint64
+	lastTimestamp uint64
+	seconds       float64
+}
+
+// observe updates block time with blocks of crawled batch, interval is measured from the last
+// block of previous batch, so batches of single block are measured too.
+func (e *blockTimeEstimator) observe(blocksIndex []indexer.BlockIndex) float64 {
+	if len(blocksIndex) == 0 {
+		return 0
+	}
+
+	blocks := append([]indexer.BlockIndex(nil), blocksIndex...)
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].BlockNumber < blocks[j].BlockNumber })
+	first, last := blocks[0], blocks[len(blocks)-1]
+
+	e.mux.Lock()
+	defer e.mux.Unlock()
+
+	// Batch which follows previous one is measured from its last block
+	if e.lastNumber != 0 && e.lastNumber < first.BlockNumber && e.lastTimestamp <= first.BlockTimestamp {
+		first = indexer.BlockIndex{BlockNumber: e.lastNumber, BlockTimestamp: e.lastTimestamp}
+	}
+	e.lastNumber, e.lastTimestamp = last.BlockNumber, last.BlockTimestamp
+
+	if last.BlockNumber <= first.BlockNumber || last.BlockTimestamp < first.BlockTimestamp {
+		return e.seconds
+	}
+
+	sample := float64(last.BlockTimestamp-first.BlockTimestamp) / float64(last.BlockNumber-first.BlockNumber)
+	if e.seconds == 0 {
+		e.seconds = sample
+	} else {
+		e.seconds = blockTimeSmoothing*sample + (1-blockTimeSmoothing)*e.seconds
+	}
+
+	return e.seconds
+}
+
+// blockTime returns observed block time, 0 until blocks are observed.
+func (e *blockTimeEstimator) blockTime() time.Duration {
+	e.mux.Lock()
+	defer e.mux.Unlock()
+	return time.Duration(e.seconds * float64(time.Second))
+}
+
+// SetHeadBatchTime sets time of blocks crawled in one batch at head of chain, 0 keeps default
+// batch size of 10 blocks.
+func (c *Crawler) SetHeadBatchTime(headBatchTime time.Duration) {
+	c.headBatchTime = headBatchTime
+}
+
+// observeBlockTime updates block time of chain with blocks of crawled batch.
+func (c *Crawler) observeBlockTime(blocksIndex []indexer.BlockIndex) {
+	if seconds := c.blockTime.observe(blocksIndex); seconds > 0 {
+		observedBlockTime.Set(seconds, c.blockchain)
+	}
+}
+
+// batchSizeAt returns batch size for crawler which is blocksBehind safe block. Crawler which
+// follows head of chain crawls batches of head batch time, so slow chains are crawled block by
+// block and fast chains in larger batches, crawler which catches up uses default batch size.
+func (c *Crawler) batchSizeAt(blocksBehind, defaultBatchSize int64) int64 {
+	blockTime := c.blockTime.blockTime()
+	if c.headBatchTime <= 0 || blockTime <= 0 {
+		return defaultBatchSize
+	}
+
+	// Range of batch includes both ends
+	headBatchSize := int64(c.headBatchTime/blockTime) - 1
+	if headBatchSize < 0 {
+		headBatchSize = 0
+	}
+	if headBatchSize > MaxHeadBatchSize {
+		headBatchSize = MaxHeadBatchSize
+	}
+
+	if blocksBehind > headBatchSize {
+		return defaultBatchSize
+	}
+	return headBatchSize
+}
+
+// pollInterval returns wait for missing blocks of next batch, it is time they are expected to be
+// mined in. Fallback is returned until block time is observed.
+func (c *Crawler) pollInterval(missingBlocks int64, fallback time.Duration) time.Duration {
+	blockTime := c.blockTime.blockTime()
+	if blockTime <= 0 {
+		return fallback
+	}
+
+	interval := time.Duration(missingBlocks) * blockTime
+	if interval < MinPollInterval {
+		interval = MinPollInterval
+	}
+	if interval > MaxPollInterval {
+		interval = MaxPollInterval
+	}
+	return interval
+}
@@ -0,0 +1,203 @@
+package crawler
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"google.golang.org/protobuf/proto"
+)
+
+// RecrawlBlocks fetches blocks again, overwrites their protos in storage and replaces their index
+// rows. Protos of indexed blocks are written back to packs they were crawled to, so every block
+// of such pack is fetched again. Other blocks are written together as new packs of consecutive
+// blocks up to proto size limit. Checkpoint of chain is not changed.
+func (c *Crawler) RecrawlBlocks(ctx context.Context, blockNumbers []int64, threads int) error {
+	numbers := append([]int64(nil), blockNumbers...)
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] < numbers[j] })
+
+	// Blocks set more than once are crawled once
+	uniqueNumbers := numbers[:0]
+	for i, blockNumber := range numbers {
+		if i == 0 || blockNumber != numbers[i-1] {
+			uniqueNumbers = append(uniqueNumbers, blockNumber)
+		}
+	}
+	numbers = uniqueNumbers
+
+	indexedNumbers := make([]uint64, len(numbers))
+	for i, blockNumber := range numbers {
+		indexedNumbers[i] = uint64(blockNumber)
+	}
+	packsPaths, readErr := indexer.DBConnection.ReadBlocksPacksPaths(ctx, c.blockchain, indexedNumbers)
+	if readErr != nil {
+		return readErr
+	}
+
+	var packsRanges []blocksRange
+	seenPacks := make(map[string]bool)
+	var unpackedNumbers []int64
+	for _, blockNumber := range numbers {
+		packPath, ok := packsPaths[uint64(blockNumber)]
+		if !ok {
+			unpackedNumbers = append(unpackedNumbers, blockNumber)
+			continue
+		}
+		if seenPacks[packPath] {
+			continue
+		}
+		seenPacks[packPath] = true
+
+		packRange, parseErr := c.parsePackPath(packPath)
+		if parseErr != nil {
+			return parseErr
+		}
+		packsRanges = append(packsRanges, packRange)
+	}
+
+	for _, packRange := range packsRanges {
+		if err := c.recrawlPack(ctx, packRange, threads); err != nil {
+			return err
+		}
+	}
+
+	return c.recrawlUnpackedBlocks(ctx, unpackedNumbers, threads)
+}
+
+// parsePackPath returns range of blocks of pack by path of its data.proto written by crawler.
+func (c *Crawler) parsePackPath(packPath string) (blocksRange, error) {
+	packDir := filepath.Dir(packPath)
+	if filepath.Dir(packDir) != c.basePath {
+		return blocksRange{}, fmt.Errorf("pack %s is not in base directory %s, use --base-dir of crawler", packPath, c.basePath)
+	}
+
+	var packRange blocksRange
+	if _, err := fmt.Sscanf(filepath.Base(packDir), "%d-%d", &packRange.start, &packRange.end); err != nil || packRange.end < packRange.start {
+		return blocksRange{}, fmt.Errorf("invalid range of blocks of pack %s", packPath)
+	}
+
+	return packRange, nil
+}
+
+// recrawlPack fetches all blocks of crawled pack again and overwrites the pack.
+func (c *Crawler) recrawlPack(ctx context.Context, packRange blocksRange, threads int) error {
+	batchSize := int64(10)
+
+	pack := newDataPack()
+	for startBlock := packRange.start; startBlock <= packRange.end; startBlock += batchSize {
+		endBlock := min(startBlock+batchSize-1, packRange.end)
+
+		var batch *crawledBatch
+		crawlErr := retryOperation(ctx, 3, 10*time.Second, func() error {
+			var err error
+			batch, err = c.crawlBatch(ctx, startBlock, endBlock, threads)
+			return err
+		})
+		if crawlErr != nil {
+			return fmt.Errorf("failed to crawl blocks %d-%d: %w", startBlock, endBlock, crawlErr)
+		}
+
+		pack.add(batch)
+	}
+
+	err := retryOperation(ctx, 3, 10*time.Second, func() error {
+		return c.rewritePack(ctx, pack)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to rewrite pack %d-%d: %w", packRange.start, packRange.end, err)
+	}
+
+	return nil
+}
+
+// recrawlUnpackedBlocks fetches blocks which are not indexed and writes consecutive blocks
+// together as packs up to proto size limit.
+func (c *Crawler) recrawlUnpackedBlocks(ctx context.Context, numbers []int64, threads int) error {
+	protoBufferSizeLimit := c.protoSizeLimit * 1024 * 1024 // In Mb
+	batchSize := int64(10)
+
+	pack := newDataPack()
+	flush := func() error {
+		if pack.isEmpty() {
+			return nil
+		}
+		err := retryOperation(ctx, 3, 10*time.Second, func() error {
+			return c.rewritePack(ctx, pack)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to rewrite blocks %d-%d: %w", pack.startBlock, pack.endBlock, err)
+		}
+		pack = newDataPack()
+		return nil
+	}
+
+	for i := 0; i < len(numbers); {
+		// Consecutive blocks are crawled in batches
+		startBlock := numbers[i]
+		endBlock := startBlock
+		for i < len(numbers) && numbers[i] <= endBlock+1 && numbers[i]-startBlock <= batchSize {
+			endBlock = numbers[i]
+			i++
+		}
+
+		if !pack.isEmpty() && startBlock != pack.endBlock+1 {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+
+		var batch *crawledBatch
+		crawlErr := retryOperation(ctx, 3, 10*time.Second, func() error {
+			var err error
+			batch, err = c.crawlBatch(ctx, startBlock, endBlock, threads)
+			return err
+		})
+		if crawlErr != nil {
+			return fmt.Errorf("failed to crawl blocks %d-%d: %w", startBlock, endBlock, crawlErr)
+		}
+
+		pack.add(batch)
+		if pack.blocksSize >= protoBufferSizeLimit {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flush()
+}
+
+// rewritePack overwrites protos of pack in storage and replaces index rows of its blocks.
+func (c *Crawler) rewritePack(ctx context.Context, pack *dataPack) error {
+	blocksBatch, batchErr := c.Client.ProcessBlocksToBatch(pack.blocks)
+	if batchErr != nil {
+		return fmt.Errorf("unable to process blocks to batch: %w", batchErr)
+	}
+
+	dataBytes, marshalErr := proto.Marshal(blocksBatch)
+	if marshalErr != nil {
+		return fmt.Errorf("failed to marshal blocks: %w", marshalErr)
+	}
+
+	blocksIndex, txsIndex, eventsIndex, customIndex, saveErr := c.savePackData(bytes.NewBuffer(dataBytes), pack.blocksIndex, pack.txsIndex, pack.eventsIndex, pack.customIndex, pack.blobSidecars, pack.callTraces, pack.flatTraces, pack.startBlock, pack.endBlock)
+	if saveErr != nil {
+		return saveErr
+	}
+
+	var blockNumbers []uint64
+	for blockNumber := pack.startBlock; blockNumber <= pack.endBlock; blockNumber++ {
+		blockNumbers = append(blockNumbers, uint64(blockNumber))
+	}
+
+	if err := indexer.DBConnection.RewriteBlocksIndexes(ctx, c.blockchain, blockNumbers, blocksIndex, txsIndex, eventsIndex, customIndex); err != nil {
+		return fmt.Errorf("failed to rewrite indexes: %w", err)
+	}
+
+	log.Printf("Recrawled blocks %d-%d of %s: %d blocks, %d transactions, %d events", pack.startBlock, pack.endBlock, c.blockchain, len(blocksIndex), len(txsIndex), len(eventsIndex))
+	return nil
+}
@@ -0,0 +1,198 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+
+	seer_blockchain "github.com/moonstream-to/seer/blockchain"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// DefaultMaxReorgDepth is number of recent blocks crawler remembers to find common ancestor when
+// chain is reorganized.
+const DefaultMaxReorgDepth = 64
+
+var (
+	reorgsTotal = metrics.NewCounterVec("seer_crawler_reorgs_total",
+		"Chain reorganizations detected by crawler", "chain")
+	orphanedBlocksTotal = metrics.NewCounterVec("seer_crawler_orphaned_blocks_total",
+		"Blocks removed from indexes because they were reorganized out of canonical chain", "chain")
+)
+
+// canonicalChain keeps hashes of recent blocks crawler considers canonical, blocks of new batch
+// are checked to continue it.
+type canonicalChain struct {
+	depth  uint64
+	hashes map[uint64]string
+	head   uint64
+}
+
+func newCanonicalChain(depth uint64) *canonicalChain {
+	return &canonicalChain{depth: depth, hashes: make(map[uint64]string)}
+}
+
+func (cc *canonicalChain) add(blockNumber uint64, blockHash string) {
+	cc.hashes[blockNumber] = blockHash
+	if blockNumber > cc.head {
+		cc.head = blockNumber
+	}
+	for number := range cc.hashes {
+		if number+cc.depth <= cc.head {
+			delete(cc.hashes, number)
+		}
+	}
+}
+
+func (cc *canonicalChain) hash(blockNumber uint64) (string, bool) {
+	blockHash, ok := cc.hashes[blockNumber]
+	return blockHash, ok
+}
+
+// truncate forgets blocks above ancestor, they are orphaned by reorganization.
+func (cc *canonicalChain) truncate(ancestor uint64) {
+	for number := range cc.hashes {
+		if number > ancestor {
+			delete(cc.hashes, number)
+		}
+	}
+	cc.head = ancestor
+}
+
+// SetMaxReorgDepth sets number of recent blocks checked for reorganization, 0 disables detection.
+func (c *Crawler) SetMaxReorgDepth(depth int64) {
+	if depth < 0 {
+		depth = 0
+	}
+	c.maxReorgDepth = depth
+}
+
+// loadCanonicalChain reads hashes of indexed blocks before start block, so the first crawled
+// batch is checked against blocks written by previous run.
+func (c *Crawler) loadCanonicalChain(ctx context.Context) {
+	if c.maxReorgDepth == 0 {
+		if c.confirmations == 0 && c.blockTag == BlockTagLatest {
+			log.Printf("Crawler follows latest block of %s without confirmations and reorganization detection, orphaned blocks will stay in indexes", c.blockchain)
+		}
+		return
+	}
+	c.canonical = newCanonicalChain(uint64(c.maxReorgDepth))
+
+	if c.startBlock <= 1 || indexer.IndexesDB == nil {
+		return
+	}
+
+	from := c.startBlock - c.maxReorgDepth
+	if from < 0 {
+		from = 0
+	}
+	hashes, err := indexer.IndexesDB.ReadBlocksHashes(ctx, c.blockchain, uint64(from), uint64(c.startBlock-1))
+	if err != nil {
+		log.Printf("Failed to read hashes of indexed blocks, reorganization of already indexed blocks will not be detected: %v", err)
+		return
+	}
+	for blockNumber, blockHash := range hashes {
+		c.canonical.add(blockNumber, blockHash)
+	}
+}
+
+// canonicalBlockHash returns hash of block with number in chain node currently follows.
+func (c *Crawler) canonicalBlockHash(ctx context.Context, blockNumber uint64) (string, error) {
+	_, blocksIndex, _, _, _, err := seer_blockchain.CrawlEntireBlocks(ctx, c.Client, new(big.Int).SetUint64(blockNumber), new(big.Int).SetUint64(blockNumber), SEER_CRAWLER_DEBUG, 1)
+	if err != nil {
+		return "", err
+	}
+	for _, block := range blocksIndex {
+		if block.BlockNumber == blockNumber {
+			return block.BlockHash, nil
+		}
+	}
+	return "", fmt.Errorf("block %d is not returned by node", blockNumber)
+}
+
+// detectReorg checks that the first block of batch is child of the last canonical block. If it
+// is not, crawler walks back to common ancestor of stored and node chains and returns it with
+// blocks orphaned by reorganization. Nil ancestor means batch continues canonical chain, blocks
+// of batch are then added to it.
+func (c *Crawler) detectReorg(ctx context.Context, blocksIndex []indexer.BlockIndex) (*uint64, []indexer.BlockIndex, error) {
+	if c.canonical == nil || len(blocksIndex) == 0 {
+		return nil, nil, nil
+	}
+
+	first := blocksIndex[0]
+	for _, block := range blocksIndex {
+		if block.BlockNumber < first.BlockNumber {
+			first = block
+		}
+	}
+
+	parentHash, ok := c.canonical.hash(first.BlockNumber - 1)
+	if first.ParentHash == "" || !ok || parentHash == first.ParentHash {
+		for _, block := range blocksIndex {
+			c.canonical.add(block.BlockNumber, block.BlockHash)
+		}
+		return nil, nil, nil
+	}
+
+	log.Printf("Parent hash %s of block %d does not match hash %s of crawled block, chain %s is reorganized", first.ParentHash, first.BlockNumber, parentHash, c.blockchain)
+
+	var orphaned []indexer.BlockIndex
+	for blockNumber := first.BlockNumber - 1; ; blockNumber-- {
+		storedHash, ok := c.canonical.hash(blockNumber)
+		if !ok {
+			return nil, nil, fmt.Errorf("common ancestor of reorganized chain is not found within %d blocks before block %d", c.maxReorgDepth, first.BlockNumber)
+		}
+
+		canonicalHash, err := c.canonicalBlockHash(ctx, blockNumber)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to fetch block %d while looking for common ancestor: %w", blockNumber, err)
+		}
+		if canonicalHash == storedHash {
+			c.canonical.truncate(blockNumber)
+
+			reorgsTotal.Inc(c.blockchain)
+			orphanedBlocksTotal.Add(float64(len(orphaned)), c.blockchain)
+			log.Printf("Common ancestor of reorganized chain %s is block %d, %d blocks are orphaned", c.blockchain, blockNumber, len(orphaned))
+
+			return &blockNumber, orphaned, nil
+		}
+
+		orphaned = append(orphaned, indexer.BlockIndex{BlockNumber: blockNumber, BlockHash: storedHash})
+		if blockNumber == 0 {
+			return nil, nil, fmt.Errorf("common ancestor of reorganized chain is not found")
+		}
+	}
+}
+
+// removeOrphanedBlocks deletes index rows of orphaned blocks. Store stage calls it after all
+// batches crawled before reorganization are written.
+func (c *Crawler) removeOrphanedBlocks(ctx context.Context, orphaned []indexer.BlockIndex) error {
+	if c.dryRun != nil {
+		log.Printf("Dry run, skipped removal of %d orphaned blocks", len(orphaned))
+		return nil
+	}
+
+	if err := indexer.IndexesDB.DeleteOrphanedBlocks(ctx, c.blockchain, orphaned); err != nil {
+		return err
+	}
+
+	if !c.checkpoints || len(orphaned) == 0 {
+		return nil
+	}
+
+	// Checkpoint should not point past common ancestor, otherwise restarted crawler skips blocks
+	// of new canonical chain
+	ancestor := orphaned[0].BlockNumber
+	for _, block := range orphaned {
+		if block.BlockNumber < ancestor {
+			ancestor = block.BlockNumber
+		}
+	}
+	if ancestor > 0 {
+		ancestor--
+	}
+
+	return indexer.IndexesDB.RewindCheckpoint(ctx, c.blockchain, ancestor, c.replica)
+}
@@ -1,9 +1,14 @@
 package crawler
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+
+	seer_blockchain "github.com/moonstream-to/seer/blockchain"
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
 )
 
 var (
@@ -11,9 +16,36 @@ var (
 
 	BlockchainURLs map[string]string
 
+	// WebsocketURLs are optional WebSocket node URLs, crawler tracks new heads with subscription for chains listed here
+	WebsocketURLs map[string]string
+
+	// ValidationURLs are optional second provider URLs, crawler cross-validates blocks with them
+	ValidationURLs map[string]string
+
+	// BeaconURLs are optional beacon node URLs, crawler fetches blob sidecars for chains listed here
+	BeaconURLs map[string]string
+
+	// BlockTags are block tags crawler follows per chain instead of latest block minus confirmations
+	BlockTags map[string]string
+
+	// Confirmations are numbers of blocks crawler stays behind latest block per chain
+	Confirmations map[string]int64
+
 	SEER_CRAWLER_DEBUG = false
 )
 
+// DefaultConfirmations is number of blocks crawler stays behind latest block when it is not set
+// for chain.
+const DefaultConfirmations = 10
+
+// ChainConfirmations returns number of blocks crawler stays behind latest block of chain.
+func ChainConfirmations(chain string) int64 {
+	if confirmations, ok := Confirmations[chain]; ok {
+		return confirmations
+	}
+	return DefaultConfirmations
+}
+
 func CheckVariablesForCrawler() error {
 	SeerCrawlerStoragePrefixEnvVar := os.Getenv("SEER_CRAWLER_STORAGE_PREFIX")
 	switch SeerCrawlerStoragePrefixEnvVar {
@@ -97,5 +129,120 @@ func CheckVariablesForCrawler() error {
 		"imx_zkevm_sepolia":            MOONSTREAM_NODE_IMX_ZKEVM_SEPOLIA_A_EXTERNAL_URI,
 	}
 
+	// Chains registered by plugins follow the same MOONSTREAM_NODE_<CHAIN>_A_EXTERNAL_URI convention
+	for _, chain := range seer_blockchain.RegisteredChains() {
+		if _, ok := BlockchainURLs[chain]; ok {
+			continue
+		}
+		chainURI := os.Getenv(fmt.Sprintf("MOONSTREAM_NODE_%s_A_EXTERNAL_URI", strings.ToUpper(chain)))
+		if chainURI != "" {
+			BlockchainURLs[chain] = chainURI
+		}
+	}
+
+	// Second providers follow MOONSTREAM_NODE_<CHAIN>_B_EXTERNAL_URI convention
+	ValidationURLs = make(map[string]string)
+	for _, chain := range seer_blockchain.RegisteredChains() {
+		validationURI := os.Getenv(fmt.Sprintf("MOONSTREAM_NODE_%s_B_EXTERNAL_URI", strings.ToUpper(chain)))
+		if validationURI != "" {
+			ValidationURLs[chain] = validationURI
+		}
+	}
+
+	// Node URLs of custom chains are hosts, RPC path of chain is appended to them
+	for _, urls := range []map[string]string{BlockchainURLs, ValidationURLs} {
+		for chain, url := range urls {
+			urls[chain] = seer_blockchain.CustomChainNodeURL(chain, url)
+		}
+	}
+
+	// WebSocket endpoints follow MOONSTREAM_WS_<CHAIN>_A_EXTERNAL_URI convention
+	WebsocketURLs = make(map[string]string)
+	for _, chain := range seer_blockchain.RegisteredChains() {
+		websocketURI := os.Getenv(fmt.Sprintf("MOONSTREAM_WS_%s_A_EXTERNAL_URI", strings.ToUpper(chain)))
+		if websocketURI != "" {
+			WebsocketURLs[chain] = websocketURI
+		}
+	}
+
+	// Credentials of RPC endpoints as JSON object with endpoint URLs as keys
+	SEER_RPC_AUTH := os.Getenv("SEER_RPC_AUTH")
+	if SEER_RPC_AUTH != "" {
+		var endpointsAuth map[string]seer_common.RPCEndpointAuth
+		if err := json.Unmarshal([]byte(SEER_RPC_AUTH), &endpointsAuth); err != nil {
+			return fmt.Errorf("invalid SEER_RPC_AUTH environment variable: %v", err)
+		}
+		for url, auth := range endpointsAuth {
+			seer_common.SetRPCEndpointAuth(url, auth)
+		}
+	}
+
+	// eth_getLogs limits of providers as JSON object with endpoint URLs as keys
+	SEER_RPC_LOGS_LIMITS := os.Getenv("SEER_RPC_LOGS_LIMITS")
+	if SEER_RPC_LOGS_LIMITS != "" {
+		var endpointsLogsLimits map[string]seer_common.LogsLimits
+		if err := json.Unmarshal([]byte(SEER_RPC_LOGS_LIMITS), &endpointsLogsLimits); err != nil {
+			return fmt.Errorf("invalid SEER_RPC_LOGS_LIMITS environment variable: %v", err)
+		}
+		for url, limits := range endpointsLogsLimits {
+			seer_common.SetRPCEndpointLogsLimits(url, limits)
+		}
+	}
+
+	// Beacon nodes follow MOONSTREAM_BEACON_<CHAIN>_A_EXTERNAL_URI convention
+	BeaconURLs = make(map[string]string)
+	for _, chain := range seer_blockchain.RegisteredChains() {
+		beaconURI := os.Getenv(fmt.Sprintf("MOONSTREAM_BEACON_%s_A_EXTERNAL_URI", strings.ToUpper(chain)))
+		if beaconURI != "" {
+			BeaconURLs[chain] = beaconURI
+		}
+	}
+
+	// Block tags follow SEER_CRAWLER_BLOCK_TAG_<CHAIN> convention, --block-tag flag overrides them
+	BlockTags = make(map[string]string)
+	for _, chain := range seer_blockchain.RegisteredChains() {
+		blockTag := os.Getenv(fmt.Sprintf("SEER_CRAWLER_BLOCK_TAG_%s", strings.ToUpper(chain)))
+		if blockTag != "" {
+			BlockTags[chain] = blockTag
+		}
+	}
+
+	// Confirmations follow SEER_CRAWLER_CONFIRMATIONS_<CHAIN> convention, --confirmations flag overrides them
+	Confirmations = make(map[string]int64)
+	for _, chain := range seer_blockchain.RegisteredChains() {
+		confirmationsVar := fmt.Sprintf("SEER_CRAWLER_CONFIRMATIONS_%s", strings.ToUpper(chain))
+		confirmationsRaw := os.Getenv(confirmationsVar)
+		if confirmationsRaw == "" {
+			continue
+		}
+		confirmations, err := strconv.ParseInt(confirmationsRaw, 10, 64)
+		if err != nil || confirmations < 0 {
+			return fmt.Errorf("invalid %s environment variable: %s", confirmationsVar, confirmationsRaw)
+		}
+		Confirmations[chain] = confirmations
+	}
+
+	// Proxy of all outbound RPC traffic, SEER_RPC_PROXY_<CHAIN> overrides it for endpoints of chain
+	if err := seer_common.SetRPCProxy(os.Getenv("SEER_RPC_PROXY")); err != nil {
+		return fmt.Errorf("invalid SEER_RPC_PROXY environment variable: %v", err)
+	}
+	for _, chain := range seer_blockchain.RegisteredChains() {
+		chainProxyVar := fmt.Sprintf("SEER_RPC_PROXY_%s", strings.ToUpper(chain))
+		chainProxy := os.Getenv(chainProxyVar)
+		if chainProxy == "" {
+			continue
+		}
+		for _, urls := range []string{BlockchainURLs[chain], ValidationURLs[chain], WebsocketURLs[chain], BeaconURLs[chain]} {
+			for _, url := range strings.Split(urls, ",") {
+				if strings.TrimSpace(url) == "" {
+					continue
+				}
+				if err := seer_common.SetRPCEndpointProxy(url, chainProxy); err != nil {
+					return fmt.Errorf("invalid %s environment variable: %v", chainProxyVar, err)
+				}
+			}
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,138 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	seer_blockchain "github.com/moonstream-to/seer/blockchain"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+var crossValidationMismatchesTotal = metrics.NewCounterVec("seer_crawler_cross_validation_mismatches_total",
+	"Blocks which differ between primary and validation RPC providers", "chain")
+
+// VerifyBlocksLinkage checks that every fetched block references hash of the previous block as
+// its parent, so gaps and blocks from different forks returned by RPC provider are rejected.
+// previousBlock is the last verified block of previous batch, nil for the first batch. Blocks of
+// chains which do not index parent hash are not checked.
+func VerifyBlocksLinkage(blocksIndex []indexer.BlockIndex, previousBlock *indexer.BlockIndex) error {
+	blocks := make([]indexer.BlockIndex, len(blocksIndex))
+	copy(blocks, blocksIndex)
+	sort.Slice(blocks, func(i, j int) bool {
+		return blocks[i].BlockNumber < blocks[j].BlockNumber
+	})
+
+	if previousBlock != nil && len(blocks) > 0 && previousBlock.BlockNumber < blocks[0].BlockNumber {
+		blocks = append([]indexer.BlockIndex{*previousBlock}, blocks...)
+	}
+
+	for i := 1; i < len(blocks); i++ {
+		parent, block := blocks[i-1], blocks[i]
+		if block.BlockNumber == parent.BlockNumber {
+			return fmt.Errorf("block %d is returned twice", block.BlockNumber)
+		}
+		if block.ParentHash == "" {
+			continue
+		}
+		if block.ParentHash != parent.BlockHash {
+			return fmt.Errorf("parent hash %s of block %d does not match hash %s of block %d", block.ParentHash, block.BlockNumber, parent.BlockHash, parent.BlockNumber)
+		}
+	}
+
+	return nil
+}
+
+// blockSummary is what cross-validation compares for every block.
+type blockSummary struct {
+	hash         string
+	transactions int
+	logs         int
+}
+
+func summarizeBlocks(blocksIndex []indexer.BlockIndex, txsIndex []indexer.TransactionIndex, eventsIndex []indexer.LogIndex) map[uint64]*blockSummary {
+	summaries := make(map[uint64]*blockSummary, len(blocksIndex))
+	for _, block := range blocksIndex {
+		summaries[block.BlockNumber] = &blockSummary{hash: block.BlockHash}
+	}
+	for _, tx := range txsIndex {
+		if summary, ok := summaries[tx.BlockNumber]; ok {
+			summary.transactions++
+		}
+	}
+	for _, event := range eventsIndex {
+		if summary, ok := summaries[event.BlockNumber]; ok {
+			summary.logs++
+		}
+	}
+
+	return summaries
+}
+
+// CrossValidateBlocks compares hashes, transactions and logs counts of blocks fetched from
+// primary and validation providers, it returns description of every block which differs.
+func CrossValidateBlocks(blocksIndex, validationBlocksIndex []indexer.BlockIndex, txsIndex, validationTxsIndex []indexer.TransactionIndex, eventsIndex, validationEventsIndex []indexer.LogIndex) []string {
+	primary := summarizeBlocks(blocksIndex, txsIndex, eventsIndex)
+	validation := summarizeBlocks(validationBlocksIndex, validationTxsIndex, validationEventsIndex)
+
+	blockNumbers := make([]uint64, 0, len(primary))
+	for blockNumber := range primary {
+		blockNumbers = append(blockNumbers, blockNumber)
+	}
+	for blockNumber := range validation {
+		if _, ok := primary[blockNumber]; !ok {
+			blockNumbers = append(blockNumbers, blockNumber)
+		}
+	}
+	sort.Slice(blockNumbers, func(i, j int) bool { return blockNumbers[i] < blockNumbers[j] })
+
+	var mismatches []string
+	for _, blockNumber := range blockNumbers {
+		p, v := primary[blockNumber], validation[blockNumber]
+		switch {
+		case p == nil:
+			mismatches = append(mismatches, fmt.Sprintf("block %d is returned by validation provider only", blockNumber))
+		case v == nil:
+			mismatches = append(mismatches, fmt.Sprintf("block %d is returned by primary provider only", blockNumber))
+		case p.hash != v.hash:
+			mismatches = append(mismatches, fmt.Sprintf("block %d hash %s differs from %s", blockNumber, p.hash, v.hash))
+		case p.transactions != v.transactions:
+			mismatches = append(mismatches, fmt.Sprintf("block %d has %d transactions instead of %d", blockNumber, p.transactions, v.transactions))
+		case p.logs != v.logs:
+			mismatches = append(mismatches, fmt.Sprintf("block %d has %d logs instead of %d", blockNumber, p.logs, v.logs))
+		}
+	}
+
+	return mismatches
+}
+
+// crossValidateBatch fetches blocks of batch from validation provider and compares them with
+// blocks fetched from primary provider.
+func (c *Crawler) crossValidateBatch(ctx context.Context, startBlock, endBlock int64, threads int, blocksIndex []indexer.BlockIndex, txsIndex []indexer.TransactionIndex, eventsIndex []indexer.LogIndex) error {
+	_, validationBlocksIndex, validationTxsIndex, validationEventsIndex, _, err := seer_blockchain.CrawlEntireBlocks(ctx, c.validationClient, big.NewInt(startBlock), big.NewInt(endBlock), SEER_CRAWLER_DEBUG, threads)
+	if err != nil {
+		return fmt.Errorf("failed to fetch blocks from validation provider: %w", err)
+	}
+
+	mismatches := CrossValidateBlocks(blocksIndex, validationBlocksIndex, txsIndex, validationTxsIndex, eventsIndex, validationEventsIndex)
+	if len(mismatches) > 0 {
+		crossValidationMismatchesTotal.Add(float64(len(mismatches)), c.blockchain)
+		return fmt.Errorf("%d blocks differ between providers: %s", len(mismatches), strings.Join(mismatches, "; "))
+	}
+
+	return nil
+}
+
+// lastBlock returns block with the highest number, nil if there are no blocks.
+func lastBlock(blocksIndex []indexer.BlockIndex) *indexer.BlockIndex {
+	var last *indexer.BlockIndex
+	for i := range blocksIndex {
+		if last == nil || blocksIndex[i].BlockNumber > last.BlockNumber {
+			last = &blocksIndex[i]
+		}
+	}
+	return last
+}
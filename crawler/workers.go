@@ -0,0 +1,114 @@
+package crawler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/big"
+	"sync"
+	"time"
+
+	seer_blockchain "github.com/moonstream-to/seer/blockchain"
+)
+
+// blocksRange is range of blocks crawled by single fetch worker, both ends are included.
+type blocksRange struct {
+	start int64
+	end   int64
+}
+
+// batchResult is batch crawled by fetch worker or error of the last attempt to crawl it.
+type batchResult struct {
+	batch *crawledBatch
+	err   error
+}
+
+// SetFetchWorkers sets number of consecutive batches crawled concurrently. Batches are still sent
+// to store stage in block order, so indexed height grows monotonically.
+func (c *Crawler) SetFetchWorkers(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	c.fetchWorkers = workers
+}
+
+// crawlBatch fetches blocks of range with transactions, events, chain specific indexes, blob
+// sidecars and traces.
+func (c *Crawler) crawlBatch(ctx context.Context, startBlock, endBlock int64, threads int) (*crawledBatch, error) {
+	log.Printf("Operates with batch of blocks: %d-%d", startBlock, endBlock)
+
+	// Fetch blocks with transactions
+	blocks, blocksIndex, txsIndex, eventsIndex, blocksSize, crawlErr := seer_blockchain.CrawlEntireBlocks(ctx, c.Client, big.NewInt(startBlock), big.NewInt(endBlock), SEER_CRAWLER_DEBUG, threads)
+	if crawlErr != nil {
+		return nil, fmt.Errorf("failed to crawl blocks, txs and events: %w", crawlErr)
+	}
+
+	if c.verifyBlocks {
+		if verifyErr := VerifyBlocksLinkage(blocksIndex, nil); verifyErr != nil {
+			return nil, fmt.Errorf("fetched blocks are not linked: %w", verifyErr)
+		}
+	}
+
+	if c.validationClient != nil {
+		if validationErr := c.crossValidateBatch(ctx, startBlock, endBlock, threads, blocksIndex, txsIndex, eventsIndex); validationErr != nil {
+			return nil, validationErr
+		}
+	}
+
+	customIndex, customErr := seer_blockchain.CrawlCustomIndexes(c.Client, blocks)
+	if customErr != nil {
+		return nil, fmt.Errorf("failed to build custom indexes: %w", customErr)
+	}
+
+	blobSidecars, blobSidecarsErr := c.CrawlBlobSidecars(ctx, blocks)
+	if blobSidecarsErr != nil {
+		return nil, fmt.Errorf("failed to fetch blob sidecars: %w", blobSidecarsErr)
+	}
+
+	callTraces, callTracesErr := c.CrawlCallTraces(ctx, blocks)
+	if callTracesErr != nil {
+		return nil, fmt.Errorf("failed to fetch call traces: %w", callTracesErr)
+	}
+
+	flatTraces, flatTracesErr := c.CrawlFlatTraces(ctx, blocks)
+	if flatTracesErr != nil {
+		return nil, fmt.Errorf("failed to fetch flat traces: %w", flatTracesErr)
+	}
+
+	return &crawledBatch{
+		startBlock:   startBlock,
+		endBlock:     endBlock,
+		blocks:       blocks,
+		blocksSize:   blocksSize,
+		blocksIndex:  blocksIndex,
+		txsIndex:     txsIndex,
+		eventsIndex:  eventsIndex,
+		customIndex:  customIndex,
+		blobSidecars: blobSidecars,
+		callTraces:   callTraces,
+		flatTraces:   flatTraces,
+	}, nil
+}
+
+// crawlBatches crawls ranges by concurrent workers, every range is retried on its own. Results
+// are returned in order of ranges.
+func (c *Crawler) crawlBatches(ctx context.Context, ranges []blocksRange, threads, attempts int, sleep time.Duration) []batchResult {
+	results := make([]batchResult, len(ranges))
+
+	var wg sync.WaitGroup
+	for i, blocks := range ranges {
+		wg.Add(1)
+		go func(i int, blocks blocksRange) {
+			defer wg.Done()
+
+			results[i].err = retryOperation(ctx, attempts, sleep, func() error {
+				batch, err := c.crawlBatch(ctx, blocks.start, blocks.end, threads)
+				results[i].batch = batch
+				return err
+			})
+		}(i, blocks)
+	}
+	wg.Wait()
+
+	return results
+}
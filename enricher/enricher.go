@@ -0,0 +1,218 @@
+// Package enricher grows coverage of decoded data without manual ABI uploads: it looks for
+// contracts which are active in index but have no ABI jobs, fetches their ABIs from Sourcify or
+// explorer API if contracts are verified and registers ABI jobs for them.
+package enricher
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+const (
+	// DefaultWindow is number of latest indexed blocks activity of addresses is counted in
+	DefaultWindow = 100000
+	// DefaultMinActivity is number of transactions and events after which address is looked up
+	DefaultMinActivity = 100
+	// DefaultAddressesPerRound is number of the most active addresses looked up in one round
+	DefaultAddressesPerRound = 50
+	// DefaultInterval is time between rounds
+	DefaultInterval = time.Hour
+)
+
+// Status of registered ABI jobs, historical crawl of them is left to job owners.
+const (
+	JobStatus             = "active"
+	HistoricalCrawlStatus = "pending"
+)
+
+const (
+	// notVerifiedRetryAfter is time after which contracts without verified ABI are looked up again
+	notVerifiedRetryAfter = 24 * time.Hour
+	// lookupInterval between lookups keeps requests within rate limits of free explorer API keys
+	lookupInterval = 250 * time.Millisecond
+)
+
+var enrichedAddresses = metrics.NewCounterVec("seer_enricher_addresses_total",
+	"Active addresses without ABI jobs looked up by enricher by result", "chain", "result")
+
+// Enricher registers ABI jobs of active verified contracts of blockchain for customer.
+type Enricher struct {
+	blockchain string
+	chainID    uint64
+	customerID string
+	userID     string
+	fetcher    *seer_common.VerifiedABIFetcher
+
+	window      uint64
+	minActivity uint64
+	limit       int
+	interval    time.Duration
+
+	// notVerified is time of the last lookup of addresses which ABI was not found
+	notVerified map[string]time.Time
+}
+
+// NewEnricher creates enricher which registers ABI jobs for customer and user. Chain ID is used
+// to look up contracts in Sourcify and explorer API.
+func NewEnricher(blockchain string, chainID uint64, customerID, userID string, fetcher *seer_common.VerifiedABIFetcher) *Enricher {
+	return &Enricher{
+		blockchain: blockchain,
+		chainID:    chainID,
+		customerID: customerID,
+		userID:     userID,
+		fetcher:    fetcher,
+
+		window:      DefaultWindow,
+		minActivity: DefaultMinActivity,
+		limit:       DefaultAddressesPerRound,
+		interval:    DefaultInterval,
+
+		notVerified: make(map[string]time.Time),
+	}
+}
+
+// SetSelection sets window of latest blocks activity is counted in, activity after which address
+// is looked up and number of the most active addresses looked up in one round.
+func (e *Enricher) SetSelection(window, minActivity uint64, limit int) {
+	e.window = window
+	e.minActivity = minActivity
+	if limit > 0 {
+		e.limit = limit
+	}
+}
+
+// SetInterval sets time between rounds.
+func (e *Enricher) SetInterval(interval time.Duration) {
+	if interval > 0 {
+		e.interval = interval
+	}
+}
+
+// Run looks up active addresses every interval until context is cancelled. Failed rounds are
+// logged and retried with the next round.
+func (e *Enricher) Run(ctx context.Context) error {
+	for {
+		registered, err := e.Round(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Printf("Enrichment round of %s failed: %v", e.blockchain, err)
+		} else {
+			log.Printf("Enrichment round of %s registered ABI jobs of %d contracts", e.blockchain, registered)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// Round looks up the most active addresses without ABI jobs and registers jobs of verified ones.
+// It returns number of contracts ABI jobs are registered for.
+func (e *Enricher) Round(ctx context.Context) (int, error) {
+	latestBlock, err := indexer.DBConnection.GetLatestDBBlockNumber(e.blockchain)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest indexed block: %w", err)
+	}
+
+	var fromBlock uint64
+	if latestBlock > e.window {
+		fromBlock = latestBlock - e.window
+	}
+
+	addresses, err := indexer.DBConnection.ReadActiveAddressesWithoutABIJobs(ctx, e.blockchain, fromBlock, e.minActivity, e.limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read active addresses: %w", err)
+	}
+
+	var registered int
+	for _, activity := range addresses {
+		if lookedUp, ok := e.notVerified[activity.Address]; ok && time.Since(lookedUp) < notVerifiedRetryAfter {
+			enrichedAddresses.Inc(e.blockchain, "skipped")
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return registered, ctx.Err()
+		case <-time.After(lookupInterval):
+		}
+
+		inserted, err := e.enrich(ctx, activity)
+		if err != nil {
+			return registered, err
+		}
+		if inserted {
+			registered++
+		}
+	}
+
+	return registered, nil
+}
+
+// enrich registers ABI jobs of address if its contract is verified. Failed lookups are logged,
+// only failures of database are returned.
+func (e *Enricher) enrich(ctx context.Context, activity indexer.AddressActivity) (bool, error) {
+	contractABI, source, err := e.fetcher.Fetch(ctx, e.chainID, activity.Address)
+	if err != nil {
+		log.Printf("Failed to fetch ABI of %s: %v", activity.Address, err)
+		enrichedAddresses.Inc(e.blockchain, "error")
+		return false, nil
+	}
+	if contractABI == "" {
+		e.notVerified[activity.Address] = time.Now()
+		enrichedAddresses.Inc(e.blockchain, "not_verified")
+		return false, nil
+	}
+
+	entries, err := seer_common.SplitABI(contractABI)
+	if err != nil || len(entries) == 0 {
+		if err != nil {
+			log.Printf("Failed to parse ABI of %s from %s: %v", activity.Address, source, err)
+		}
+		e.notVerified[activity.Address] = time.Now()
+		enrichedAddresses.Inc(e.blockchain, "no_entries")
+		return false, nil
+	}
+
+	address, err := hex.DecodeString(strings.TrimPrefix(activity.Address, "0x"))
+	if err != nil {
+		return false, fmt.Errorf("invalid address %s: %w", activity.Address, err)
+	}
+
+	jobs := make([]indexer.AbiJob, 0, len(entries))
+	for _, entry := range entries {
+		jobs = append(jobs, indexer.AbiJob{
+			Address:               address,
+			UserID:                e.userID,
+			CustomerID:            e.customerID,
+			AbiSelector:           entry.Selector,
+			Chain:                 e.blockchain,
+			AbiName:               entry.Name,
+			Status:                JobStatus,
+			HistoricalCrawlStatus: HistoricalCrawlStatus,
+			Abi:                   entry.ABI,
+		})
+	}
+
+	inserted, err := indexer.DBConnection.InsertABIJobs(ctx, jobs)
+	if err != nil {
+		return false, fmt.Errorf("failed to register ABI jobs of %s: %w", activity.Address, err)
+	}
+
+	log.Printf("Registered %d ABI jobs of %s (%d transactions, %d events) with ABI from %s", inserted, activity.Address, activity.Transactions, activity.Events, source)
+	enrichedAddresses.Inc(e.blockchain, "registered")
+
+	return true, nil
+}
@@ -0,0 +1,146 @@
+package enricher
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+const (
+	// DefaultNamesMinActivity is number of transactions after which address is resolved to name
+	DefaultNamesMinActivity = 10
+	// DefaultAddressesToNamePerRound is number of the most active addresses resolved in one round
+	DefaultAddressesToNamePerRound = 500
+	// DefaultNamesRefreshAfter is time after which names of addresses are resolved again, owners
+	// of addresses could change their reverse records
+	DefaultNamesRefreshAfter = 7 * 24 * time.Hour
+)
+
+var namedAddresses = metrics.NewCounterVec("seer_enricher_names_total",
+	"Active addresses resolved to names by name enricher by result", "chain", "result")
+
+// NameEnricher resolves active addresses of blockchain to names of name service and keeps them
+// in names table of index database, which is also cache of reverse records of enricher.
+type NameEnricher struct {
+	blockchain string
+	resolver   *seer_common.NameResolver
+
+	window       uint64
+	minActivity  uint64
+	limit        int
+	interval     time.Duration
+	refreshAfter time.Duration
+}
+
+// NewNameEnricher creates enricher which resolves names of addresses with resolver.
+func NewNameEnricher(blockchain string, resolver *seer_common.NameResolver) *NameEnricher {
+	return &NameEnricher{
+		blockchain: blockchain,
+		resolver:   resolver,
+
+		window:       DefaultWindow,
+		minActivity:  DefaultNamesMinActivity,
+		limit:        DefaultAddressesToNamePerRound,
+		interval:     DefaultInterval,
+		refreshAfter: DefaultNamesRefreshAfter,
+	}
+}
+
+// SetSelection sets window of latest blocks activity is counted in, activity after which address
+// is resolved and number of the most active addresses resolved in one round.
+func (e *NameEnricher) SetSelection(window, minActivity uint64, limit int) {
+	e.window = window
+	e.minActivity = minActivity
+	if limit > 0 {
+		e.limit = limit
+	}
+}
+
+// SetInterval sets time between rounds and time after which names are resolved again.
+func (e *NameEnricher) SetInterval(interval, refreshAfter time.Duration) {
+	if interval > 0 {
+		e.interval = interval
+	}
+	if refreshAfter > 0 {
+		e.refreshAfter = refreshAfter
+	}
+}
+
+// Run resolves active addresses every interval until context is cancelled. Failed rounds are
+// logged and retried with the next round.
+func (e *NameEnricher) Run(ctx context.Context) error {
+	for {
+		named, err := e.Round(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			log.Printf("Names enrichment round of %s failed: %v", e.blockchain, err)
+		} else {
+			log.Printf("Names enrichment round of %s resolved names of %d addresses", e.blockchain, named)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(e.interval):
+		}
+	}
+}
+
+// Round resolves the most active addresses which were not resolved or which names are stale and
+// writes their names. It returns number of addresses which have name.
+func (e *NameEnricher) Round(ctx context.Context) (int, error) {
+	latestBlock, err := indexer.DBConnection.GetLatestDBBlockNumber(e.blockchain)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get latest indexed block: %w", err)
+	}
+
+	var fromBlock uint64
+	if latestBlock > e.window {
+		fromBlock = latestBlock - e.window
+	}
+
+	addresses, err := indexer.DBConnection.ReadAddressesToName(ctx, e.blockchain, fromBlock, e.minActivity, time.Now().Add(-e.refreshAfter), e.limit)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read active addresses: %w", err)
+	}
+
+	service := e.resolver.Service().Name
+
+	var names []indexer.AddressName
+	var named int
+	for _, activity := range addresses {
+		if ctx.Err() != nil {
+			break
+		}
+
+		name, err := e.resolver.ReverseName(ctx, activity.Address)
+		if err != nil {
+			// Address is not written, so it is resolved again with the next round
+			log.Printf("Failed to resolve name of %s: %v", activity.Address, err)
+			namedAddresses.Inc(e.blockchain, "error")
+			continue
+		}
+
+		if name == "" {
+			namedAddresses.Inc(e.blockchain, "no_name")
+		} else {
+			namedAddresses.Inc(e.blockchain, "named")
+			named++
+		}
+		names = append(names, indexer.AddressName{Address: activity.Address, Name: name, Service: service})
+	}
+
+	// Names resolved before cancellation are kept
+	if err := indexer.DBConnection.WriteAddressNames(context.WithoutCancel(ctx), e.blockchain, names); err != nil {
+		return named, fmt.Errorf("failed to write names: %w", err)
+	}
+
+	return named, ctx.Err()
+}
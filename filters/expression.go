@@ -0,0 +1,449 @@
+// Package filters implements small expression language evaluated on decoded labels, outputs of
+// synchronizer use it to route only relevant records, for example:
+//
+//	address == 0x5a3b... && event == "Transfer" && args.value > 1e18
+//
+// Expressions combine comparisons (==, !=, >, >=, <, <=, in) of fields of label and literals with
+// &&, || and !. Literals are strings in double or single quotes, numbers including exponent
+// (1e18), 0x prefixed hex values, lists in brackets and true and false. Numbers and strings with
+// integers are compared by value, other strings are compared ignoring case, so addresses match in
+// any case. Comparison with field which label does not have is false.
+package filters
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Expression is parsed filter expression.
+type Expression struct {
+	source string
+	root   node
+}
+
+// Parse parses filter expression.
+func Parse(source string) (*Expression, error) {
+	tokens, err := tokenize(source)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek().kind != tokenEnd {
+		return nil, fmt.Errorf("unexpected %s at position %d", p.peek(), p.peek().pos)
+	}
+
+	return &Expression{source: source, root: root}, nil
+}
+
+// String returns source of expression.
+func (e *Expression) String() string {
+	return e.source
+}
+
+// Match reports whether record satisfies expression.
+func (e *Expression) Match(record Record) bool {
+	return truthy(e.root.eval(record))
+}
+
+// Tokens
+
+type tokenKind int
+
+const (
+	tokenEnd tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenHex
+	tokenOperator
+)
+
+type token struct {
+	kind  tokenKind
+	value string
+	pos   int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEnd {
+		return "end of expression"
+	}
+	return fmt.Sprintf("%q", t.value)
+}
+
+// operators are ordered so longer operators are matched first
+var operators = []string{"&&", "||", "==", "!=", ">=", "<=", ">", "<", "!", "(", ")", "[", "]", ","}
+
+func tokenize(source string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(source) && source[end] != c {
+				if source[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(source) {
+				return nil, fmt.Errorf("unterminated string at position %d", i)
+			}
+			value := source[i+1 : end]
+			if c == '"' {
+				unquoted, err := strconv.Unquote(source[i : end+1])
+				if err != nil {
+					return nil, fmt.Errorf("invalid string at position %d: %w", i, err)
+				}
+				value = unquoted
+			}
+			tokens = append(tokens, token{kind: tokenString, value: value, pos: i})
+			i = end + 1
+		case strings.HasPrefix(source[i:], "0x") || strings.HasPrefix(source[i:], "0X"):
+			end := i + 2
+			for end < len(source) && isHexDigit(source[end]) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenHex, value: strings.ToLower(source[i:end]), pos: i})
+			i = end
+		case isDigit(c) || (c == '-' || c == '.') && i+1 < len(source) && isDigit(source[i+1]):
+			end := i + 1
+			for end < len(source) && (isDigit(source[end]) || source[end] == '.' ||
+				source[end] == 'e' || source[end] == 'E' ||
+				(source[end] == '-' || source[end] == '+') && (source[end-1] == 'e' || source[end-1] == 'E')) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: source[i:end], pos: i})
+			i = end
+		case isIdentStart(c):
+			end := i + 1
+			for end < len(source) && (isIdentStart(source[end]) || isDigit(source[end]) || source[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: source[i:end], pos: i})
+			i = end
+		default:
+			matched := false
+			for _, operator := range operators {
+				if strings.HasPrefix(source[i:], operator) {
+					tokens = append(tokens, token{kind: tokenOperator, value: operator, pos: i})
+					i += len(operator)
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return nil, fmt.Errorf("unexpected character %q at position %d", c, i)
+			}
+		}
+	}
+	return append(tokens, token{kind: tokenEnd, pos: len(source)}), nil
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isHexDigit(c byte) bool {
+	return isDigit(c) || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F'
+}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// Parser
+
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEnd {
+		p.pos++
+	}
+	return t
+}
+
+// accept consumes operator or keyword if it is the next token.
+func (p *parser) accept(value string) bool {
+	t := p.peek()
+	if (t.kind == tokenOperator || t.kind == tokenIdent) && t.value == value {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expect(value string) error {
+	if !p.accept(value) {
+		return fmt.Errorf("expected %q at position %d, got %s", value, p.peek().pos, p.peek())
+	}
+	return nil
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = orNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = andNode{left, right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	if p.accept("!") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand}, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *parser) parseComparison() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+
+	if p.accept("in") {
+		if err := p.expect("["); err != nil {
+			return nil, err
+		}
+		var values []node
+		for !p.accept("]") {
+			if len(values) > 0 {
+				if err := p.expect(","); err != nil {
+					return nil, err
+				}
+			}
+			value, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, value)
+		}
+		return inNode{left, values}, nil
+	}
+
+	for _, op := range []string{"==", "!=", ">=", "<=", ">", "<"} {
+		if p.accept(op) {
+			right, err := p.parsePrimary()
+			if err != nil {
+				return nil, err
+			}
+			return compareNode{op, left, right}, nil
+		}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenOperator:
+		if t.value == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return inner, nil
+		}
+	case tokenString, tokenHex:
+		return literalNode{t.value}, nil
+	case tokenNumber:
+		number, ok := new(big.Rat).SetString(t.value)
+		if !ok {
+			return nil, fmt.Errorf("invalid number %q at position %d", t.value, t.pos)
+		}
+		return literalNode{number}, nil
+	case tokenIdent:
+		switch t.value {
+		case "true":
+			return literalNode{true}, nil
+		case "false":
+			return literalNode{false}, nil
+		case "in":
+		default:
+			return fieldNode{strings.Split(t.value, ".")}, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected %s at position %d", t, t.pos)
+}
+
+// Evaluation
+
+type node interface {
+	eval(record Record) interface{}
+}
+
+type orNode struct{ left, right node }
+
+func (n orNode) eval(record Record) interface{} {
+	return truthy(n.left.eval(record)) || truthy(n.right.eval(record))
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) eval(record Record) interface{} {
+	return truthy(n.left.eval(record)) && truthy(n.right.eval(record))
+}
+
+type notNode struct{ operand node }
+
+func (n notNode) eval(record Record) interface{} {
+	return !truthy(n.operand.eval(record))
+}
+
+type literalNode struct{ value interface{} }
+
+func (n literalNode) eval(record Record) interface{} {
+	return n.value
+}
+
+type fieldNode struct{ path []string }
+
+func (n fieldNode) eval(record Record) interface{} {
+	return record.Lookup(n.path)
+}
+
+type inNode struct {
+	operand node
+	values  []node
+}
+
+func (n inNode) eval(record Record) interface{} {
+	operand := n.operand.eval(record)
+	for _, value := range n.values {
+		if cmp, ok := compare(operand, value.eval(record)); ok && cmp == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type compareNode struct {
+	op          string
+	left, right node
+}
+
+func (n compareNode) eval(record Record) interface{} {
+	left, right := n.left.eval(record), n.right.eval(record)
+	if n.op == "==" || n.op == "!=" {
+		equal, ok := equals(left, right)
+		return ok && equal == (n.op == "==")
+	}
+
+	leftNumber, leftOk := toNumber(left)
+	rightNumber, rightOk := toNumber(right)
+	if !leftOk || !rightOk {
+		return false
+	}
+	cmp := leftNumber.Cmp(rightNumber)
+	switch n.op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	default:
+		return cmp <= 0
+	}
+}
+
+// equals compares values, false is returned as second value when one of them is missing.
+func equals(left, right interface{}) (bool, bool) {
+	cmp, ok := compare(left, right)
+	return ok && cmp == 0, ok
+}
+
+// compare returns 0 for equal values, numbers are compared by value and other values as
+// strings ignoring case. False is returned when one of values is missing.
+func compare(left, right interface{}) (int, bool) {
+	if left == nil || right == nil {
+		return 0, false
+	}
+
+	leftNumber, leftOk := toNumber(left)
+	rightNumber, rightOk := toNumber(right)
+	if leftOk && rightOk {
+		return leftNumber.Cmp(rightNumber), true
+	}
+
+	if strings.EqualFold(fmt.Sprint(left), fmt.Sprint(right)) {
+		return 0, true
+	}
+	return 1, true
+}
+
+// toNumber converts numbers, decimal strings and 0x prefixed hex strings to number.
+func toNumber(value interface{}) (*big.Rat, bool) {
+	switch v := value.(type) {
+	case *big.Rat:
+		return v, true
+	case string:
+		if digits, ok := strings.CutPrefix(strings.ToLower(v), "0x"); ok {
+			integer, ok := new(big.Int).SetString(digits, 16)
+			if !ok {
+				return nil, false
+			}
+			return new(big.Rat).SetInt(integer), true
+		}
+		return new(big.Rat).SetString(v)
+	case fmt.Stringer:
+		return new(big.Rat).SetString(v.String())
+	case float64:
+		number := new(big.Rat).SetFloat64(v)
+		return number, number != nil
+	}
+	return nil, false
+}
+
+func truthy(value interface{}) bool {
+	b, ok := value.(bool)
+	return ok && b
+}
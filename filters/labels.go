@@ -0,0 +1,78 @@
+package filters
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/moonstream-to/seer/indexer"
+)
+
+// Record is fields of label expressions are evaluated on. Nested fields, like arguments of
+// decoded call, are looked up by dotted path.
+type Record map[string]interface{}
+
+// Lookup returns value of field by path, nil if record does not have it.
+func (r Record) Lookup(path []string) interface{} {
+	var value interface{} = map[string]interface{}(r)
+	for _, key := range path {
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if value, ok = fields[key]; !ok {
+			return nil
+		}
+	}
+	return value
+}
+
+// EventRecord returns record of event label. Fields are label, label_type, event (name of event),
+// address, caller_address, origin_address, transaction_hash, log_index, block_number,
+// block_hash, block_timestamp, args (decoded arguments) and data (the whole label data).
+func EventRecord(label indexer.EventLabel) Record {
+	record := labelRecord(label.Label, label.LabelType, label.Address, label.CallerAddress, label.OriginAddress,
+		label.TransactionHash, label.BlockHash, label.BlockNumber, label.BlockTimestamp, label.LabelData)
+	record["event"] = label.LabelName
+	record["log_index"] = json.Number(uintString(label.LogIndex))
+	return record
+}
+
+// TransactionRecord returns record of transaction label with the same fields as EventRecord,
+// method (name of called method) is set instead of event and log_index.
+func TransactionRecord(label indexer.TransactionLabel) Record {
+	record := labelRecord(label.Label, label.LabelType, label.Address, label.CallerAddress, label.OriginAddress,
+		label.TransactionHash, label.BlockHash, label.BlockNumber, label.BlockTimestamp, label.LabelData)
+	record["method"] = label.LabelName
+	return record
+}
+
+func labelRecord(label, labelType, address, callerAddress, originAddress, transactionHash, blockHash string, blockNumber, blockTimestamp uint64, labelData string) Record {
+	record := Record{
+		"label":            label,
+		"label_type":       labelType,
+		"address":          strings.ToLower(address),
+		"caller_address":   strings.ToLower(callerAddress),
+		"origin_address":   strings.ToLower(originAddress),
+		"transaction_hash": transactionHash,
+		"block_hash":       blockHash,
+		"block_number":     json.Number(uintString(blockNumber)),
+		"block_timestamp":  json.Number(uintString(blockTimestamp)),
+	}
+
+	var data map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(labelData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&data); err == nil {
+		record["data"] = data
+		if args, ok := data["args"]; ok {
+			record["args"] = args
+		}
+	}
+
+	return record
+}
+
+func uintString(value uint64) string {
+	return strconv.FormatUint(value, 10)
+}
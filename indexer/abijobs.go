@@ -0,0 +1,99 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+)
+
+// AddressActivity is number of transactions to address and events emitted by it in range of blocks.
+type AddressActivity struct {
+	Address      string
+	Transactions uint64
+	Events       uint64
+}
+
+// ReadActiveAddressesWithoutABIJobs returns addresses with at least minActivity transactions and
+// events since fromBlock which have no ABI jobs on blockchain, the most active first.
+func (p *PostgreSQLpgx) ReadActiveAddressesWithoutABIJobs(ctx context.Context, blockchain string, fromBlock, minActivity uint64, limit int) ([]AddressActivity, error) {
+	query := fmt.Sprintf(`WITH blocks AS (
+        SELECT block_hash FROM %s WHERE block_number >= $1
+    ),
+    activity AS (
+        SELECT tx.to_address AS address, count(*) AS transactions, 0 AS events
+        FROM %s tx
+        WHERE tx.block_number >= $1 AND tx.to_address IS NOT NULL
+        GROUP BY tx.to_address
+        UNION ALL
+        SELECT logs.address, 0 AS transactions, count(*) AS events
+        FROM blocks INNER JOIN %s logs ON logs.block_hash = blocks.block_hash
+        GROUP BY logs.address
+    )
+    SELECT
+        '0x' || encode(address, 'hex'),
+        sum(transactions)::BIGINT,
+        sum(events)::BIGINT
+    FROM
+        activity
+    WHERE
+        NOT EXISTS (
+            SELECT 1 FROM abi_jobs
+            WHERE abi_jobs.chain = $2 AND abi_jobs.address = activity.address
+        )
+    GROUP BY
+        address
+    HAVING
+        sum(transactions) + sum(events) >= $3
+    ORDER BY
+        sum(transactions) + sum(events) DESC
+    LIMIT $4`, BlocksTableName(blockchain), TransactionsTableName(blockchain), LogsTableName(blockchain))
+
+	rows, err := p.GetPool().Query(ctx, query, fromBlock, blockchain, minActivity, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var addresses []AddressActivity
+	for rows.Next() {
+		var activity AddressActivity
+		if err := rows.Scan(&activity.Address, &activity.Transactions, &activity.Events); err != nil {
+			return nil, err
+		}
+		addresses = append(addresses, activity)
+	}
+
+	return addresses, rows.Err()
+}
+
+// InsertABIJobs registers ABI jobs, jobs which already exist for the same customer, chain,
+// address and selector are skipped. It returns number of inserted jobs.
+func (p *PostgreSQLpgx) InsertABIJobs(ctx context.Context, jobs []AbiJob) (int64, error) {
+	tx, err := p.GetPool().Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var inserted int64
+	for _, job := range jobs {
+		result, err := tx.Exec(ctx, `INSERT INTO abi_jobs (
+            id, address, user_id, customer_id, abi_selector, chain, abi_name, status,
+            historical_crawl_status, progress, moonworm_task_pickedup, abi, created_at, updated_at
+        )
+        SELECT gen_random_uuid(), $1, $2, $3, $4, $5, $6, $7, $8, 0, false, $9, now(), now()
+        WHERE NOT EXISTS (
+            SELECT 1 FROM abi_jobs
+            WHERE customer_id = $3 AND chain = $5 AND address = $1 AND abi_selector = $4
+        )`, job.Address, job.UserID, job.CustomerID, job.AbiSelector, job.Chain, job.AbiName, job.Status, job.HistoricalCrawlStatus, job.Abi)
+		if err != nil {
+			return 0, fmt.Errorf("failed to insert ABI job %s: %w", job.AbiName, err)
+		}
+		inserted += result.RowsAffected()
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return inserted, nil
+}
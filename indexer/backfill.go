@@ -0,0 +1,177 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// BackfillUnitsTableName is table with ranges of historical blocks crawler instances lease.
+const BackfillUnitsTableName = "seer_backfill_units"
+
+// BackfillUnitsTableDDL creates backfill units table, backfill commands apply it on start.
+var BackfillUnitsTableDDL = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    blockchain TEXT NOT NULL,
+    start_block BIGINT NOT NULL,
+    end_block BIGINT NOT NULL,
+    status TEXT NOT NULL DEFAULT 'pending',
+    worker TEXT,
+    leased_until TIMESTAMP WITH TIME ZONE,
+    attempts INT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (blockchain, start_block)
+);
+
+CREATE INDEX IF NOT EXISTS ix_%s_blockchain_status ON %s (blockchain, status);
+`, BackfillUnitsTableName, BackfillUnitsTableName, BackfillUnitsTableName)
+
+// Statuses of backfill unit
+const (
+	BackfillUnitPending = "pending"
+	BackfillUnitLeased  = "leased"
+	BackfillUnitDone    = "done"
+)
+
+// ErrBackfillLeaseLost is returned when lease of unit expired and unit was leased by other worker.
+var ErrBackfillLeaseLost = errors.New("lease of backfill unit is lost")
+
+// BackfillUnit is range of blocks crawled by single crawler instance, both ends are included.
+type BackfillUnit struct {
+	Blockchain  string
+	StartBlock  uint64
+	EndBlock    uint64
+	Status      string
+	Worker      string
+	LeasedUntil *time.Time
+	Attempts    int
+}
+
+// BackfillStatus is number of units of blockchain in every state and blocks crawled in done units.
+type BackfillStatus struct {
+	Total       int
+	Pending     int
+	Leased      int
+	Expired     int
+	Done        int
+	TotalBlocks uint64
+	DoneBlocks  uint64
+}
+
+// EnsureBackfillUnitsTable creates backfill units table if it does not exist.
+func (p *PostgreSQLpgx) EnsureBackfillUnitsTable(ctx context.Context) error {
+	_, err := p.GetPool().Exec(ctx, BackfillUnitsTableDDL)
+	return err
+}
+
+// CreateBackfillUnits splits range of blocks into units of unitSize blocks. Units which already
+// exist are kept with their state, so range could be planned again after it was extended.
+func (p *PostgreSQLpgx) CreateBackfillUnits(ctx context.Context, blockchain string, startBlock, endBlock, unitSize uint64) (int, error) {
+	if unitSize == 0 {
+		return 0, fmt.Errorf("unit size should be greater than 0")
+	}
+	if startBlock > endBlock {
+		return 0, fmt.Errorf("start block %d is greater than end block %d", startBlock, endBlock)
+	}
+
+	var starts, ends []int64
+	for unitStart := startBlock; unitStart <= endBlock; unitStart += unitSize {
+		unitEnd := unitStart + unitSize - 1
+		if unitEnd > endBlock {
+			unitEnd = endBlock
+		}
+		starts = append(starts, int64(unitStart))
+		ends = append(ends, int64(unitEnd))
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (blockchain, start_block, end_block)
+SELECT $1, unnest($2::BIGINT[]), unnest($3::BIGINT[])
+ON CONFLICT (blockchain, start_block) DO NOTHING`, BackfillUnitsTableName)
+	tag, err := p.GetPool().Exec(ctx, query, blockchain, starts, ends)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+// LeaseBackfillUnit leases the first pending unit of blockchain or unit which lease expired to
+// worker for lease duration. Nil unit is returned when there is nothing to lease.
+func (p *PostgreSQLpgx) LeaseBackfillUnit(ctx context.Context, blockchain, worker string, leaseDuration time.Duration) (*BackfillUnit, error) {
+	query := fmt.Sprintf(`UPDATE %s SET status = '%s', worker = $2, leased_until = now() + make_interval(secs => $3), attempts = attempts + 1, updated_at = now()
+WHERE (blockchain, start_block) = (
+    SELECT blockchain, start_block FROM %s
+    WHERE blockchain = $1 AND (status = '%s' OR (status = '%s' AND leased_until < now()))
+    ORDER BY start_block
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+RETURNING blockchain, start_block, end_block, status, worker, leased_until, attempts`, BackfillUnitsTableName, BackfillUnitLeased, BackfillUnitsTableName, BackfillUnitPending, BackfillUnitLeased)
+
+	var unit BackfillUnit
+	err := p.GetPool().QueryRow(ctx, query, blockchain, worker, leaseDuration.Seconds()).Scan(&unit.Blockchain, &unit.StartBlock, &unit.EndBlock, &unit.Status, &unit.Worker, &unit.LeasedUntil, &unit.Attempts)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &unit, nil
+}
+
+// HeartbeatBackfillUnit extends lease of unit held by worker, ErrBackfillLeaseLost is returned
+// if unit is leased by other worker.
+func (p *PostgreSQLpgx) HeartbeatBackfillUnit(ctx context.Context, unit *BackfillUnit, worker string, leaseDuration time.Duration) error {
+	query := fmt.Sprintf(`UPDATE %s SET leased_until = now() + make_interval(secs => $4), updated_at = now()
+WHERE blockchain = $1 AND start_block = $2 AND worker = $3 AND status = '%s'`, BackfillUnitsTableName, BackfillUnitLeased)
+
+	tag, err := p.GetPool().Exec(ctx, query, unit.Blockchain, unit.StartBlock, worker, leaseDuration.Seconds())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrBackfillLeaseLost
+	}
+
+	return nil
+}
+
+// CompleteBackfillUnit marks unit crawled by worker as done.
+func (p *PostgreSQLpgx) CompleteBackfillUnit(ctx context.Context, unit *BackfillUnit, worker string) error {
+	query := fmt.Sprintf(`UPDATE %s SET status = '%s', leased_until = NULL, updated_at = now()
+WHERE blockchain = $1 AND start_block = $2 AND worker = $3`, BackfillUnitsTableName, BackfillUnitDone)
+
+	tag, err := p.GetPool().Exec(ctx, query, unit.Blockchain, unit.StartBlock, worker)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrBackfillLeaseLost
+	}
+
+	return nil
+}
+
+// ReadBackfillStatus returns completion of backfill of blockchain.
+func (p *PostgreSQLpgx) ReadBackfillStatus(ctx context.Context, blockchain string) (*BackfillStatus, error) {
+	query := fmt.Sprintf(`SELECT
+    count(*),
+    count(*) FILTER (WHERE status = '%s'),
+    count(*) FILTER (WHERE status = '%s' AND leased_until >= now()),
+    count(*) FILTER (WHERE status = '%s' AND leased_until < now()),
+    count(*) FILTER (WHERE status = '%s'),
+    COALESCE(sum(end_block - start_block + 1), 0)::BIGINT,
+    COALESCE(sum(end_block - start_block + 1) FILTER (WHERE status = '%s'), 0)::BIGINT
+FROM %s WHERE blockchain = $1`, BackfillUnitPending, BackfillUnitLeased, BackfillUnitLeased, BackfillUnitDone, BackfillUnitDone, BackfillUnitsTableName)
+
+	var status BackfillStatus
+	err := p.GetPool().QueryRow(ctx, query, blockchain).Scan(&status.Total, &status.Pending, &status.Leased, &status.Expired, &status.Done, &status.TotalBlocks, &status.DoneBlocks)
+	if err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
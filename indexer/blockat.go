@@ -0,0 +1,37 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ReadBlocksAroundTimestamp returns the last indexed block with timestamp at or before timestamp
+// and the first indexed block after it, nil if there is no such block in index.
+func (p *PostgreSQLpgx) ReadBlocksAroundTimestamp(ctx context.Context, blockchain string, timestamp uint64) (*BlockIndex, *BlockIndex, error) {
+	readBlock := func(query string) (*BlockIndex, error) {
+		block := BlockIndex{chain: blockchain}
+		err := p.GetPool().QueryRow(ctx, query, timestamp).Scan(&block.BlockNumber, &block.BlockHash, &block.BlockTimestamp)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return &block, nil
+	}
+
+	before, err := readBlock(fmt.Sprintf("SELECT block_number, block_hash, block_timestamp FROM %s WHERE block_timestamp <= $1 ORDER BY block_timestamp DESC, block_number DESC LIMIT 1", BlocksTableName(blockchain)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	after, err := readBlock(fmt.Sprintf("SELECT block_number, block_hash, block_timestamp FROM %s WHERE block_timestamp > $1 ORDER BY block_timestamp, block_number LIMIT 1", BlocksTableName(blockchain)))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return before, after, nil
+}
@@ -0,0 +1,123 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// IndexTableColumns returns columns of index table as "name type" in order of table definition,
+// empty if table does not exist.
+func (p *PostgreSQLpgx) IndexTableColumns(ctx context.Context, table string) ([]string, error) {
+	rows, err := p.GetPool().Query(ctx, "SELECT column_name, data_type FROM information_schema.columns WHERE table_schema = current_schema() AND table_name = $1 ORDER BY ordinal_position", table)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read columns of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var name, dataType string
+		if err := rows.Scan(&name, &dataType); err != nil {
+			return nil, err
+		}
+		columns = append(columns, fmt.Sprintf("%s %s", name, dataType))
+	}
+
+	return columns, rows.Err()
+}
+
+// ReadPacksPaths returns distinct paths of packs with blocks in range.
+func (p *PostgreSQLpgx) ReadPacksPaths(ctx context.Context, blockchain string, startBlock, endBlock uint64) ([]string, error) {
+	query := fmt.Sprintf("SELECT DISTINCT path FROM %s WHERE block_number >= $1 AND block_number <= $2 ORDER BY path", BlocksTableName(blockchain))
+	rows, err := p.GetPool().Query(ctx, query, startBlock, endBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read packs paths: %w", err)
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, rows.Err()
+}
+
+// ExportPacksRows streams rows of index table which belong to packs in directories as JSON
+// objects. Blocks are narrowed by block range of packs, so tables with block_number or
+// block_hash column are not scanned by path only.
+func (p *PostgreSQLpgx) ExportPacksRows(ctx context.Context, blockchain, table string, columns []string, packsDirs []string, startBlock, endBlock uint64, fn func(row json.RawMessage) error) (int, error) {
+	var patterns []string
+	for _, packDir := range packsDirs {
+		escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(strings.TrimSuffix(packDir, "/"))
+		patterns = append(patterns, escaped+"/%")
+	}
+
+	var hasBlockNumber, hasBlockHash bool
+	for _, column := range columns {
+		switch strings.SplitN(column, " ", 2)[0] {
+		case "block_number":
+			hasBlockNumber = true
+		case "block_hash":
+			hasBlockHash = true
+		}
+	}
+
+	query := fmt.Sprintf("SELECT row_to_json(t) FROM %s t WHERE t.path LIKE ANY($1)", table)
+	args := []interface{}{patterns}
+	switch {
+	case hasBlockNumber:
+		query += " AND t.block_number >= $2 AND t.block_number <= $3"
+		args = append(args, startBlock, endBlock)
+	case hasBlockHash:
+		query += fmt.Sprintf(" AND t.block_hash IN (SELECT block_hash FROM %s WHERE block_number >= $2 AND block_number <= $3)", BlocksTableName(blockchain))
+		args = append(args, startBlock, endBlock)
+	}
+
+	rows, err := p.GetPool().Query(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read rows of %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	var rowsNum int
+	for rows.Next() {
+		var row json.RawMessage
+		if err := rows.Scan(&row); err != nil {
+			return rowsNum, err
+		}
+		if err := fn(row); err != nil {
+			return rowsNum, err
+		}
+		rowsNum++
+	}
+
+	return rowsNum, rows.Err()
+}
+
+// ImportRows inserts rows exported with ExportPacksRows into index table.
+// Columns are matched by name and rows which already exist are skipped.
+func (p *PostgreSQLpgx) ImportRows(ctx context.Context, table string, rows []json.RawMessage) (int64, error) {
+	if len(rows) == 0 {
+		return 0, nil
+	}
+
+	rowsJSON, err := json.Marshal(rows)
+	if err != nil {
+		return 0, err
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s SELECT * FROM json_populate_recordset(NULL::%s, $1::json) ON CONFLICT DO NOTHING", table, table)
+	result, err := p.GetPool().Exec(ctx, query, string(rowsJSON))
+	if err != nil {
+		return 0, fmt.Errorf("failed to import rows into %s: %w", table, err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,97 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CheckpointsTableName is table with the last block crawler committed per chain.
+const CheckpointsTableName = "seer_crawler_checkpoints"
+
+// CheckpointsTableDDL creates checkpoints table, crawler applies it on start.
+var CheckpointsTableDDL = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    blockchain TEXT NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash TEXT,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (blockchain)
+);
+`, CheckpointsTableName)
+
+var writeCheckpointQuery = fmt.Sprintf(`INSERT INTO %s (blockchain, block_number, block_hash, updated_at) VALUES ($1, $2, NULLIF($3, ''), now())
+ON CONFLICT (blockchain) DO UPDATE SET block_number = EXCLUDED.block_number, block_hash = EXCLUDED.block_hash, updated_at = EXCLUDED.updated_at`, CheckpointsTableName)
+
+// Checkpoint is the last block which data and indexes were committed by crawler.
+type Checkpoint struct {
+	Blockchain  string
+	BlockNumber uint64
+	BlockHash   string
+	UpdatedAt   time.Time
+
+	// Leader is replica of crawler which writes checkpoint, indexes and checkpoint are written
+	// only while it holds lease of leadership
+	Leader string
+}
+
+// EnsureCheckpointsTable creates checkpoints table if it does not exist.
+func (p *PostgreSQLpgx) EnsureCheckpointsTable(ctx context.Context) error {
+	_, err := p.GetPool().Exec(ctx, CheckpointsTableDDL)
+	return err
+}
+
+// ReadCheckpoint returns checkpoint of blockchain, nil if crawler has not committed blocks yet.
+func (p *PostgreSQLpgx) ReadCheckpoint(ctx context.Context, blockchain string) (*Checkpoint, error) {
+	checkpoint := Checkpoint{Blockchain: blockchain}
+	var blockHash *string
+
+	query := fmt.Sprintf("SELECT block_number, block_hash, updated_at FROM %s WHERE blockchain = $1", CheckpointsTableName)
+	err := p.GetPool().QueryRow(ctx, query, blockchain).Scan(&checkpoint.BlockNumber, &blockHash, &checkpoint.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if blockHash != nil {
+		checkpoint.BlockHash = *blockHash
+	}
+
+	return &checkpoint, nil
+}
+
+// WriteCheckpoint stores the last committed block of blockchain.
+func (p *PostgreSQLpgx) WriteCheckpoint(ctx context.Context, blockchain string, blockNumber uint64, blockHash string) error {
+	_, err := p.GetPool().Exec(ctx, writeCheckpointQuery, blockchain, blockNumber, blockHash)
+	return err
+}
+
+// RewindCheckpoint moves checkpoint of blockchain back to block number if it is ahead of it, for
+// example to common ancestor when committed blocks are orphaned by reorganization. Checkpoint of
+// leader is moved only while replica holds lease of leadership.
+func (p *PostgreSQLpgx) RewindCheckpoint(ctx context.Context, blockchain string, blockNumber uint64, leader string) error {
+	query := fmt.Sprintf("UPDATE %s SET block_number = $2, block_hash = NULL, updated_at = now() WHERE blockchain = $1 AND block_number > $2", CheckpointsTableName)
+	if leader == "" {
+		_, err := p.GetPool().Exec(ctx, query, blockchain, blockNumber)
+		return err
+	}
+
+	tx, err := p.GetPool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := checkLeadership(ctx, tx, blockchain, leader); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, query, blockchain, blockNumber); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
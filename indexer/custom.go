@@ -0,0 +1,126 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+)
+
+// CustomIndexColumn describes column of chain specific index table.
+type CustomIndexColumn struct {
+	Name string
+	Type string // PostgreSQL data type, e.g. "BIGINT" or "TEXT"
+}
+
+// CustomIndexTable describes chain specific index table. Column "path" is added
+// automatically and filled with path to the proto batch in storage.
+type CustomIndexTable struct {
+	Kind           string
+	Columns        []CustomIndexColumn
+	ConflictClause string
+}
+
+var (
+	customIndexTablesMu sync.RWMutex
+	customIndexTables   = map[string]map[string]CustomIndexTable{}
+)
+
+// RegisterCustomIndexTable registers chain specific index table, usually from init function
+// of blockchain client package.
+func RegisterCustomIndexTable(blockchain string, table CustomIndexTable) {
+	customIndexTablesMu.Lock()
+	defer customIndexTablesMu.Unlock()
+
+	if _, ok := customIndexTables[blockchain]; !ok {
+		customIndexTables[blockchain] = make(map[string]CustomIndexTable)
+	}
+	customIndexTables[blockchain][table.Kind] = table
+}
+
+// GetCustomIndexTables returns chain specific index tables registered for blockchain.
+func GetCustomIndexTables(blockchain string) []CustomIndexTable {
+	customIndexTablesMu.RLock()
+	defer customIndexTablesMu.RUnlock()
+
+	var tables []CustomIndexTable
+	for _, table := range customIndexTables[blockchain] {
+		tables = append(tables, table)
+	}
+
+	return tables
+}
+
+func CustomIndexTableName(blockchain, kind string) string {
+	return fmt.Sprintf("%s_%s", blockchain, kind)
+}
+
+// ContractDeploymentsIndexKind is kind of custom index with contracts created by transactions,
+// synchronizer reads deployments with constructor ABI jobs from it.
+const ContractDeploymentsIndexKind = "contract_deployments"
+
+// contractDeploymentsSource returns contract deployments table of blockchain, or empty relation
+// with the same columns when blockchain does not register it.
+func contractDeploymentsSource(blockchain string) string {
+	customIndexTablesMu.RLock()
+	_, ok := customIndexTables[blockchain][ContractDeploymentsIndexKind]
+	customIndexTablesMu.RUnlock()
+
+	if ok {
+		return CustomIndexTableName(blockchain, ContractDeploymentsIndexKind)
+	}
+	return "(SELECT NULL::TEXT AS transaction_hash, NULL::TEXT AS address, NULL::BIGINT AS block_number WHERE false)"
+}
+
+func writeCustomIndexToDB(insert batchInserter, blockchain string, indexes []CustomIndex) error {
+	customIndexTablesMu.RLock()
+	tables := customIndexTables[blockchain]
+	customIndexTablesMu.RUnlock()
+
+	indexesByKind := make(map[string][]CustomIndex)
+	for _, index := range indexes {
+		indexesByKind[index.Kind] = append(indexesByKind[index.Kind], index)
+	}
+
+	ctx := context.Background()
+
+	for kind, kindIndexes := range indexesByKind {
+		table, ok := tables[kind]
+		if !ok {
+			return fmt.Errorf("custom index table %s is not registered for blockchain %s", kind, blockchain)
+		}
+
+		tableName := CustomIndexTableName(blockchain, kind)
+
+		var columns []string
+		valuesMap := make(map[string]UnnestInsertValueStruct)
+		for _, column := range table.Columns {
+			columns = append(columns, column.Name)
+			valuesMap[column.Name] = UnnestInsertValueStruct{
+				Type:   column.Type,
+				Values: make([]interface{}, 0),
+			}
+		}
+		columns = append(columns, "path")
+		valuesMap["path"] = UnnestInsertValueStruct{
+			Type:   "TEXT",
+			Values: make([]interface{}, 0),
+		}
+
+		for _, index := range kindIndexes {
+			for _, column := range table.Columns {
+				updateValues(valuesMap, column.Name, index.Values[column.Name])
+			}
+			updateValues(valuesMap, "path", index.Path)
+		}
+
+		err := insert(ctx, tableName, columns, valuesMap, table.ConflictClause)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Add %d records into %s table", len(kindIndexes), tableName)
+	}
+
+	return nil
+}
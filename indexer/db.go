@@ -8,11 +8,14 @@ import (
 	"log"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/moonstream-to/seer/progress"
 )
 
 // DB is a global variable to hold the GORM database connection.
@@ -21,6 +24,16 @@ func LabelsTableName(blockchain string) string {
 	return fmt.Sprintf(blockchain + "_labels")
 }
 
+// LabelsDatabase is customer database synchronizer writes labels to. It is PostgreSQL database
+// or database of SQL dialect, synchronizer creates labels table only in database of SQL dialect.
+type LabelsDatabase interface {
+	Ping(ctx context.Context) error
+	ReadLastLabel(blockchain string) (uint64, error)
+	WriteLabes(blockchain string, transactions []TransactionLabel, events []EventLabel) error
+	ReplaceAddressLabels(ctx context.Context, blockchain, address string, fromBlock, toBlock uint64, transactions []TransactionLabel, events []EventLabel) (int64, error)
+	DeleteLabelsOfBlocks(ctx context.Context, blockchain string, blockHashes []string) (int64, error)
+}
+
 func TransactionsTableName(blockchain string) string {
 	return fmt.Sprintf(blockchain + "_transactions")
 }
@@ -52,6 +65,36 @@ type UnnestInsertValueStruct struct {
 	Values []interface{} `json:"values"` // e.g. [1, 2, 3, 4, 5]
 }
 
+var (
+	blockchainsWithL1ChainMu sync.RWMutex
+	blockchainsWithL1Chain   = map[string]bool{}
+)
+
+// RegisterBlockchainWithL1Chain marks blockchain as chain with L1 chain, its blocks index gets
+// l1_block_number column. Generated clients of side chains and OP-stack chains call it from init.
+func RegisterBlockchainWithL1Chain(blockchain string) {
+	blockchainsWithL1ChainMu.Lock()
+	defer blockchainsWithL1ChainMu.Unlock()
+
+	blockchainsWithL1Chain[blockchain] = true
+}
+
+func isRegisteredBlockchainWithL1Chain(blockchain string) bool {
+	blockchainsWithL1ChainMu.RLock()
+	defer blockchainsWithL1ChainMu.RUnlock()
+
+	return blockchainsWithL1Chain[blockchain]
+}
+
+var indexGasUsed atomic.Bool
+
+// SetIndexGasUsed makes writes of transactions index fill gas_used column with gas used by
+// transactions. Column is nullable and is not written by default, so databases which transactions
+// index tables were created before the column was added keep working until it is added.
+func SetIndexGasUsed(enabled bool) {
+	indexGasUsed.Store(enabled)
+}
+
 func IsBlockchainWithL1Chain(blockchain string) bool {
 	switch blockchain {
 	case "ethereum":
@@ -74,8 +117,14 @@ func IsBlockchainWithL1Chain(blockchain string) bool {
 		return false
 	case "mantle_sepolia":
 		return false
+	case "optimism":
+		return true
+	case "base":
+		return true
+	case "base_sepolia":
+		return true
 	default:
-		return false
+		return isRegisteredBlockchainWithL1Chain(blockchain)
 	}
 }
 
@@ -125,6 +174,11 @@ func (p *PostgreSQLpgx) GetPool() *pgxpool.Pool {
 	return p.pool
 }
 
+// Ping checks that database accepts connections.
+func (p *PostgreSQLpgx) Ping(ctx context.Context) error {
+	return p.pool.Ping(ctx)
+}
+
 // read from database
 
 func (p *PostgreSQLpgx) ReadBlockIndex(ctx context.Context, startBlock uint64, endBlock uint64) ([]BlockIndex, error) {
@@ -281,6 +335,10 @@ func decodeAddress(address string) ([]byte, error) {
 	if len(address) < 2 {
 		return []byte{0x00}, nil
 	}
+	if !strings.HasPrefix(address, "0x") {
+		// Non hex addresses (account names, base58 or bech32 encoded) are stored as is
+		return []byte(address), nil
+	}
 	return hex.DecodeString(address[2:])
 }
 
@@ -291,7 +349,7 @@ func updateValues(valuesMap map[string]UnnestInsertValueStruct, key string, valu
 	valuesMap[key] = tmp
 }
 
-func (p *PostgreSQLpgx) WriteIndexes(blockchain string, blocksIndexPack []BlockIndex, transactionsIndexPack []TransactionIndex, logsIndexPack []LogIndex) error {
+func (p *PostgreSQLpgx) WriteIndexes(blockchain string, blocksIndexPack []BlockIndex, transactionsIndexPack []TransactionIndex, logsIndexPack []LogIndex, customIndexPack []CustomIndex, checkpoint *Checkpoint) error {
 
 	ctx := context.Background()
 	pool := p.GetPool()
@@ -317,9 +375,17 @@ func (p *PostgreSQLpgx) WriteIndexes(blockchain string, blocksIndexPack []BlockI
 		}
 	}()
 
+	// Replica which lost leadership could not overwrite checkpoint of new leader
+	if checkpoint != nil && checkpoint.Leader != "" {
+		err = checkLeadership(ctx, tx, blockchain, checkpoint.Leader)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Write blocks index
 	if len(blocksIndexPack) > 0 {
-		err = p.writeBlockIndexToDB(tx, blockchain, blocksIndexPack)
+		err = writeBlockIndexToDB(p.txBatchInserter(tx), blockchain, blocksIndexPack)
 		if err != nil {
 			return err
 		}
@@ -327,7 +393,7 @@ func (p *PostgreSQLpgx) WriteIndexes(blockchain string, blocksIndexPack []BlockI
 
 	// Write transactions index
 	if len(transactionsIndexPack) > 0 {
-		err = p.writeTransactionIndexToDB(tx, blockchain, transactionsIndexPack)
+		err = writeTransactionIndexToDB(p.txBatchInserter(tx), blockchain, transactionsIndexPack)
 		if err != nil {
 			return err
 		}
@@ -335,18 +401,49 @@ func (p *PostgreSQLpgx) WriteIndexes(blockchain string, blocksIndexPack []BlockI
 
 	// Write logs index
 	if len(logsIndexPack) > 0 {
-		err = p.writeLogIndexToDB(tx, blockchain, logsIndexPack)
+		err = writeLogIndexToDB(p.txBatchInserter(tx), blockchain, logsIndexPack)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Write chain specific indexes
+	if len(customIndexPack) > 0 {
+		err = writeCustomIndexToDB(p.txBatchInserter(tx), blockchain, customIndexPack)
 		if err != nil {
 			return err
 		}
 	}
 
+	// Checkpoint is committed together with indexes, so it never points past indexed blocks
+	if checkpoint != nil {
+		_, err = tx.Exec(ctx, writeCheckpointQuery, blockchain, checkpoint.BlockNumber, checkpoint.BlockHash)
+		if err != nil {
+			return fmt.Errorf("failed to write checkpoint: %w", err)
+		}
+	}
+
 	return nil
 }
 
 // Batch insert
 func (p *PostgreSQLpgx) executeBatchInsert(tx pgx.Tx, ctx context.Context, tableName string, columns []string, values map[string]UnnestInsertValueStruct, conflictClause string) error {
 
+	query, valuesSlice := unnestInsertQuery(tableName, columns, values, conflictClause)
+
+	// track execution time
+
+	if _, err := tx.Exec(ctx, query, valuesSlice...); err != nil {
+		fmt.Println("Error executing bulk insert", err)
+		return fmt.Errorf("error executing bulk insert for batch: %w", err)
+	}
+
+	return nil
+}
+
+// unnestInsertQuery returns single insert of rows of values with unnest and its arguments, one
+// array of values per column.
+func unnestInsertQuery(tableName string, columns []string, values map[string]UnnestInsertValueStruct, conflictClause string) (string, []interface{}) {
 	types := make([]string, 0)
 
 	for index, column := range columns {
@@ -364,17 +461,22 @@ func (p *PostgreSQLpgx) executeBatchInsert(tx pgx.Tx, ctx context.Context, table
 		valuesSlice = append(valuesSlice, values[column].Values)
 	}
 
-	// track execution time
+	return query, valuesSlice
+}
 
-	if _, err := tx.Exec(ctx, query, valuesSlice...); err != nil {
-		fmt.Println("Error executing bulk insert", err)
-		return fmt.Errorf("error executing bulk insert for batch: %w", err)
-	}
+// batchInserter inserts rows of values into table in transaction of indexes or labels database,
+// rows which conflict with existing rows are handled by PostgreSQL conflict clause. Index and
+// labels writers are shared by PostgreSQL database and databases of SQL dialects.
+type batchInserter func(ctx context.Context, tableName string, columns []string, values map[string]UnnestInsertValueStruct, conflictClause string) error
 
-	return nil
+// txBatchInserter returns inserter which writes rows in transaction with single unnest insert.
+func (p *PostgreSQLpgx) txBatchInserter(tx pgx.Tx) batchInserter {
+	return func(ctx context.Context, tableName string, columns []string, values map[string]UnnestInsertValueStruct, conflictClause string) error {
+		return p.executeBatchInsert(tx, ctx, tableName, columns, values, conflictClause)
+	}
 }
 
-func (p *PostgreSQLpgx) writeBlockIndexToDB(tx pgx.Tx, blockchain string, indexes []BlockIndex) error {
+func writeBlockIndexToDB(insert batchInserter, blockchain string, indexes []BlockIndex) error {
 	tableName := BlocksTableName(blockchain)
 	isBlockchainWithL1Chain := IsBlockchainWithL1Chain(blockchain)
 	columns := []string{"block_number", "block_hash", "block_timestamp", "parent_hash", "row_id", "path"}
@@ -434,7 +536,7 @@ func (p *PostgreSQLpgx) writeBlockIndexToDB(tx pgx.Tx, blockchain string, indexe
 	}
 
 	ctx := context.Background()
-	err = p.executeBatchInsert(tx, ctx, tableName, columns, valuesMap, "ON CONFLICT (block_number) DO NOTHING")
+	err = insert(ctx, tableName, columns, valuesMap, "ON CONFLICT (block_number) DO NOTHING")
 
 	if err != nil {
 		return err
@@ -445,7 +547,7 @@ func (p *PostgreSQLpgx) writeBlockIndexToDB(tx pgx.Tx, blockchain string, indexe
 	return nil
 }
 
-func (p *PostgreSQLpgx) writeTransactionIndexToDB(tx pgx.Tx, blockchain string, indexes []TransactionIndex) error {
+func writeTransactionIndexToDB(insert batchInserter, blockchain string, indexes []TransactionIndex) error {
 
 	tableName := TransactionsTableName(blockchain)
 
@@ -502,6 +604,15 @@ func (p *PostgreSQLpgx) writeTransactionIndexToDB(tx pgx.Tx, blockchain string,
 		Values: make([]interface{}, 0),
 	}
 
+	withGasUsed := indexGasUsed.Load()
+	if withGasUsed {
+		columns = append(columns, "gas_used")
+		valuesMap["gas_used"] = UnnestInsertValueStruct{
+			Type:   "BIGINT",
+			Values: make([]interface{}, 0),
+		}
+	}
+
 	for _, index := range indexes {
 
 		fromAddressBytes, err := decodeAddress(index.FromAddress)
@@ -528,11 +639,19 @@ func (p *PostgreSQLpgx) writeTransactionIndexToDB(tx pgx.Tx, blockchain string,
 		updateValues(valuesMap, "row_id", index.RowID)
 		updateValues(valuesMap, "path", index.Path)
 
+		if withGasUsed {
+			if index.GasUsed != nil {
+				updateValues(valuesMap, "gas_used", *index.GasUsed)
+			} else {
+				updateValues(valuesMap, "gas_used", nil)
+			}
+		}
+
 	}
 
 	ctx := context.Background()
 
-	err = p.executeBatchInsert(tx, ctx, tableName, columns, valuesMap, "ON CONFLICT (hash) DO NOTHING")
+	err = insert(ctx, tableName, columns, valuesMap, "ON CONFLICT (hash) DO NOTHING")
 
 	if err != nil {
 		return err
@@ -543,7 +662,7 @@ func (p *PostgreSQLpgx) writeTransactionIndexToDB(tx pgx.Tx, blockchain string,
 	return nil
 }
 
-func (p *PostgreSQLpgx) writeLogIndexToDB(tx pgx.Tx, blockchain string, indexes []LogIndex) error {
+func writeLogIndexToDB(insert batchInserter, blockchain string, indexes []LogIndex) error {
 
 	tableName := LogsTableName(blockchain)
 
@@ -624,7 +743,7 @@ func (p *PostgreSQLpgx) writeLogIndexToDB(tx pgx.Tx, blockchain string, indexes
 
 	ctx := context.Background()
 
-	err = p.executeBatchInsert(tx, ctx, tableName, columns, valuesMap, "ON CONFLICT (transaction_hash, log_index) DO NOTHING")
+	err = insert(ctx, tableName, columns, valuesMap, "ON CONFLICT (transaction_hash, log_index) DO NOTHING")
 
 	if err != nil {
 		return err
@@ -704,6 +823,126 @@ func (p *PostgreSQLpgx) GetLatestDBBlockNumber(blockchain string) (uint64, error
 
 }
 
+// ReadHeadLag returns the latest indexed block and indexed block with number, their timestamps are
+// used to measure how far behind the latest indexed block decoding is.
+func (p *PostgreSQLpgx) ReadHeadLag(ctx context.Context, blockchain string, blockNumber uint64) (*BlockIndex, *BlockIndex, error) {
+	query := fmt.Sprintf(`SELECT block_number, block_timestamp FROM %s WHERE block_number = $1
+UNION ALL
+(SELECT block_number, block_timestamp FROM %s ORDER BY block_number DESC LIMIT 1)`, BlocksTableName(blockchain), BlocksTableName(blockchain))
+	rows, err := p.GetPool().Query(ctx, query, blockNumber)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var head, block *BlockIndex
+	for rows.Next() {
+		row := BlockIndex{chain: blockchain}
+		if err := rows.Scan(&row.BlockNumber, &row.BlockTimestamp); err != nil {
+			return nil, nil, err
+		}
+		if row.BlockNumber == blockNumber {
+			block = &row
+		}
+		if head == nil || row.BlockNumber > head.BlockNumber {
+			head = &row
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if head == nil || block == nil {
+		return nil, nil, fmt.Errorf("block %d is not indexed", blockNumber)
+	}
+
+	return head, block, nil
+}
+
+// ReadBlocksHashes returns hashes of indexed blocks in range by block number.
+func (p *PostgreSQLpgx) ReadBlocksHashes(ctx context.Context, blockchain string, startBlock, endBlock uint64) (map[uint64]string, error) {
+	conn, err := p.GetPool().Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	query := fmt.Sprintf("SELECT block_number, block_hash FROM %s WHERE block_number >= $1 AND block_number <= $2", BlocksTableName(blockchain))
+	rows, err := conn.Query(ctx, query, startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := make(map[uint64]string)
+	for rows.Next() {
+		var blockNumber uint64
+		var blockHash string
+		if err := rows.Scan(&blockNumber, &blockHash); err != nil {
+			return nil, err
+		}
+		hashes[blockNumber] = blockHash
+	}
+
+	return hashes, rows.Err()
+}
+
+// DeleteOrphanedBlocks removes index rows of blocks which were reorganized out of canonical
+// chain, so blocks and transactions of canonical chain are not skipped by conflict clauses when
+// they are written. Rows of custom index tables are removed if table has block_hash column.
+// Orphaned blocks are recorded in orphaned blocks table, so synchronizer removes their labels.
+func (p *PostgreSQLpgx) DeleteOrphanedBlocks(ctx context.Context, blockchain string, orphaned []BlockIndex) error {
+	blockHashes := make([]string, len(orphaned))
+	for i, block := range orphaned {
+		blockHashes[i] = block.BlockHash
+	}
+
+	conn, err := p.GetPool().Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := deleteBlocksRows(ctx, tx, blockchain, blockHashes, "orphaned"); err != nil {
+		return err
+	}
+
+	if err := recordOrphanedBlocks(ctx, tx, blockchain, orphaned); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// deleteBlocksRows removes rows of blocks with hashes from blocks, transactions, logs and custom
+// index tables which have block_hash column.
+func deleteBlocksRows(ctx context.Context, tx pgx.Tx, blockchain string, blockHashes []string, kind string) error {
+	tables := []string{BlocksTableName(blockchain), TransactionsTableName(blockchain), LogsTableName(blockchain)}
+	for _, table := range GetCustomIndexTables(blockchain) {
+		for _, column := range table.Columns {
+			if column.Name == "block_hash" {
+				tables = append(tables, CustomIndexTableName(blockchain, table.Kind))
+				break
+			}
+		}
+	}
+
+	for _, table := range tables {
+		result, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE block_hash = ANY($1)", table), blockHashes)
+		if err != nil {
+			return fmt.Errorf("failed to delete %s rows from %s: %w", kind, table, err)
+		}
+		log.Printf("Deleted %d %s rows from %s table", result.RowsAffected(), kind, table)
+	}
+
+	return nil
+}
+
 func (p *PostgreSQLpgx) ReadABIJobs(blockchain string) ([]AbiJob, error) {
 	pool := p.GetPool()
 
@@ -773,7 +1012,13 @@ func (p *PostgreSQLpgx) GetCustomersIDs(blockchain string) ([]string, error) {
 	return customerIds, nil
 }
 
-func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, toBlock uint64, customerIds []string) ([]CustomerUpdates, error) {
+// ReadUpdates returns transactions and events of customers ABI jobs and deployments of contracts
+// with constructor ABI jobs in range of blocks. With
+// withUnknownSelectors transactions and events of contracts with ABI jobs which selectors are
+// missing in ABIs of customer are returned too, so they could be decoded with signature databases.
+// Transactions with multicallSelectors are returned for every customer with ABI jobs, so calls
+// batched in them could be decoded.
+func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, toBlock uint64, customerIds []string, withUnknownSelectors bool, multicallSelectors []string) ([]CustomerUpdates, error) {
 
 	pool := p.GetPool()
 
@@ -911,6 +1156,96 @@ func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, toBlock
 			AND events.event_address = jobs.address
             AND events.event_selector = jobs.abi_selector
     ),
+    abi_deployments AS (
+        SELECT
+            transactions.block_number,
+            transactions.block_timestamp,
+            jobs.customer_id,
+            jobs.abi_name,
+            jobs.address_str,
+            transactions.transaction_hash,
+            transactions.transaction_address,
+            transactions.transaction_selector,
+            transactions.transaction_row_id,
+            transactions.transaction_path
+        FROM
+            %s deployments
+            inner JOIN transactions ON transactions.transaction_hash = deployments.transaction_hash
+            inner JOIN jobs ON abi_type = 'constructor'
+            AND jobs.address_str = deployments.address
+        WHERE
+            deployments.block_number >= $1
+            and deployments.block_number <= $2
+    ),
+    job_addresses AS (
+        SELECT DISTINCT
+            address,
+            address_str,
+            customer_id
+        FROM
+            jobs
+    ),
+    unknown_transactions AS (
+        SELECT
+            transactions.block_number,
+            transactions.block_timestamp,
+            job_addresses.customer_id,
+            job_addresses.address_str,
+            transactions.transaction_hash,
+            transactions.transaction_address,
+            transactions.transaction_selector,
+            transactions.transaction_row_id,
+            transactions.transaction_path
+        FROM
+            transactions
+            inner JOIN job_addresses ON $4::boolean
+            AND transactions.transaction_address = job_addresses.address
+        WHERE
+            NOT EXISTS (
+                SELECT 1 FROM jobs
+                WHERE jobs.customer_id = job_addresses.customer_id
+                AND jobs.address = transactions.transaction_address
+                AND jobs.abi_selector = transactions.transaction_selector
+            )
+    ),
+    unknown_events AS (
+        SELECT
+            events.block_number,
+            events.block_timestamp,
+            job_addresses.customer_id,
+            job_addresses.address_str,
+            events.transaction_hash,
+            events.event_address,
+            events.event_selector,
+            events.event_row_id,
+            events.event_path
+        FROM
+            events
+            inner JOIN job_addresses ON $4::boolean
+            AND events.event_address = job_addresses.address
+        WHERE
+            NOT EXISTS (
+                SELECT 1 FROM jobs
+                WHERE jobs.customer_id = job_addresses.customer_id
+                AND jobs.address = events.event_address
+                AND jobs.abi_selector = events.event_selector
+            )
+    ),
+    multicall_transactions AS (
+        SELECT
+            transactions.block_number,
+            transactions.block_timestamp,
+            customers.customer_id,
+            '0x' || encode(transactions.transaction_address, 'hex') AS address_str,
+            transactions.transaction_hash,
+            transactions.transaction_address,
+            transactions.transaction_selector,
+            transactions.transaction_row_id,
+            transactions.transaction_path
+        FROM
+            transactions
+            inner JOIN (SELECT DISTINCT customer_id FROM jobs) customers ON transactions.transaction_selector = ANY($5::text[])
+    ),
     combined AS (
         SELECT
             block_number,
@@ -942,6 +1277,70 @@ func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, toBlock
             event_path AS path
         FROM
             abi_events
+        UNION
+        ALL
+        SELECT
+            block_number,
+            block_timestamp,
+            customer_id,
+            'transaction' AS type,
+            abi_name,
+            address_str,
+            transaction_hash AS hash,
+            transaction_address AS address,
+            transaction_selector AS selector,
+            transaction_row_id AS row_id,
+            transaction_path AS path
+        FROM
+            abi_deployments
+        UNION
+        ALL
+        SELECT
+            block_number,
+            block_timestamp,
+            customer_id,
+            'transaction' AS type,
+            NULL AS abi_name,
+            address_str,
+            transaction_hash AS hash,
+            transaction_address AS address,
+            transaction_selector AS selector,
+            transaction_row_id AS row_id,
+            transaction_path AS path
+        FROM
+            unknown_transactions
+        UNION
+        ALL
+        SELECT
+            block_number,
+            block_timestamp,
+            customer_id,
+            'event' AS type,
+            NULL AS abi_name,
+            address_str,
+            transaction_hash AS hash,
+            event_address AS address,
+            event_selector AS selector,
+            event_row_id AS row_id,
+            event_path AS path
+        FROM
+            unknown_events
+        UNION
+        ALL
+        SELECT
+            block_number,
+            block_timestamp,
+            customer_id,
+            'transaction' AS type,
+            NULL AS abi_name,
+            address_str,
+            transaction_hash AS hash,
+            transaction_address AS address,
+            transaction_selector AS selector,
+            transaction_row_id AS row_id,
+            transaction_path AS path
+        FROM
+            multicall_transactions
     )
     SELECT
         customer_id,
@@ -1003,9 +1402,9 @@ func (p *PostgreSQLpgx) ReadUpdates(blockchain string, fromBlock uint64, toBlock
     FROM
         combined
     GROUP BY
-        customer_id`, blocksTableName, transactionsTableName, logsTableName)
+        customer_id`, blocksTableName, transactionsTableName, logsTableName, contractDeploymentsSource(blockchain))
 
-	rows, err := conn.Query(context.Background(), query, fromBlock, toBlock, blockchain)
+	rows, err := conn.Query(context.Background(), query, fromBlock, toBlock, blockchain, withUnknownSelectors, multicallSelectors)
 
 	if err != nil {
 		log.Println("Error querying abi jobs from database", err)
@@ -1101,14 +1500,14 @@ func (p *PostgreSQLpgx) WriteLabes(
 	}()
 
 	if len(transactions) > 0 {
-		err := p.WriteTransactions(tx, blockchain, transactions)
+		err := writeTransactionLabelsToDB(p.txBatchInserter(tx), blockchain, transactions)
 		if err != nil {
 			return err
 		}
 	}
 
 	if len(events) > 0 {
-		err := p.WriteEvents(tx, blockchain, events)
+		err := writeEventLabelsToDB(p.txBatchInserter(tx), blockchain, events)
 		if err != nil {
 			return err
 		}
@@ -1117,7 +1516,42 @@ func (p *PostgreSQLpgx) WriteLabes(
 	return nil
 }
 
-func (p *PostgreSQLpgx) WriteEvents(tx pgx.Tx, blockchain string, events []EventLabel) error {
+// ReplaceAddressLabels replaces labels of synchronizer for contract at address in range of blocks
+// with labels in one database transaction, so labels of range are never missing for readers. It
+// returns number of removed labels.
+func (p *PostgreSQLpgx) ReplaceAddressLabels(ctx context.Context, blockchain, address string, fromBlock, toBlock uint64, transactions []TransactionLabel, events []EventLabel) (int64, error) {
+	addressBytes, err := decodeAddress(address)
+	if err != nil {
+		return 0, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	tx, err := p.GetPool().Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE address = $1 AND block_number >= $2 AND block_number <= $3 AND label = ANY($4)", LabelsTableName(blockchain))
+	result, err := tx.Exec(ctx, query, addressBytes, fromBlock, toBlock, []string{SeerCrawlerLabel, SeerCrawlerRawLabel, SeerCrawlerPartialLabel})
+	if err != nil {
+		return 0, err
+	}
+
+	if len(transactions) > 0 {
+		if err := writeTransactionLabelsToDB(p.txBatchInserter(tx), blockchain, transactions); err != nil {
+			return 0, err
+		}
+	}
+	if len(events) > 0 {
+		if err := writeEventLabelsToDB(p.txBatchInserter(tx), blockchain, events); err != nil {
+			return 0, err
+		}
+	}
+
+	return result.RowsAffected(), tx.Commit(ctx)
+}
+
+func writeEventLabelsToDB(insert batchInserter, blockchain string, events []EventLabel) error {
 
 	tableName := LabelsTableName(blockchain)
 	columns := []string{"id", "label", "transaction_hash", "log_index", "block_number", "block_hash", "block_timestamp", "caller_address", "origin_address", "address", "label_name", "label_type", "label_data"}
@@ -1228,7 +1662,7 @@ func (p *PostgreSQLpgx) WriteEvents(tx pgx.Tx, blockchain string, events []Event
 
 	ctx := context.Background()
 
-	err := p.executeBatchInsert(tx, ctx, tableName, columns, valuesMap, "ON CONFLICT DO NOTHING")
+	err := insert(ctx, tableName, columns, valuesMap, "ON CONFLICT DO NOTHING")
 
 	if err != nil {
 		return err
@@ -1239,9 +1673,9 @@ func (p *PostgreSQLpgx) WriteEvents(tx pgx.Tx, blockchain string, events []Event
 	return nil
 }
 
-func (p *PostgreSQLpgx) WriteTransactions(tx pgx.Tx, blockchain string, transactions []TransactionLabel) error {
+func writeTransactionLabelsToDB(insert batchInserter, blockchain string, transactions []TransactionLabel) error {
 	tableName := LabelsTableName(blockchain)
-	columns := []string{"id", "address", "block_number", "block_hash", "caller_address", "label_name", "label_type", "origin_address", "label", "transaction_hash", "label_data", "block_timestamp"}
+	columns := []string{"id", "address", "block_number", "block_hash", "caller_address", "label_name", "label_type", "origin_address", "label", "transaction_hash", "label_data", "block_timestamp", "call_index"}
 
 	var valuesMap = make(map[string]UnnestInsertValueStruct)
 
@@ -1305,6 +1739,11 @@ func (p *PostgreSQLpgx) WriteTransactions(tx pgx.Tx, blockchain string, transact
 		Values: make([]interface{}, 0),
 	}
 
+	valuesMap["call_index"] = UnnestInsertValueStruct{
+		Type:   "TEXT",
+		Values: make([]interface{}, 0),
+	}
+
 	for _, transaction := range transactions {
 
 		id := uuid.New()
@@ -1339,12 +1778,13 @@ func (p *PostgreSQLpgx) WriteTransactions(tx pgx.Tx, blockchain string, transact
 		updateValues(valuesMap, "transaction_hash", transaction.TransactionHash)
 		updateValues(valuesMap, "label_data", transaction.LabelData)
 		updateValues(valuesMap, "block_timestamp", transaction.BlockTimestamp)
+		updateValues(valuesMap, "call_index", transaction.CallIndex)
 
 	}
 
 	ctx := context.Background()
 
-	err := p.executeBatchInsert(tx, ctx, tableName, columns, valuesMap, "ON CONFLICT DO NOTHING")
+	err := insert(ctx, tableName, columns, valuesMap, "ON CONFLICT DO NOTHING")
 
 	if err != nil {
 		return err
@@ -1354,3 +1794,70 @@ func (p *PostgreSQLpgx) WriteTransactions(tx pgx.Tx, blockchain string, transact
 
 	return nil
 }
+
+// ArchiveIndexTables moves index tables of decommissioned chain with their partitions to archive
+// schema and, if tablespace is set, to tablespace on cold storage. Missing tables are skipped, names
+// of moved tables are returned.
+func (p *PostgreSQLpgx) ArchiveIndexTables(ctx context.Context, tables []string, schema, tablespace string) ([]string, error) {
+	conn, err := p.GetPool().Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf("CREATE SCHEMA IF NOT EXISTS %s", pgx.Identifier{schema}.Sanitize())); err != nil {
+		return nil, fmt.Errorf("failed to create archive schema %s: %w", schema, err)
+	}
+
+	archiveProgress := progress.NewTracker(fmt.Sprintf("archive tables to %s", schema), uint64(len(tables)))
+	defer archiveProgress.Finish()
+
+	var archived []string
+	for _, table := range tables {
+		var exists bool
+		if err := tx.QueryRow(ctx, "SELECT to_regclass($1) IS NOT NULL", table).Scan(&exists); err != nil {
+			return nil, err
+		}
+		if !exists {
+			log.Printf("Table %s does not exist, skipping", table)
+			archiveProgress.Add(1)
+			continue
+		}
+
+		// Partitions are moved separately, schema of partitioned table is not inherited
+		rows, err := tx.Query(ctx, "SELECT child.relname FROM pg_inherits JOIN pg_class child ON child.oid = pg_inherits.inhrelid WHERE pg_inherits.inhparent = to_regclass($1)", table)
+		if err != nil {
+			return nil, err
+		}
+		relations, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil {
+			return nil, err
+		}
+		relations = append(relations, table)
+
+		for _, relation := range relations {
+			if tablespace != "" {
+				if _, err := tx.Exec(ctx, fmt.Sprintf("ALTER TABLE %s SET TABLESPACE %s", pgx.Identifier{relation}.Sanitize(), pgx.Identifier{tablespace}.Sanitize())); err != nil {
+					return nil, fmt.Errorf("failed to move %s to tablespace %s: %w", relation, tablespace, err)
+				}
+			}
+			if _, err := tx.Exec(ctx, fmt.Sprintf("ALTER TABLE %s SET SCHEMA %s", pgx.Identifier{relation}.Sanitize(), pgx.Identifier{schema}.Sanitize())); err != nil {
+				return nil, fmt.Errorf("failed to move %s to schema %s: %w", relation, schema, err)
+			}
+			archived = append(archived, relation)
+		}
+		archiveProgress.Add(1)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return archived, nil
+}
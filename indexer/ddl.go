@@ -0,0 +1,90 @@
+package indexer
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/moonstream-to/seer/migrations"
+)
+
+var conflictColumnsRe = regexp.MustCompile(`(?i)ON\s+CONFLICT\s*\(([^)]*)\)`)
+
+type ddlColumn struct {
+	name       string
+	definition string
+}
+
+func createTableDDL(tableName string, columns []ddlColumn, constraint string, indexes [][]string) string {
+	var ddl strings.Builder
+
+	fmt.Fprintf(&ddl, "CREATE TABLE IF NOT EXISTS %s (\n", tableName)
+	for i, column := range columns {
+		fmt.Fprintf(&ddl, "    %s %s", column.name, column.definition)
+		if i < len(columns)-1 || constraint != "" {
+			ddl.WriteString(",")
+		}
+		ddl.WriteString("\n")
+	}
+	if constraint != "" {
+		fmt.Fprintf(&ddl, "    %s\n", constraint)
+	}
+	ddl.WriteString(");\n")
+
+	for _, indexColumns := range indexes {
+		fmt.Fprintf(&ddl, "\nCREATE INDEX IF NOT EXISTS ix_%s_%s ON %s (%s);\n", tableName, strings.Join(indexColumns, "_"), tableName, strings.Join(indexColumns, ", "))
+	}
+
+	return ddl.String()
+}
+
+// IndexTablesDDL returns PostgreSQL DDL of blocks, transactions, logs and custom index tables of
+// blockchain. Tables except custom ones are rendered from migrations of indexes, so databases of
+// all dialects and generated DDL get the same schema. Statements are idempotent, so DDL could be
+// applied to database where some of tables already exist.
+func IndexTablesDDL(blockchain string, withL1Chain bool, customTables []CustomIndexTable) (string, error) {
+	ddl, err := migrations.Schema(migrations.IndexesTarget, blockchain, withL1Chain)
+	if err != nil {
+		return "", err
+	}
+
+	if customDDL := CustomIndexTablesDDL(blockchain, customTables); customDDL != "" {
+		ddl = ddl + "\n" + customDDL
+	}
+
+	return ddl, nil
+}
+
+// CustomIndexTablesDDL returns PostgreSQL DDL of custom index tables of blockchain, it is empty
+// when there are no custom tables.
+func CustomIndexTablesDDL(blockchain string, customTables []CustomIndexTable) string {
+	customTables = append([]CustomIndexTable(nil), customTables...)
+	sort.Slice(customTables, func(i, j int) bool { return customTables[i].Kind < customTables[j].Kind })
+
+	var statements []string
+	for _, table := range customTables {
+		var columns []ddlColumn
+		for _, column := range table.Columns {
+			columns = append(columns, ddlColumn{column.Name, column.Type})
+		}
+		columns = append(columns, ddlColumn{"path", "TEXT NOT NULL"})
+
+		// Unique constraint is required by ON CONFLICT clause of inserts
+		var constraint string
+		if match := conflictColumnsRe.FindStringSubmatch(table.ConflictClause); match != nil {
+			constraint = fmt.Sprintf("UNIQUE (%s)", strings.TrimSpace(match[1]))
+		}
+
+		statements = append(statements, createTableDDL(CustomIndexTableName(blockchain, table.Kind), columns, constraint, nil))
+	}
+
+	return strings.Join(statements, "\n")
+}
+
+// LabelsTableDDL returns PostgreSQL DDL of labels table of blockchain rendered from migrations of
+// labels. Labels tables of PostgreSQL customer databases are created by the migrations, DDL is
+// applied to customer databases of other SQL dialects.
+func LabelsTableDDL(blockchain string) (string, error) {
+	return migrations.Schema(migrations.LabelsTarget, blockchain, false)
+}
@@ -0,0 +1,73 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// FailedRangesTableName is dead-letter table with ranges of blocks crawler failed to crawl.
+const FailedRangesTableName = "seer_failed_ranges"
+
+// FailedRangesTableDDL creates dead-letter table, crawler applies it when dead-letter is enabled.
+var FailedRangesTableDDL = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    blockchain TEXT NOT NULL,
+    start_block BIGINT NOT NULL,
+    end_block BIGINT NOT NULL,
+    error TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (blockchain, start_block, end_block)
+);
+`, FailedRangesTableName)
+
+// FailedRange is range of blocks which crawler failed to crawl, both ends are included.
+type FailedRange struct {
+	Blockchain string
+	StartBlock uint64
+	EndBlock   uint64
+	Error      string
+	Attempts   int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// EnsureFailedRangesTable creates dead-letter table if it does not exist.
+func (p *PostgreSQLpgx) EnsureFailedRangesTable(ctx context.Context) error {
+	_, err := p.GetPool().Exec(ctx, FailedRangesTableDDL)
+	return err
+}
+
+// RecordFailedRange adds range to dead-letter table, attempts of range which is already there
+// are increased and its error is replaced.
+func (p *PostgreSQLpgx) RecordFailedRange(ctx context.Context, blockchain string, startBlock, endBlock uint64, errorMessage string) error {
+	query := fmt.Sprintf(`INSERT INTO %s (blockchain, start_block, end_block, error) VALUES ($1, $2, $3, $4)
+ON CONFLICT (blockchain, start_block, end_block) DO UPDATE SET error = EXCLUDED.error, attempts = %s.attempts + 1, updated_at = now()`, FailedRangesTableName, FailedRangesTableName)
+	_, err := p.GetPool().Exec(ctx, query, blockchain, startBlock, endBlock, errorMessage)
+	return err
+}
+
+// ReadFailedRanges returns ranges of blockchain from dead-letter table in blocks order.
+func (p *PostgreSQLpgx) ReadFailedRanges(ctx context.Context, blockchain string) ([]FailedRange, error) {
+	query := fmt.Sprintf("SELECT blockchain, start_block, end_block, error, attempts, created_at, updated_at FROM %s WHERE blockchain = $1 ORDER BY start_block", FailedRangesTableName)
+	rows, err := p.GetPool().Query(ctx, query, blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FailedRange, error) {
+		var failedRange FailedRange
+		err := row.Scan(&failedRange.Blockchain, &failedRange.StartBlock, &failedRange.EndBlock, &failedRange.Error, &failedRange.Attempts, &failedRange.CreatedAt, &failedRange.UpdatedAt)
+		return failedRange, err
+	})
+}
+
+// DeleteFailedRange removes range crawled on retry from dead-letter table.
+func (p *PostgreSQLpgx) DeleteFailedRange(ctx context.Context, blockchain string, startBlock, endBlock uint64) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE blockchain = $1 AND start_block = $2 AND end_block = $3", FailedRangesTableName)
+	_, err := p.GetPool().Exec(ctx, query, blockchain, startBlock, endBlock)
+	return err
+}
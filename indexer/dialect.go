@@ -0,0 +1,90 @@
+package indexer
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Dialect is SQL dialect of indexes and labels databases accessed with database/sql instead of
+// pgx. DDL and conflict clauses of indexer are written for PostgreSQL, dialect converts them.
+// Queries of all dialects use ? placeholders.
+type Dialect interface {
+	// Name is name of dialect in logs and errors.
+	Name() string
+	// DDL converts PostgreSQL DDL of indexer to statements of dialect.
+	DDL(ddl string) []string
+	// IsExistsError reports whether DDL statement failed because object already exists.
+	IsExistsError(err error) bool
+	// QuoteIdentifier quotes name of column, names of indexer columns include keywords.
+	QuoteIdentifier(name string) string
+	// InsertQuery returns statement which inserts rows of values of columns into table, rows which
+	// conflict with existing rows are handled as by PostgreSQL conflict clause.
+	InsertQuery(tableName string, columns []string, rows int, conflictClause string) string
+}
+
+// ddlTypeMapping replaces PostgreSQL type in definitions of columns with type of dialect.
+type ddlTypeMapping struct {
+	re          *regexp.Regexp
+	replacement string
+}
+
+func newDDLTypeMapping(pattern, replacement string) ddlTypeMapping {
+	return ddlTypeMapping{re: regexp.MustCompile(`(?i)` + pattern), replacement: replacement}
+}
+
+var (
+	ddlColumnRe = regexp.MustCompile(`(?m)^([ \t]+)(\w+) (.*)$`)
+	// ddlAddedColumnRe matches column added to table by ALTER TABLE of migrations
+	ddlAddedColumnRe = regexp.MustCompile(`(?i)\b(ADD COLUMN(?: IF NOT EXISTS)?) (\w+) ([^;\n]*)`)
+	// ddlAddColumnRe matches ADD COLUMN of migrations, dialects without IF NOT EXISTS of columns
+	// report existing column as exists error instead
+	ddlAddColumnRe = regexp.MustCompile(`(?i)\bADD COLUMN IF NOT EXISTS\b`)
+)
+
+// convertDDL quotes names of columns of DDL of migrations and custom index tables, converts
+// types of their definitions and splits DDL into statements.
+func convertDDL(ddl string, dialect Dialect, mappings []ddlTypeMapping) []string {
+	convertDefinition := func(definition string) string {
+		for _, mapping := range mappings {
+			definition = mapping.re.ReplaceAllString(definition, mapping.replacement)
+		}
+		return definition
+	}
+
+	ddl = ddlColumnRe.ReplaceAllStringFunc(ddl, func(line string) string {
+		match := ddlColumnRe.FindStringSubmatch(line)
+		switch strings.ToUpper(match[2]) {
+		case "PRIMARY", "UNIQUE", "CONSTRAINT":
+			return line
+		}
+
+		return fmt.Sprintf("%s%s %s", match[1], dialect.QuoteIdentifier(match[2]), convertDefinition(match[3]))
+	})
+	ddl = ddlAddedColumnRe.ReplaceAllStringFunc(ddl, func(clause string) string {
+		match := ddlAddedColumnRe.FindStringSubmatch(clause)
+		return fmt.Sprintf("%s %s %s", match[1], dialect.QuoteIdentifier(match[2]), convertDefinition(match[3]))
+	})
+
+	var statements []string
+	for _, statement := range strings.Split(ddl, ";") {
+		if statement = strings.TrimSpace(statement); statement != "" {
+			statements = append(statements, statement)
+		}
+	}
+
+	return statements
+}
+
+// insertColumnsAndValues returns quoted list of columns and placeholders of rows of insert.
+func insertColumnsAndValues(dialect Dialect, columns []string, rows int) (string, string) {
+	quotedColumns := make([]string, len(columns))
+	for i, column := range columns {
+		quotedColumns[i] = dialect.QuoteIdentifier(column)
+	}
+
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ") + ")"
+	values := strings.TrimSuffix(strings.Repeat(row+", ", rows), ", ")
+
+	return strings.Join(quotedColumns, ", "), values
+}
@@ -0,0 +1,110 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CrawlerLeadersTableName is table with replica of crawler which holds leadership per chain.
+const CrawlerLeadersTableName = "seer_crawler_leaders"
+
+// CrawlerLeadersTableDDL creates leaders table, crawler replicas apply it on start.
+var CrawlerLeadersTableDDL = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    blockchain TEXT NOT NULL,
+    leader TEXT NOT NULL,
+    leased_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (blockchain)
+);
+`, CrawlerLeadersTableName)
+
+// ErrLeadershipLost is returned when lease of leadership expired and it was taken by other replica.
+var ErrLeadershipLost = errors.New("leadership of crawler is lost")
+
+// CrawlerLeader is replica which holds leadership of chain until lease expires.
+type CrawlerLeader struct {
+	Blockchain  string
+	Leader      string
+	LeasedUntil time.Time
+}
+
+// EnsureCrawlerLeadersTable creates leaders table if it does not exist.
+func (p *PostgreSQLpgx) EnsureCrawlerLeadersTable(ctx context.Context) error {
+	_, err := p.GetPool().Exec(ctx, CrawlerLeadersTableDDL)
+	return err
+}
+
+// AcquireLeadership makes replica leader of blockchain for lease duration if there is no leader,
+// lease of leader expired or replica is already leader. It returns true when replica is leader.
+func (p *PostgreSQLpgx) AcquireLeadership(ctx context.Context, blockchain, replica string, leaseDuration time.Duration) (bool, error) {
+	query := fmt.Sprintf(`INSERT INTO %s (blockchain, leader, leased_until, updated_at) VALUES ($1, $2, now() + make_interval(secs => $3), now())
+ON CONFLICT (blockchain) DO UPDATE SET leader = EXCLUDED.leader, leased_until = EXCLUDED.leased_until, updated_at = EXCLUDED.updated_at
+WHERE %s.leader = EXCLUDED.leader OR %s.leased_until < now()`, CrawlerLeadersTableName, CrawlerLeadersTableName, CrawlerLeadersTableName)
+
+	tag, err := p.GetPool().Exec(ctx, query, blockchain, replica, leaseDuration.Seconds())
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// HeartbeatLeadership extends lease of leadership held by replica, ErrLeadershipLost is returned
+// if other replica is leader.
+func (p *PostgreSQLpgx) HeartbeatLeadership(ctx context.Context, blockchain, replica string, leaseDuration time.Duration) error {
+	query := fmt.Sprintf(`UPDATE %s SET leased_until = now() + make_interval(secs => $3), updated_at = now()
+WHERE blockchain = $1 AND leader = $2`, CrawlerLeadersTableName)
+
+	tag, err := p.GetPool().Exec(ctx, query, blockchain, replica, leaseDuration.Seconds())
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrLeadershipLost
+	}
+
+	return nil
+}
+
+// leaseFenceQuery locks row of leadership held by replica with valid lease. Writes of replica are
+// done in the same transaction, so standby could not take over until they are committed.
+var leaseFenceQuery = fmt.Sprintf("SELECT leader FROM %s WHERE blockchain = $1 AND leader = $2 AND leased_until > now() FOR SHARE", CrawlerLeadersTableName)
+
+// checkLeadership returns ErrLeadershipLost if replica does not hold valid lease of leadership of
+// blockchain in transaction.
+func checkLeadership(ctx context.Context, tx pgx.Tx, blockchain, replica string) error {
+	var leader string
+	err := tx.QueryRow(ctx, leaseFenceQuery, blockchain, replica).Scan(&leader)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrLeadershipLost
+	}
+	return err
+}
+
+// ReleaseLeadership expires lease of leadership held by replica, so standby replica takes over
+// without waiting for lease to expire.
+func (p *PostgreSQLpgx) ReleaseLeadership(ctx context.Context, blockchain, replica string) error {
+	query := fmt.Sprintf("UPDATE %s SET leased_until = now(), updated_at = now() WHERE blockchain = $1 AND leader = $2", CrawlerLeadersTableName)
+	_, err := p.GetPool().Exec(ctx, query, blockchain, replica)
+	return err
+}
+
+// ReadCrawlerLeader returns leader of blockchain, nil if no replica was leader yet.
+func (p *PostgreSQLpgx) ReadCrawlerLeader(ctx context.Context, blockchain string) (*CrawlerLeader, error) {
+	leader := CrawlerLeader{Blockchain: blockchain}
+
+	query := fmt.Sprintf("SELECT leader, leased_until FROM %s WHERE blockchain = $1", CrawlerLeadersTableName)
+	err := p.GetPool().QueryRow(ctx, query, blockchain).Scan(&leader.Leader, &leader.LeasedUntil)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &leader, nil
+}
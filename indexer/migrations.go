@@ -0,0 +1,113 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SchemaMigrationsTableName is table with migrations applied to database per target and
+// blockchain.
+const SchemaMigrationsTableName = "seer_schema_migrations"
+
+// SchemaMigrationsTableDDL creates schema migrations table, migrator applies it on start.
+var SchemaMigrationsTableDDL = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    target TEXT NOT NULL,
+    blockchain TEXT NOT NULL,
+    version INT NOT NULL,
+    name TEXT NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (target, blockchain, version)
+);
+`, SchemaMigrationsTableName)
+
+// EnsureSchemaMigrationsTable creates schema migrations table if it does not exist.
+func (p *PostgreSQLpgx) EnsureSchemaMigrationsTable(ctx context.Context) error {
+	_, err := p.GetPool().Exec(ctx, SchemaMigrationsTableDDL)
+	return err
+}
+
+// appliedMigration is migration recorded in schema migrations table.
+type appliedMigration struct {
+	version   int
+	appliedAt time.Time
+}
+
+// ReadAppliedMigrations returns time migrations of target were applied for blockchain at by
+// version.
+func (p *PostgreSQLpgx) ReadAppliedMigrations(ctx context.Context, target, blockchain string) (map[int]time.Time, error) {
+	query := fmt.Sprintf("SELECT version, applied_at FROM %s WHERE target = $1 AND blockchain = $2", SchemaMigrationsTableName)
+	rows, err := p.GetPool().Query(ctx, query, target, blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (appliedMigration, error) {
+		var migration appliedMigration
+		err := row.Scan(&migration.version, &migration.appliedAt)
+		return migration, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int]time.Time, len(migrations))
+	for _, migration := range migrations {
+		applied[migration.version] = migration.appliedAt
+	}
+
+	return applied, nil
+}
+
+// ApplyMigration executes SQL of migration and records it as applied, or as reverted when up is
+// false, in single transaction. Concurrent migrators wait for each other on advisory lock, so
+// migration is skipped and false is returned when it was already applied or reverted.
+func (p *PostgreSQLpgx) ApplyMigration(ctx context.Context, target, blockchain string, version int, name, query string, up bool) (bool, error) {
+	tx, err := p.GetPool().Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", SchemaMigrationsTableName); err != nil {
+		return false, fmt.Errorf("failed to lock migrations: %w", err)
+	}
+
+	var applied bool
+	checkQuery := fmt.Sprintf("SELECT EXISTS (SELECT 1 FROM %s WHERE target = $1 AND blockchain = $2 AND version = $3)", SchemaMigrationsTableName)
+	if err := tx.QueryRow(ctx, checkQuery, target, blockchain, version).Scan(&applied); err != nil {
+		return false, err
+	}
+	if applied == up {
+		return false, nil
+	}
+
+	if _, err := tx.Exec(ctx, query); err != nil {
+		return false, err
+	}
+
+	if up {
+		_, err = tx.Exec(ctx, fmt.Sprintf("INSERT INTO %s (target, blockchain, version, name) VALUES ($1, $2, $3, $4)", SchemaMigrationsTableName), target, blockchain, version, name)
+	} else {
+		_, err = tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE target = $1 AND blockchain = $2 AND version = $3", SchemaMigrationsTableName), target, blockchain, version)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit(ctx)
+}
+
+// EnsureCustomIndexTables creates custom index tables registered for blockchain if they do not
+// exist.
+func (p *PostgreSQLpgx) EnsureCustomIndexTables(ctx context.Context, blockchain string) error {
+	ddl := CustomIndexTablesDDL(blockchain, GetCustomIndexTables(blockchain))
+	if ddl == "" {
+		return nil
+	}
+
+	_, err := p.GetPool().Exec(ctx, ddl)
+	return err
+}
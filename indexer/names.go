@@ -0,0 +1,143 @@
+package indexer
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AddressNamesTableName is table of names of addresses resolved from reverse records of name
+// services, it is lookup table for dashboards and cache of name enricher.
+const AddressNamesTableName = "seer_address_names"
+
+// AddressNamesTableDDL creates names table, name enricher applies it on start. Addresses without
+// name are kept with empty name, so they are not resolved again until record is stale.
+var AddressNamesTableDDL = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    blockchain TEXT NOT NULL,
+    address BYTEA NOT NULL,
+    name TEXT NOT NULL,
+    service TEXT NOT NULL,
+    resolved_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (blockchain, address)
+);
+CREATE INDEX IF NOT EXISTS ix_%s_name ON %s (name) WHERE name <> '';
+`, AddressNamesTableName, AddressNamesTableName, AddressNamesTableName)
+
+// AddressName is name of address of blockchain in name service, empty when address has no name.
+type AddressName struct {
+	Address    string
+	Name       string
+	Service    string
+	ResolvedAt time.Time
+}
+
+// EnsureAddressNamesTable creates names table if it does not exist.
+func (p *PostgreSQLpgx) EnsureAddressNamesTable(ctx context.Context) error {
+	_, err := p.GetPool().Exec(ctx, AddressNamesTableDDL)
+	return err
+}
+
+// ReadAddressesToName returns senders and recipients of at least minActivity transactions since
+// fromBlock which were never resolved or were resolved before staleBefore, the most active first.
+func (p *PostgreSQLpgx) ReadAddressesToName(ctx context.Context, blockchain string, fromBlock, minActivity uint64, staleBefore time.Time, limit int) ([]AddressActivity, error) {
+	query := fmt.Sprintf(`WITH activity AS (
+        SELECT tx.from_address AS address, count(*) AS transactions
+        FROM %s tx
+        WHERE tx.block_number >= $1 AND tx.from_address IS NOT NULL
+        GROUP BY tx.from_address
+        UNION ALL
+        SELECT tx.to_address AS address, count(*) AS transactions
+        FROM %s tx
+        WHERE tx.block_number >= $1 AND tx.to_address IS NOT NULL
+        GROUP BY tx.to_address
+    )
+    SELECT
+        '0x' || encode(address, 'hex'),
+        sum(transactions)::BIGINT
+    FROM
+        activity
+    WHERE
+        NOT EXISTS (
+            SELECT 1 FROM %s names
+            WHERE names.blockchain = $2 AND names.address = activity.address AND names.resolved_at >= $3
+        )
+    GROUP BY
+        address
+    HAVING
+        sum(transactions) >= $4
+    ORDER BY
+        sum(transactions) DESC
+    LIMIT $5`, TransactionsTableName(blockchain), TransactionsTableName(blockchain), AddressNamesTableName)
+
+	rows, err := p.GetPool().Query(ctx, query, fromBlock, blockchain, staleBefore, minActivity, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (AddressActivity, error) {
+		var activity AddressActivity
+		err := row.Scan(&activity.Address, &activity.Transactions)
+		return activity, err
+	})
+}
+
+// WriteAddressNames inserts names of addresses of blockchain or replaces names resolved before.
+func (p *PostgreSQLpgx) WriteAddressNames(ctx context.Context, blockchain string, names []AddressName) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	addresses := make([][]byte, 0, len(names))
+	values := make([]string, 0, len(names))
+	services := make([]string, 0, len(names))
+	for _, name := range names {
+		address, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(name.Address), "0x"))
+		if err != nil {
+			return fmt.Errorf("invalid address %s: %w", name.Address, err)
+		}
+		addresses = append(addresses, address)
+		values = append(values, name.Name)
+		services = append(services, name.Service)
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (blockchain, address, name, service, resolved_at)
+SELECT $1, address, name, service, now() FROM unnest($2::BYTEA[], $3::TEXT[], $4::TEXT[]) AS names(address, name, service)
+ON CONFLICT (blockchain, address) DO UPDATE SET name = EXCLUDED.name, service = EXCLUDED.service, resolved_at = EXCLUDED.resolved_at`, AddressNamesTableName)
+	_, err := p.GetPool().Exec(ctx, query, blockchain, addresses, values, services)
+	return err
+}
+
+// ReadAddressNames returns names of addresses of blockchain which have name, keys are lowercase
+// addresses.
+func (p *PostgreSQLpgx) ReadAddressNames(ctx context.Context, blockchain string, addresses []string) (map[string]string, error) {
+	addressesBytes := make([][]byte, 0, len(addresses))
+	for _, address := range addresses {
+		addressBytes, err := hex.DecodeString(strings.TrimPrefix(strings.ToLower(address), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %s: %w", address, err)
+		}
+		addressesBytes = append(addressesBytes, addressBytes)
+	}
+
+	query := fmt.Sprintf("SELECT '0x' || encode(address, 'hex'), name FROM %s WHERE blockchain = $1 AND address = ANY($2) AND name <> ''", AddressNamesTableName)
+	rows, err := p.GetPool().Query(ctx, query, blockchain, addressesBytes)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make(map[string]string)
+	for rows.Next() {
+		var address, name string
+		if err := rows.Scan(&address, &name); err != nil {
+			return nil, err
+		}
+		names[address] = name
+	}
+
+	return names, rows.Err()
+}
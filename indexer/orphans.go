@@ -0,0 +1,98 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// OrphanedBlocksTableName is table with blocks crawler removed from indexes because they were
+// reorganized out of canonical chain. Synchronizer removes labels of them from customer databases.
+const OrphanedBlocksTableName = "seer_orphaned_blocks"
+
+// OrphanedBlocksTableDDL creates orphaned blocks table, it is applied with the first removal of
+// orphaned blocks and by synchronizer on start.
+var OrphanedBlocksTableDDL = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    id BIGSERIAL PRIMARY KEY,
+    blockchain TEXT NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    detected_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    labels_invalidated_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX IF NOT EXISTS ix_%s_pending ON %s (blockchain) WHERE labels_invalidated_at IS NULL;
+`, OrphanedBlocksTableName, OrphanedBlocksTableName, OrphanedBlocksTableName)
+
+// OrphanedBlock is block removed from indexes by reorganization.
+type OrphanedBlock struct {
+	ID          int64
+	BlockNumber uint64
+	BlockHash   string
+}
+
+// EnsureOrphanedBlocksTable creates orphaned blocks table if it does not exist.
+func (p *PostgreSQLpgx) EnsureOrphanedBlocksTable(ctx context.Context) error {
+	_, err := p.GetPool().Exec(ctx, OrphanedBlocksTableDDL)
+	return err
+}
+
+// recordOrphanedBlocks adds orphaned blocks to orphaned blocks table in transaction which
+// removes their index rows.
+func recordOrphanedBlocks(ctx context.Context, tx pgx.Tx, blockchain string, orphaned []BlockIndex) error {
+	if _, err := tx.Exec(ctx, OrphanedBlocksTableDDL); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", OrphanedBlocksTableName, err)
+	}
+
+	blockNumbers := make([]int64, len(orphaned))
+	blockHashes := make([]string, len(orphaned))
+	for i, block := range orphaned {
+		blockNumbers[i] = int64(block.BlockNumber)
+		blockHashes[i] = block.BlockHash
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (blockchain, block_number, block_hash) SELECT $1, * FROM unnest($2::BIGINT[], $3::TEXT[])", OrphanedBlocksTableName)
+	if _, err := tx.Exec(ctx, query, blockchain, blockNumbers, blockHashes); err != nil {
+		return fmt.Errorf("failed to record orphaned blocks: %w", err)
+	}
+
+	return nil
+}
+
+// ReadOrphanedBlocks returns orphaned blocks of blockchain which labels were not invalidated yet.
+func (p *PostgreSQLpgx) ReadOrphanedBlocks(ctx context.Context, blockchain string) ([]OrphanedBlock, error) {
+	query := fmt.Sprintf("SELECT id, block_number, block_hash FROM %s WHERE blockchain = $1 AND labels_invalidated_at IS NULL ORDER BY id", OrphanedBlocksTableName)
+	rows, err := p.GetPool().Query(ctx, query, blockchain)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orphaned []OrphanedBlock
+	for rows.Next() {
+		var block OrphanedBlock
+		if err := rows.Scan(&block.ID, &block.BlockNumber, &block.BlockHash); err != nil {
+			return nil, err
+		}
+		orphaned = append(orphaned, block)
+	}
+
+	return orphaned, rows.Err()
+}
+
+// MarkOrphanedBlocksInvalidated records that labels of orphaned blocks with ids were removed.
+func (p *PostgreSQLpgx) MarkOrphanedBlocksInvalidated(ctx context.Context, ids []int64) error {
+	query := fmt.Sprintf("UPDATE %s SET labels_invalidated_at = now() WHERE id = ANY($1)", OrphanedBlocksTableName)
+	_, err := p.GetPool().Exec(ctx, query, ids)
+	return err
+}
+
+// DeleteLabelsOfBlocks removes labels of blocks with hashes from customer database, it returns
+// number of removed labels.
+func (p *PostgreSQLpgx) DeleteLabelsOfBlocks(ctx context.Context, blockchain string, blockHashes []string) (int64, error) {
+	result, err := p.GetPool().Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE block_hash = ANY($1)", LabelsTableName(blockchain)), blockHashes)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
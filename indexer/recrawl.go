@@ -0,0 +1,100 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+)
+
+// RewriteBlocksIndexes replaces index rows of blocks with rows of recrawled blocks in single
+// transaction. Rows of indexed blocks with the same numbers and rows of recrawled block hashes
+// are removed first, so corrected rows are not skipped by conflict clauses of inserts.
+func (p *PostgreSQLpgx) RewriteBlocksIndexes(ctx context.Context, blockchain string, blockNumbers []uint64, blocksIndexPack []BlockIndex, transactionsIndexPack []TransactionIndex, logsIndexPack []LogIndex, customIndexPack []CustomIndex) error {
+	conn, err := p.GetPool().Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Release()
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := fmt.Sprintf("SELECT block_hash FROM %s WHERE block_number = ANY($1)", BlocksTableName(blockchain))
+	rows, err := tx.Query(ctx, query, blockNumbers)
+	if err != nil {
+		return fmt.Errorf("failed to read indexed blocks: %w", err)
+	}
+
+	var blockHashes []string
+	for rows.Next() {
+		var blockHash string
+		if err := rows.Scan(&blockHash); err != nil {
+			rows.Close()
+			return err
+		}
+		blockHashes = append(blockHashes, blockHash)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, block := range blocksIndexPack {
+		blockHashes = append(blockHashes, block.BlockHash)
+	}
+
+	if err := deleteBlocksRows(ctx, tx, blockchain, blockHashes, "stale"); err != nil {
+		return err
+	}
+
+	if len(blocksIndexPack) > 0 {
+		if err := writeBlockIndexToDB(p.txBatchInserter(tx), blockchain, blocksIndexPack); err != nil {
+			return err
+		}
+	}
+
+	if len(transactionsIndexPack) > 0 {
+		if err := writeTransactionIndexToDB(p.txBatchInserter(tx), blockchain, transactionsIndexPack); err != nil {
+			return err
+		}
+	}
+
+	if len(logsIndexPack) > 0 {
+		if err := writeLogIndexToDB(p.txBatchInserter(tx), blockchain, logsIndexPack); err != nil {
+			return err
+		}
+	}
+
+	if len(customIndexPack) > 0 {
+		if err := writeCustomIndexToDB(p.txBatchInserter(tx), blockchain, customIndexPack); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ReadBlocksPacksPaths returns paths of protos of packs indexed blocks belong to by numbers of
+// blocks, blocks which are not indexed are missing in result.
+func (p *PostgreSQLpgx) ReadBlocksPacksPaths(ctx context.Context, blockchain string, blockNumbers []uint64) (map[uint64]string, error) {
+	query := fmt.Sprintf("SELECT block_number, path FROM %s WHERE block_number = ANY($1)", BlocksTableName(blockchain))
+	rows, err := p.GetPool().Query(ctx, query, blockNumbers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read packs of blocks: %w", err)
+	}
+	defer rows.Close()
+
+	paths := make(map[uint64]string)
+	for rows.Next() {
+		var blockNumber uint64
+		var path string
+		if err := rows.Scan(&blockNumber, &path); err != nil {
+			return nil, err
+		}
+		paths[blockNumber] = path
+	}
+
+	return paths, rows.Err()
+}
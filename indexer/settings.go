@@ -11,6 +11,10 @@ var (
 	SeerCrawlerLabel             string
 	MOONSTREAM_DB_V3_INDEXES_URI string
 	SeerCrawlerRawLabel          string
+
+	// SeerCrawlerPartialLabel marks labels decoded with signatures of selectors missing in ABIs,
+	// arguments of such labels are not named
+	SeerCrawlerPartialLabel string
 )
 
 func CheckVariablesForIndexer() error {
@@ -20,6 +24,7 @@ func CheckVariablesForIndexer() error {
 	}
 
 	SeerCrawlerRawLabel = SeerCrawlerLabel + "-raw"
+	SeerCrawlerPartialLabel = SeerCrawlerLabel + "-partial"
 
 	MOONSTREAM_DB_V3_INDEXES_URI = os.Getenv("MOONSTREAM_DB_V3_INDEXES_URI")
 	if MOONSTREAM_DB_V3_INDEXES_URI == "" {
@@ -0,0 +1,507 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// sqlInsertMaxParameters is limit of parameters of one insert statement, it is below limits of
+// all dialects.
+const sqlInsertMaxParameters = 32766
+
+// errLeaderElectionNotSupported is returned for writes of crawler replicas, their leaders are
+// coordinated only in PostgreSQL.
+var errLeaderElectionNotSupported = errors.New("leader election of crawler requires PostgreSQL indexes database")
+
+// IsSQLDatabaseURI reports whether database URI points to database of SQL dialect instead of
+// PostgreSQL.
+func IsSQLDatabaseURI(uri string) bool {
+	return strings.HasPrefix(uri, SQLiteURIPrefix) || strings.HasPrefix(uri, MySQLURIPrefix)
+}
+
+// OpenSQLDatabase opens database of SQL dialect by scheme of URI.
+func OpenSQLDatabase(uri string) (*SQLDatabase, error) {
+	switch {
+	case strings.HasPrefix(uri, SQLiteURIPrefix):
+		return NewSQLite(strings.TrimPrefix(uri, SQLiteURIPrefix))
+	case strings.HasPrefix(uri, MySQLURIPrefix):
+		return NewMySQL(uri)
+	}
+
+	return nil, fmt.Errorf("unsupported database URI scheme, expected %s or %s", SQLiteURIPrefix, MySQLURIPrefix)
+}
+
+// SQLDatabase is indexes or labels database of SQL dialect accessed with database/sql. Index
+// tables and labels table of blockchain are created with embedded migrations of PostgreSQL
+// converted to dialect when blockchain is used first.
+type SQLDatabase struct {
+	db      *sql.DB
+	dialect Dialect
+
+	mu      sync.Mutex
+	ensured map[string]bool
+}
+
+func newSQLDatabase(db *sql.DB, dialect Dialect) *SQLDatabase {
+	return &SQLDatabase{db: db, dialect: dialect, ensured: make(map[string]bool)}
+}
+
+// Dialect returns SQL dialect of database.
+func (s *SQLDatabase) Dialect() Dialect {
+	return s.dialect
+}
+
+func (s *SQLDatabase) Close() {
+	s.db.Close()
+}
+
+// Ping checks that database could be reached.
+func (s *SQLDatabase) Ping(ctx context.Context) error {
+	return s.db.PingContext(ctx)
+}
+
+// execDDL applies PostgreSQL DDL of migrations converted to dialect.
+func (s *SQLDatabase) execDDL(ctx context.Context, ddl string) error {
+	for _, statement := range s.dialect.DDL(ddl) {
+		if _, err := s.db.ExecContext(ctx, statement); err != nil && !s.dialect.IsExistsError(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ensureTables applies DDL once per connection, key identifies tables created by DDL.
+func (s *SQLDatabase) ensureTables(ctx context.Context, key, ddl string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ensured[key] {
+		return nil
+	}
+
+	if err := s.execDDL(ctx, ddl); err != nil {
+		return fmt.Errorf("failed to create %s tables in %s database: %w", key, s.dialect.Name(), err)
+	}
+	s.ensured[key] = true
+
+	return nil
+}
+
+// ensureIndexTables creates blocks, transactions, logs and custom index tables of blockchain if
+// they were not created by this connection yet.
+func (s *SQLDatabase) ensureIndexTables(ctx context.Context, blockchain string) error {
+	ddl, err := IndexTablesDDL(blockchain, IsBlockchainWithL1Chain(blockchain), GetCustomIndexTables(blockchain))
+	if err != nil {
+		return err
+	}
+
+	return s.ensureTables(ctx, blockchain+" index", ddl)
+}
+
+// EnsureLabelsTable creates labels table of blockchain if it does not exist.
+func (s *SQLDatabase) EnsureLabelsTable(ctx context.Context, blockchain string) error {
+	ddl, err := LabelsTableDDL(blockchain)
+	if err != nil {
+		return err
+	}
+
+	return s.ensureTables(ctx, blockchain+" labels", ddl)
+}
+
+// txBatchInserter returns inserter which writes rows in transaction with multi-row inserts, rows
+// are split into statements by limit of parameters.
+func (s *SQLDatabase) txBatchInserter(tx *sql.Tx) batchInserter {
+	return func(ctx context.Context, tableName string, columns []string, values map[string]UnnestInsertValueStruct, conflictClause string) error {
+		if len(columns) == 0 {
+			return nil
+		}
+
+		rows := len(values[columns[0]].Values)
+		chunkSize := sqlInsertMaxParameters / len(columns)
+
+		for start := 0; start < rows; start += chunkSize {
+			end := start + chunkSize
+			if end > rows {
+				end = rows
+			}
+
+			args := make([]interface{}, 0, (end-start)*len(columns))
+			for row := start; row < end; row++ {
+				for _, column := range columns {
+					args = append(args, values[column].Values[row])
+				}
+			}
+
+			query := s.dialect.InsertQuery(tableName, columns, end-start, conflictClause)
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return fmt.Errorf("error executing insert into %s: %w", tableName, err)
+			}
+		}
+
+		return nil
+	}
+}
+
+// WriteIndexes writes indexes of blocks and checkpoint in single transaction.
+func (s *SQLDatabase) WriteIndexes(blockchain string, blocksIndexPack []BlockIndex, transactionsIndexPack []TransactionIndex, logsIndexPack []LogIndex, customIndexPack []CustomIndex, checkpoint *Checkpoint) error {
+	ctx := context.Background()
+
+	if checkpoint != nil && checkpoint.Leader != "" {
+		return errLeaderElectionNotSupported
+	}
+
+	if err := s.ensureIndexTables(ctx, blockchain); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	insert := s.txBatchInserter(tx)
+
+	if len(blocksIndexPack) > 0 {
+		if err := writeBlockIndexToDB(insert, blockchain, blocksIndexPack); err != nil {
+			return err
+		}
+	}
+
+	if len(transactionsIndexPack) > 0 {
+		if err := writeTransactionIndexToDB(insert, blockchain, transactionsIndexPack); err != nil {
+			return err
+		}
+	}
+
+	if len(logsIndexPack) > 0 {
+		if err := writeLogIndexToDB(insert, blockchain, logsIndexPack); err != nil {
+			return err
+		}
+	}
+
+	if len(customIndexPack) > 0 {
+		if err := writeCustomIndexToDB(insert, blockchain, customIndexPack); err != nil {
+			return err
+		}
+	}
+
+	if checkpoint != nil {
+		var blockHash interface{}
+		if checkpoint.BlockHash != "" {
+			blockHash = checkpoint.BlockHash
+		}
+
+		query := s.dialect.InsertQuery(CheckpointsTableName, []string{"blockchain", "block_number", "block_hash", "updated_at"}, 1,
+			"ON CONFLICT (blockchain) DO UPDATE SET block_number = EXCLUDED.block_number, block_hash = EXCLUDED.block_hash, updated_at = EXCLUDED.updated_at")
+		if _, err := tx.ExecContext(ctx, query, blockchain, checkpoint.BlockNumber, blockHash, time.Now().UTC()); err != nil {
+			return fmt.Errorf("failed to write checkpoint: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetLatestDBBlockNumber returns number of the latest indexed block of blockchain, error is
+// pgx.ErrNoRows when no blocks are indexed, as of PostgreSQL database.
+func (s *SQLDatabase) GetLatestDBBlockNumber(blockchain string) (uint64, error) {
+	ctx := context.Background()
+
+	if err := s.ensureIndexTables(ctx, blockchain); err != nil {
+		return 0, err
+	}
+
+	var blockNumber uint64
+	blocksTableName := BlocksTableName(blockchain)
+	query := fmt.Sprintf("SELECT block_number FROM %s ORDER BY block_number DESC LIMIT 1", blocksTableName)
+	err := s.db.QueryRowContext(ctx, query).Scan(&blockNumber)
+	if errors.Is(err, sql.ErrNoRows) {
+		log.Printf("No data found in %s table", blocksTableName)
+		return 0, pgx.ErrNoRows
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return blockNumber, nil
+}
+
+// GetEdgeDBBlock fetch first or last block for specified blockchain
+func (s *SQLDatabase) GetEdgeDBBlock(ctx context.Context, blockchain, side string) (BlockIndex, error) {
+	blockIndex := BlockIndex{chain: blockchain}
+
+	if err := s.ensureIndexTables(ctx, blockchain); err != nil {
+		return blockIndex, err
+	}
+
+	l1BlockNumberColumn := "NULL"
+	if IsBlockchainWithL1Chain(blockchain) {
+		l1BlockNumberColumn = "l1_block_number"
+	}
+	query := fmt.Sprintf("SELECT block_number, block_hash, block_timestamp, parent_hash, row_id, path, %s FROM %s ORDER BY block_number", l1BlockNumberColumn, BlocksTableName(blockchain))
+
+	switch side {
+	case "first":
+		query = fmt.Sprintf("%s LIMIT 1", query)
+	case "last":
+		query = fmt.Sprintf("%s DESC LIMIT 1", query)
+	default:
+		return blockIndex, fmt.Errorf("not supported side, choose 'first' or 'last' block")
+	}
+
+	var l1BlockNumber sql.NullInt64
+	err := s.db.QueryRowContext(ctx, query).Scan(
+		&blockIndex.BlockNumber,
+		&blockIndex.BlockHash,
+		&blockIndex.BlockTimestamp,
+		&blockIndex.ParentHash,
+		&blockIndex.RowID,
+		&blockIndex.Path,
+		&l1BlockNumber,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return blockIndex, pgx.ErrNoRows
+	}
+	if err != nil {
+		return blockIndex, err
+	}
+	blockIndex.L1BlockNumber = uint64(l1BlockNumber.Int64)
+
+	return blockIndex, nil
+}
+
+// ReadBlocksHashes returns hashes of indexed blocks in range by block number.
+func (s *SQLDatabase) ReadBlocksHashes(ctx context.Context, blockchain string, startBlock, endBlock uint64) (map[uint64]string, error) {
+	if err := s.ensureIndexTables(ctx, blockchain); err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf("SELECT block_number, block_hash FROM %s WHERE block_number >= ? AND block_number <= ?", BlocksTableName(blockchain))
+	rows, err := s.db.QueryContext(ctx, query, startBlock, endBlock)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	hashes := make(map[uint64]string)
+	for rows.Next() {
+		var blockNumber uint64
+		var blockHash string
+		if err := rows.Scan(&blockNumber, &blockHash); err != nil {
+			return nil, err
+		}
+		hashes[blockNumber] = blockHash
+	}
+
+	return hashes, rows.Err()
+}
+
+// inPlaceholders returns placeholders and arguments of IN list of values.
+func inPlaceholders(values []string) (string, []interface{}) {
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+	return strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "), args
+}
+
+// DeleteOrphanedBlocks removes index rows of blocks which were reorganized out of canonical
+// chain. Orphaned blocks are not recorded, synchronizer reads them only from PostgreSQL indexes
+// database.
+func (s *SQLDatabase) DeleteOrphanedBlocks(ctx context.Context, blockchain string, orphaned []BlockIndex) error {
+	if len(orphaned) == 0 {
+		return nil
+	}
+
+	if err := s.ensureIndexTables(ctx, blockchain); err != nil {
+		return err
+	}
+
+	blockHashes := make([]string, len(orphaned))
+	for i, block := range orphaned {
+		blockHashes[i] = block.BlockHash
+	}
+	placeholders, args := inPlaceholders(blockHashes)
+
+	tables := []string{BlocksTableName(blockchain), TransactionsTableName(blockchain), LogsTableName(blockchain)}
+	for _, table := range GetCustomIndexTables(blockchain) {
+		for _, column := range table.Columns {
+			if column.Name == "block_hash" {
+				tables = append(tables, CustomIndexTableName(blockchain, table.Kind))
+				break
+			}
+		}
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, table := range tables {
+		result, err := tx.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE block_hash IN (%s)", table, placeholders), args...)
+		if err != nil {
+			return fmt.Errorf("failed to delete orphaned rows from %s: %w", table, err)
+		}
+		if deleted, err := result.RowsAffected(); err == nil && deleted > 0 {
+			log.Printf("Deleted %d orphaned rows from %s table", deleted, table)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// EnsureCheckpointsTable creates checkpoints table if it does not exist.
+func (s *SQLDatabase) EnsureCheckpointsTable(ctx context.Context) error {
+	return s.execDDL(ctx, CheckpointsTableDDL)
+}
+
+// ReadCheckpoint returns checkpoint of blockchain, nil if crawler has not committed blocks yet.
+func (s *SQLDatabase) ReadCheckpoint(ctx context.Context, blockchain string) (*Checkpoint, error) {
+	checkpoint := Checkpoint{Blockchain: blockchain}
+	var blockHash sql.NullString
+
+	query := fmt.Sprintf("SELECT block_number, block_hash, updated_at FROM %s WHERE blockchain = ?", CheckpointsTableName)
+	err := s.db.QueryRowContext(ctx, query, blockchain).Scan(&checkpoint.BlockNumber, &blockHash, &checkpoint.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	checkpoint.BlockHash = blockHash.String
+
+	return &checkpoint, nil
+}
+
+// RewindCheckpoint moves checkpoint of blockchain back to block number if it is ahead of it.
+func (s *SQLDatabase) RewindCheckpoint(ctx context.Context, blockchain string, blockNumber uint64, leader string) error {
+	if leader != "" {
+		return errLeaderElectionNotSupported
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET block_number = ?, block_hash = NULL, updated_at = ? WHERE blockchain = ? AND block_number > ?", CheckpointsTableName)
+	_, err := s.db.ExecContext(ctx, query, blockNumber, time.Now().UTC(), blockchain, blockNumber)
+	return err
+}
+
+// WriteLabes writes labels of transactions and events in single transaction.
+func (s *SQLDatabase) WriteLabes(blockchain string, transactions []TransactionLabel, events []EventLabel) error {
+	ctx := context.Background()
+
+	if err := s.EnsureLabelsTable(ctx, blockchain); err != nil {
+		return err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if len(transactions) > 0 {
+		if err := writeTransactionLabelsToDB(s.txBatchInserter(tx), blockchain, transactions); err != nil {
+			return err
+		}
+	}
+
+	if len(events) > 0 {
+		if err := writeEventLabelsToDB(s.txBatchInserter(tx), blockchain, events); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// ReadLastLabel returns block number of the latest label of blockchain, 0 if there are no labels.
+func (s *SQLDatabase) ReadLastLabel(blockchain string) (uint64, error) {
+	ctx := context.Background()
+
+	if err := s.EnsureLabelsTable(ctx, blockchain); err != nil {
+		return 0, err
+	}
+
+	var label uint64
+	query := fmt.Sprintf("SELECT block_number FROM %s ORDER BY block_number DESC LIMIT 1", LabelsTableName(blockchain))
+	err := s.db.QueryRowContext(ctx, query).Scan(&label)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return label, nil
+}
+
+// ReplaceAddressLabels replaces labels of synchronizer for contract at address in range of blocks
+// with labels in one database transaction. It returns number of removed labels.
+func (s *SQLDatabase) ReplaceAddressLabels(ctx context.Context, blockchain, address string, fromBlock, toBlock uint64, transactions []TransactionLabel, events []EventLabel) (int64, error) {
+	addressBytes, err := decodeAddress(address)
+	if err != nil {
+		return 0, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	if err := s.EnsureLabelsTable(ctx, blockchain); err != nil {
+		return 0, err
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE address = ? AND block_number >= ? AND block_number <= ? AND label IN (?, ?, ?)", LabelsTableName(blockchain))
+	result, err := tx.ExecContext(ctx, query, addressBytes, fromBlock, toBlock, SeerCrawlerLabel, SeerCrawlerRawLabel, SeerCrawlerPartialLabel)
+	if err != nil {
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	if len(transactions) > 0 {
+		if err := writeTransactionLabelsToDB(s.txBatchInserter(tx), blockchain, transactions); err != nil {
+			return 0, err
+		}
+	}
+	if len(events) > 0 {
+		if err := writeEventLabelsToDB(s.txBatchInserter(tx), blockchain, events); err != nil {
+			return 0, err
+		}
+	}
+
+	return deleted, tx.Commit()
+}
+
+// DeleteLabelsOfBlocks removes labels of blocks with hashes, it returns number of removed labels.
+func (s *SQLDatabase) DeleteLabelsOfBlocks(ctx context.Context, blockchain string, blockHashes []string) (int64, error) {
+	if len(blockHashes) == 0 {
+		return 0, nil
+	}
+
+	if err := s.EnsureLabelsTable(ctx, blockchain); err != nil {
+		return 0, err
+	}
+
+	placeholders, args := inPlaceholders(blockHashes)
+	result, err := s.db.ExecContext(ctx, fmt.Sprintf("DELETE FROM %s WHERE block_hash IN (%s)", LabelsTableName(blockchain), placeholders), args...)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,75 @@
+package indexer
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteURIPrefix is prefix of URI of SQLite database, the rest of URI is path to database file,
+// for example sqlite://seer.db or sqlite:///tmp/seer.db.
+const SQLiteURIPrefix = "sqlite://"
+
+// NewSQLite opens SQLite database at path for local development, database file is created if it
+// does not exist.
+func NewSQLite(path string) (*SQLDatabase, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, err
+	}
+
+	// SQLite has single writer, one connection keeps writes of crawler from waiting on locks
+	db.SetMaxOpenConns(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	for _, pragma := range []string{"PRAGMA journal_mode = WAL", "PRAGMA busy_timeout = 5000"} {
+		if _, err := db.ExecContext(ctx, pragma); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to set %s: %w", pragma, err)
+		}
+	}
+
+	return newSQLDatabase(db, sqliteDialect{}), nil
+}
+
+var sqliteTypeMappings = []ddlTypeMapping{
+	newDDLTypeMapping(`\bTIMESTAMP WITH TIME ZONE\b`, "TIMESTAMP"),
+	newDDLTypeMapping(`\bDEFAULT now\(\)`, "DEFAULT CURRENT_TIMESTAMP"),
+	newDDLTypeMapping(`\bBYTEA\b`, "BLOB"),
+	newDDLTypeMapping(`\b(JSONB|UUID)\b`, "TEXT"),
+}
+
+// sqliteDialect is dialect of SQLite, it supports IF NOT EXISTS of tables and indexes, partial
+// indexes and ON CONFLICT clauses of PostgreSQL as is.
+type sqliteDialect struct{}
+
+func (sqliteDialect) Name() string {
+	return "SQLite"
+}
+
+func (d sqliteDialect) DDL(ddl string) []string {
+	statements := convertDDL(ddl, d, sqliteTypeMappings)
+	for i, statement := range statements {
+		statements[i] = ddlAddColumnRe.ReplaceAllString(statement, "ADD COLUMN")
+	}
+	return statements
+}
+
+func (sqliteDialect) IsExistsError(err error) bool {
+	return strings.Contains(err.Error(), "duplicate column name")
+}
+
+func (sqliteDialect) QuoteIdentifier(name string) string {
+	return fmt.Sprintf("%q", name)
+}
+
+func (d sqliteDialect) InsertQuery(tableName string, columns []string, rows int, conflictClause string) string {
+	quotedColumns, values := insertColumnsAndValues(d, columns, rows)
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s %s", tableName, quotedColumns, values, conflictClause)
+}
@@ -0,0 +1,249 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// AggregationsTableName is table with the last complete day of every aggregation of blockchain.
+const AggregationsTableName = "seer_aggregations"
+
+// AddressDailyStatsAggregation is name of aggregation of per-address daily stats in aggregations
+// table.
+const AddressDailyStatsAggregation = "address_daily_stats"
+
+// AddressDailyStatsTableName returns table of index database with daily activity of addresses of
+// blockchain aggregated from transactions and logs indexes.
+func AddressDailyStatsTableName(blockchain string) string {
+	return fmt.Sprintf("%s_address_daily_stats", blockchain)
+}
+
+// AddressDailyStatsTablesDDL creates daily stats table of blockchain and aggregations table,
+// aggregator applies it on start. Days are UTC days of block timestamps.
+func AddressDailyStatsTablesDDL(blockchain string) string {
+	stats := AddressDailyStatsTableName(blockchain)
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    address BYTEA NOT NULL,
+    day DATE NOT NULL,
+    transactions_sent BIGINT NOT NULL,
+    transactions_received BIGINT NOT NULL,
+    counterparties BIGINT NOT NULL,
+    gas_used NUMERIC NOT NULL,
+    events_emitted BIGINT NOT NULL,
+    PRIMARY KEY (address, day)
+);
+CREATE INDEX IF NOT EXISTS ix_%s_day ON %s (day);
+CREATE TABLE IF NOT EXISTS %s (
+    blockchain TEXT NOT NULL,
+    aggregation TEXT NOT NULL,
+    completed_day DATE NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (blockchain, aggregation)
+);
+`, stats, stats, stats, AggregationsTableName)
+}
+
+// AddressDailyStats is activity of address in one day. GasUsed is gas used by transactions sent
+// by address, it is counted only for transactions indexed with gas used.
+type AddressDailyStats struct {
+	Address              string
+	Day                  time.Time
+	TransactionsSent     uint64
+	TransactionsReceived uint64
+	Counterparties       uint64
+	GasUsed              string
+	EventsEmitted        uint64
+}
+
+// EnsureAddressDailyStatsTables creates daily stats table of blockchain and aggregations table if
+// they do not exist.
+func (p *PostgreSQLpgx) EnsureAddressDailyStatsTables(ctx context.Context, blockchain string) error {
+	_, err := p.GetPool().Exec(ctx, AddressDailyStatsTablesDDL(blockchain))
+	return err
+}
+
+// ReadIndexedTimeRange returns timestamps of the first and the latest indexed blocks of
+// blockchain, false if blocks index is empty.
+func (p *PostgreSQLpgx) ReadIndexedTimeRange(ctx context.Context, blockchain string) (uint64, uint64, bool, error) {
+	var first, latest *int64
+	query := fmt.Sprintf("SELECT min(block_timestamp), max(block_timestamp) FROM %s", BlocksTableName(blockchain))
+	if err := p.GetPool().QueryRow(ctx, query).Scan(&first, &latest); err != nil {
+		return 0, 0, false, err
+	}
+	if first == nil || latest == nil {
+		return 0, 0, false, nil
+	}
+
+	return uint64(*first), uint64(*latest), true, nil
+}
+
+// ReadCompletedDay returns the last complete day of aggregation of blockchain, false if no day
+// was aggregated yet.
+func (p *PostgreSQLpgx) ReadCompletedDay(ctx context.Context, blockchain, aggregation string) (time.Time, bool, error) {
+	var day time.Time
+	query := fmt.Sprintf("SELECT completed_day FROM %s WHERE blockchain = $1 AND aggregation = $2", AggregationsTableName)
+	err := p.GetPool().QueryRow(ctx, query, blockchain, aggregation).Scan(&day)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	return day, true, nil
+}
+
+// ResetCompletedDay makes aggregation of blockchain start again after day, days after it are
+// aggregated again with the next round.
+func (p *PostgreSQLpgx) ResetCompletedDay(ctx context.Context, blockchain, aggregation string, day time.Time) error {
+	query := fmt.Sprintf(`INSERT INTO %s (blockchain, aggregation, completed_day, updated_at) VALUES ($1, $2, $3, now())
+ON CONFLICT (blockchain, aggregation) DO UPDATE SET completed_day = EXCLUDED.completed_day, updated_at = EXCLUDED.updated_at`, AggregationsTableName)
+	_, err := p.GetPool().Exec(ctx, query, blockchain, aggregation, day)
+	return err
+}
+
+// AggregateAddressDailyStats replaces stats of addresses of blockchain in UTC day with stats
+// aggregated from blocks of the day in transactions and logs indexes, it returns number of
+// addresses active in the day. Complete day is recorded as the last complete day of aggregation,
+// stats of incomplete day are replaced again when the day is aggregated with more blocks.
+func (p *PostgreSQLpgx) AggregateAddressDailyStats(ctx context.Context, blockchain string, day time.Time, complete bool) (int64, error) {
+	day = day.UTC().Truncate(24 * time.Hour)
+	statsTable := AddressDailyStatsTableName(blockchain)
+
+	// Transactions index tables created before gas_used column was added do not have it
+	var withGasUsed bool
+	err := p.GetPool().QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = $1 AND column_name = 'gas_used')", TransactionsTableName(blockchain)).Scan(&withGasUsed)
+	if err != nil {
+		return 0, err
+	}
+	gasUsedColumn := "NULL::BIGINT"
+	if withGasUsed {
+		gasUsedColumn = "tx.gas_used"
+	}
+
+	tx, err := p.GetPool().Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var fromBlock, toBlock *int64
+	query := fmt.Sprintf("SELECT min(block_number), max(block_number) FROM %s WHERE block_timestamp >= $1 AND block_timestamp < $2", BlocksTableName(blockchain))
+	if err := tx.QueryRow(ctx, query, day.Unix(), day.Add(24*time.Hour).Unix()).Scan(&fromBlock, &toBlock); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE day = $1", statsTable), day); err != nil {
+		return 0, err
+	}
+
+	var addresses int64
+	if fromBlock != nil && toBlock != nil {
+		// Contract creations are indexed with single zero byte as recipient
+		query = fmt.Sprintf(`WITH day_transactions AS (
+        SELECT
+            tx.from_address,
+            CASE WHEN octet_length(tx.to_address) = 20 THEN tx.to_address END AS to_address,
+            %s AS gas_used
+        FROM %s tx
+        WHERE tx.block_number BETWEEN $1 AND $2
+    ),
+    activity AS (
+        SELECT from_address AS address, count(*) AS sent, 0 AS received, coalesce(sum(gas_used), 0) AS gas_used, 0 AS events
+        FROM day_transactions
+        WHERE from_address IS NOT NULL
+        GROUP BY from_address
+        UNION ALL
+        SELECT to_address AS address, 0, count(*), 0, 0
+        FROM day_transactions
+        WHERE to_address IS NOT NULL
+        GROUP BY to_address
+        UNION ALL
+        SELECT logs.address, 0, 0, 0, count(*)
+        FROM %s logs
+        JOIN %s blocks ON blocks.block_hash = logs.block_hash
+        WHERE blocks.block_number BETWEEN $1 AND $2
+        GROUP BY logs.address
+    ),
+    counterparties AS (
+        SELECT address, count(DISTINCT counterparty) AS counterparties
+        FROM (
+            SELECT from_address AS address, to_address AS counterparty FROM day_transactions
+            WHERE from_address IS NOT NULL AND to_address IS NOT NULL
+            UNION ALL
+            SELECT to_address, from_address FROM day_transactions
+            WHERE from_address IS NOT NULL AND to_address IS NOT NULL
+        ) pairs
+        GROUP BY address
+    )
+    INSERT INTO %s (address, day, transactions_sent, transactions_received, counterparties, gas_used, events_emitted)
+    SELECT
+        activity.address,
+        $3::DATE,
+        sum(activity.sent),
+        sum(activity.received),
+        coalesce(max(counterparties.counterparties), 0),
+        sum(activity.gas_used),
+        sum(activity.events)
+    FROM
+        activity
+        LEFT JOIN counterparties ON counterparties.address = activity.address
+    GROUP BY
+        activity.address`, gasUsedColumn, TransactionsTableName(blockchain), LogsTableName(blockchain), BlocksTableName(blockchain), statsTable)
+
+		tag, err := tx.Exec(ctx, query, *fromBlock, *toBlock, day)
+		if err != nil {
+			return 0, err
+		}
+		addresses = tag.RowsAffected()
+	}
+
+	if complete {
+		query = fmt.Sprintf(`INSERT INTO %s (blockchain, aggregation, completed_day, updated_at) VALUES ($1, $2, $3, now())
+ON CONFLICT (blockchain, aggregation) DO UPDATE SET completed_day = EXCLUDED.completed_day, updated_at = EXCLUDED.updated_at`, AggregationsTableName)
+		if _, err := tx.Exec(ctx, query, blockchain, AddressDailyStatsAggregation, day); err != nil {
+			return 0, err
+		}
+	}
+
+	return addresses, tx.Commit(ctx)
+}
+
+// ReadAddressDailyStats returns daily stats of address of blockchain from day to day inclusive,
+// the latest day first.
+func (p *PostgreSQLpgx) ReadAddressDailyStats(ctx context.Context, blockchain, address string, from, to time.Time) ([]AddressDailyStats, error) {
+	addressBytes, err := decodeAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s: %w", address, err)
+	}
+
+	query := fmt.Sprintf(`SELECT
+        '0x' || encode(address, 'hex'),
+        day,
+        transactions_sent,
+        transactions_received,
+        counterparties,
+        gas_used::TEXT,
+        events_emitted
+    FROM
+        %s
+    WHERE
+        address = $1 AND day BETWEEN $2 AND $3
+    ORDER BY
+        day DESC`, AddressDailyStatsTableName(blockchain))
+
+	rows, err := p.GetPool().Query(ctx, query, addressBytes, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (AddressDailyStats, error) {
+		var stats AddressDailyStats
+		err := row.Scan(&stats.Address, &stats.Day, &stats.TransactionsSent, &stats.TransactionsReceived, &stats.Counterparties, &stats.GasUsed, &stats.EventsEmitted)
+		return stats, err
+	})
+}
@@ -0,0 +1,112 @@
+package indexer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// SyncCheckpointsTableName is table with position of historical synchronizations per chain,
+// direction and range of blocks.
+const SyncCheckpointsTableName = "seer_synchronizer_checkpoints"
+
+// SyncCheckpointsTableDDL creates synchronizer checkpoints table, historical synchronizer applies
+// it on start.
+var SyncCheckpointsTableDDL = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    blockchain TEXT NOT NULL,
+    direction TEXT NOT NULL,
+    start_block BIGINT NOT NULL,
+    end_block BIGINT NOT NULL,
+    block_number BIGINT NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (blockchain, direction, start_block, end_block)
+);
+`, SyncCheckpointsTableName)
+
+// Directions of historical synchronization
+const (
+	SyncForward  = "forward"
+	SyncBackward = "backward"
+)
+
+var writeSyncCheckpointQuery = fmt.Sprintf(`INSERT INTO %s (blockchain, direction, start_block, end_block, block_number, updated_at) VALUES ($1, $2, $3, $4, $5, now())
+ON CONFLICT (blockchain, direction, start_block, end_block) DO UPDATE SET block_number = EXCLUDED.block_number, updated_at = EXCLUDED.updated_at`, SyncCheckpointsTableName)
+
+// SyncCheckpoint is the last block which labels were written by historical synchronization of
+// range of blocks. Forward synchronization processed blocks from start block to it, backward one
+// from end block down to it.
+type SyncCheckpoint struct {
+	Blockchain  string
+	Direction   string
+	StartBlock  uint64
+	EndBlock    uint64
+	BlockNumber uint64
+	UpdatedAt   time.Time
+}
+
+// Done returns number of processed blocks of range.
+func (c *SyncCheckpoint) Done() uint64 {
+	if c.Direction == SyncBackward {
+		return c.EndBlock - c.BlockNumber + 1
+	}
+	return c.BlockNumber - c.StartBlock + 1
+}
+
+// Percent returns processed part of range in percents.
+func (c *SyncCheckpoint) Percent() float64 {
+	return float64(c.Done()) * 100 / float64(c.EndBlock-c.StartBlock+1)
+}
+
+// EnsureSyncCheckpointsTable creates synchronizer checkpoints table if it does not exist.
+func (p *PostgreSQLpgx) EnsureSyncCheckpointsTable(ctx context.Context) error {
+	_, err := p.GetPool().Exec(ctx, SyncCheckpointsTableDDL)
+	return err
+}
+
+// ReadSyncCheckpoint returns checkpoint of historical synchronization, nil if it has not written
+// labels yet.
+func (p *PostgreSQLpgx) ReadSyncCheckpoint(ctx context.Context, blockchain, direction string, startBlock, endBlock uint64) (*SyncCheckpoint, error) {
+	checkpoint := SyncCheckpoint{Blockchain: blockchain, Direction: direction, StartBlock: startBlock, EndBlock: endBlock}
+
+	query := fmt.Sprintf("SELECT block_number, updated_at FROM %s WHERE blockchain = $1 AND direction = $2 AND start_block = $3 AND end_block = $4", SyncCheckpointsTableName)
+	err := p.GetPool().QueryRow(ctx, query, blockchain, direction, startBlock, endBlock).Scan(&checkpoint.BlockNumber, &checkpoint.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &checkpoint, nil
+}
+
+// ReadSyncCheckpoints returns checkpoints of all historical synchronizations of blockchain, the
+// most recently updated first.
+func (p *PostgreSQLpgx) ReadSyncCheckpoints(ctx context.Context, blockchain string) ([]SyncCheckpoint, error) {
+	query := fmt.Sprintf("SELECT blockchain, direction, start_block, end_block, block_number, updated_at FROM %s WHERE blockchain = $1 ORDER BY updated_at DESC", SyncCheckpointsTableName)
+	rows, err := p.GetPool().Query(ctx, query, blockchain)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checkpoints []SyncCheckpoint
+	for rows.Next() {
+		var checkpoint SyncCheckpoint
+		if err := rows.Scan(&checkpoint.Blockchain, &checkpoint.Direction, &checkpoint.StartBlock, &checkpoint.EndBlock, &checkpoint.BlockNumber, &checkpoint.UpdatedAt); err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+
+	return checkpoints, rows.Err()
+}
+
+// WriteSyncCheckpoint stores the last block of historical synchronization which labels were written.
+func (p *PostgreSQLpgx) WriteSyncCheckpoint(ctx context.Context, checkpoint SyncCheckpoint) error {
+	_, err := p.GetPool().Exec(ctx, writeSyncCheckpointQuery, checkpoint.Blockchain, checkpoint.Direction, checkpoint.StartBlock, checkpoint.EndBlock, checkpoint.BlockNumber)
+	return err
+}
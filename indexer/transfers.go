@@ -0,0 +1,395 @@
+package indexer
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ERC20TransfersTableName returns table of customer database with ERC-20 transfers of blockchain.
+func ERC20TransfersTableName(blockchain string) string {
+	return fmt.Sprintf("%s_erc20_transfers", blockchain)
+}
+
+// NFTTransfersTableName returns table of customer database with ERC-721 and ERC-1155 transfers
+// of blockchain.
+func NFTTransfersTableName(blockchain string) string {
+	return fmt.Sprintf("%s_nft_transfers", blockchain)
+}
+
+// NFTOwnersTableName returns table of customer database with current owners of ERC-721 tokens
+// and balances of ERC-1155 tokens of blockchain, derived from NFT transfers.
+func NFTOwnersTableName(blockchain string) string {
+	return fmt.Sprintf("%s_nft_owners", blockchain)
+}
+
+// TokenTransfersTablesDDL creates token transfers tables and NFT owners table of blockchain in
+// customer database, synchronizer applies it before the first write of transfers.
+func TokenTransfersTablesDDL(blockchain string) string {
+	erc20 := ERC20TransfersTableName(blockchain)
+	nft := NFTTransfersTableName(blockchain)
+	owners := NFTOwnersTableName(blockchain)
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    transaction_hash TEXT NOT NULL,
+    log_index BIGINT NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    block_timestamp BIGINT NOT NULL,
+    token BYTEA NOT NULL,
+    from_address BYTEA NOT NULL,
+    to_address BYTEA NOT NULL,
+    amount NUMERIC NOT NULL,
+    UNIQUE (transaction_hash, log_index)
+);
+CREATE INDEX IF NOT EXISTS ix_%s_token_block_number ON %s (token, block_number);
+CREATE INDEX IF NOT EXISTS ix_%s_from_address ON %s (from_address);
+CREATE INDEX IF NOT EXISTS ix_%s_to_address ON %s (to_address);
+CREATE TABLE IF NOT EXISTS %s (
+    transaction_hash TEXT NOT NULL,
+    log_index BIGINT NOT NULL,
+    batch_index BIGINT NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    block_timestamp BIGINT NOT NULL,
+    token BYTEA NOT NULL,
+    standard TEXT NOT NULL,
+    operator BYTEA,
+    from_address BYTEA NOT NULL,
+    to_address BYTEA NOT NULL,
+    token_id NUMERIC NOT NULL,
+    amount NUMERIC NOT NULL,
+    UNIQUE (transaction_hash, log_index, batch_index)
+);
+CREATE INDEX IF NOT EXISTS ix_%s_token_token_id ON %s (token, token_id);
+CREATE INDEX IF NOT EXISTS ix_%s_from_address ON %s (from_address);
+CREATE INDEX IF NOT EXISTS ix_%s_to_address ON %s (to_address);
+CREATE TABLE IF NOT EXISTS %s (
+    token BYTEA NOT NULL,
+    token_id NUMERIC NOT NULL,
+    owner BYTEA NOT NULL,
+    standard TEXT NOT NULL,
+    amount NUMERIC NOT NULL,
+    block_number BIGINT NOT NULL,
+    PRIMARY KEY (token, token_id, owner)
+);
+CREATE INDEX IF NOT EXISTS ix_%s_owner ON %s (owner, token);
+`, erc20, erc20, erc20, erc20, erc20, erc20, erc20, nft, nft, nft, nft, nft, nft, nft, owners, owners, owners)
+}
+
+// Standards of NFT transfers
+const (
+	ERC721Standard  = "erc721"
+	ERC1155Standard = "erc1155"
+)
+
+// NFTOwner is owner of ERC-721 token or holder of ERC-1155 token with its balance, BlockNumber is
+// block of the last written transfer of token to or from owner.
+type NFTOwner struct {
+	Token       string
+	TokenID     string
+	Owner       string
+	Standard    string
+	Amount      string
+	BlockNumber uint64
+}
+
+// TokenTransfer is ERC-20, ERC-721 or ERC-1155 transfer recognized in event labels. Amount and
+// TokenID are decimal numbers, Standard is empty for ERC-20 transfers.
+type TokenTransfer struct {
+	TransactionHash string
+	LogIndex        uint64
+	// BatchIndex is position of token in TransferBatch event, 0 for other events
+	BatchIndex     uint64
+	BlockNumber    uint64
+	BlockHash      string
+	BlockTimestamp uint64
+	Token          string
+	Standard       string
+	Operator       string
+	From           string
+	To             string
+	TokenID        string
+	Amount         string
+}
+
+// EnsureTokenTransfersTables creates token transfers tables of blockchain if they do not exist.
+func (p *PostgreSQLpgx) EnsureTokenTransfersTables(ctx context.Context, blockchain string) error {
+	_, err := p.GetPool().Exec(ctx, TokenTransfersTablesDDL(blockchain))
+	return err
+}
+
+// WriteTokenTransfers writes ERC-20 transfers and NFT transfers to their tables, transfers which
+// are already written are skipped.
+func (p *PostgreSQLpgx) WriteTokenTransfers(ctx context.Context, blockchain string, transfers []TokenTransfer) error {
+	tx, err := p.GetPool().Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	erc20Columns := []string{"transaction_hash", "log_index", "block_number", "block_hash", "block_timestamp", "token", "from_address", "to_address", "amount"}
+	nftColumns := []string{"transaction_hash", "log_index", "batch_index", "block_number", "block_hash", "block_timestamp", "token", "standard", "operator", "from_address", "to_address", "token_id", "amount"}
+	types := map[string]string{
+		"transaction_hash": "TEXT",
+		"log_index":        "BIGINT",
+		"batch_index":      "BIGINT",
+		"block_number":     "BIGINT",
+		"block_hash":       "TEXT",
+		"block_timestamp":  "BIGINT",
+		"token":            "BYTEA",
+		"standard":         "TEXT",
+		"operator":         "BYTEA",
+		"from_address":     "BYTEA",
+		"to_address":       "BYTEA",
+		"token_id":         "NUMERIC",
+		"amount":           "NUMERIC",
+	}
+
+	erc20Values := make(map[string]UnnestInsertValueStruct)
+	for _, column := range erc20Columns {
+		erc20Values[column] = UnnestInsertValueStruct{Type: types[column], Values: make([]interface{}, 0)}
+	}
+	nftValues := make(map[string]UnnestInsertValueStruct)
+	for _, column := range nftColumns {
+		nftValues[column] = UnnestInsertValueStruct{Type: types[column], Values: make([]interface{}, 0)}
+	}
+
+	var erc20Count, nftCount int
+	for _, transfer := range transfers {
+		token, err := decodeAddress(transfer.Token)
+		if err != nil {
+			return fmt.Errorf("invalid token address %s: %w", transfer.Token, err)
+		}
+		from, err := decodeAddress(transfer.From)
+		if err != nil {
+			return fmt.Errorf("invalid from address %s: %w", transfer.From, err)
+		}
+		to, err := decodeAddress(transfer.To)
+		if err != nil {
+			return fmt.Errorf("invalid to address %s: %w", transfer.To, err)
+		}
+
+		values := erc20Values
+		if transfer.Standard == "" {
+			erc20Count++
+		} else {
+			values = nftValues
+			nftCount++
+
+			var operator []byte
+			if transfer.Operator != "" {
+				if operator, err = decodeAddress(transfer.Operator); err != nil {
+					return fmt.Errorf("invalid operator address %s: %w", transfer.Operator, err)
+				}
+			}
+			updateValues(values, "batch_index", transfer.BatchIndex)
+			updateValues(values, "standard", transfer.Standard)
+			updateValues(values, "operator", operator)
+			updateValues(values, "token_id", transfer.TokenID)
+		}
+
+		updateValues(values, "transaction_hash", transfer.TransactionHash)
+		updateValues(values, "log_index", transfer.LogIndex)
+		updateValues(values, "block_number", transfer.BlockNumber)
+		updateValues(values, "block_hash", transfer.BlockHash)
+		updateValues(values, "block_timestamp", transfer.BlockTimestamp)
+		updateValues(values, "token", token)
+		updateValues(values, "from_address", from)
+		updateValues(values, "to_address", to)
+		updateValues(values, "amount", transfer.Amount)
+	}
+
+	if erc20Count > 0 {
+		if err := p.executeBatchInsert(tx, ctx, ERC20TransfersTableName(blockchain), erc20Columns, erc20Values, "ON CONFLICT DO NOTHING"); err != nil {
+			return err
+		}
+	}
+	if nftCount > 0 {
+		insert, args := unnestInsertQuery(NFTTransfersTableName(blockchain), nftColumns, nftValues, "ON CONFLICT DO NOTHING")
+		if _, err := applyNFTOwnerDeltas(ctx, tx, blockchain, insert, 1, args...); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// DeleteTokenTransfersOfBlocks removes token transfers of blocks with hashes from customer
+// database and subtracts NFTs they transferred from balances of owners, it returns number of
+// removed transfers.
+func (p *PostgreSQLpgx) DeleteTokenTransfersOfBlocks(ctx context.Context, blockchain string, blockHashes []string) (int64, error) {
+	tx, err := p.GetPool().Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	result, err := tx.Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE block_hash = ANY($1)", ERC20TransfersTableName(blockchain)), blockHashes)
+	if err != nil {
+		return 0, err
+	}
+	deleted := result.RowsAffected()
+
+	nftDeleted, err := applyNFTOwnerDeltas(ctx, tx, blockchain, fmt.Sprintf("DELETE FROM %s WHERE block_hash = ANY($1)", NFTTransfersTableName(blockchain)), -1, blockHashes)
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted + nftDeleted, tx.Commit(ctx)
+}
+
+// nftBalancesQuery sums transfers of NFTs to and from every address, tokens are limited by join
+// clause. Zero address which NFTs are minted from and burned to is not owner.
+func nftBalancesQuery(blockchain, join string) string {
+	return fmt.Sprintf(`INSERT INTO %s (token, token_id, owner, standard, amount, block_number)
+SELECT token, token_id, owner, max(standard), sum(delta), max(block_number)
+FROM (
+    SELECT t.token, t.token_id, t.to_address AS owner, t.standard, t.amount AS delta, t.block_number FROM %s t %s
+    UNION ALL
+    SELECT t.token, t.token_id, t.from_address AS owner, t.standard, -t.amount AS delta, t.block_number FROM %s t %s
+) balances
+WHERE owner <> '\x0000000000000000000000000000000000000000'::BYTEA
+GROUP BY token, token_id, owner
+HAVING sum(delta) > 0`, NFTOwnersTableName(blockchain), NFTTransfersTableName(blockchain), join, NFTTransfersTableName(blockchain), join)
+}
+
+// nftOwnerDeltasQuery adds NFT transfers changed by insert or delete statement to balances of
+// owners, sign is 1 for written transfers and -1 for removed ones. Transfers which insert skipped
+// as conflicting are not returned by it, so repeated writes do not change balances. Query returns
+// number of changed transfers and owners which balances became zero.
+func nftOwnerDeltasQuery(blockchain, change string, sign int) string {
+	owners := NFTOwnersTableName(blockchain)
+	blockNumber := "o.block_number"
+	if sign > 0 {
+		blockNumber = "GREATEST(o.block_number, EXCLUDED.block_number)"
+	}
+
+	return fmt.Sprintf(`WITH changed AS (
+    %s RETURNING token, token_id, standard, from_address, to_address, amount, block_number
+), balances AS (
+    INSERT INTO %s AS o (token, token_id, owner, standard, amount, block_number)
+    SELECT token, token_id, owner, max(standard), sum(delta), max(block_number)
+    FROM (
+        SELECT token, token_id, to_address AS owner, standard, %d * amount AS delta, block_number FROM changed
+        UNION ALL
+        SELECT token, token_id, from_address AS owner, standard, %d * amount AS delta, block_number FROM changed
+    ) deltas
+    WHERE owner <> '\x0000000000000000000000000000000000000000'::BYTEA
+    GROUP BY token, token_id, owner
+    HAVING sum(delta) <> 0
+    ON CONFLICT (token, token_id, owner) DO UPDATE SET amount = o.amount + EXCLUDED.amount, block_number = %s
+    RETURNING token, token_id, owner, amount
+)
+SELECT (SELECT count(*) FROM changed), b.token, b.token_id::TEXT, b.owner
+FROM (SELECT 1) c LEFT JOIN balances b ON b.amount = 0`, change, owners, sign, -sign, blockNumber)
+}
+
+// applyNFTOwnerDeltas runs insert or delete statement of NFT transfers and applies them to
+// balances of owners, owners without tokens are removed. Balances of owners could be negative
+// until transfers to them are written, so ownership is correct regardless of order transfers are
+// written and removed in. It returns number of changed transfers.
+func applyNFTOwnerDeltas(ctx context.Context, tx pgx.Tx, blockchain, change string, sign int, args ...interface{}) (int64, error) {
+	rows, err := tx.Query(ctx, nftOwnerDeltasQuery(blockchain, change, sign), args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to update NFT owners: %w", err)
+	}
+
+	var changed int64
+	var tokens, owners [][]byte
+	var tokenIDs []string
+	for rows.Next() {
+		var token, owner []byte
+		var tokenID *string
+		if err := rows.Scan(&changed, &token, &tokenID, &owner); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		if tokenID != nil {
+			tokens = append(tokens, token)
+			tokenIDs = append(tokenIDs, *tokenID)
+			owners = append(owners, owner)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("failed to update NFT owners: %w", err)
+	}
+
+	if len(tokens) > 0 {
+		query := fmt.Sprintf(`DELETE FROM %s o USING unnest($1::BYTEA[], $2::NUMERIC[], $3::BYTEA[]) AS emptied(token, token_id, owner)
+WHERE o.token = emptied.token AND o.token_id = emptied.token_id AND o.owner = emptied.owner AND o.amount = 0`, NFTOwnersTableName(blockchain))
+		if _, err := tx.Exec(ctx, query, tokens, tokenIDs, owners); err != nil {
+			return 0, fmt.Errorf("failed to remove NFT owners: %w", err)
+		}
+	}
+
+	return changed, nil
+}
+
+// RebuildNFTOwners derives owners of all NFTs from all NFT transfers of blockchain again, for
+// example when owners table is added to database with transfers. It returns number of owners.
+func (p *PostgreSQLpgx) RebuildNFTOwners(ctx context.Context, blockchain string) (int64, error) {
+	tx, err := p.GetPool().Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf("TRUNCATE %s", NFTOwnersTableName(blockchain))); err != nil {
+		return 0, err
+	}
+	result, err := tx.Exec(ctx, nftBalancesQuery(blockchain, ""))
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected(), tx.Commit(ctx)
+}
+
+// ReadNFTOwners returns up to limit owners of NFTs of token, of token with id when tokenID is set,
+// or NFTs of owner when owner is set, ordered by token, token id and owner.
+func (p *PostgreSQLpgx) ReadNFTOwners(ctx context.Context, blockchain, token, tokenID, owner string, limit int) ([]NFTOwner, error) {
+	var conditions []string
+	var args []interface{}
+	if token != "" {
+		tokenBytes, err := decodeAddress(strings.ToLower(token))
+		if err != nil {
+			return nil, fmt.Errorf("invalid token address %s: %w", token, err)
+		}
+		args = append(args, tokenBytes)
+		conditions = append(conditions, fmt.Sprintf("token = $%d", len(args)))
+	}
+	if tokenID != "" {
+		args = append(args, tokenID)
+		conditions = append(conditions, fmt.Sprintf("token_id = $%d::NUMERIC", len(args)))
+	}
+	if owner != "" {
+		ownerBytes, err := decodeAddress(strings.ToLower(owner))
+		if err != nil {
+			return nil, fmt.Errorf("invalid owner address %s: %w", owner, err)
+		}
+		args = append(args, ownerBytes)
+		conditions = append(conditions, fmt.Sprintf("owner = $%d", len(args)))
+	}
+	if len(conditions) == 0 {
+		return nil, fmt.Errorf("token or owner is required")
+	}
+	// Balances are negative while transfers from owner are written before transfers to owner
+	conditions = append(conditions, "amount > 0")
+	args = append(args, limit)
+
+	query := fmt.Sprintf(`SELECT '0x' || encode(token, 'hex'), token_id::TEXT, '0x' || encode(owner, 'hex'), standard, amount::TEXT, block_number
+FROM %s WHERE %s ORDER BY token, token_id, owner LIMIT $%d`, NFTOwnersTableName(blockchain), strings.Join(conditions, " AND "), len(args))
+	rows, err := p.GetPool().Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (NFTOwner, error) {
+		var nftOwner NFTOwner
+		err := row.Scan(&nftOwner.Token, &nftOwner.TokenID, &nftOwner.Owner, &nftOwner.Standard, &nftOwner.Amount, &nftOwner.BlockNumber)
+		return nftOwner, err
+	})
+}
@@ -48,6 +48,9 @@ type TransactionIndex struct {
 	TransactionIndex uint64
 	Type             uint64
 	Path             string
+
+	// GasUsed is gas used by transaction from its receipt, nil when receipt was not fetched
+	GasUsed *uint64
 }
 
 func (t TransactionIndex) TableName() string {
@@ -106,6 +109,15 @@ func NewLogIndex(chain string, address string, blockNumber uint64, blockHash str
 	}
 }
 
+// CustomIndex is a chain specific index row which does not fit blocks, transactions
+// and logs indexes (for example UTXO inputs and outputs). It is written to the table
+// registered for its Kind with RegisterCustomIndexTable.
+type CustomIndex struct {
+	Kind   string
+	Values map[string]interface{}
+	Path   string
+}
+
 type IndexType string
 
 const (
@@ -194,6 +206,8 @@ type TransactionLabel struct {
 	TransactionHash string
 	LabelData       string
 	BlockTimestamp  uint64
+	// CallIndex is position of call expanded from transaction, empty for transaction call itself
+	CallIndex string
 }
 
 type protoEventsWithAbi struct {
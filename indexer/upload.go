@@ -1,24 +1,70 @@
 package indexer
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 )
 
 var DBConnection *PostgreSQLpgx
 var err error
 
+// IndexesDatabase is database crawler writes blocks, transactions and logs indexes to and
+// inspector reads them from. It is PostgreSQL database or database of SQL dialect, SQLite for
+// local development or MySQL.
+type IndexesDatabase interface {
+	Ping(ctx context.Context) error
+	WriteIndexes(blockchain string, blocksIndexPack []BlockIndex, transactionsIndexPack []TransactionIndex, logsIndexPack []LogIndex, customIndexPack []CustomIndex, checkpoint *Checkpoint) error
+	GetLatestDBBlockNumber(blockchain string) (uint64, error)
+	GetEdgeDBBlock(ctx context.Context, blockchain, side string) (BlockIndex, error)
+	ReadBlocksHashes(ctx context.Context, blockchain string, startBlock, endBlock uint64) (map[uint64]string, error)
+	DeleteOrphanedBlocks(ctx context.Context, blockchain string, orphaned []BlockIndex) error
+	EnsureCheckpointsTable(ctx context.Context) error
+	ReadCheckpoint(ctx context.Context, blockchain string) (*Checkpoint, error)
+	RewindCheckpoint(ctx context.Context, blockchain string, blockNumber uint64, leader string) error
+}
+
+// IndexesDB is indexes database of crawler and inspector, it is DBConnection when indexes database
+// is PostgreSQL.
+var IndexesDB IndexesDatabase
+
 // init initializes the DBConnection variable with a new PostgreSQLpgx instance.
 func InitDBConnection() {
 
+	if IsSQLDatabaseURI(MOONSTREAM_DB_V3_INDEXES_URI) {
+		fmt.Println("Error initializing DBConnection: SQLite and MySQL indexes databases are supported only by crawler and inspector")
+		return
+	}
+
 	DBConnection, err = NewPostgreSQLpgx()
 
 	if err != nil {
 		fmt.Println("Error initializing DBConnection: ", err)
+		return
+	}
+
+	IndexesDB = DBConnection
+}
+
+// InitIndexesDBConnection initializes IndexesDB with PostgreSQL, SQLite or MySQL database by
+// scheme of MOONSTREAM_DB_V3_INDEXES_URI, DBConnection is initialized only for PostgreSQL.
+func InitIndexesDBConnection() {
+	if !IsSQLDatabaseURI(MOONSTREAM_DB_V3_INDEXES_URI) {
+		InitDBConnection()
+		return
 	}
+
+	sqlDB, sqlErr := OpenSQLDatabase(MOONSTREAM_DB_V3_INDEXES_URI)
+	if sqlErr != nil {
+		fmt.Println("Error initializing indexes database: ", sqlErr)
+		return
+	}
+
+	IndexesDB = sqlDB
 }
 
 func WriteIndicesToFile(indices []interface{}, filePath string) error {
@@ -122,10 +168,10 @@ func filterDuplicates(indexType string, existingIndices, newIndices []interface{
 	if indexType == "log" {
 		// Convert the existing indices to a map for faster lookups
 		// Unique identifier for log indices is the transaction hash and log index
-		var existingLogIndices map[string]LogIndex
+		existingLogIndices := make(map[string]LogIndex)
 		for _, index := range existingIndices {
 			logIndex := index.(LogIndex)
-			uniqueKey := logIndex.TransactionHash + string(logIndex.LogIndex)
+			uniqueKey := logIndex.TransactionHash + strconv.FormatUint(logIndex.LogIndex, 10)
 			existingLogIndices[uniqueKey] = logIndex
 		}
 
@@ -133,7 +179,7 @@ func filterDuplicates(indexType string, existingIndices, newIndices []interface{
 		var filteredIndices []interface{}
 		for _, index := range newIndices {
 			logIndex := index.(LogIndex)
-			uniqueKey := logIndex.TransactionHash + string(logIndex.LogIndex)
+			uniqueKey := logIndex.TransactionHash + strconv.FormatUint(logIndex.LogIndex, 10)
 			if _, exists := existingLogIndices[uniqueKey]; !exists {
 				filteredIndices = append(filteredIndices, index)
 			}
@@ -145,9 +191,10 @@ func filterDuplicates(indexType string, existingIndices, newIndices []interface{
 	return nil
 }
 
-// WriteIndicesToDatabase writes the given indices to the database
-func WriteIndicesToDatabase(blockchain string, blocks []BlockIndex, transactions []TransactionIndex, logs []LogIndex) error {
+// WriteIndicesToDatabase writes the given indices to the database, checkpoint if it is not nil is
+// written in the same transaction
+func WriteIndicesToDatabase(blockchain string, blocks []BlockIndex, transactions []TransactionIndex, logs []LogIndex, customs []CustomIndex, checkpoint *Checkpoint) error {
 	// Write block indices
 
-	return DBConnection.WriteIndexes(blockchain, blocks, transactions, logs)
+	return IndexesDB.WriteIndexes(blockchain, blocks, transactions, logs, customs, checkpoint)
 }
@@ -0,0 +1,250 @@
+package indexer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Tables of webhooks customers registered for decoded events and of their deliveries.
+const (
+	WebhooksTableName          = "seer_webhooks"
+	WebhookDeliveriesTableName = "seer_webhook_deliveries"
+)
+
+// WebhooksTablesDDL creates webhooks and deliveries tables, synchronizer with webhooks and webhook
+// commands apply it on start.
+var WebhooksTablesDDL = fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+    id BIGSERIAL PRIMARY KEY,
+    customer_id TEXT NOT NULL,
+    blockchain TEXT NOT NULL,
+    address TEXT NOT NULL,
+    event_name TEXT NOT NULL,
+    predicates JSONB NOT NULL DEFAULT '[]',
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+CREATE INDEX IF NOT EXISTS ix_%s_blockchain ON %s (blockchain);
+CREATE TABLE IF NOT EXISTS %s (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id BIGINT NOT NULL REFERENCES %s (id) ON DELETE CASCADE,
+    blockchain TEXT NOT NULL,
+    transaction_hash TEXT NOT NULL,
+    log_index BIGINT NOT NULL,
+    block_number BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    status TEXT NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    last_error TEXT,
+    response_status INT,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (webhook_id, transaction_hash, log_index)
+);
+CREATE INDEX IF NOT EXISTS ix_%s_due ON %s (blockchain, status, next_attempt_at);
+ALTER TABLE %s ADD COLUMN IF NOT EXISTS expression TEXT NOT NULL DEFAULT '';
+`, WebhooksTableName, WebhooksTableName, WebhooksTableName, WebhookDeliveriesTableName, WebhooksTableName, WebhookDeliveriesTableName, WebhookDeliveriesTableName, WebhooksTableName)
+
+// Statuses of webhook deliveries
+const (
+	WebhookDeliveryPending   = "pending"
+	WebhookDeliveryDelivered = "delivered"
+	WebhookDeliveryFailed    = "failed"
+)
+
+// WebhookPredicate compares argument of event with value, Op is one of =, !=, >, >=, < and <=.
+type WebhookPredicate struct {
+	Arg   string `json:"arg"`
+	Op    string `json:"op"`
+	Value string `json:"value"`
+}
+
+// Webhook is URL customer receives decoded events of contract at address with name at, when
+// arguments of event satisfy all predicates and filter expression, if it is set. Deliveries are
+// signed with secret.
+type Webhook struct {
+	ID         int64
+	CustomerID string
+	Blockchain string
+	Address    string
+	EventName  string
+	Predicates []WebhookPredicate
+	Expression string
+	URL        string
+	Secret     string
+	CreatedAt  time.Time
+}
+
+// WebhookDelivery is event label sent or to be sent to webhook. URL and Secret are taken from
+// webhook when due deliveries are read.
+type WebhookDelivery struct {
+	ID              int64
+	WebhookID       int64
+	Blockchain      string
+	TransactionHash string
+	LogIndex        uint64
+	BlockNumber     uint64
+	Payload         string
+	Status          string
+	Attempts        int
+	URL             string
+	Secret          string
+}
+
+// WebhookDeliveriesStats is number of deliveries of webhook by status.
+type WebhookDeliveriesStats struct {
+	Pending   int64
+	Delivered int64
+	Failed    int64
+}
+
+// EnsureWebhooksTables creates webhooks and deliveries tables if they do not exist.
+func (p *PostgreSQLpgx) EnsureWebhooksTables(ctx context.Context) error {
+	_, err := p.GetPool().Exec(ctx, WebhooksTablesDDL)
+	return err
+}
+
+// CreateWebhook registers webhook and returns its id.
+func (p *PostgreSQLpgx) CreateWebhook(ctx context.Context, webhook Webhook) (int64, error) {
+	predicates, err := json.Marshal(webhook.Predicates)
+	if err != nil {
+		return 0, err
+	}
+	if webhook.Predicates == nil {
+		predicates = []byte("[]")
+	}
+
+	query := fmt.Sprintf("INSERT INTO %s (customer_id, blockchain, address, event_name, predicates, expression, url, secret) VALUES ($1, $2, $3, $4, $5::jsonb, $6, $7, $8) RETURNING id", WebhooksTableName)
+	var id int64
+	err = p.GetPool().QueryRow(ctx, query, webhook.CustomerID, webhook.Blockchain, webhook.Address, webhook.EventName, string(predicates), webhook.Expression, webhook.URL, webhook.Secret).Scan(&id)
+	return id, err
+}
+
+// ReadWebhooks returns webhooks of blockchain in order of registration.
+func (p *PostgreSQLpgx) ReadWebhooks(ctx context.Context, blockchain string) ([]Webhook, error) {
+	query := fmt.Sprintf("SELECT id, customer_id, blockchain, address, event_name, predicates, expression, url, secret, created_at FROM %s WHERE blockchain = $1 ORDER BY id", WebhooksTableName)
+	rows, err := p.GetPool().Query(ctx, query, blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (Webhook, error) {
+		var webhook Webhook
+		var predicates []byte
+		if err := row.Scan(&webhook.ID, &webhook.CustomerID, &webhook.Blockchain, &webhook.Address, &webhook.EventName, &predicates, &webhook.Expression, &webhook.URL, &webhook.Secret, &webhook.CreatedAt); err != nil {
+			return webhook, err
+		}
+		if err := json.Unmarshal(predicates, &webhook.Predicates); err != nil {
+			return webhook, fmt.Errorf("invalid predicates of webhook %d: %w", webhook.ID, err)
+		}
+		return webhook, nil
+	})
+}
+
+// DeleteWebhook removes webhook with its deliveries, false is returned if there is no webhook
+// with id.
+func (p *PostgreSQLpgx) DeleteWebhook(ctx context.Context, id int64) (bool, error) {
+	result, err := p.GetPool().Exec(ctx, fmt.Sprintf("DELETE FROM %s WHERE id = $1", WebhooksTableName), id)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// ReadWebhookDeliveriesStats returns number of deliveries by status of every webhook of blockchain
+// which has deliveries.
+func (p *PostgreSQLpgx) ReadWebhookDeliveriesStats(ctx context.Context, blockchain string) (map[int64]WebhookDeliveriesStats, error) {
+	query := fmt.Sprintf(`SELECT webhook_id,
+    count(*) FILTER (WHERE status = $2),
+    count(*) FILTER (WHERE status = $3),
+    count(*) FILTER (WHERE status = $4)
+FROM %s WHERE blockchain = $1 GROUP BY webhook_id`, WebhookDeliveriesTableName)
+	rows, err := p.GetPool().Query(ctx, query, blockchain, WebhookDeliveryPending, WebhookDeliveryDelivered, WebhookDeliveryFailed)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := make(map[int64]WebhookDeliveriesStats)
+	for rows.Next() {
+		var id int64
+		var webhookStats WebhookDeliveriesStats
+		if err := rows.Scan(&id, &webhookStats.Pending, &webhookStats.Delivered, &webhookStats.Failed); err != nil {
+			return nil, err
+		}
+		stats[id] = webhookStats
+	}
+
+	return stats, rows.Err()
+}
+
+// EnqueueWebhookDeliveries adds pending deliveries, events which are already queued for the same
+// webhook are skipped, so labels written again do not trigger webhooks twice. It returns number
+// of queued deliveries.
+func (p *PostgreSQLpgx) EnqueueWebhookDeliveries(ctx context.Context, deliveries []WebhookDelivery) (int64, error) {
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	query := fmt.Sprintf(`INSERT INTO %s (webhook_id, blockchain, transaction_hash, log_index, block_number, payload, status)
+SELECT * FROM unnest($1::BIGINT[], $2::TEXT[], $3::TEXT[], $4::BIGINT[], $5::BIGINT[], $6::JSONB[], $7::TEXT[])
+ON CONFLICT (webhook_id, transaction_hash, log_index) DO NOTHING`, WebhookDeliveriesTableName)
+
+	webhookIDs := make([]int64, len(deliveries))
+	blockchains := make([]string, len(deliveries))
+	transactionHashes := make([]string, len(deliveries))
+	logIndexes := make([]int64, len(deliveries))
+	blockNumbers := make([]int64, len(deliveries))
+	payloads := make([]string, len(deliveries))
+	statuses := make([]string, len(deliveries))
+	for i, delivery := range deliveries {
+		webhookIDs[i] = delivery.WebhookID
+		blockchains[i] = delivery.Blockchain
+		transactionHashes[i] = delivery.TransactionHash
+		logIndexes[i] = int64(delivery.LogIndex)
+		blockNumbers[i] = int64(delivery.BlockNumber)
+		payloads[i] = delivery.Payload
+		statuses[i] = WebhookDeliveryPending
+	}
+
+	result, err := p.GetPool().Exec(ctx, query, webhookIDs, blockchains, transactionHashes, logIndexes, blockNumbers, payloads, statuses)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+// ReadDueWebhookDeliveries returns up to limit pending deliveries of blockchain which next attempt
+// is due, the oldest first.
+func (p *PostgreSQLpgx) ReadDueWebhookDeliveries(ctx context.Context, blockchain string, limit int) ([]WebhookDelivery, error) {
+	query := fmt.Sprintf(`SELECT d.id, d.webhook_id, d.blockchain, d.transaction_hash, d.log_index, d.block_number, d.payload::TEXT, d.status, d.attempts, w.url, w.secret
+FROM %s d JOIN %s w ON w.id = d.webhook_id
+WHERE d.blockchain = $1 AND d.status = $2 AND d.next_attempt_at <= now()
+ORDER BY d.id
+LIMIT $3`, WebhookDeliveriesTableName, WebhooksTableName)
+	rows, err := p.GetPool().Query(ctx, query, blockchain, WebhookDeliveryPending, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (WebhookDelivery, error) {
+		var delivery WebhookDelivery
+		err := row.Scan(&delivery.ID, &delivery.WebhookID, &delivery.Blockchain, &delivery.TransactionHash, &delivery.LogIndex, &delivery.BlockNumber, &delivery.Payload, &delivery.Status, &delivery.Attempts, &delivery.URL, &delivery.Secret)
+		return delivery, err
+	})
+}
+
+// UpdateWebhookDelivery records result of delivery attempt. Response status is 0 when request
+// failed without response, next attempt time is used only by pending deliveries.
+func (p *PostgreSQLpgx) UpdateWebhookDelivery(ctx context.Context, id int64, status string, attempts int, responseStatus int, lastError string, nextAttemptAt time.Time) error {
+	query := fmt.Sprintf(`UPDATE %s SET status = $2, attempts = $3, response_status = NULLIF($4, 0), last_error = NULLIF($5, ''), next_attempt_at = $6,
+    delivered_at = CASE WHEN $2 = '%s' THEN now() ELSE delivered_at END
+WHERE id = $1`, WebhookDeliveriesTableName, WebhookDeliveryDelivered)
+	_, err := p.GetPool().Exec(ctx, query, id, status, attempts, responseStatus, lastError, nextAttemptAt)
+	return err
+}
@@ -0,0 +1,95 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// HealthCheckTimeout bounds time of all readiness checks of single /readyz request.
+var HealthCheckTimeout = 5 * time.Second
+
+// HealthCheck checks dependency of process, it returns short status, for example lag of crawler,
+// and error if dependency is not available.
+type HealthCheck func(ctx context.Context) (string, error)
+
+var (
+	healthChecksMux sync.Mutex
+	healthChecks    = map[string]HealthCheck{}
+)
+
+// RegisterHealthCheck adds check to readiness checks reported at /readyz, check with the same
+// name is replaced.
+func RegisterHealthCheck(name string, check HealthCheck) {
+	healthChecksMux.Lock()
+	healthChecks[name] = check
+	healthChecksMux.Unlock()
+}
+
+type healthCheckResult struct {
+	Healthy bool   `json:"healthy"`
+	Status  string `json:"status,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type readinessResponse struct {
+	Ready  bool                         `json:"ready"`
+	Checks map[string]healthCheckResult `json:"checks"`
+}
+
+// LivenessHandler returns HTTP handler which responds while process is able to serve requests.
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write([]byte("ok\n"))
+	})
+}
+
+// ReadinessHandler returns HTTP handler which runs registered health checks concurrently and
+// responds with 503 status if any of them fails.
+func ReadinessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel := context.WithTimeout(r.Context(), HealthCheckTimeout)
+		defer cancel()
+
+		healthChecksMux.Lock()
+		checks := make(map[string]HealthCheck, len(healthChecks))
+		for name, check := range healthChecks {
+			checks[name] = check
+		}
+		healthChecksMux.Unlock()
+
+		response := readinessResponse{Ready: true, Checks: make(map[string]healthCheckResult, len(checks))}
+
+		var wg sync.WaitGroup
+		var resultsMux sync.Mutex
+		for name, check := range checks {
+			wg.Add(1)
+			go func(name string, check HealthCheck) {
+				defer wg.Done()
+
+				status, err := check(ctx)
+				result := healthCheckResult{Healthy: err == nil, Status: status}
+				if err != nil {
+					result.Error = err.Error()
+				}
+
+				resultsMux.Lock()
+				response.Checks[name] = result
+				if err != nil {
+					response.Ready = false
+				}
+				resultsMux.Unlock()
+			}(name, check)
+		}
+		wg.Wait()
+
+		w.Header().Set("Content-Type", "application/json")
+		if !response.Ready {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(response)
+	})
+}
@@ -0,0 +1,249 @@
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// DefaultDurationBuckets are upper bounds in seconds of histogram buckets for request durations
+var DefaultDurationBuckets = []float64{0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// Collector writes its metrics in Prometheus text exposition format.
+type Collector interface {
+	WriteMetrics(w io.Writer)
+}
+
+var (
+	registryMux sync.Mutex
+	registry    []Collector
+)
+
+// Register adds collector to metrics exported by Handler.
+func Register(collector Collector) {
+	registryMux.Lock()
+	registry = append(registry, collector)
+	registryMux.Unlock()
+}
+
+// Handler returns HTTP handler which exports all registered metrics.
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+		registryMux.Lock()
+		collectors := append([]Collector{}, registry...)
+		registryMux.Unlock()
+
+		for _, collector := range collectors {
+			collector.WriteMetrics(w)
+		}
+	})
+}
+
+// Serve starts HTTP server with metrics at /metrics, liveness probe at /healthz and readiness
+// probe at /readyz on addr in background.
+func Serve(addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	mux.Handle("/healthz", LivenessHandler())
+	mux.Handle("/readyz", ReadinessHandler())
+
+	go func() {
+		log.Printf("Serving metrics at %s/metrics, health checks at %s/healthz and %s/readyz", addr, addr, addr)
+		if err := http.ListenAndServe(addr, mux); err != nil {
+			log.Printf("Metrics server stopped: %v", err)
+		}
+	}()
+}
+
+// CounterVec is a set of counters partitioned by label values.
+type CounterVec struct {
+	name       string
+	help       string
+	labelNames []string
+
+	mux    sync.Mutex
+	values map[string]float64
+}
+
+// NewCounterVec creates counter and registers it.
+func NewCounterVec(name, help string, labelNames ...string) *CounterVec {
+	counter := &CounterVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		values:     make(map[string]float64),
+	}
+	Register(counter)
+	return counter
+}
+
+// Add increases counter with label values, values must be in order of label names.
+func (c *CounterVec) Add(value float64, labelValues ...string) {
+	key := formatLabels(c.labelNames, labelValues)
+
+	c.mux.Lock()
+	c.values[key] += value
+	c.mux.Unlock()
+}
+
+// Inc increases counter with label values by one.
+func (c *CounterVec) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+func (c *CounterVec) WriteMetrics(w io.Writer) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s counter\n", c.name, c.help, c.name)
+	for _, key := range sortedKeys(c.values) {
+		fmt.Fprintf(w, "%s%s %g\n", c.name, key, c.values[key])
+	}
+}
+
+// GaugeVec is a set of gauges partitioned by label values.
+type GaugeVec struct {
+	name       string
+	help       string
+	labelNames []string
+
+	mux    sync.Mutex
+	values map[string]float64
+}
+
+// NewGaugeVec creates gauge and registers it.
+func NewGaugeVec(name, help string, labelNames ...string) *GaugeVec {
+	gauge := &GaugeVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		values:     make(map[string]float64),
+	}
+	Register(gauge)
+	return gauge
+}
+
+// Set sets gauge with label values, values must be in order of label names.
+func (g *GaugeVec) Set(value float64, labelValues ...string) {
+	key := formatLabels(g.labelNames, labelValues)
+
+	g.mux.Lock()
+	g.values[key] = value
+	g.mux.Unlock()
+}
+
+func (g *GaugeVec) WriteMetrics(w io.Writer) {
+	g.mux.Lock()
+	defer g.mux.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n", g.name, g.help, g.name)
+	for _, key := range sortedKeys(g.values) {
+		fmt.Fprintf(w, "%s%s %g\n", g.name, key, g.values[key])
+	}
+}
+
+// HistogramVec is a set of histograms partitioned by label values.
+type HistogramVec struct {
+	name       string
+	help       string
+	labelNames []string
+	buckets    []float64
+
+	mux    sync.Mutex
+	values map[string]*histogramValue
+}
+
+type histogramValue struct {
+	labelValues []string
+	counts      []uint64
+	count       uint64
+	sum         float64
+}
+
+// NewHistogramVec creates histogram with buckets upper bounds and registers it.
+func NewHistogramVec(name, help string, buckets []float64, labelNames ...string) *HistogramVec {
+	histogram := &HistogramVec{
+		name:       name,
+		help:       help,
+		labelNames: labelNames,
+		buckets:    buckets,
+		values:     make(map[string]*histogramValue),
+	}
+	Register(histogram)
+	return histogram
+}
+
+// Observe adds observation to histogram with label values.
+func (h *HistogramVec) Observe(value float64, labelValues ...string) {
+	key := formatLabels(h.labelNames, labelValues)
+
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	v, ok := h.values[key]
+	if !ok {
+		v = &histogramValue{
+			labelValues: append([]string{}, labelValues...),
+			counts:      make([]uint64, len(h.buckets)),
+		}
+		h.values[key] = v
+	}
+	for i, bound := range h.buckets {
+		if value <= bound {
+			v.counts[i]++
+		}
+	}
+	v.count++
+	v.sum += value
+}
+
+func (h *HistogramVec) WriteMetrics(w io.Writer) {
+	h.mux.Lock()
+	defer h.mux.Unlock()
+
+	fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name)
+	for _, key := range sortedKeys(h.values) {
+		v := h.values[key]
+		for i, bound := range h.buckets {
+			bucketLabels := formatLabels(append(append([]string{}, h.labelNames...), "le"), append(append([]string{}, v.labelValues...), fmt.Sprintf("%g", bound)))
+			fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, bucketLabels, v.counts[i])
+		}
+		infLabels := formatLabels(append(append([]string{}, h.labelNames...), "le"), append(append([]string{}, v.labelValues...), "+Inf"))
+		fmt.Fprintf(w, "%s_bucket%s %d\n", h.name, infLabels, v.count)
+		fmt.Fprintf(w, "%s_sum%s %g\n", h.name, key, v.sum)
+		fmt.Fprintf(w, "%s_count%s %d\n", h.name, key, v.count)
+	}
+}
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		var value string
+		if i < len(values) {
+			value = values[i]
+		}
+		value = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+		pairs[i] = fmt.Sprintf(`%s="%s"`, name, value)
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS {{.Blockchain}}_logs;
+
+DROP TABLE IF EXISTS {{.Blockchain}}_transactions;
+
+DROP TABLE IF EXISTS {{.Blockchain}}_blocks;
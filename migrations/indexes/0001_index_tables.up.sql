@@ -0,0 +1,55 @@
+CREATE TABLE IF NOT EXISTS {{.Blockchain}}_blocks (
+    block_number BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    block_timestamp BIGINT NOT NULL,
+    parent_hash TEXT NOT NULL,
+    row_id BIGINT NOT NULL,
+    path TEXT NOT NULL,
+{{- if .WithL1Chain}}
+    l1_block_number BIGINT,
+{{- end}}
+    indexed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (block_number)
+);
+
+CREATE INDEX IF NOT EXISTS ix_{{.Blockchain}}_blocks_block_hash ON {{.Blockchain}}_blocks (block_hash);
+
+CREATE INDEX IF NOT EXISTS ix_{{.Blockchain}}_blocks_block_timestamp ON {{.Blockchain}}_blocks (block_timestamp);
+
+CREATE TABLE IF NOT EXISTS {{.Blockchain}}_transactions (
+    hash TEXT NOT NULL,
+    block_number BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    index BIGINT NOT NULL,
+    type INT,
+    from_address BYTEA,
+    to_address BYTEA,
+    selector TEXT,
+    row_id BIGINT NOT NULL,
+    path TEXT NOT NULL,
+    indexed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (hash)
+);
+
+CREATE INDEX IF NOT EXISTS ix_{{.Blockchain}}_transactions_block_number ON {{.Blockchain}}_transactions (block_number);
+
+CREATE INDEX IF NOT EXISTS ix_{{.Blockchain}}_transactions_to_address_selector ON {{.Blockchain}}_transactions (to_address, selector);
+
+CREATE TABLE IF NOT EXISTS {{.Blockchain}}_logs (
+    transaction_hash TEXT NOT NULL,
+    block_hash TEXT NOT NULL,
+    address BYTEA NOT NULL,
+    selector TEXT,
+    topic1 TEXT,
+    topic2 TEXT,
+    topic3 TEXT,
+    row_id BIGINT NOT NULL,
+    log_index BIGINT NOT NULL,
+    path TEXT NOT NULL,
+    indexed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (transaction_hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS ix_{{.Blockchain}}_logs_block_hash ON {{.Blockchain}}_logs (block_hash);
+
+CREATE INDEX IF NOT EXISTS ix_{{.Blockchain}}_logs_address_selector ON {{.Blockchain}}_logs (address, selector);
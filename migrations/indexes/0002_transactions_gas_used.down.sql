@@ -0,0 +1 @@
+ALTER TABLE {{.Blockchain}}_transactions DROP COLUMN IF EXISTS gas_used;
@@ -0,0 +1 @@
+ALTER TABLE {{.Blockchain}}_transactions ADD COLUMN IF NOT EXISTS gas_used BIGINT;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS {{.Blockchain}}_labels (
+    id UUID NOT NULL,
+    label TEXT NOT NULL,
+    transaction_hash TEXT NOT NULL,
+    log_index BIGINT,
+    block_number BIGINT NOT NULL,
+    block_hash TEXT NOT NULL,
+    block_timestamp BIGINT NOT NULL,
+    caller_address BYTEA,
+    origin_address BYTEA,
+    address BYTEA NOT NULL,
+    label_name TEXT,
+    label_type TEXT,
+    label_data JSONB,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS ix_{{.Blockchain}}_labels_block_number ON {{.Blockchain}}_labels (block_number);
+
+CREATE INDEX IF NOT EXISTS ix_{{.Blockchain}}_labels_block_hash ON {{.Blockchain}}_labels (block_hash);
+
+CREATE INDEX IF NOT EXISTS ix_{{.Blockchain}}_labels_address_block_number ON {{.Blockchain}}_labels (address, block_number);
+
+-- Labels are written with ON CONFLICT DO NOTHING, unique indexes keep synchronizer from writing
+-- labels of the same transaction call or event twice
+CREATE UNIQUE INDEX IF NOT EXISTS uk_{{.Blockchain}}_labels_transaction_hash_tx_call ON {{.Blockchain}}_labels (transaction_hash, label) WHERE label_type = 'tx_call';
+
+CREATE UNIQUE INDEX IF NOT EXISTS uk_{{.Blockchain}}_labels_transaction_hash_log_index_event ON {{.Blockchain}}_labels (transaction_hash, log_index, label) WHERE label_type = 'event';
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS uk_{{.Blockchain}}_labels_call_index;
+
+ALTER TABLE {{.Blockchain}}_labels DROP COLUMN IF EXISTS call_index;
@@ -0,0 +1,8 @@
+ALTER TABLE {{.Blockchain}}_labels ADD COLUMN IF NOT EXISTS call_index TEXT;
+
+-- Labels of failed transaction calls and deployments are unique by transaction, labels of calls
+-- expanded from transaction (sub-calls and calls of user operations) by position of call in it.
+-- Call index is empty for transaction call itself, labels written before the column was added
+-- have no call index and are not covered. Label type is not part of key, MySQL limits length of
+-- key, and label types of transaction call and of expanded calls do not share call index.
+CREATE UNIQUE INDEX IF NOT EXISTS uk_{{.Blockchain}}_labels_call_index ON {{.Blockchain}}_labels (transaction_hash, label, call_index) WHERE label_type IN ('tx_call_failed', 'deployment', 'tx_subcall', 'user_operation');
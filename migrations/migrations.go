@@ -0,0 +1,298 @@
+// Package migrations ships schema of index tables of indexes database and labels tables of
+// customer databases as versioned PostgreSQL migrations embedded into seer binary. Migrations
+// are templates of blockchain, applied migrations are recorded per blockchain in database.
+// Migrations are the only source of schema: up migrations of target together are DDL which
+// indexer converts for SQLite and MySQL databases and generates for new chains, so their
+// statements should be idempotent.
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/moonstream-to/seer/progress"
+)
+
+const (
+	// IndexesTarget is target of migrations of index tables in indexes database
+	IndexesTarget = "indexes"
+	// LabelsTarget is target of migrations of labels tables in customer databases
+	LabelsTarget = "labels"
+)
+
+//go:embed indexes/*.sql labels/*.sql
+var migrationFiles embed.FS
+
+var migrationFileRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is versioned change of schema of target, up and down are templates of SQL.
+type Migration struct {
+	Version int
+	Name    string
+	up      string
+	down    string
+}
+
+// templateData is data migrations are rendered with.
+type templateData struct {
+	Blockchain  string
+	WithL1Chain bool
+}
+
+// Targets returns targets with migrations.
+func Targets() []string {
+	return []string{IndexesTarget, LabelsTarget}
+}
+
+// Load returns migrations of target ordered by version.
+func Load(target string) ([]Migration, error) {
+	entries, err := fs.ReadDir(migrationFiles, target)
+	if err != nil {
+		return nil, fmt.Errorf("unknown migrations target %s, choose one of: %s", target, strings.Join(Targets(), ", "))
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		match := migrationFileRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid name of migration file %s/%s", target, entry.Name())
+		}
+
+		content, err := migrationFiles.ReadFile(path.Join(target, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		version, _ := strconv.Atoi(match[1])
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migrations %s and %s of %s have the same version %d", migration.Name, match[2], target, version)
+		}
+
+		if match[3] == "up" {
+			migration.up = string(content)
+		} else {
+			migration.down = string(content)
+		}
+	}
+
+	var migrations []Migration
+	for _, migration := range byVersion {
+		if migration.up == "" || migration.down == "" {
+			return nil, fmt.Errorf("migration %d_%s of %s requires both up and down files", migration.Version, migration.Name, target)
+		}
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	return migrations, nil
+}
+
+// render renders SQL template of migration for blockchain.
+func render(name, sqlTemplate, blockchain string, withL1Chain bool) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(sqlTemplate)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse migration %s: %w", name, err)
+	}
+
+	var rendered strings.Builder
+	data := templateData{Blockchain: blockchain, WithL1Chain: withL1Chain}
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render migration %s: %w", name, err)
+	}
+
+	return rendered.String(), nil
+}
+
+// Schema returns PostgreSQL DDL of target for blockchain, all up migrations of target rendered in
+// order of versions. L1 block number column is added to blocks table of chains with L1 chain.
+func Schema(target, blockchain string, withL1Chain bool) (string, error) {
+	migrations, err := Load(target)
+	if err != nil {
+		return "", err
+	}
+
+	statements := make([]string, len(migrations))
+	for i, migration := range migrations {
+		statements[i], err = render(fmt.Sprintf("%d_%s.up", migration.Version, migration.Name), migration.up, blockchain, withL1Chain)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return strings.Join(statements, "\n"), nil
+}
+
+// MigrationStatus is migration with time it was applied to database.
+type MigrationStatus struct {
+	Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Database is PostgreSQL database migrations are applied to.
+type Database interface {
+	EnsureSchemaMigrationsTable(ctx context.Context) error
+	// ReadAppliedMigrations returns time migrations of target were applied for blockchain at by
+	// version.
+	ReadAppliedMigrations(ctx context.Context, target, blockchain string) (map[int]time.Time, error)
+	ApplyMigration(ctx context.Context, target, blockchain string, version int, name, query string, up bool) (bool, error)
+	EnsureCustomIndexTables(ctx context.Context, blockchain string) error
+}
+
+// Migrator applies migrations of target for blockchain to PostgreSQL database.
+type Migrator struct {
+	db          Database
+	target      string
+	blockchain  string
+	withL1Chain bool
+	migrations  []Migration
+}
+
+// NewMigrator creates migrator of target for blockchain, withL1Chain is set for chains which
+// blocks reference block of L1 chain.
+func NewMigrator(db Database, target, blockchain string, withL1Chain bool) (*Migrator, error) {
+	migrations, err := Load(target)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db: db, target: target, blockchain: blockchain, withL1Chain: withL1Chain, migrations: migrations}, nil
+}
+
+// Status returns migrations of target with their state in database.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	if err := m.db.EnsureSchemaMigrationsTable(ctx); err != nil {
+		return nil, err
+	}
+
+	applied, err := m.db.ReadAppliedMigrations(ctx, m.target, m.blockchain)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i] = MigrationStatus{Migration: migration}
+		if appliedAt, ok := applied[migration.Version]; ok {
+			statuses[i].Applied = true
+			statuses[i].AppliedAt = appliedAt
+		}
+	}
+
+	return statuses, nil
+}
+
+// Up applies pending migrations up to version inclusive, all pending migrations when version is
+// 0. Migrations of indexes are followed by custom index tables registered for blockchain, they
+// are created if they do not exist and are not versioned. It returns number of applied
+// migrations.
+func (m *Migrator) Up(ctx context.Context, toVersion int) (int, error) {
+	if err := m.db.EnsureSchemaMigrationsTable(ctx); err != nil {
+		return 0, err
+	}
+
+	var pending []Migration
+	for _, migration := range m.migrations {
+		if toVersion > 0 && migration.Version > toVersion {
+			break
+		}
+		pending = append(pending, migration)
+	}
+
+	upProgress := progress.NewTracker(fmt.Sprintf("migrate %s %s up", m.target, m.blockchain), uint64(len(pending)))
+	defer upProgress.Finish()
+
+	var applied int
+	for _, migration := range pending {
+
+		query, err := render(fmt.Sprintf("%d_%s.up", migration.Version, migration.Name), migration.up, m.blockchain, m.withL1Chain)
+		if err != nil {
+			return applied, err
+		}
+
+		ok, err := m.db.ApplyMigration(ctx, m.target, m.blockchain, migration.Version, migration.Name, query, true)
+		if err != nil {
+			return applied, fmt.Errorf("failed to apply migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if ok {
+			log.Printf("Applied migration %d_%s of %s for %s", migration.Version, migration.Name, m.target, m.blockchain)
+			applied++
+		}
+		upProgress.Add(1)
+	}
+
+	if m.target == IndexesTarget {
+		if err := m.db.EnsureCustomIndexTables(ctx, m.blockchain); err != nil {
+			return applied, fmt.Errorf("failed to create custom index tables: %w", err)
+		}
+	}
+
+	return applied, nil
+}
+
+// Down reverts applied migrations after version in reverse order, only the latest applied
+// migration when version is negative. It returns number of reverted migrations.
+func (m *Migrator) Down(ctx context.Context, toVersion int) (int, error) {
+	if err := m.db.EnsureSchemaMigrationsTable(ctx); err != nil {
+		return 0, err
+	}
+
+	total := 1
+	if toVersion >= 0 {
+		total = 0
+		for _, migration := range m.migrations {
+			if migration.Version > toVersion {
+				total++
+			}
+		}
+	}
+
+	downProgress := progress.NewTracker(fmt.Sprintf("migrate %s %s down", m.target, m.blockchain), uint64(total))
+	defer downProgress.Finish()
+
+	var reverted int
+	for i := len(m.migrations) - 1; i >= 0; i-- {
+		migration := m.migrations[i]
+		if toVersion >= 0 && migration.Version <= toVersion {
+			break
+		}
+
+		query, err := render(fmt.Sprintf("%d_%s.down", migration.Version, migration.Name), migration.down, m.blockchain, m.withL1Chain)
+		if err != nil {
+			return reverted, err
+		}
+
+		ok, err := m.db.ApplyMigration(ctx, m.target, m.blockchain, migration.Version, migration.Name, query, false)
+		if err != nil {
+			return reverted, fmt.Errorf("failed to revert migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		if ok {
+			log.Printf("Reverted migration %d_%s of %s for %s", migration.Version, migration.Name, m.target, m.blockchain)
+			reverted++
+			if toVersion < 0 {
+				downProgress.Add(1)
+				break
+			}
+		}
+		if toVersion >= 0 {
+			downProgress.Add(1)
+		}
+	}
+
+	return reverted, nil
+}
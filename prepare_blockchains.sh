@@ -9,14 +9,26 @@ for PROTO in $PROTO_FILES; do
   echo "Regenerated from proto: $PROTO"
 done
 
+# Blockchains with hand written clients, not generated from blockchain.go.tmpl
+NON_TEMPLATE_BLOCKCHAINS="aptos bitcoin near substrate sui ton"
+
 BLOCKCHAIN_NAMES_RAW=$(find blockchain/ -maxdepth 1 -type d | cut -f2 -d '/')
 for BLOCKCHAIN in $BLOCKCHAIN_NAMES_RAW; do
+  if echo " $NON_TEMPLATE_BLOCKCHAINS " | grep -q " $BLOCKCHAIN "; then
+    continue
+  fi
   if [ "$BLOCKCHAIN" != "" ] && [ "$BLOCKCHAIN" != "common" ]; then
-    if [ "$BLOCKCHAIN" != "ethereum" ] && [ "$BLOCKCHAIN" != "polygon" ] && [ "$BLOCKCHAIN" != "mantle" ] && [ "$BLOCKCHAIN" != "mantle_sepolia" ] && [ "$BLOCKCHAIN" != "sepolia" ] && [ "$BLOCKCHAIN" != "imx_zkevm" ] && [ "$BLOCKCHAIN" != "imx_zkevm_sepolia" ]; then
-      ./seer blockchain generate -n $BLOCKCHAIN --side-chain
+    if [ "$BLOCKCHAIN" = "zksync_era" ] || [ "$BLOCKCHAIN" = "zksync_era_sepolia" ]; then
+      ./seer blockchain generate --skip-protoc -n $BLOCKCHAIN --zksync
+      echo "Generated interface for zkSync Era blockchain $BLOCKCHAIN"
+    elif [ "$BLOCKCHAIN" = "optimism" ] || [ "$BLOCKCHAIN" = "base" ] || [ "$BLOCKCHAIN" = "base_sepolia" ]; then
+      ./seer blockchain generate --skip-protoc -n $BLOCKCHAIN --op-stack
+      echo "Generated interface for OP-stack blockchain $BLOCKCHAIN"
+    elif [ "$BLOCKCHAIN" != "ethereum" ] && [ "$BLOCKCHAIN" != "polygon" ] && [ "$BLOCKCHAIN" != "mantle" ] && [ "$BLOCKCHAIN" != "mantle_sepolia" ] && [ "$BLOCKCHAIN" != "sepolia" ] && [ "$BLOCKCHAIN" != "imx_zkevm" ] && [ "$BLOCKCHAIN" != "imx_zkevm_sepolia" ]; then
+      ./seer blockchain generate --skip-protoc -n $BLOCKCHAIN --side-chain
       echo "Generated interface for side-chain blockchain $BLOCKCHAIN"
     else
-      ./seer blockchain generate -n $BLOCKCHAIN
+      ./seer blockchain generate --skip-protoc -n $BLOCKCHAIN
       echo "Generated interface for blockchain $BLOCKCHAIN"
     fi
   fi
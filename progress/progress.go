@@ -0,0 +1,243 @@
+package progress
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/moonstream-to/seer/metrics"
+	"golang.org/x/term"
+)
+
+var (
+	// LogInterval is how often progress line is written when output is not a terminal
+	LogInterval = 30 * time.Second
+
+	// redrawInterval limits how often progress bar is redrawn in terminal
+	redrawInterval = 200 * time.Millisecond
+
+	// RateWindow is period current throughput is measured over
+	RateWindow = 30 * time.Second
+)
+
+var (
+	progressDone      = metrics.NewGaugeVec("seer_progress_done", "Units processed by long running operation", "operation")
+	progressTotal     = metrics.NewGaugeVec("seer_progress_total", "Total units of long running operation", "operation")
+	progressRemaining = metrics.NewGaugeVec("seer_progress_remaining", "Units left to process by long running operation", "operation")
+	progressRate      = metrics.NewGaugeVec("seer_progress_rate", "Units processed per second during the last rate window", "operation")
+	progressETA       = metrics.NewGaugeVec("seer_progress_eta_seconds", "Estimated seconds until long running operation is completed, 0 if it could not be estimated", "operation")
+)
+
+// Tracker reports progress of long running operations such as backfills, exports,
+// migrations and prunes. When stderr is attached to a terminal it renders a bar
+// with rate and ETA, otherwise it writes periodic structured log lines. Progress
+// is also exported in seer_progress_* metrics labeled with operation.
+type Tracker struct {
+	mu sync.Mutex
+
+	operation  string
+	total      uint64
+	current    uint64
+	startedAt  time.Time
+	reportedAt time.Time
+
+	// Current throughput is measured from sample taken at the start of rate window
+	windowStartedAt time.Time
+	windowStart     uint64
+	windowRate      float64
+
+	out        io.Writer
+	isTerminal bool
+	finished   bool
+}
+
+// NewTracker creates progress tracker for operation with known total amount of units (blocks, rows, files).
+func NewTracker(operation string, total uint64) *Tracker {
+	now := time.Now()
+	t := &Tracker{
+		operation:       operation,
+		total:           total,
+		startedAt:       now,
+		reportedAt:      now,
+		windowStartedAt: now,
+		out:             os.Stderr,
+		isTerminal:      term.IsTerminal(int(os.Stderr.Fd())),
+	}
+	t.publish()
+	return t
+}
+
+// Add increases amount of processed units.
+func (t *Tracker) Add(n uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.current += n
+	t.publish()
+	t.report(false)
+}
+
+// Set sets amount of processed units.
+func (t *Tracker) Set(current uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.current = current
+	t.publish()
+	t.report(false)
+}
+
+// SetTotal updates total amount of units, useful when it grows during operation.
+func (t *Tracker) SetTotal(total uint64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.total = total
+	t.publish()
+}
+
+// Finish writes final progress state.
+func (t *Tracker) Finish() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.finished {
+		return
+	}
+	t.report(true)
+	t.finished = true
+	if t.isTerminal {
+		fmt.Fprintln(t.out)
+	}
+}
+
+// Rate returns average amount of processed units per second.
+func (t *Tracker) Rate() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.rate()
+}
+
+// CurrentRate returns amount of processed units per second during the last rate window, average
+// rate is returned until the first window is completed.
+func (t *Tracker) CurrentRate() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.currentRate()
+}
+
+// Remaining returns amount of units left to process.
+func (t *Tracker) Remaining() uint64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.remaining()
+}
+
+// ETA returns estimated time left, zero if it could not be estimated.
+func (t *Tracker) ETA() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.eta()
+}
+
+func (t *Tracker) rate() float64 {
+	elapsed := time.Since(t.startedAt).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(t.current) / elapsed
+}
+
+func (t *Tracker) currentRate() float64 {
+	now := time.Now()
+	if elapsed := now.Sub(t.windowStartedAt); elapsed >= RateWindow {
+		if t.current >= t.windowStart {
+			t.windowRate = float64(t.current-t.windowStart) / elapsed.Seconds()
+		}
+		t.windowStartedAt = now
+		t.windowStart = t.current
+	}
+
+	if t.windowRate > 0 {
+		return t.windowRate
+	}
+	return t.rate()
+}
+
+func (t *Tracker) remaining() uint64 {
+	if t.current >= t.total {
+		return 0
+	}
+	return t.total - t.current
+}
+
+// eta is estimated from current throughput, so it follows slowdowns of operation
+func (t *Tracker) eta() time.Duration {
+	rate := t.currentRate()
+	if rate <= 0 || t.current >= t.total {
+		return 0
+	}
+	return time.Duration(float64(t.remaining()) / rate * float64(time.Second))
+}
+
+func (t *Tracker) percent() float64 {
+	if t.total == 0 {
+		return 0
+	}
+	percent := float64(t.current) / float64(t.total) * 100
+	if percent > 100 {
+		percent = 100
+	}
+	return percent
+}
+
+// publish exports progress in metrics
+func (t *Tracker) publish() {
+	progressDone.Set(float64(t.current), t.operation)
+	progressTotal.Set(float64(t.total), t.operation)
+	progressRemaining.Set(float64(t.remaining()), t.operation)
+	progressRate.Set(t.currentRate(), t.operation)
+	progressETA.Set(t.eta().Seconds(), t.operation)
+}
+
+func (t *Tracker) report(force bool) {
+	if t.finished {
+		return
+	}
+
+	interval := LogInterval
+	if t.isTerminal {
+		interval = redrawInterval
+	}
+	if !force && time.Since(t.reportedAt) < interval {
+		return
+	}
+	t.reportedAt = time.Now()
+
+	if t.isTerminal {
+		t.renderBar()
+		return
+	}
+
+	log.Printf("progress operation=%q done=%d total=%d remaining=%d percent=%.2f rate=%.2f/s avg_rate=%.2f/s elapsed=%s eta=%s", t.operation, t.current, t.total, t.remaining(), t.percent(), t.currentRate(), t.rate(), time.Since(t.startedAt).Round(time.Second), t.eta().Round(time.Second))
+}
+
+func (t *Tracker) renderBar() {
+	barWidth := 30
+	if width, _, err := term.GetSize(int(os.Stderr.Fd())); err == nil && width < 100 {
+		barWidth = 10
+	}
+
+	filled := int(t.percent() / 100 * float64(barWidth))
+	bar := strings.Repeat("=", filled) + strings.Repeat(" ", barWidth-filled)
+
+	fmt.Fprintf(t.out, "\r%s [%s] %6.2f%% %d/%d %.2f/s ETA %s\033[K", t.operation, bar, t.percent(), t.current, t.total, t.currentRate(), t.eta().Round(time.Second))
+}
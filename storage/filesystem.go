@@ -9,6 +9,8 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+
+	"github.com/moonstream-to/seer/progress"
 )
 
 type FileStorage struct {
@@ -144,3 +146,42 @@ func (fs *FileStorage) Delete(key string) error {
 	// Implement the Delete method
 	return nil
 }
+
+// Archive moves base directory to tier directory, directories must be on the same filesystem.
+func (fs *FileStorage) Archive(ctx context.Context, tier string) (int, error) {
+	var filesNum int
+	walkErr := filepath.WalkDir(fs.BasePath, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			filesNum++
+		}
+		return nil
+	})
+	if walkErr != nil {
+		return 0, walkErr
+	}
+
+	if err := os.MkdirAll(tier, os.ModePerm); err != nil {
+		return 0, err
+	}
+
+	archivePath := filepath.Join(tier, filepath.Base(fs.BasePath))
+	if _, err := os.Stat(archivePath); err == nil {
+		return 0, fmt.Errorf("archive directory %s already exists", archivePath)
+	}
+
+	// Files are moved with their directory at once, so progress is completed by rename
+	archiveProgress := progress.NewTracker(fmt.Sprintf("archive %s", fs.BasePath), uint64(filesNum))
+	defer archiveProgress.Finish()
+
+	if err := os.Rename(fs.BasePath, archivePath); err != nil {
+		return 0, fmt.Errorf("failed to move %s to %s: %w", fs.BasePath, archivePath, err)
+	}
+	archiveProgress.Set(uint64(filesNum))
+
+	log.Printf("Moved %d files from %s to %s", filesNum, fs.BasePath, archivePath)
+
+	return filesNum, nil
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"cloud.google.com/go/storage"
+	"github.com/moonstream-to/seer/progress"
 	"google.golang.org/api/iterator"
 )
 
@@ -216,3 +217,46 @@ func (g *GCS) ReadBatch(readItems []ReadItem) (map[string][]string, error) {
 
 	return result, nil
 }
+
+// Archive rewrites objects under base path with tier storage class, e.g. COLDLINE or ARCHIVE.
+// Objects keep their names, so data stays readable by inspector.
+func (g *GCS) Archive(ctx context.Context, tier string) (int, error) {
+	bucket := g.Client.Bucket(SeerCrawlerStorageBucket)
+
+	it := bucket.Objects(ctx, &storage.Query{Prefix: fmt.Sprintf("%s/", g.BasePath)})
+
+	// Objects are listed first, so progress of rewrites has total
+	var names []string
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return 0, fmt.Errorf("Bucket(%q).Objects: %w", SeerCrawlerStorageBucket, err)
+		}
+		if attrs.StorageClass == tier {
+			continue
+		}
+		names = append(names, attrs.Name)
+	}
+
+	archiveProgress := progress.NewTracker(fmt.Sprintf("archive %s", g.BasePath), uint64(len(names)))
+	defer archiveProgress.Finish()
+
+	archived := 0
+	for _, name := range names {
+		obj := bucket.Object(name)
+		copier := obj.CopierFrom(obj)
+		copier.StorageClass = tier
+		if _, err := copier.Run(ctx); err != nil {
+			return archived, fmt.Errorf("failed to move %s to %s storage class: %w", name, tier, err)
+		}
+		archived++
+		archiveProgress.Add(1)
+	}
+
+	log.Printf("Moved %d objects with prefix %s to %s storage class", archived, g.BasePath, tier)
+
+	return archived, nil
+}
@@ -19,3 +19,11 @@ type ReadItem struct {
 	Key    string
 	RowIds []uint64
 }
+
+// Archiver is implemented by storages which could move all data under base path to cold tier,
+// for example when chain is decommissioned. Meaning of tier depends on storage: storage class
+// for Google Cloud Storage and target directory for filesystem. It returns number of archived
+// objects.
+type Archiver interface {
+	Archive(ctx context.Context, tier string) (int, error)
+}
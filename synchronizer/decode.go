@@ -0,0 +1,283 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	seer_blockchain "github.com/moonstream-to/seer/blockchain"
+	seer_common "github.com/moonstream-to/seer/blockchain/common"
+	"github.com/moonstream-to/seer/crawler"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+	"github.com/moonstream-to/seer/storage"
+	"github.com/moonstream-to/seer/webhooks"
+)
+
+// DefaultDecodeWorkers is number of proto batches of customer decoded concurrently.
+const DefaultDecodeWorkers = 4
+
+// labelsChunkSize is number of labels after which decoded batches are written to customer database.
+const labelsChunkSize = 10000
+
+var decodedBatches = metrics.NewCounterVec("seer_synchronizer_decoded_batches_total",
+	"Proto batches decoded to labels by synchronizer", "chain")
+
+// SetDecodeWorkers sets number of proto batches of customer decoded concurrently.
+func (d *Synchronizer) SetDecodeWorkers(workers int) {
+	if workers < 1 {
+		workers = 1
+	}
+	d.decodeWorkers = workers
+}
+
+// SetResolveUnknownSelectors enables decoding of transactions and events of customer contracts
+// which selectors are missing in ABIs, signature resolver must be set with
+// seer_common.SetSignatureResolver.
+func (d *Synchronizer) SetResolveUnknownSelectors(resolve bool) {
+	d.resolveUnknownSelectors = resolve
+}
+
+// SetExpandMulticalls enables reading of multicall transactions for customers, expansion of
+// them into sub-calls must be enabled in client with SetExpandMulticalls.
+func (d *Synchronizer) SetExpandMulticalls(expand bool) {
+	d.multicallSelectors = nil
+	if expand {
+		d.multicallSelectors = seer_common.MulticallSelectors()
+	}
+}
+
+// SetWebhooks enables queueing of webhook deliveries of event labels written to customer
+// databases, deliveries are sent by webhooks.Dispatcher.
+func (d *Synchronizer) SetWebhooks(notifier *webhooks.Notifier) {
+	d.notifier = notifier
+}
+
+// decodedBatch is labels decoded from proto batch in storage, or part of them when batch is
+// decoded block by block.
+type decodedBatch struct {
+	events       []indexer.EventLabel
+	transactions []indexer.TransactionLabel
+	err          error
+}
+
+// syncCustomer decodes proto batches of customer update with pool of workers and writes labels in
+// order of batches. Consecutive decoded batches are written in chunks as soon as all previous
+// batches are decoded, workers decode at most twice their number of batches ahead of writes.
+// Clients which decode batches block by block pass labels to writer in parts, so worker holds at
+// most two parts of batch which writer did not reach yet.
+func (d *Synchronizer) syncCustomer(ctx context.Context, update indexer.CustomerUpdates, customer CustomerDBConnection) error {
+	// Events and transactions grouped by path for batch reading
+	groupByPath := make(map[string][]uint64)
+	for _, event := range update.Data.Events {
+		groupByPath[event.Path] = append(groupByPath[event.Path], event.RowID)
+	}
+	for _, transaction := range update.Data.Transactions {
+		if _, ok := groupByPath[transaction.Path]; !ok {
+			groupByPath[transaction.Path] = nil
+		}
+	}
+
+	var readItems []storage.ReadItem
+	for path, rowIds := range groupByPath {
+		readItems = append(readItems, storage.ReadItem{Key: path, RowIds: rowIds})
+	}
+	sort.Slice(readItems, func(i, j int) bool {
+		return packStartBlock(readItems[i].Key) < packStartBlock(readItems[j].Key)
+	})
+
+	if len(readItems) == 0 {
+		return nil
+	}
+
+	if d.tokenTransfers && customer.Pgx != nil {
+		if _, ok := d.transfersTables.Load(update.CustomerID); !ok {
+			if err := customer.Pgx.EnsureTokenTransfersTables(ctx, d.blockchain); err != nil {
+				return fmt.Errorf("error creating token transfers tables for customer %s: %w", update.CustomerID, err)
+			}
+			d.transfersTables.Store(update.CustomerID, true)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	workers := d.decodeWorkers
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]chan decodedBatch, len(readItems))
+	for i := range results {
+		results[i] = make(chan decodedBatch, 1)
+	}
+
+	// Batches are dispatched in order and only when writer is close enough to them
+	ahead := make(chan struct{}, 2*workers)
+	jobs := make(chan int)
+	go func() {
+		defer close(jobs)
+		for i := range readItems {
+			select {
+			case ahead <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				d.decodeBatch(ctx, update, readItems[i], results[i])
+			}
+		}()
+	}
+	// Dispatch is cancelled before waiting for workers when writer stops early
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	var eventsChunk []indexer.EventLabel
+	var transactionsChunk []indexer.TransactionLabel
+	writeChunk := func() error {
+		if len(eventsChunk) == 0 && len(transactionsChunk) == 0 {
+			return nil
+		}
+		if err := customer.Labels.WriteLabes(d.blockchain, transactionsChunk, eventsChunk); err != nil {
+			return fmt.Errorf("error writing labels for customer %s: %w", update.CustomerID, err)
+		}
+		if d.tokenTransfers && customer.Pgx != nil {
+			if err := d.writeTokenTransfers(ctx, update, customer, eventsChunk); err != nil {
+				return err
+			}
+		}
+		if d.notifier != nil {
+			if err := d.notifier.Enqueue(ctx, update.CustomerID, eventsChunk); err != nil {
+				return err
+			}
+		}
+		eventsChunk, transactionsChunk = nil, nil
+		return nil
+	}
+
+	for i := range readItems {
+	parts:
+		for {
+			var batch decodedBatch
+			var ok bool
+			select {
+			case batch, ok = <-results[i]:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			if !ok {
+				break parts
+			}
+
+			if batch.err != nil {
+				return batch.err
+			}
+
+			eventsChunk = append(eventsChunk, batch.events...)
+			transactionsChunk = append(transactionsChunk, batch.transactions...)
+			if len(eventsChunk)+len(transactionsChunk) >= labelsChunkSize {
+				if err := writeChunk(); err != nil {
+					return err
+				}
+			}
+		}
+		<-ahead
+	}
+
+	return writeChunk()
+}
+
+// decodeBatch reads proto batch from storage, decodes it with ABIs of customer and sends labels to
+// out, which is closed when batch is decoded. Clients implementing ProtoLabelsStreamer decode batch
+// block by block and labels are sent in parts of about labelsChunkSize labels.
+func (d *Synchronizer) decodeBatch(ctx context.Context, update indexer.CustomerUpdates, item storage.ReadItem, out chan<- decodedBatch) {
+	defer close(out)
+
+	// Sends are cancelled when writer stops early
+	send := func(batch decodedBatch) error {
+		select {
+		case out <- batch:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if crawler.SEER_CRAWLER_DEBUG {
+		log.Printf("Key: %s", item.Key)
+	}
+
+	rawData, readErr := d.StorageInstance.Read(item.Key)
+	if readErr != nil {
+		send(decodedBatch{err: fmt.Errorf("error reading events for customer %s: %w", update.CustomerID, readErr)})
+		return
+	}
+
+	streamer, ok := d.Client.(seer_blockchain.ProtoLabelsStreamer)
+	if !ok {
+		decodedEvents, decodedTransactions, decErr := d.Client.DecodeProtoEntireBlockToLabels(ctx, &rawData, update.BlocksCache, update.Abis)
+		if decErr != nil {
+			send(decodedBatch{err: fmt.Errorf("error decoding events for customer %s: %w", update.CustomerID, decErr)})
+			return
+		}
+		decodedBatches.Inc(d.blockchain)
+
+		send(decodedBatch{events: decodedEvents, transactions: decodedTransactions})
+		return
+	}
+
+	var part decodedBatch
+	decErr := streamer.StreamProtoEntireBlockToLabels(ctx, &rawData, update.BlocksCache, update.Abis, func(events []indexer.EventLabel, transactions []indexer.TransactionLabel) error {
+		part.events = append(part.events, events...)
+		part.transactions = append(part.transactions, transactions...)
+		if len(part.events)+len(part.transactions) < labelsChunkSize {
+			return nil
+		}
+
+		err := send(part)
+		part = decodedBatch{}
+		return err
+	})
+	if decErr != nil {
+		if ctx.Err() == nil {
+			send(decodedBatch{err: fmt.Errorf("error decoding events for customer %s: %w", update.CustomerID, decErr)})
+		}
+		return
+	}
+	decodedBatches.Inc(d.blockchain)
+
+	if len(part.events) > 0 || len(part.transactions) > 0 {
+		send(part)
+	}
+}
+
+// packStartBlock returns first block of pack from its path <start block>-<end block>/data.proto,
+// 0 if path does not contain blocks range.
+func packStartBlock(path string) uint64 {
+	bounds := strings.SplitN(filepath.Base(filepath.Dir(path)), "-", 2)
+	startBlock, err := strconv.ParseUint(bounds[0], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return startBlock
+}
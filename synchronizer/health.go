@@ -0,0 +1,50 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// RegisterHealthChecks adds readiness checks of node, indexes database and synchronization lag.
+// Synchronization lag is number of blocks between the latest indexed block and the last block
+// which labels are written, with positive maxLag synchronizer is not ready when lag exceeds it.
+func (d *Synchronizer) RegisterHealthChecks(maxLag uint64) {
+	metrics.RegisterHealthCheck("rpc", func(ctx context.Context) (string, error) {
+		latestBlockNumber, err := d.Client.GetLatestBlockNumber(ctx)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("latest block %d", latestBlockNumber), nil
+	})
+
+	metrics.RegisterHealthCheck("database", func(ctx context.Context) (string, error) {
+		if indexer.DBConnection == nil {
+			return "", fmt.Errorf("indexes database is not connected")
+		}
+		return "", indexer.DBConnection.Ping(ctx)
+	})
+
+	metrics.RegisterHealthCheck("lag", func(ctx context.Context) (string, error) {
+		syncedBlock := d.syncedBlock.Load()
+		if syncedBlock == 0 {
+			return "synchronization has not started", nil
+		}
+
+		indexedLatestBlock, err := indexer.DBConnection.GetLatestDBBlockNumber(d.blockchain)
+		if err != nil {
+			return "", fmt.Errorf("failed to get latest indexed block: %w", err)
+		}
+
+		var lag uint64
+		if indexedLatestBlock > syncedBlock {
+			lag = indexedLatestBlock - syncedBlock
+		}
+		if maxLag > 0 && lag > maxLag {
+			return fmt.Sprintf("%d blocks", lag), fmt.Errorf("synchronizer is %d blocks behind indexes, allowed lag is %d blocks", lag, maxLag)
+		}
+		return fmt.Sprintf("%d blocks", lag), nil
+	})
+}
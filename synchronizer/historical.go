@@ -0,0 +1,131 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/progress"
+)
+
+// historicalWaitInterval is time forward historical synchronization waits for blocks of range
+// which are not indexed yet.
+const historicalWaitInterval = 10 * time.Second
+
+// SyncHistorical decodes fixed range of blocks from start block to end block, or from end block
+// down to start block in backward direction. Position is written to checkpoints table of indexes
+// database after every batch, so synchronization of the same chain, direction and range resumes
+// from it after restart.
+func (d *Synchronizer) SyncHistorical(ctx context.Context, customerDbUriFlag, direction string) error {
+	if direction != indexer.SyncForward && direction != indexer.SyncBackward {
+		return fmt.Errorf("unknown direction %s, use %s or %s", direction, indexer.SyncForward, indexer.SyncBackward)
+	}
+	if d.startBlock == 0 || d.endBlock < d.startBlock {
+		return fmt.Errorf("historical synchronization requires range of blocks, got %d-%d", d.startBlock, d.endBlock)
+	}
+
+	if err := indexer.DBConnection.EnsureSyncCheckpointsTable(ctx); err != nil {
+		return fmt.Errorf("failed to create %s table: %w", indexer.SyncCheckpointsTableName, err)
+	}
+
+	checkpoint := indexer.SyncCheckpoint{Blockchain: d.blockchain, Direction: direction, StartBlock: d.startBlock, EndBlock: d.endBlock}
+
+	// Next block to synchronize, backward synchronization goes from end block down
+	nextBlock := d.startBlock
+	if direction == indexer.SyncBackward {
+		nextBlock = d.endBlock
+	}
+
+	tracker := progress.NewTracker(fmt.Sprintf("synchronizer %s %s", d.blockchain, direction), d.endBlock-d.startBlock+1)
+
+	saved, err := indexer.DBConnection.ReadSyncCheckpoint(ctx, d.blockchain, direction, d.startBlock, d.endBlock)
+	if err != nil {
+		return fmt.Errorf("failed to read checkpoint: %w", err)
+	}
+	if saved != nil {
+		log.Printf("Resuming %s synchronization of %s blocks %d-%d from checkpoint of %s at block %d, %.2f%% complete", direction, d.blockchain, d.startBlock, d.endBlock, saved.UpdatedAt, saved.BlockNumber, saved.Percent())
+		tracker.Set(saved.Done())
+		if direction == indexer.SyncBackward {
+			nextBlock = saved.BlockNumber - 1
+		} else {
+			nextBlock = saved.BlockNumber + 1
+		}
+		if saved.BlockNumber == d.startBlock && direction == indexer.SyncBackward || saved.BlockNumber == d.endBlock && direction == indexer.SyncForward {
+			log.Printf("Synchronization of %s blocks %d-%d is already complete", d.blockchain, d.startBlock, d.endBlock)
+			tracker.Finish()
+			return nil
+		}
+	}
+
+	customerDBConnections, customerIds, err := d.getCustomers(customerDbUriFlag)
+	if err != nil {
+		return err
+	}
+
+	d.backward = direction == indexer.SyncBackward
+	defer func() { d.backward = false }()
+
+	for {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		indexedLatestBlock, err := indexer.DBConnection.GetLatestDBBlockNumber(d.blockchain)
+		if err != nil {
+			return err
+		}
+
+		var fromBlock, toBlock uint64
+		if direction == indexer.SyncBackward {
+			if d.endBlock > indexedLatestBlock {
+				return fmt.Errorf("end block %d is not indexed yet, the latest indexed block is %d", d.endBlock, indexedLatestBlock)
+			}
+			toBlock = nextBlock
+			fromBlock = d.startBlock
+			if toBlock-d.startBlock > d.batchSize {
+				fromBlock = toBlock - d.batchSize
+			}
+		} else {
+			if nextBlock > indexedLatestBlock {
+				log.Printf("Block %d is not indexed yet, waiting..", nextBlock)
+				select {
+				case <-ctx.Done():
+					return nil
+				case <-time.After(historicalWaitInterval):
+				}
+				continue
+			}
+			fromBlock = nextBlock
+			toBlock = min(nextBlock+d.batchSize, d.endBlock, indexedLatestBlock)
+		}
+
+		if err := d.syncRange(ctx, customerDBConnections, customerIds, fromBlock, toBlock); err != nil {
+			return err
+		}
+
+		if direction == indexer.SyncBackward {
+			checkpoint.BlockNumber = fromBlock
+		} else {
+			checkpoint.BlockNumber = toBlock
+			d.syncedBlock.Store(toBlock)
+		}
+		if err := indexer.DBConnection.WriteSyncCheckpoint(ctx, checkpoint); err != nil {
+			return fmt.Errorf("failed to write checkpoint: %w", err)
+		}
+		tracker.Set(checkpoint.Done())
+
+		if direction == indexer.SyncBackward && fromBlock == d.startBlock || direction == indexer.SyncForward && toBlock == d.endBlock {
+			tracker.Finish()
+			log.Printf("Synchronization of %s blocks %d-%d is complete", d.blockchain, d.startBlock, d.endBlock)
+			return nil
+		}
+
+		if direction == indexer.SyncBackward {
+			nextBlock = fromBlock - 1
+		} else {
+			nextBlock = toBlock + 1
+		}
+	}
+}
@@ -0,0 +1,59 @@
+package synchronizer
+
+import (
+	"context"
+	"log"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+var (
+	lagBlocks = metrics.NewGaugeVec("seer_synchronizer_lag_blocks",
+		"Blocks between the latest crawled block and the last decoded block", "chain")
+	lagSeconds = metrics.NewGaugeVec("seer_synchronizer_lag_seconds",
+		"Seconds between timestamps of the latest crawled block and the last decoded block", "chain")
+	customerLagBlocks = metrics.NewGaugeVec("seer_synchronizer_customer_lag_blocks",
+		"Blocks between the latest crawled block and the last block which labels of customer are written", "chain", "customer", "priority")
+	customerLagSeconds = metrics.NewGaugeVec("seer_synchronizer_customer_lag_seconds",
+		"Seconds between timestamps of the latest crawled block and the last block which labels of customer are written", "chain", "customer", "priority")
+)
+
+// headLag is distance from block to the latest block in indexes database.
+type headLag struct {
+	blocks  uint64
+	seconds uint64
+}
+
+// measureLag returns lag of block behind the latest crawled block, nil when it could not be read
+// or synchronization goes backward. Lag of chain is set after labels of block are written by
+// every customer.
+func (d *Synchronizer) measureLag(ctx context.Context, blockNumber uint64) *headLag {
+	if d.backward {
+		return nil
+	}
+
+	head, block, err := indexer.DBConnection.ReadHeadLag(ctx, d.blockchain, blockNumber)
+	if err != nil {
+		log.Printf("Failed to measure lag of block %d: %v", blockNumber, err)
+		return nil
+	}
+
+	var lag headLag
+	if head.BlockNumber > block.BlockNumber {
+		lag.blocks = head.BlockNumber - block.BlockNumber
+	}
+	if head.BlockTimestamp > block.BlockTimestamp {
+		lag.seconds = head.BlockTimestamp - block.BlockTimestamp
+	}
+	return &lag
+}
+
+// observeLag sets lag of chain after range is synchronized.
+func (d *Synchronizer) observeLag(lag *headLag) {
+	if lag == nil {
+		return
+	}
+	lagBlocks.Set(float64(lag.blocks), d.blockchain)
+	lagSeconds.Set(float64(lag.seconds), d.blockchain)
+}
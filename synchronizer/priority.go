@@ -0,0 +1,116 @@
+package synchronizer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// Priority classes of customers, customers without class have normal priority
+const (
+	PriorityHigh   = "high"
+	PriorityNormal = "normal"
+	PriorityLow    = "low"
+)
+
+// priorityClasses are classes from the highest one with number of customers of class dispatched
+// before customer of the next class when several classes wait for workers. Weights guarantee
+// that lower classes get share of workers while higher classes are decoded first.
+var priorityClasses = []struct {
+	name   string
+	weight int
+}{
+	{PriorityHigh, 4},
+	{PriorityNormal, 2},
+	{PriorityLow, 1},
+}
+
+var (
+	customerSyncSeconds = metrics.NewHistogramVec("seer_synchronizer_customer_sync_seconds",
+		"Time from start of range synchronization until labels of customer are written", []float64{1, 5, 15, 30, 60, 120, 300, 600}, "chain", "priority")
+)
+
+// ParseCustomerPriorities parses priority classes of customers from comma separated
+// <customer id>=<class> pairs, e.g. "5a3b...=high,7c1d...=low".
+func ParseCustomerPriorities(spec string) (map[string]string, error) {
+	priorities := make(map[string]string)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		customerID, class, ok := strings.Cut(pair, "=")
+		customerID, class = strings.TrimSpace(customerID), strings.TrimSpace(class)
+		if !ok || customerID == "" {
+			return nil, fmt.Errorf("invalid customer priority %q, use <customer id>=<class>", pair)
+		}
+		if class != PriorityHigh && class != PriorityNormal && class != PriorityLow {
+			return nil, fmt.Errorf("unknown priority class %q of customer %s, use %s, %s or %s", class, customerID, PriorityHigh, PriorityNormal, PriorityLow)
+		}
+		priorities[customerID] = class
+	}
+	return priorities, nil
+}
+
+// SetCustomerPriorities sets priority classes of customers by their ids.
+func (d *Synchronizer) SetCustomerPriorities(priorities map[string]string) {
+	d.customerPriorities = priorities
+}
+
+// customerPriority returns priority class of customer, unknown classes are normal.
+func (d *Synchronizer) customerPriority(customerID string) string {
+	switch class := d.customerPriorities[customerID]; class {
+	case PriorityHigh, PriorityLow:
+		return class
+	default:
+		return PriorityNormal
+	}
+}
+
+// scheduleUpdates returns updates of customers in order they are dispatched to workers. Classes
+// are interleaved by their weights, customers of the same class take turns to be the first one
+// in consecutive ranges.
+func (d *Synchronizer) scheduleUpdates(updates []indexer.CustomerUpdates) []indexer.CustomerUpdates {
+	queues := make(map[string][]indexer.CustomerUpdates)
+	for _, update := range updates {
+		class := d.customerPriority(update.CustomerID)
+		queues[class] = append(queues[class], update)
+	}
+
+	round := d.scheduleRound
+	d.scheduleRound++
+
+	for class, queue := range queues {
+		sort.Slice(queue, func(i, j int) bool { return queue[i].CustomerID < queue[j].CustomerID })
+		shift := round % len(queue)
+		queues[class] = append(queue[shift:], queue[:shift]...)
+	}
+
+	scheduled := make([]indexer.CustomerUpdates, 0, len(updates))
+	for len(scheduled) < len(updates) {
+		for _, class := range priorityClasses {
+			n := min(class.weight, len(queues[class.name]))
+			scheduled = append(scheduled, queues[class.name][:n]...)
+			queues[class.name] = queues[class.name][n:]
+		}
+	}
+
+	return scheduled
+}
+
+// observeCustomerSync records lag and time of synchronization of customer after labels of range
+// are written.
+func (d *Synchronizer) observeCustomerSync(customerID string, lag *headLag, started time.Time) {
+	class := d.customerPriority(customerID)
+	customerSyncSeconds.Observe(time.Since(started).Seconds(), d.blockchain, class)
+
+	if lag != nil {
+		customerLagBlocks.Set(float64(lag.blocks), d.blockchain, customerID, class)
+		customerLagSeconds.Set(float64(lag.seconds), d.blockchain, customerID, class)
+	}
+}
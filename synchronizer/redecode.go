@@ -0,0 +1,144 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+	"github.com/moonstream-to/seer/storage"
+)
+
+var redecodedLabelsTotal = metrics.NewCounterVec("seer_synchronizer_redecoded_labels_total",
+	"Labels written to customer databases by redecode of ranges of blocks", "chain")
+
+// Redecode decodes blocks of range again with current ABI jobs of contract at address and replaces
+// its labels in databases of customers with ABI jobs for it. Range is processed in batches of
+// synchronizer, labels of every batch are replaced in one database transaction.
+func (d *Synchronizer) Redecode(ctx context.Context, customerDbUriFlag, address string, fromBlock, toBlock uint64) error {
+	address = strings.ToLower(address)
+
+	customerDBConnections, customerIds, err := d.getCustomers(customerDbUriFlag)
+	if err != nil {
+		return err
+	}
+
+	abiJobs, err := d.ReadAbiJobsFromDatabase(d.blockchain)
+	if err != nil {
+		return err
+	}
+	addressCustomers := make(map[string]bool)
+	for _, job := range abiJobs {
+		if strings.EqualFold(fmt.Sprintf("0x%x", job.Address), address) {
+			addressCustomers[job.CustomerID] = true
+		}
+	}
+
+	var redecodeIds []string
+	for _, id := range customerIds {
+		if addressCustomers[id] {
+			redecodeIds = append(redecodeIds, id)
+		}
+	}
+	if len(redecodeIds) == 0 {
+		return fmt.Errorf("no customers with ABI jobs for %s on %s", address, d.blockchain)
+	}
+
+	batchSize := d.batchSize
+	if batchSize == 0 {
+		batchSize = 1
+	}
+
+	for batchFrom := fromBlock; batchFrom <= toBlock; batchFrom += batchSize {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		batchTo := min(batchFrom+batchSize-1, toBlock)
+
+		updates, err := indexer.DBConnection.ReadUpdates(d.blockchain, batchFrom, batchTo, redecodeIds, d.resolveUnknownSelectors, d.multicallSelectors)
+		if err != nil {
+			return fmt.Errorf("error reading updates: %w", err)
+		}
+		updatesByCustomer := make(map[string]indexer.CustomerUpdates)
+		for _, update := range updates {
+			updatesByCustomer[update.CustomerID] = update
+		}
+
+		// Customers without updates in batch have their labels of batch removed
+		for _, id := range redecodeIds {
+			update, ok := updatesByCustomer[id]
+			if !ok {
+				update = indexer.CustomerUpdates{CustomerID: id}
+			}
+
+			transactions, events, err := d.redecodeCustomer(ctx, update, address, batchFrom, batchTo)
+			if err != nil {
+				return err
+			}
+
+			deleted, err := customerDBConnections[id].Labels.ReplaceAddressLabels(ctx, d.blockchain, address, batchFrom, batchTo, transactions, events)
+			if err != nil {
+				return fmt.Errorf("error replacing labels for customer %s: %w", id, err)
+			}
+			redecodedLabelsTotal.Add(float64(len(transactions)+len(events)), d.blockchain)
+			log.Printf("Replaced %d labels of %s with %d labels in blocks %d-%d for customer %s", deleted, address, len(transactions)+len(events), batchFrom, batchTo, id)
+		}
+	}
+
+	return nil
+}
+
+// redecodeCustomer decodes proto batches of customer update with ABIs of contract at address only
+// and returns labels of contract in range. Batches could contain blocks outside of range, their
+// labels are skipped.
+func (d *Synchronizer) redecodeCustomer(ctx context.Context, update indexer.CustomerUpdates, address string, fromBlock, toBlock uint64) ([]indexer.TransactionLabel, []indexer.EventLabel, error) {
+	if update.Abis[address] == nil {
+		return nil, nil, nil
+	}
+	update.Abis = map[string]map[string]map[string]string{address: update.Abis[address]}
+
+	paths := make(map[string]bool)
+	for _, event := range update.Data.Events {
+		paths[event.Path] = true
+	}
+	for _, transaction := range update.Data.Transactions {
+		paths[transaction.Path] = true
+	}
+
+	inRange := func(blockNumber uint64, labelAddress string) bool {
+		return blockNumber >= fromBlock && blockNumber <= toBlock && strings.EqualFold(labelAddress, address)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var transactions []indexer.TransactionLabel
+	var events []indexer.EventLabel
+	for path := range paths {
+		parts := make(chan decodedBatch, 1)
+		go d.decodeBatch(ctx, update, storage.ReadItem{Key: path}, parts)
+
+		for batch := range parts {
+			if batch.err != nil {
+				return nil, nil, batch.err
+			}
+			for _, label := range batch.transactions {
+				if inRange(label.BlockNumber, label.Address) {
+					transactions = append(transactions, label)
+				}
+			}
+			for _, label := range batch.events {
+				if inRange(label.BlockNumber, label.Address) {
+					events = append(events, label)
+				}
+			}
+		}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return transactions, events, nil
+}
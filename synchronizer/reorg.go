@@ -0,0 +1,72 @@
+package synchronizer
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+var invalidatedLabelsTotal = metrics.NewCounterVec("seer_synchronizer_invalidated_labels_total",
+	"Labels removed from customer databases because their blocks were orphaned by reorganization", "chain")
+
+// invalidateOrphanedLabels removes labels of blocks crawler recorded as orphaned from databases
+// of customers. When orphaned blocks were already synchronized, synchronizer is moved back to the
+// first of them, so labels of canonical blocks which replaced them are decoded once they are
+// indexed.
+func (d *Synchronizer) invalidateOrphanedLabels(ctx context.Context, customerDBConnections map[string]CustomerDBConnection) error {
+	if !d.orphansTable {
+		if err := indexer.DBConnection.EnsureOrphanedBlocksTable(ctx); err != nil {
+			return fmt.Errorf("failed to create %s table: %w", indexer.OrphanedBlocksTableName, err)
+		}
+		d.orphansTable = true
+	}
+
+	orphaned, err := indexer.DBConnection.ReadOrphanedBlocks(ctx, d.blockchain)
+	if err != nil {
+		return fmt.Errorf("failed to read orphaned blocks: %w", err)
+	}
+	if len(orphaned) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, len(orphaned))
+	blockHashes := make([]string, len(orphaned))
+	firstBlock := orphaned[0].BlockNumber
+	for i, block := range orphaned {
+		ids[i] = block.ID
+		blockHashes[i] = block.BlockHash
+		if block.BlockNumber < firstBlock {
+			firstBlock = block.BlockNumber
+		}
+	}
+
+	for id, customer := range customerDBConnections {
+		deleted, err := customer.Labels.DeleteLabelsOfBlocks(ctx, d.blockchain, blockHashes)
+		if err != nil {
+			return fmt.Errorf("failed to remove labels of orphaned blocks for customer %s: %w", id, err)
+		}
+		if deleted > 0 {
+			log.Printf("Removed %d labels of %d orphaned blocks for customer %s", deleted, len(blockHashes), id)
+			invalidatedLabelsTotal.Add(float64(deleted), d.blockchain)
+		}
+
+		if d.tokenTransfers && customer.Pgx != nil {
+			if err := customer.Pgx.EnsureTokenTransfersTables(ctx, d.blockchain); err != nil {
+				return fmt.Errorf("failed to create token transfers tables for customer %s: %w", id, err)
+			}
+			if _, err := customer.Pgx.DeleteTokenTransfersOfBlocks(ctx, d.blockchain, blockHashes); err != nil {
+				return fmt.Errorf("failed to remove token transfers of orphaned blocks for customer %s: %w", id, err)
+			}
+		}
+	}
+
+	if firstBlock < d.startBlock {
+		log.Printf("Blocks from %d were orphaned by reorganization, synchronizing them again", firstBlock)
+		d.startBlock = firstBlock
+	}
+
+	return indexer.DBConnection.MarkOrphanedBlocksInvalidated(ctx, ids)
+}
@@ -10,12 +10,15 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	seer_blockchain "github.com/moonstream-to/seer/blockchain"
 	"github.com/moonstream-to/seer/crawler"
 	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/progress"
 	"github.com/moonstream-to/seer/storage"
+	"github.com/moonstream-to/seer/webhooks"
 	"golang.org/x/exp/slices"
 )
 
@@ -29,6 +32,42 @@ type Synchronizer struct {
 	batchSize  uint64
 	baseDir    string
 	basePath   string
+
+	// decodeWorkers is number of proto batches of customer decoded concurrently
+	decodeWorkers int
+
+	// resolveUnknownSelectors reads transactions and events of contracts with ABI jobs which
+	// selectors are missing in customer ABIs, they are decoded with signature databases
+	resolveUnknownSelectors bool
+
+	// multicallSelectors are selectors of multicall transactions read for every customer, calls
+	// batched in them are decoded by client
+	multicallSelectors []string
+
+	// orphansTable is set when orphaned blocks table is created
+	orphansTable bool
+
+	// tokenTransfers writes transfers of event labels to token transfers tables of customers
+	tokenTransfers bool
+	// transfersTables has ids of customers which token transfers tables are created
+	transfersTables sync.Map
+
+	// notifier queues webhook deliveries of written event labels, nil disables webhooks
+	notifier *webhooks.Notifier
+
+	backfillProgress   *progress.Tracker
+	backfillStartBlock uint64
+
+	// syncedBlock is the last block which labels are written, it is read by health checks
+	syncedBlock atomic.Uint64
+	// backward is set while historical synchronization goes from end block down, lag behind the
+	// latest indexed block is not measured then
+	backward bool
+
+	// customerPriorities are priority classes of customers by their ids
+	customerPriorities map[string]string
+	// scheduleRound is number of scheduled ranges, it rotates customers of the same class
+	scheduleRound int
 }
 
 // NewSynchronizer creates a new synchronizer instance with the given blockchain handler.
@@ -60,6 +99,8 @@ func NewSynchronizer(blockchain, baseDir string, startBlock, endBlock, batchSize
 		batchSize:  batchSize,
 		baseDir:    baseDir,
 		basePath:   basePath,
+
+		decodeWorkers: DefaultDecodeWorkers,
 	}
 
 	return &synchronizer, nil
@@ -139,8 +180,9 @@ func ensurePortInConnectionString(connStr string) (string, error) {
 		return "", err
 	}
 
+	// Drivers of SQL dialects use their default ports
 	host := parsedURL.Host
-	if !strings.Contains(host, ":") {
+	if !strings.Contains(host, ":") && !indexer.IsSQLDatabaseURI(connStr) {
 		host = host + ":5432" // Add the default PostgreSQL port
 		parsedURL.Host = host
 	}
@@ -148,10 +190,13 @@ func ensurePortInConnectionString(connStr string) (string, error) {
 	return parsedURL.String(), nil
 }
 
+// CustomerDBConnection is connection to database of customer. Labels are written to PostgreSQL
+// database or database of SQL dialect, Pgx is set only for PostgreSQL database.
 type CustomerDBConnection struct {
 	Uri string
 
-	Pgx *indexer.PostgreSQLpgx
+	Pgx    *indexer.PostgreSQLpgx
+	Labels indexer.LabelsDatabase
 }
 
 // getCustomers fetch ABI jobs, customer IDs and database URLs
@@ -184,6 +229,24 @@ func (d *Synchronizer) getCustomers(customerDbUriFlag string) (map[string]Custom
 			connectionString = customerDbUriFlag
 		}
 
+		if indexer.IsSQLDatabaseURI(connectionString) {
+			sqlDB, sqlErr := indexer.OpenSQLDatabase(connectionString)
+			if sqlErr != nil {
+				log.Printf("Error creating database connection for %s customer, err: %v", id, sqlErr)
+				continue
+			}
+			if d.tokenTransfers {
+				log.Printf("Token transfers are not written for %s customer, they are supported only in PostgreSQL databases", id)
+			}
+
+			customerDBConnections[id] = CustomerDBConnection{
+				Uri:    connectionString,
+				Labels: sqlDB,
+			}
+			customerIds = append(customerIds, id)
+			continue
+		}
+
 		pgx, pgxErr := indexer.NewPostgreSQLpgxWithCustomURI(connectionString)
 		if pgxErr != nil {
 			log.Printf("Error creating RDS connection for %s customer, err: %v", id, pgxErr)
@@ -191,8 +254,9 @@ func (d *Synchronizer) getCustomers(customerDbUriFlag string) (map[string]Custom
 		}
 
 		customerDBConnections[id] = CustomerDBConnection{
-			Uri: connectionString,
-			Pgx: pgx,
+			Uri:    connectionString,
+			Pgx:    pgx,
+			Labels: pgx,
 		}
 		customerIds = append(customerIds, id)
 
@@ -202,13 +266,14 @@ func (d *Synchronizer) getCustomers(customerDbUriFlag string) (map[string]Custom
 	return customerDBConnections, customerIds, nil
 }
 
-func (d *Synchronizer) Start(customerDbUriFlag string) {
+// Start runs synchronization cycles every 10 seconds until the end block is reached or ctx is cancelled.
+func (d *Synchronizer) Start(ctx context.Context, customerDbUriFlag string) {
 	var isEnd bool
 
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 
-	isEnd, err := d.SyncCycle(customerDbUriFlag)
+	isEnd, err := d.SyncCycle(ctx, customerDbUriFlag)
 	if err != nil {
 		fmt.Println("Error during first synchronization cycle:", err)
 	}
@@ -218,8 +283,10 @@ func (d *Synchronizer) Start(customerDbUriFlag string) {
 
 	for {
 		select {
+		case <-ctx.Done():
+			return
 		case <-ticker.C:
-			isEnd, err := d.SyncCycle(customerDbUriFlag)
+			isEnd, err := d.SyncCycle(ctx, customerDbUriFlag)
 			if err != nil {
 				fmt.Println("Error during synchronization cycle:", err)
 			}
@@ -230,7 +297,7 @@ func (d *Synchronizer) Start(customerDbUriFlag string) {
 	}
 }
 
-func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
+func (d *Synchronizer) SyncCycle(ctx context.Context, customerDbUriFlag string) (bool, error) {
 	var isEnd bool
 
 	customerDBConnections, customerIds, customersErr := d.getCustomers(customerDbUriFlag)
@@ -242,15 +309,12 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 		var latestCustomerBlocks []uint64
 		for id, customer := range customerDBConnections {
 
-			pool := customer.Pgx.GetPool()
-			conn, err := pool.Acquire(context.Background())
-			if err != nil {
-				log.Println("Error acquiring pool connection: ", err)
+			if err := customer.Labels.Ping(ctx); err != nil {
+				log.Println("Error connecting to customer database: ", err)
 				return isEnd, err
 			}
-			defer conn.Release()
 
-			latestLabelBlock, err := customer.Pgx.ReadLastLabel(d.blockchain)
+			latestLabelBlock, err := customer.Labels.ReadLastLabel(d.blockchain)
 			if err != nil {
 				log.Println("Error reading latest block: ", err)
 				return isEnd, err
@@ -268,7 +332,7 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 			d.startBlock = maxCustomerLatestBlock - 100
 		} else {
 			// In case start block is still 0, get the latest block from the blockchain minus shift
-			latestBlockNumber, latestErr := d.Client.GetLatestBlockNumber()
+			latestBlockNumber, latestErr := d.Client.GetLatestBlockNumber(ctx)
 			if latestErr != nil {
 				return isEnd, fmt.Errorf("failed to get latest block number: %v", latestErr)
 			}
@@ -276,6 +340,11 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 		}
 	}
 
+	// Labels of blocks orphaned by reorganizations are removed before new blocks are decoded
+	if err := d.invalidateOrphanedLabels(ctx, customerDBConnections); err != nil {
+		log.Printf("Failed to invalidate labels of orphaned blocks: %v", err)
+	}
+
 	// Get the latest block from indexes database
 	indexedLatestBlock, idxLatestErr := indexer.DBConnection.GetLatestDBBlockNumber(d.blockchain)
 	if idxLatestErr != nil {
@@ -291,6 +360,12 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 		return isEnd, nil
 	}
 
+	// Report progress for historical synchronization with known end block
+	if d.endBlock != 0 && d.backfillProgress == nil && d.endBlock >= d.startBlock {
+		d.backfillStartBlock = d.startBlock
+		d.backfillProgress = progress.NewTracker(fmt.Sprintf("synchronizer %s", d.blockchain), d.endBlock-d.startBlock+1)
+	}
+
 	// Main loop Steps:
 	// 1. Read updates from the indexer db
 	// 2. For each update, read the original event data from storage
@@ -317,113 +392,96 @@ func (d *Synchronizer) SyncCycle(customerDbUriFlag string) (bool, error) {
 			log.Printf("Syncing %d blocks from %d to %d\n", tempEndBlock-d.startBlock, d.startBlock, tempEndBlock)
 		}
 
-		// Read updates from the indexer db
-		// This function will return a list of customer updates 1 update is 1 customer
-		updates, err := indexer.DBConnection.ReadUpdates(d.blockchain, d.startBlock, tempEndBlock, customerIds)
-		if err != nil {
-			return isEnd, fmt.Errorf("error reading updates: %w", err)
+		if err := d.syncRange(ctx, customerDBConnections, customerIds, d.startBlock, tempEndBlock); err != nil {
+			return isEnd, err
 		}
 
-		log.Printf("Read %d users updates from the indexer db in range of blocks %d-%d\n", len(updates), d.startBlock, tempEndBlock)
-
-		var wg sync.WaitGroup
-
-		sem := make(chan struct{}, 5)  // Semaphore to control concurrency
-		errChan := make(chan error, 1) // Buffered channel for error handling
-
-		for _, update := range updates {
-			wg.Add(1)
-			go func(update indexer.CustomerUpdates) {
-				defer wg.Done()
-
-				sem <- struct{}{} // Acquire semaphore
-
-				// Get the RDS connection for the customer
-				customer := customerDBConnections[update.CustomerID]
-
-				// Create a connection to the user RDS
-				pool := customer.Pgx.GetPool()
-				conn, err := pool.Acquire(context.Background())
-				if err != nil {
-					errChan <- fmt.Errorf("error acquiring connection for customer %s: %w", update.CustomerID, err)
-					return
-				}
-				defer conn.Release()
+		d.startBlock = tempEndBlock + 1
+		d.syncedBlock.Store(tempEndBlock)
 
-				// Events group by path for batch reading
-				groupByPathEvents := make(map[string][]uint64)
+		if d.backfillProgress != nil {
+			d.backfillProgress.Set(tempEndBlock - d.backfillStartBlock + 1)
+			if isEnd {
+				d.backfillProgress.Finish()
+			}
+		}
 
-				for _, event := range update.Data.Events {
-					if _, ok := groupByPathEvents[event.Path]; !ok {
-						groupByPathEvents[event.Path] = []uint64{}
-					}
+		if isCycleFinished {
+			break
+		}
+	}
 
-					groupByPathEvents[event.Path] = append(groupByPathEvents[event.Path], event.RowID)
-				}
+	return isEnd, nil
+}
 
-				eventsReadMap := []storage.ReadItem{}
+// syncRange decodes updates of customers in range of blocks and writes labels to their databases.
+func (d *Synchronizer) syncRange(ctx context.Context, customerDBConnections map[string]CustomerDBConnection, customerIds []string, fromBlock, toBlock uint64) error {
+	// Read updates from the indexer db
+	// This function will return a list of customer updates 1 update is 1 customer
+	updates, err := indexer.DBConnection.ReadUpdates(d.blockchain, fromBlock, toBlock, customerIds, d.resolveUnknownSelectors, d.multicallSelectors)
+	if err != nil {
+		return fmt.Errorf("error reading updates: %w", err)
+	}
 
-				for path, rowIds := range groupByPathEvents {
-					eventsReadMap = append(eventsReadMap, storage.ReadItem{
-						Key:    path,
-						RowIds: rowIds,
-					})
-				}
+	log.Printf("Read %d users updates from the indexer db in range of blocks %d-%d\n", len(updates), fromBlock, toBlock)
 
-				var decodedEventsPack []indexer.EventLabel
-				var decodedTransactionsPack []indexer.TransactionLabel
+	if d.notifier != nil {
+		if err := d.notifier.Refresh(ctx); err != nil {
+			return err
+		}
+	}
 
-				for _, item := range eventsReadMap {
-					if crawler.SEER_CRAWLER_DEBUG {
-						log.Printf("Key: %s", item.Key)
-					}
+	started := time.Now()
+	lag := d.measureLag(ctx, toBlock)
 
-					// Read events from storage
-					rawData, readErr := d.StorageInstance.Read(item.Key)
-					if readErr != nil {
-						errChan <- fmt.Errorf("error reading events for customer %s: %w", update.CustomerID, readErr)
-						return
-					}
+	var wg sync.WaitGroup
 
-					// Decode the events using ABIs
+	sem := make(chan struct{}, 5)             // Semaphore to control concurrency
+	errChan := make(chan error, len(updates)) // Buffered channel for error handling
 
-					// decodedEvents, decodedTransactions, decErr
-					decodedEvents, decodedTransactions, decErr := d.Client.DecodeProtoEntireBlockToLabels(&rawData, update.BlocksCache, update.Abis)
-					if decErr != nil {
-						fmt.Println("Error decoding events: ", decErr)
-						errChan <- fmt.Errorf("error decoding events for customer %s: %w", update.CustomerID, decErr)
-						return
-					}
+	// Workers are acquired before customer is dispatched, so customers start in order of priority
+	updated := make(map[string]bool)
+	for _, update := range d.scheduleUpdates(updates) {
+		updated[update.CustomerID] = true
 
-					decodedEventsPack = append(decodedEventsPack, decodedEvents...)
-					decodedTransactionsPack = append(decodedTransactionsPack, decodedTransactions...)
-				}
+		sem <- struct{}{} // Acquire semaphore
+		wg.Add(1)
+		go func(update indexer.CustomerUpdates) {
+			defer wg.Done()
+			defer func() { <-sem }()
 
-				customer.Pgx.WriteLabes(d.blockchain, decodedTransactionsPack, decodedEventsPack)
+			// Get the RDS connection for the customer
+			customer := customerDBConnections[update.CustomerID]
 
-				<-sem
-			}(update)
-		}
-
-		wg.Wait()
+			if err := d.syncCustomer(ctx, update, customer); err != nil {
+				errChan <- err
+				return
+			}
+			d.observeCustomerSync(update.CustomerID, lag, started)
+		}(update)
+	}
 
-		close(sem)
-		close(errChan) // Close the channel to signal that all goroutines have finished
+	wg.Wait()
 
-		// Check for errors from goroutines
-		for err := range errChan {
-			fmt.Println("Error during synchronization cycle:", err)
-			if err != nil {
-				return isEnd, err
-			}
+	// Customers without updates in range are synchronized up to its end too
+	for _, customerID := range customerIds {
+		if !updated[customerID] {
+			d.observeCustomerSync(customerID, lag, started)
 		}
+	}
 
-		d.startBlock = tempEndBlock + 1
+	close(sem)
+	close(errChan) // Close the channel to signal that all goroutines have finished
 
-		if isCycleFinished {
-			break
+	// Check for errors from goroutines
+	for err := range errChan {
+		fmt.Println("Error during synchronization cycle:", err)
+		if err != nil {
+			return err
 		}
 	}
 
-	return isEnd, nil
+	d.observeLag(lag)
+
+	return nil
 }
@@ -0,0 +1,202 @@
+package synchronizer
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// Topics of standard token transfer events
+const (
+	transferTopic       = "0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef"
+	transferSingleTopic = "0xc3d58168c5ae7397731d063d5bbf3d657854427343f4c083240f7aacaa2d0f62"
+	transferBatchTopic  = "0x4a39dc06d4c0dbc64b70af90fd698a233a518aa5d07e595d983b8c0526c8f7fb"
+)
+
+var tokenTransfersTotal = metrics.NewCounterVec("seer_synchronizer_token_transfers_total",
+	"Token transfers written to customer databases by standard", "chain", "standard")
+
+// SetTokenTransfers enables writing of ERC-20, ERC-721 and ERC-1155 transfers recognized in event
+// labels to token transfers tables of customer databases.
+func (d *Synchronizer) SetTokenTransfers(tokenTransfers bool) {
+	d.tokenTransfers = tokenTransfers
+}
+
+// writeTokenTransfers writes token transfers of event labels to customer database.
+func (d *Synchronizer) writeTokenTransfers(ctx context.Context, update indexer.CustomerUpdates, customer CustomerDBConnection, events []indexer.EventLabel) error {
+	transfers := tokenTransfers(update.Abis, events)
+	if len(transfers) == 0 {
+		return nil
+	}
+
+	if err := customer.Pgx.WriteTokenTransfers(ctx, d.blockchain, transfers); err != nil {
+		return fmt.Errorf("error writing token transfers for customer %s: %w", update.CustomerID, err)
+	}
+
+	for _, transfer := range transfers {
+		standard := transfer.Standard
+		if standard == "" {
+			standard = "erc20"
+		}
+		tokenTransfersTotal.Inc(d.blockchain, standard)
+	}
+
+	return nil
+}
+
+// tokenTransfers returns transfers of Transfer, TransferSingle and TransferBatch event labels.
+// Arguments are taken by position in event ABI of customer, so events with non standard names of
+// arguments are recognized too. Events which do not match standard layout are skipped.
+func tokenTransfers(abis map[string]map[string]map[string]string, events []indexer.EventLabel) []indexer.TokenTransfer {
+	var transfers []indexer.TokenTransfer
+	for _, event := range events {
+		if event.Label != indexer.SeerCrawlerLabel {
+			continue
+		}
+
+		var topic string
+		switch event.LabelName {
+		case "Transfer":
+			topic = transferTopic
+		case "TransferSingle":
+			topic = transferSingleTopic
+		case "TransferBatch":
+			topic = transferBatchTopic
+		default:
+			continue
+		}
+
+		job := abis[strings.ToLower(event.Address)][topic]
+		if job == nil {
+			continue
+		}
+		eventTransfers, err := eventTokenTransfers(job["abi"], topic, event)
+		if err != nil {
+			continue
+		}
+		transfers = append(transfers, eventTransfers...)
+	}
+	return transfers
+}
+
+// eventTokenTransfers reads transfers from label of event with ABI.
+func eventTokenTransfers(eventABI, topic string, event indexer.EventLabel) ([]indexer.TokenTransfer, error) {
+	parsed, err := abi.JSON(strings.NewReader(eventABI))
+	if err != nil {
+		return nil, err
+	}
+	var inputs abi.Arguments
+	for _, e := range parsed.Events {
+		if e.ID.Hex() == topic {
+			inputs = e.Inputs
+		}
+	}
+
+	var labelData struct {
+		Args map[string]interface{} `json:"args"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(event.LabelData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&labelData); err != nil {
+		return nil, err
+	}
+
+	// Arguments of event by position
+	args := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		args[i] = labelData.Args[input.Name]
+	}
+
+	transfer := indexer.TokenTransfer{
+		TransactionHash: event.TransactionHash,
+		LogIndex:        event.LogIndex,
+		BlockNumber:     event.BlockNumber,
+		BlockHash:       event.BlockHash,
+		BlockTimestamp:  event.BlockTimestamp,
+		Token:           strings.ToLower(event.Address),
+	}
+
+	switch topic {
+	case transferTopic:
+		if len(args) != 3 {
+			return nil, fmt.Errorf("unexpected Transfer arguments")
+		}
+		transfer.From, transfer.To = addressArg(args[0]), addressArg(args[1])
+		// ERC-721 transfers have indexed token id, ERC-20 transfers have amount in data
+		if inputs[2].Indexed {
+			transfer.Standard, transfer.TokenID, transfer.Amount = indexer.ERC721Standard, numberArg(args[2]), "1"
+		} else {
+			transfer.Amount = numberArg(args[2])
+		}
+		if transfer.From == "" || transfer.To == "" || transfer.Amount == "" || transfer.Standard != "" && transfer.TokenID == "" {
+			return nil, fmt.Errorf("unexpected Transfer arguments")
+		}
+		return []indexer.TokenTransfer{transfer}, nil
+	case transferSingleTopic:
+		if len(args) != 5 {
+			return nil, fmt.Errorf("unexpected TransferSingle arguments")
+		}
+		transfer.Standard = indexer.ERC1155Standard
+		transfer.Operator, transfer.From, transfer.To = addressArg(args[0]), addressArg(args[1]), addressArg(args[2])
+		transfer.TokenID, transfer.Amount = numberArg(args[3]), numberArg(args[4])
+		if transfer.From == "" || transfer.To == "" || transfer.TokenID == "" || transfer.Amount == "" {
+			return nil, fmt.Errorf("unexpected TransferSingle arguments")
+		}
+		return []indexer.TokenTransfer{transfer}, nil
+	case transferBatchTopic:
+		if len(args) != 5 {
+			return nil, fmt.Errorf("unexpected TransferBatch arguments")
+		}
+		ids, idsOk := args[3].([]interface{})
+		values, valuesOk := args[4].([]interface{})
+		if !idsOk || !valuesOk || len(ids) != len(values) {
+			return nil, fmt.Errorf("unexpected TransferBatch arguments")
+		}
+
+		transfer.Standard = indexer.ERC1155Standard
+		transfer.Operator, transfer.From, transfer.To = addressArg(args[0]), addressArg(args[1]), addressArg(args[2])
+		if transfer.From == "" || transfer.To == "" {
+			return nil, fmt.Errorf("unexpected TransferBatch arguments")
+		}
+
+		transfers := make([]indexer.TokenTransfer, len(ids))
+		for i := range ids {
+			transfers[i] = transfer
+			transfers[i].BatchIndex = uint64(i)
+			transfers[i].TokenID, transfers[i].Amount = numberArg(ids[i]), numberArg(values[i])
+			if transfers[i].TokenID == "" || transfers[i].Amount == "" {
+				return nil, fmt.Errorf("unexpected TransferBatch arguments")
+			}
+		}
+		return transfers, nil
+	}
+
+	return nil, nil
+}
+
+// addressArg returns lowercase address of label argument, empty if it is not address.
+func addressArg(arg interface{}) string {
+	address, ok := arg.(string)
+	if !ok || !strings.HasPrefix(address, "0x") {
+		return ""
+	}
+	return strings.ToLower(address)
+}
+
+// numberArg returns decimal number of label argument, empty if it is not integer.
+func numberArg(arg interface{}) string {
+	number, ok := arg.(json.Number)
+	if !ok {
+		return ""
+	}
+	if strings.ContainsAny(number.String(), ".eE-") {
+		return ""
+	}
+	return number.String()
+}
@@ -0,0 +1,144 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+const (
+	// DefaultMaxAttempts is number of delivery attempts after which delivery is failed
+	DefaultMaxAttempts = 10
+	// pollInterval is time between reads of due deliveries
+	pollInterval = 5 * time.Second
+	// deliveriesPerPoll is number of due deliveries read at once
+	deliveriesPerPoll = 100
+	// deliveryTimeout is timeout of delivery request
+	deliveryTimeout = 10 * time.Second
+	// firstRetryDelay is delay of the first retry, delay is doubled with every attempt up to
+	// maxRetryDelay
+	firstRetryDelay = 30 * time.Second
+	maxRetryDelay   = time.Hour
+)
+
+var deliveryAttempts = metrics.NewCounterVec("seer_webhook_delivery_attempts_total",
+	"Webhook delivery attempts by result: delivered, retry or failed after the last attempt", "chain", "result")
+
+// Dispatcher sends pending webhook deliveries of blockchain. Deliveries which are not answered
+// with 2xx status are retried with exponential backoff until maximal number of attempts.
+type Dispatcher struct {
+	blockchain  string
+	maxAttempts int
+	httpClient  *http.Client
+}
+
+// NewDispatcher creates dispatcher of deliveries of blockchain.
+func NewDispatcher(blockchain string, maxAttempts int) *Dispatcher {
+	if maxAttempts < 1 {
+		maxAttempts = 1
+	}
+	return &Dispatcher{
+		blockchain:  blockchain,
+		maxAttempts: maxAttempts,
+		httpClient:  &http.Client{Timeout: deliveryTimeout},
+	}
+}
+
+// Run sends due deliveries until ctx is cancelled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	for {
+		deliveries, err := indexer.DBConnection.ReadDueWebhookDeliveries(ctx, d.blockchain, deliveriesPerPoll)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("Failed to read webhook deliveries: %v", err)
+		}
+		for _, delivery := range deliveries {
+			if ctx.Err() != nil {
+				return
+			}
+			d.deliver(ctx, delivery)
+		}
+
+		// Next page of due deliveries is read at once
+		if len(deliveries) == deliveriesPerPoll {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// deliver sends delivery and records result of attempt.
+func (d *Dispatcher) deliver(ctx context.Context, delivery indexer.WebhookDelivery) {
+	attempts := delivery.Attempts + 1
+	responseStatus, err := d.send(ctx, delivery)
+
+	status, nextAttemptAt, lastError := indexer.WebhookDeliveryDelivered, time.Now(), ""
+	if err != nil {
+		lastError = err.Error()
+		if attempts >= d.maxAttempts {
+			status = indexer.WebhookDeliveryFailed
+			log.Printf("Webhook %d delivery %d failed after %d attempts: %v", delivery.WebhookID, delivery.ID, attempts, err)
+		} else {
+			status = indexer.WebhookDeliveryPending
+			nextAttemptAt = nextAttemptAt.Add(retryDelay(attempts))
+		}
+	}
+
+	if status == indexer.WebhookDeliveryPending {
+		deliveryAttempts.Inc(d.blockchain, "retry")
+	} else {
+		deliveryAttempts.Inc(d.blockchain, status)
+	}
+
+	if err := indexer.DBConnection.UpdateWebhookDelivery(ctx, delivery.ID, status, attempts, responseStatus, lastError, nextAttemptAt); err != nil {
+		log.Printf("Failed to record result of webhook delivery %d: %v", delivery.ID, err)
+	}
+}
+
+// send posts payload of delivery signed with secret of webhook, it returns status of response, 0
+// if request failed without response.
+func (d *Dispatcher) send(ctx context.Context, delivery indexer.WebhookDelivery) (int, error) {
+	body := []byte(delivery.Payload)
+	timestamp := time.Now().Unix()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	request.Header.Set(TimestampHeader, strconv.FormatInt(timestamp, 10))
+	request.Header.Set(SignatureHeader, Sign(delivery.Secret, timestamp, body))
+
+	response, err := d.httpClient.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(response.Body, 64*1024))
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return response.StatusCode, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+	return response.StatusCode, nil
+}
+
+// retryDelay returns delay before next attempt after number of failed attempts.
+func retryDelay(attempts int) time.Duration {
+	delay := firstRetryDelay
+	for i := 1; i < attempts && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxRetryDelay)
+}
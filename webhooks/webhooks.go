@@ -0,0 +1,235 @@
+// Package webhooks delivers decoded events to HTTP endpoints of customers. Customers register
+// webhooks for event of contract with predicates on its arguments, synchronizer queues matching
+// event labels it writes and dispatcher delivers them with signed requests and retries.
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"math/big"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/moonstream-to/seer/filters"
+	"github.com/moonstream-to/seer/indexer"
+	"github.com/moonstream-to/seer/metrics"
+)
+
+// Headers of delivery requests. Signature is hex encoded HMAC-SHA256 of "<timestamp>.<body>" with
+// secret of webhook, prefixed with "sha256=".
+const (
+	SignatureHeader = "X-Seer-Signature"
+	TimestampHeader = "X-Seer-Timestamp"
+	DeliveryHeader  = "X-Seer-Delivery"
+)
+
+var enqueuedDeliveries = metrics.NewCounterVec("seer_webhook_enqueued_total",
+	"Webhook deliveries queued for event labels written by synchronizer", "chain")
+
+var predicatePattern = regexp.MustCompile(`^\s*([A-Za-z0-9_]+)\s*(!=|>=|<=|=|>|<)\s*(.*?)\s*$`)
+
+// ParsePredicate parses predicate on argument of event written as <arg><op><value>, for example
+// "value>=1000000" or "to=0x...". Operators are =, !=, >, >=, < and <=, values of ordering
+// operators must be integers.
+func ParsePredicate(spec string) (indexer.WebhookPredicate, error) {
+	match := predicatePattern.FindStringSubmatch(spec)
+	if match == nil || match[3] == "" {
+		return indexer.WebhookPredicate{}, fmt.Errorf("invalid predicate %q, use <arg><op><value> with =, !=, >, >=, < or <=", spec)
+	}
+
+	predicate := indexer.WebhookPredicate{Arg: match[1], Op: match[2], Value: match[3]}
+	if predicate.Op != "=" && predicate.Op != "!=" {
+		if _, ok := parseInteger(predicate.Value); !ok {
+			return indexer.WebhookPredicate{}, fmt.Errorf("value of predicate %q should be integer", spec)
+		}
+	}
+	return predicate, nil
+}
+
+// Matches returns arguments of event label when it is fully decoded event of webhook which
+// arguments satisfy all predicates of webhook and which matches filter expression of webhook, nil
+// expression matches every event.
+func Matches(webhook indexer.Webhook, expression *filters.Expression, event indexer.EventLabel) (map[string]interface{}, bool) {
+	if event.Label != indexer.SeerCrawlerLabel || event.LabelName != webhook.EventName || !strings.EqualFold(event.Address, webhook.Address) {
+		return nil, false
+	}
+
+	var labelData struct {
+		Args map[string]interface{} `json:"args"`
+	}
+	decoder := json.NewDecoder(strings.NewReader(event.LabelData))
+	decoder.UseNumber()
+	if err := decoder.Decode(&labelData); err != nil {
+		return nil, false
+	}
+
+	for _, predicate := range webhook.Predicates {
+		arg, ok := labelData.Args[predicate.Arg]
+		if !ok || !satisfies(predicate, arg) {
+			return nil, false
+		}
+	}
+
+	if expression != nil && !expression.Match(filters.EventRecord(event)) {
+		return nil, false
+	}
+
+	return labelData.Args, true
+}
+
+// satisfies reports whether argument satisfies predicate. Integers are compared by value, other
+// arguments are compared as strings ignoring case, so addresses match in any case.
+func satisfies(predicate indexer.WebhookPredicate, arg interface{}) bool {
+	argString := fmt.Sprint(arg)
+	argNumber, argIsNumber := parseInteger(argString)
+	value, valueIsNumber := parseInteger(predicate.Value)
+
+	if predicate.Op == "=" || predicate.Op == "!=" {
+		equal := strings.EqualFold(argString, predicate.Value)
+		if argIsNumber && valueIsNumber {
+			equal = argNumber.Cmp(value) == 0
+		}
+		return equal == (predicate.Op == "=")
+	}
+
+	if !argIsNumber || !valueIsNumber {
+		return false
+	}
+	cmp := argNumber.Cmp(value)
+	switch predicate.Op {
+	case ">":
+		return cmp > 0
+	case ">=":
+		return cmp >= 0
+	case "<":
+		return cmp < 0
+	case "<=":
+		return cmp <= 0
+	}
+	return false
+}
+
+// parseInteger parses decimal integer or 0x prefixed hex integer.
+func parseInteger(value string) (*big.Int, bool) {
+	if digits, ok := strings.CutPrefix(value, "0x"); ok {
+		return new(big.Int).SetString(digits, 16)
+	}
+	return new(big.Int).SetString(value, 10)
+}
+
+// Sign returns signature of delivery body sent at timestamp with secret of webhook.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Notifier queues deliveries of event labels written by synchronizer to webhooks of their
+// customers.
+type Notifier struct {
+	blockchain string
+
+	mux         sync.RWMutex
+	webhooks    map[string][]indexer.Webhook
+	expressions map[int64]*filters.Expression
+}
+
+// NewNotifier creates notifier of webhooks of blockchain, they are read with Refresh.
+func NewNotifier(blockchain string) *Notifier {
+	return &Notifier{
+		blockchain:  blockchain,
+		webhooks:    make(map[string][]indexer.Webhook),
+		expressions: make(map[int64]*filters.Expression),
+	}
+}
+
+// Refresh reads webhooks of blockchain again, so registered and removed webhooks are picked up.
+// Webhooks with filter expression which does not parse are skipped.
+func (n *Notifier) Refresh(ctx context.Context) error {
+	webhooks, err := indexer.DBConnection.ReadWebhooks(ctx, n.blockchain)
+	if err != nil {
+		return fmt.Errorf("failed to read webhooks: %w", err)
+	}
+
+	byCustomer := make(map[string][]indexer.Webhook)
+	expressions := make(map[int64]*filters.Expression)
+	for _, webhook := range webhooks {
+		if webhook.Expression != "" {
+			expression, err := filters.Parse(webhook.Expression)
+			if err != nil {
+				log.Printf("Skipping webhook %d with invalid filter %q: %v", webhook.ID, webhook.Expression, err)
+				continue
+			}
+			expressions[webhook.ID] = expression
+		}
+		byCustomer[webhook.CustomerID] = append(byCustomer[webhook.CustomerID], webhook)
+	}
+
+	n.mux.Lock()
+	n.webhooks = byCustomer
+	n.expressions = expressions
+	n.mux.Unlock()
+
+	return nil
+}
+
+// Enqueue queues deliveries of events of customer which match its webhooks.
+func (n *Notifier) Enqueue(ctx context.Context, customerID string, events []indexer.EventLabel) error {
+	n.mux.RLock()
+	webhooks := n.webhooks[customerID]
+	expressions := n.expressions
+	n.mux.RUnlock()
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	var deliveries []indexer.WebhookDelivery
+	for _, event := range events {
+		for _, webhook := range webhooks {
+			args, ok := Matches(webhook, expressions[webhook.ID], event)
+			if !ok {
+				continue
+			}
+
+			payload, err := json.Marshal(map[string]interface{}{
+				"webhook_id":       webhook.ID,
+				"chain":            n.blockchain,
+				"address":          strings.ToLower(event.Address),
+				"event":            event.LabelName,
+				"args":             args,
+				"transaction_hash": event.TransactionHash,
+				"log_index":        event.LogIndex,
+				"block_number":     event.BlockNumber,
+				"block_hash":       event.BlockHash,
+				"block_timestamp":  event.BlockTimestamp,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to encode payload of webhook %d: %w", webhook.ID, err)
+			}
+
+			deliveries = append(deliveries, indexer.WebhookDelivery{
+				WebhookID:       webhook.ID,
+				Blockchain:      n.blockchain,
+				TransactionHash: event.TransactionHash,
+				LogIndex:        event.LogIndex,
+				BlockNumber:     event.BlockNumber,
+				Payload:         string(payload),
+			})
+		}
+	}
+
+	queued, err := indexer.DBConnection.EnqueueWebhookDeliveries(ctx, deliveries)
+	if err != nil {
+		return fmt.Errorf("failed to queue webhook deliveries for customer %s: %w", customerID, err)
+	}
+	enqueuedDeliveries.Add(float64(queued), n.blockchain)
+
+	return nil
+}